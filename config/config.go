@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 type Config struct {
 	Port              int    `env:"PORT,default=8080"`
 	BookStoreUsername string `env:"BOOKSTORE_USERNAME,default=bookstorebe"`
@@ -12,4 +14,61 @@ type Config struct {
 		Name     string `env:"DATABASE_NAME,required"`
 		SSL      string `env:"SSL_MODE,default=disable"`
 	}
+	MaxCoverSize              int64         `env:"MAX_COVER_SIZE,default=5242880"`
+	ReservationTTL            time.Duration `env:"RESERVATION_TTL,default=15m"`
+	ReservationReaperInterval time.Duration `env:"RESERVATION_REAPER_INTERVAL,default=1m"`
+	AllocationStrategy        string        `env:"ALLOCATION_STRATEGY,default=most_stock"`
+	LowStockScanInterval      time.Duration `env:"LOW_STOCK_SCAN_INTERVAL,default=1h"`
+	BackInStockInterval       time.Duration `env:"BACK_IN_STOCK_INTERVAL,default=1m"`
+	PreOrderFulfillInterval   time.Duration `env:"PREORDER_FULFILL_INTERVAL,default=1m"`
+	Storage                   struct {
+		Driver      string `env:"STORAGE_DRIVER,default=local"`
+		LocalDir    string `env:"STORAGE_LOCAL_DIR,default=uploads"`
+		LocalURL    string `env:"STORAGE_LOCAL_URL,default=http://localhost:8080/files"`
+		S3Endpoint  string `env:"STORAGE_S3_ENDPOINT"`
+		S3Region    string `env:"STORAGE_S3_REGION,default=us-east-1"`
+		S3Bucket    string `env:"STORAGE_S3_BUCKET"`
+		S3AccessKey string `env:"STORAGE_S3_ACCESS_KEY"`
+		S3SecretKey string `env:"STORAGE_S3_SECRET_KEY"`
+		S3PublicURL string `env:"STORAGE_S3_PUBLIC_URL"`
+	}
+	Currency struct {
+		Base                string        `env:"CURRENCY_BASE,default=IDR"`
+		RatesFile           string        `env:"CURRENCY_RATES_FILE"`
+		RatesReloadInterval time.Duration `env:"CURRENCY_RATES_RELOAD_INTERVAL,default=1h"`
+	}
+	Locale struct {
+		Default   string   `env:"LOCALE_DEFAULT,default=en"`
+		Fallbacks []string `env:"LOCALE_FALLBACKS"`
+	}
+	Tax struct {
+		PricesIncludeTax bool   `env:"TAX_PRICES_INCLUDE_TAX,default=false"`
+		DefaultCountry   string `env:"TAX_DEFAULT_COUNTRY"`
+	}
+	Payment struct {
+		Gateway       string `env:"PAYMENT_GATEWAY,default=fake"`
+		WebhookSecret string `env:"PAYMENT_WEBHOOK_SECRET"`
+	}
+	Invoice struct {
+		FiscalYearStart int           `env:"INVOICE_FISCAL_YEAR_START,default=1"`
+		IssueInterval   time.Duration `env:"INVOICE_ISSUE_INTERVAL,default=1m"`
+		SellerName      string        `env:"INVOICE_SELLER_NAME,default=Book Store"`
+		SellerAddress   []string      `env:"INVOICE_SELLER_ADDRESS"`
+		SellerTaxID     string        `env:"INVOICE_SELLER_TAX_ID"`
+	}
+	Recommendation struct {
+		Limit           int           `env:"RECOMMENDATION_LIMIT,default=10"`
+		RefreshInterval time.Duration `env:"RECOMMENDATION_REFRESH_INTERVAL,default=1h"`
+	}
+	Notifier struct {
+		Drivers            []string `env:"NOTIFIERS,default=log"`
+		BackInStockDrivers []string `env:"BACK_IN_STOCK_NOTIFIERS,default=log"`
+		WebhookURL         string   `env:"NOTIFIER_WEBHOOK_URL"`
+		SMTPHost           string   `env:"SMTP_HOST,default=localhost"`
+		SMTPPort           int      `env:"SMTP_PORT,default=1025"`
+		SMTPUsername       string   `env:"SMTP_USERNAME"`
+		SMTPPassword       string   `env:"SMTP_PASSWORD"`
+		SMTPFrom           string   `env:"SMTP_FROM,default=bookstore@localhost"`
+		SMTPTo             []string `env:"SMTP_TO"`
+	}
 }
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"fmt"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/exchange"
+)
+
+// NewRates returns the exchange rates of the store's currency, read from the rates file when
+// one is configured
+func NewRates(cfg *Config) (*exchange.Rates, error) {
+	if !entity.KnownCurrency(cfg.Currency.Base) {
+		return nil, fmt.Errorf("unknown currency %q", cfg.Currency.Base)
+	}
+
+	rates := exchange.NewRates(cfg.Currency.Base)
+	if cfg.Currency.RatesFile == "" {
+		return rates, nil
+	}
+
+	err := rates.Load(cfg.Currency.RatesFile)
+	if err != nil {
+		return nil, fmt.Errorf("load exchange rates: %w", err)
+	}
+
+	return rates, nil
+}
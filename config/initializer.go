@@ -1,14 +1,18 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 	"winartodev/book-store-be/delivery"
 	"winartodev/book-store-be/handler"
 	"winartodev/book-store-be/logger"
+	"winartodev/book-store-be/pdf"
 	"winartodev/book-store-be/repository"
 	"winartodev/book-store-be/usecase"
+	"winartodev/book-store-be/worker"
 
 	"github.com/joeshaw/envdecode"
 	"github.com/subosito/gotenv"
@@ -36,19 +40,207 @@ func Serve() {
 
 	logger.Init()
 
+	fileStorage, registrations, err := NewStorage(&cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	lowStockNotifier, err := NewNotifier(&cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	backInStockNotifier, err := NewBackInStockNotifier(&cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	rates, err := NewRates(&cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	gateway, err := NewPaymentGateway(&cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	localeFallbacks, err := NewLocaleFallbacks(&cfg)
+	if err != nil {
+		panic(err)
+	}
+
 	categoryRepo := repository.NewMysqlCategory(db)
+	publisherRepo := repository.NewMysqlPublisher(db)
+	bookRepo := repository.NewMysqlBook(db)
+	translationUsecase := usecase.NewTranslationUsecase(&usecase.TranslationRepository{TranslationRepo: repository.NewMysqlTranslation(db), BookRepo: bookRepo, CategoryRepo: categoryRepo, PublisherRepo: publisherRepo, Fallbacks: localeFallbacks})
+	translationHandler := delivery.NewTranslationHandler(translationUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
 	categoryUsecase := usecase.NewCategoryUsecase(&usecase.CategoryRepository{CategoryRepo: categoryRepo})
-	categoryHander := delivery.NewCategoryHandler(categoryUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+	categoryHander := delivery.NewCategoryHandler(categoryUsecase, translationUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
 
-	publisherRepo := repository.NewMysqlPublisher(db)
 	publisherUsecase := usecase.NewPublihserUsecase(&usecase.PublisherRepository{PublisherRepo: publisherRepo})
-	publisherHandler := delivery.NewPublisherHandler(publisherUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+	publisherHandler := delivery.NewPublisherHandler(publisherUsecase, translationUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
 
-	bookRepo := repository.NewMysqlBook(db)
-	bookUsecase := usecase.NewBookUsecase(&usecase.BookRepository{BookRepo: bookRepo})
-	bookHandler := delivery.NewBookHandler(bookUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+	authorRepo := repository.NewMysqlAuthor(db)
+	authorUsecase := usecase.NewAuthorUsecase(&usecase.AuthorRepository{AuthorRepo: authorRepo})
+	authorHandler := delivery.NewAuthorHandler(authorUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	tagRepo := repository.NewMysqlTag(db)
+	tagUsecase := usecase.NewTagUsecase(&usecase.TagRepository{TagRepo: tagRepo})
+	tagHandler := delivery.NewTagHandler(tagUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	seriesRepo := repository.NewMysqlSeries(db)
+	seriesUsecase := usecase.NewSeriesUsecase(&usecase.SeriesRepository{SeriesRepo: seriesRepo})
+	seriesHandler := delivery.NewSeriesHandler(seriesUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	skuRepo := repository.NewMysqlSKU(db)
+	coverRepo := repository.NewMysqlCover(db)
+	stockRepo := repository.NewMysqlStock(db)
+	warehouseRepo := repository.NewMysqlWarehouse(db)
+	priceRepo := repository.NewMysqlPrice(db)
+	taxRepo := repository.NewMysqlTax(db)
 
-	h := handler.NewHandler(&categoryHander, &publisherHandler, &bookHandler)
+	taxUsecase := usecase.NewTaxUsecase(&usecase.TaxRepository{TaxRepo: taxRepo})
+	taxHandler := delivery.NewTaxHandler(taxUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+	taxPolicy := usecase.TaxPolicy{PricesIncludeTax: cfg.Tax.PricesIncludeTax, DefaultCountry: cfg.Tax.DefaultCountry}
+
+	shippingRepo := repository.NewMysqlShipping(db)
+	shippingUsecase := usecase.NewShippingUsecase(&usecase.ShippingRepository{ShippingRepo: shippingRepo})
+	shippingHandler := delivery.NewShippingHandler(shippingUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	bookUsecase := usecase.NewBookUsecase(&usecase.BookRepository{BookRepo: bookRepo, AuthorRepo: authorRepo, CategoryRepo: categoryRepo, TagRepo: tagRepo, SeriesRepo: seriesRepo, SKURepo: skuRepo, CoverRepo: coverRepo, WarehouseRepo: warehouseRepo, PriceRepo: priceRepo, TaxRepo: taxRepo, Storage: fileStorage})
+	currencyUsecase := usecase.NewCurrencyUsecase(&usecase.CurrencyRepository{CurrencyPriceRepo: repository.NewMysqlCurrencyPrice(db), BookRepo: bookRepo, Rates: rates})
+	currencyHandler := delivery.NewCurrencyHandler(currencyUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+	bookHandler := delivery.NewBookHandler(bookUsecase, currencyUsecase, translationUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	skuUsecase := usecase.NewSKUUsecase(&usecase.SKURepository{SKURepo: skuRepo, BookRepo: bookRepo, TaxRepo: taxRepo})
+	skuHandler := delivery.NewSKUHandler(skuUsecase, currencyUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	coverUsecase := usecase.NewCoverUsecase(&usecase.CoverRepository{CoverRepo: coverRepo, BookRepo: bookRepo, Storage: fileStorage})
+	coverHandler := delivery.NewCoverHandler(coverUsecase, cfg.MaxCoverSize, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	priceUsecase := usecase.NewPriceUsecase(&usecase.PriceRepository{PriceRepo: priceRepo, BookRepo: bookRepo})
+	priceHandler := delivery.NewPriceHandler(priceUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	warehouseUsecase := usecase.NewWarehouseUsecase(&usecase.WarehouseRepository{WarehouseRepo: warehouseRepo})
+	warehouseHandler := delivery.NewWarehouseHandler(warehouseUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	stockUsecase := usecase.NewStockUsecase(&usecase.StockRepository{StockRepo: stockRepo, BookRepo: bookRepo, SKURepo: skuRepo, WarehouseRepo: warehouseRepo})
+	stockHandler := delivery.NewStockHandler(stockUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	reorderRepo := repository.NewMysqlReorder(db)
+	reorderUsecase := usecase.NewReorderUsecase(&usecase.ReorderRepository{ReorderRepo: reorderRepo, BookRepo: bookRepo, Notifier: lowStockNotifier})
+	reorderHandler := delivery.NewReorderHandler(reorderUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	supplierRepo := repository.NewMysqlSupplier(db)
+	supplierUsecase := usecase.NewSupplierUsecase(&usecase.SupplierRepository{SupplierRepo: supplierRepo, PublisherRepo: publisherRepo})
+	supplierHandler := delivery.NewSupplierHandler(supplierUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	purchaseOrderRepo := repository.NewMysqlPurchaseOrder(db)
+	purchaseOrderUsecase := usecase.NewPurchaseOrderUsecase(&usecase.PurchaseOrderRepository{PurchaseOrderRepo: purchaseOrderRepo, SupplierRepo: supplierRepo, WarehouseRepo: warehouseRepo, BookRepo: bookRepo})
+	purchaseOrderHandler := delivery.NewPurchaseOrderHandler(purchaseOrderUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	bundleRepo := repository.NewMysqlBundle(db)
+	bundleUsecase := usecase.NewBundleUsecase(&usecase.BundleRepository{BundleRepo: bundleRepo, BookRepo: bookRepo})
+	bundleHandler := delivery.NewBundleHandler(bundleUsecase, currencyUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	promotionRepo := repository.NewMysqlPromotion(db)
+	promotionUsecase := usecase.NewPromotionUsecase(&usecase.PromotionRepository{PromotionRepo: promotionRepo, BookRepo: bookRepo, SKURepo: skuRepo, CategoryRepo: categoryRepo, TaxRepo: taxRepo, ShippingRepo: shippingRepo, BundleRepo: bundleRepo, TaxPolicy: taxPolicy})
+	promotionHandler := delivery.NewPromotionHandler(promotionUsecase, currencyUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	orderRepo := repository.NewMysqlOrder(db)
+	orderUsecase := usecase.NewOrderUsecase(&usecase.OrderRepository{OrderRepo: orderRepo, BookRepo: bookRepo, SKURepo: skuRepo, CategoryRepo: categoryRepo, WarehouseRepo: warehouseRepo, PromotionRepo: promotionRepo, TaxRepo: taxRepo, ShippingRepo: shippingRepo, BundleRepo: bundleRepo, TaxPolicy: taxPolicy, ReservationTTL: cfg.ReservationTTL, AllocationStrategy: cfg.AllocationStrategy})
+	orderHandler := delivery.NewOrderHandler(orderUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	shipmentUsecase := usecase.NewShipmentUsecase(&usecase.ShipmentRepository{ShipmentRepo: repository.NewMysqlShipment(db), OrderRepo: orderRepo, ShippingRepo: shippingRepo})
+	shipmentHandler := delivery.NewShipmentHandler(shipmentUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	paymentRepo := repository.NewMysqlPayment(db)
+	paymentUsecase := usecase.NewPaymentUsecase(&usecase.PaymentRepository{PaymentRepo: paymentRepo, OrderRepo: orderRepo, Gateway: gateway, Currency: cfg.Currency.Base})
+	paymentHandler := delivery.NewPaymentHandler(paymentUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	returnUsecase := usecase.NewReturnUsecase(&usecase.ReturnRepository{ReturnRepo: repository.NewMysqlReturn(db), OrderRepo: orderRepo, PaymentRepo: paymentRepo, Gateway: gateway})
+	returnHandler := delivery.NewReturnHandler(returnUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	if cfg.Invoice.FiscalYearStart < 1 || cfg.Invoice.FiscalYearStart > 12 {
+		panic(fmt.Errorf("INVOICE_FISCAL_YEAR_START must be a month from 1 to 12, not %d", cfg.Invoice.FiscalYearStart))
+	}
+	seller := pdf.Seller{Name: cfg.Invoice.SellerName, Address: cfg.Invoice.SellerAddress, TaxID: cfg.Invoice.SellerTaxID}
+	invoiceUsecase := usecase.NewInvoiceUsecase(&usecase.InvoiceRepository{InvoiceRepo: repository.NewMysqlInvoice(db), OrderRepo: orderRepo, PaymentRepo: paymentRepo, BookRepo: bookRepo, FiscalYearStart: time.Month(cfg.Invoice.FiscalYearStart), Seller: seller})
+	invoiceHandler := delivery.NewInvoiceHandler(invoiceUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	reviewUsecase := usecase.NewReviewUsecase(&usecase.ReviewRepository{ReviewRepo: repository.NewMysqlReview(db), BookRepo: bookRepo})
+	reviewHandler := delivery.NewReviewHandler(reviewUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	wishlistUsecase := usecase.NewWishlistUsecase(&usecase.WishlistRepository{WishlistRepo: repository.NewMysqlWishlist(db), BookRepo: bookRepo, Notifier: backInStockNotifier})
+	wishlistHandler := delivery.NewWishlistHandler(wishlistUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	if cfg.Recommendation.Limit < 1 {
+		panic(fmt.Errorf("RECOMMENDATION_LIMIT must be positive, not %d", cfg.Recommendation.Limit))
+	}
+	recommendationUsecase := usecase.NewRecommendationUsecase(&usecase.RecommendationRepository{RecommendationRepo: repository.NewMysqlRecommendation(db), BookRepo: bookRepo, Limit: cfg.Recommendation.Limit})
+	recommendationHandler := delivery.NewRecommendationHandler(recommendationUsecase, cfg.BookStoreUsername, cfg.BookStorePassword)
+
+	registrations = append(registrations, &categoryHander, &publisherHandler, &authorHandler, &tagHandler, &seriesHandler, &bookHandler, &translationHandler, &skuHandler, &coverHandler, &priceHandler, &currencyHandler, &taxHandler, &warehouseHandler, &stockHandler, &reorderHandler, &supplierHandler, &purchaseOrderHandler, &bundleHandler, &promotionHandler, &orderHandler, &shippingHandler, &shipmentHandler, &paymentHandler, &returnHandler, &invoiceHandler, &reviewHandler, &wishlistHandler, &recommendationHandler)
+	h := handler.NewHandler(registrations...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go worker.Run(ctx, "expire orders", cfg.ReservationReaperInterval, func(ctx context.Context) error {
+		expired, err := orderUsecase.ExpireOrders(ctx)
+		if expired > 0 {
+			logger.Info(fmt.Sprintf("released the reservations of %d expired orders", expired), logger.Fields{})
+		}
+		return err
+	})
+
+	go worker.Run(ctx, "fulfill pre-orders", cfg.PreOrderFulfillInterval, func(ctx context.Context) error {
+		fulfilled, err := orderUsecase.FulfillPreOrders(ctx)
+		if fulfilled > 0 {
+			logger.Info(fmt.Sprintf("fulfilled %d pre-orders", fulfilled), logger.Fields{})
+		}
+		return err
+	})
+
+	go worker.Run(ctx, "scan low stock", cfg.LowStockScanInterval, func(ctx context.Context) error {
+		alerted, err := reorderUsecase.ScanLowStock(ctx)
+		if alerted > 0 {
+			logger.Info(fmt.Sprintf("sent low-stock alerts for %d books", alerted), logger.Fields{})
+		}
+		return err
+	})
+
+	go worker.Run(ctx, "notify back in stock", cfg.BackInStockInterval, func(ctx context.Context) error {
+		notified, err := wishlistUsecase.NotifyBackInStock(ctx)
+		if notified > 0 {
+			logger.Info(fmt.Sprintf("sent back-in-stock notices to %d customers", notified), logger.Fields{})
+		}
+		return err
+	})
+
+	go worker.Run(ctx, "issue invoices", cfg.Invoice.IssueInterval, func(ctx context.Context) error {
+		invoiced, err := invoiceUsecase.IssueInvoices(ctx)
+		if invoiced > 0 {
+			logger.Info(fmt.Sprintf("issued the invoices and credit notes of %d orders", invoiced), logger.Fields{})
+		}
+		return err
+	})
+
+	go worker.Run(ctx, "refresh recommendations", cfg.Recommendation.RefreshInterval, func(ctx context.Context) error {
+		stored, err := recommendationUsecase.RefreshRecommendations(ctx)
+		if stored > 0 {
+			logger.Info(fmt.Sprintf("stored %d recommendations", stored), logger.Fields{})
+		}
+		return err
+	})
+
+	if cfg.Currency.RatesFile != "" {
+		go worker.Run(ctx, "reload exchange rates", cfg.Currency.RatesReloadInterval, func(ctx context.Context) error {
+			return rates.Load(cfg.Currency.RatesFile)
+		})
+	}
 
 	s := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.Port),
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"winartodev/book-store-be/entity"
+)
+
+// NewLocaleFallbacks reads the catalog's locale and the fallbacks between locales, given as
+// locale=fallback pairs
+func NewLocaleFallbacks(cfg *Config) (entity.LocaleFallbacks, error) {
+	fallbacks := entity.LocaleFallbacks{
+		Default: entity.NormalizeLocale(cfg.Locale.Default),
+		Next:    make(map[string]string),
+	}
+	if fallbacks.Default == "" {
+		return entity.LocaleFallbacks{}, fmt.Errorf("unknown locale %q", cfg.Locale.Default)
+	}
+
+	for _, pair := range cfg.Locale.Fallbacks {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return entity.LocaleFallbacks{}, fmt.Errorf("locale fallback %q is not locale=fallback", pair)
+		}
+
+		locale, next := entity.NormalizeLocale(parts[0]), entity.NormalizeLocale(parts[1])
+		if locale == "" || next == "" {
+			return entity.LocaleFallbacks{}, fmt.Errorf("locale fallback %q is not locale=fallback", pair)
+		}
+		fallbacks.Next[locale] = next
+	}
+
+	return fallbacks, nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"winartodev/book-store-be/notifier"
+)
+
+// NewNotifier returns the notifiers low-stock alerts are delivered through
+func NewNotifier(cfg *Config) (notifier.Notifier, error) {
+	return newNotifier(cfg, cfg.Notifier.Drivers, true)
+}
+
+// NewBackInStockNotifier returns the notifiers customers are told through that the books they
+// wait for are back in stock. The smtp notifier mails the customer, so SMTP_TO is not needed.
+func NewBackInStockNotifier(cfg *Config) (notifier.Notifier, error) {
+	return newNotifier(cfg, cfg.Notifier.BackInStockDrivers, false)
+}
+
+func newNotifier(cfg *Config, drivers []string, needsRecipients bool) (notifier.Notifier, error) {
+	var notifiers []notifier.Notifier
+	for _, driver := range drivers {
+		switch driver {
+		case "log":
+			notifiers = append(notifiers, notifier.NewLog())
+		case "webhook":
+			if cfg.Notifier.WebhookURL == "" {
+				return nil, errors.New("webhook notifier needs NOTIFIER_WEBHOOK_URL")
+			}
+			notifiers = append(notifiers, notifier.NewWebhook(cfg.Notifier.WebhookURL, nil))
+		case "smtp":
+			if needsRecipients && len(cfg.Notifier.SMTPTo) == 0 {
+				return nil, errors.New("smtp notifier needs SMTP_TO")
+			}
+			notifiers = append(notifiers, notifier.NewSMTP(notifier.SMTPConfig{
+				Host:     cfg.Notifier.SMTPHost,
+				Port:     cfg.Notifier.SMTPPort,
+				Username: cfg.Notifier.SMTPUsername,
+				Password: cfg.Notifier.SMTPPassword,
+				From:     cfg.Notifier.SMTPFrom,
+				To:       cfg.Notifier.SMTPTo,
+			}))
+		default:
+			return nil, fmt.Errorf("unknown notifier %q", driver)
+		}
+	}
+
+	return notifier.NewMulti(notifiers...), nil
+}
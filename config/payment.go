@@ -0,0 +1,16 @@
+package config
+
+import (
+	"fmt"
+	"winartodev/book-store-be/payment"
+)
+
+// NewPaymentGateway returns the gateway orders are paid through
+func NewPaymentGateway(cfg *Config) (payment.PaymentGateway, error) {
+	switch cfg.Payment.Gateway {
+	case "fake":
+		return payment.NewFake(cfg.Payment.WebhookSecret), nil
+	}
+
+	return nil, fmt.Errorf("unknown payment gateway %q", cfg.Payment.Gateway)
+}
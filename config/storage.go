@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/storage"
+)
+
+// NewStorage returns the configured file storage. Files kept on the local filesystem are
+// served by the API itself, so a handler serving them is returned as well.
+func NewStorage(cfg *Config) (storage.Storage, []handler.Registration, error) {
+	switch cfg.Storage.Driver {
+	case "local":
+		u, err := url.Parse(cfg.Storage.LocalURL)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		static := delivery.NewStaticHandler(u.Path, http.Dir(cfg.Storage.LocalDir))
+		return storage.NewLocal(cfg.Storage.LocalDir, cfg.Storage.LocalURL), []handler.Registration{&static}, nil
+	case "s3":
+		return storage.NewS3(storage.S3Config{
+			Endpoint:  cfg.Storage.S3Endpoint,
+			Region:    cfg.Storage.S3Region,
+			Bucket:    cfg.Storage.S3Bucket,
+			AccessKey: cfg.Storage.S3AccessKey,
+			SecretKey: cfg.Storage.S3SecretKey,
+			PublicURL: cfg.Storage.S3PublicURL,
+		}, nil), nil, nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+}
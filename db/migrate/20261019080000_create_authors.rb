@@ -0,0 +1,103 @@
+class CreateAuthors < ActiveRecord::Migration[5.2]
+  def up
+    create_table :authors do |t|
+      t.string :name
+      t.string :normalized_name
+      t.timestamps
+    end
+    add_index :authors, :normalized_name, unique: true
+
+    create_table :book_authors do |t|
+      t.integer :book_id, null: false
+      t.integer :author_id, null: false
+      t.string :role, null: false, default: "author"
+      t.integer :position, null: false, default: 1
+    end
+    add_index :book_authors, [:book_id, :author_id, :role], unique: true
+    add_index :book_authors, :author_id
+    add_foreign_key :book_authors, :books, on_delete: :cascade
+    add_foreign_key :book_authors, :authors, on_delete: :cascade
+
+    # Split "A, B & C and D" into one author per name. The serial comma of "A, B, and C" does
+    # not make an author "and C", and a comma before a suffix or initials, as in "King, Jr." and
+    # "Tolkien, J.R.R.", stays in the name.
+    execute <<-'SQL'
+      CREATE FUNCTION pg_temp.split_authors(author text) RETURNS TABLE (author_name text, author_position bigint) AS $$
+        SELECT string_agg(part, ', ' ORDER BY ordinal), row_number() OVER (ORDER BY MIN(ordinal))
+        FROM (
+          SELECT part, ordinal,
+                 SUM(CASE WHEN part ~* '^(jr|sr|ii|iii|iv)\.?$' OR part ~ '^([A-Z]\.\s*)+$' THEN 0 ELSE 1 END) OVER (ORDER BY ordinal) AS author_number
+          FROM (
+            SELECT trim(regexp_replace(regexp_replace(p.part, '^and\s+', '', 'i'), '\s+', ' ', 'g')) AS part, p.ordinal
+            FROM regexp_split_to_table(author, '\s*(,\s*and\s+|,|;|&|\s+and\s+)\s*') WITH ORDINALITY AS p(part, ordinal)
+          ) split
+          WHERE part <> ''
+        ) parts
+        GROUP BY author_number
+      $$ LANGUAGE sql IMMUTABLE;
+    SQL
+
+    # the forms the split has to get right, the migration stops before touching any book if one
+    # of them comes out differently
+    execute <<-'SQL'
+      DO $$
+      DECLARE
+        sample text;
+        want text;
+        got text;
+      BEGIN
+        FOR sample, want IN SELECT * FROM (VALUES
+          ('A, B & C and D', 'A | B | C | D'),
+          ('A, B, and C', 'A | B | C'),
+          ('A; and B', 'A | B'),
+          ('Tolkien, J.R.R.', 'Tolkien, J.R.R.'),
+          ('Martin Luther King, Jr.', 'Martin Luther King, Jr.'),
+          ('Rowling, J. K. and Tolkien, J.R.R.', 'Rowling, J. K. | Tolkien, J.R.R.')
+        ) AS samples
+        LOOP
+          SELECT string_agg(author_name, ' | ' ORDER BY author_position) INTO got FROM pg_temp.split_authors(sample);
+          IF got IS DISTINCT FROM want THEN
+            RAISE EXCEPTION 'authors % are split into %, not %', sample, got, want;
+          END IF;
+        END LOOP;
+      END
+      $$;
+    SQL
+
+    # one author per normalized name
+    execute <<-'SQL'
+      INSERT INTO authors (name, normalized_name, created_at, updated_at)
+      SELECT DISTINCT ON (lower(a.author_name)) a.author_name, lower(a.author_name), NOW(), NOW()
+      FROM books
+      CROSS JOIN LATERAL pg_temp.split_authors(books.author) AS a
+      ORDER BY lower(a.author_name), a.author_name;
+    SQL
+
+    execute <<-'SQL'
+      INSERT INTO book_authors (book_id, author_id, role, position)
+      SELECT b.id, au.id, 'author', MIN(a.author_position)
+      FROM books b
+      CROSS JOIN LATERAL pg_temp.split_authors(b.author) AS a
+      JOIN authors au ON au.normalized_name = lower(a.author_name)
+      GROUP BY b.id, au.id;
+    SQL
+
+    remove_column :books, :author
+  end
+
+  def down
+    add_column :books, :author, :string
+
+    execute <<-'SQL'
+      UPDATE books SET author = (
+        SELECT string_agg(a.name, ', ' ORDER BY ba.position)
+        FROM book_authors ba
+        JOIN authors a ON a.id = ba.author_id
+        WHERE ba.book_id = books.id AND ba.role = 'author'
+      );
+    SQL
+
+    drop_table :book_authors
+    drop_table :authors
+  end
+end
@@ -0,0 +1,13 @@
+class AddParentIdToCategories < ActiveRecord::Migration[5.2]
+  def up
+    add_column :categories, :parent_id, :integer
+    add_index :categories, :parent_id
+    add_foreign_key :categories, :categories, column: :parent_id
+  end
+
+  def down
+    remove_foreign_key :categories, column: :parent_id
+    remove_index :categories, :parent_id
+    remove_column :categories, :parent_id
+  end
+end
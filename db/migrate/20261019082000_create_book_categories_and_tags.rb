@@ -0,0 +1,43 @@
+class CreateBookCategoriesAndTags < ActiveRecord::Migration[5.2]
+  def up
+    create_table :book_categories do |t|
+      t.integer :book_id, null: false
+      t.integer :category_id, null: false
+      t.boolean :is_primary, null: false, default: false
+    end
+    add_index :book_categories, [:book_id, :category_id], unique: true
+    add_index :book_categories, :category_id
+    add_index :book_categories, :book_id, unique: true, where: "is_primary", name: "index_book_categories_on_primary"
+    add_foreign_key :book_categories, :books, on_delete: :cascade
+    add_foreign_key :book_categories, :categories, on_delete: :cascade
+
+    execute <<-'SQL'
+      INSERT INTO book_categories (book_id, category_id, is_primary)
+      SELECT books.id, books.category_id, TRUE
+      FROM books
+      JOIN categories ON categories.id = books.category_id;
+    SQL
+
+    create_table :tags do |t|
+      t.string :name, null: false
+      t.string :slug, null: false
+      t.datetime :created_at, null: false
+    end
+    add_index :tags, :slug, unique: true
+
+    create_table :book_tags do |t|
+      t.integer :book_id, null: false
+      t.integer :tag_id, null: false
+    end
+    add_index :book_tags, [:book_id, :tag_id], unique: true
+    add_index :book_tags, :tag_id
+    add_foreign_key :book_tags, :books, on_delete: :cascade
+    add_foreign_key :book_tags, :tags, on_delete: :cascade
+  end
+
+  def down
+    drop_table :book_tags
+    drop_table :tags
+    drop_table :book_categories
+  end
+end
@@ -0,0 +1,23 @@
+class CreateSkus < ActiveRecord::Migration[5.2]
+  def up
+    create_table :skus do |t|
+      t.integer :book_id, null: false
+      t.string :format, null: false
+      t.string :isbn, null: false, default: ""
+      t.integer :price, null: false, default: 0
+      t.integer :stock, null: false, default: 0
+      t.integer :weight_grams, null: false, default: 0
+      t.integer :width_mm, null: false, default: 0
+      t.integer :height_mm, null: false, default: 0
+      t.integer :depth_mm, null: false, default: 0
+      t.timestamps
+    end
+    add_index :skus, :book_id
+    add_index :skus, :isbn, unique: true, where: "isbn <> ''"
+    add_foreign_key :skus, :books, on_delete: :cascade
+  end
+
+  def down
+    drop_table :skus
+  end
+end
@@ -0,0 +1,19 @@
+class CreateBookCovers < ActiveRecord::Migration[5.2]
+  def up
+    create_table :book_covers do |t|
+      t.integer :book_id, null: false
+      t.string :rendition, null: false
+      t.string :storage_key, null: false
+      t.string :content_type, null: false
+      t.integer :width, null: false
+      t.integer :height, null: false
+      t.datetime :created_at, null: false
+    end
+    add_index :book_covers, [:book_id, :rendition], unique: true
+    add_foreign_key :book_covers, :books, on_delete: :cascade
+  end
+
+  def down
+    drop_table :book_covers
+  end
+end
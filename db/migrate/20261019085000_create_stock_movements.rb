@@ -0,0 +1,27 @@
+class CreateStockMovements < ActiveRecord::Migration[5.2]
+  def up
+    create_table :stock_movements do |t|
+      t.integer :book_id, null: false
+      t.string :movement_type, null: false
+      t.integer :quantity, null: false
+      t.string :reason, null: false, default: ""
+      t.string :actor, null: false
+      t.integer :stock_after, null: false
+      t.datetime :created_at, null: false
+    end
+    add_index :stock_movements, [:book_id, :id]
+    add_foreign_key :stock_movements, :books, on_delete: :cascade
+
+    # open the ledger of every existing book with its current stock so the history adds up
+    execute <<-'SQL'
+      INSERT INTO stock_movements (book_id, movement_type, quantity, reason, actor, stock_after, created_at)
+      SELECT id, 'adjustment', stock, 'opening balance', 'system', stock, NOW()
+      FROM books
+      WHERE stock <> 0
+    SQL
+  end
+
+  def down
+    drop_table :stock_movements
+  end
+end
@@ -0,0 +1,46 @@
+class CreateOrdersAndStockReservations < ActiveRecord::Migration[5.2]
+  def up
+    add_column :books, :reserved, :integer, null: false, default: 0
+
+    create_table :orders do |t|
+      t.string :customer_email, null: false
+      t.string :status, null: false
+      t.datetime :expires_at, null: false
+      t.timestamps
+    end
+    add_index :orders, [:status, :expires_at]
+
+    create_table :order_lines do |t|
+      t.integer :order_id, null: false
+      t.integer :book_id, null: false
+      t.integer :quantity, null: false
+    end
+    add_index :order_lines, :order_id
+    add_index :order_lines, :book_id
+    add_foreign_key :order_lines, :orders, on_delete: :cascade
+    add_foreign_key :order_lines, :books
+
+    create_table :stock_reservations do |t|
+      t.integer :order_id, null: false
+      t.integer :book_id, null: false
+      t.integer :quantity, null: false
+      t.datetime :expires_at, null: false
+      t.datetime :created_at, null: false
+    end
+    add_index :stock_reservations, :order_id
+    add_index :stock_reservations, :book_id
+    add_foreign_key :stock_reservations, :orders, on_delete: :cascade
+    add_foreign_key :stock_reservations, :books, on_delete: :cascade
+
+    execute <<-'SQL'
+      ALTER TABLE books ADD CONSTRAINT books_reserved_not_negative CHECK (reserved >= 0)
+    SQL
+  end
+
+  def down
+    drop_table :stock_reservations
+    drop_table :order_lines
+    drop_table :orders
+    remove_column :books, :reserved
+  end
+end
@@ -0,0 +1,73 @@
+class CreateWarehouses < ActiveRecord::Migration[5.2]
+  def up
+    create_table :warehouses do |t|
+      t.string :code, null: false
+      t.string :name, null: false
+      t.float :latitude, null: false, default: 0
+      t.float :longitude, null: false, default: 0
+      t.timestamps
+    end
+    add_index :warehouses, :code, unique: true
+
+    create_table :warehouse_stocks do |t|
+      t.integer :warehouse_id, null: false
+      t.integer :book_id, null: false
+      t.integer :stock, null: false, default: 0
+      t.integer :reserved, null: false, default: 0
+    end
+    add_index :warehouse_stocks, [:warehouse_id, :book_id], unique: true
+    add_index :warehouse_stocks, :book_id
+    add_foreign_key :warehouse_stocks, :warehouses, on_delete: :cascade
+    add_foreign_key :warehouse_stocks, :books, on_delete: :cascade
+
+    create_table :order_allocations do |t|
+      t.integer :order_line_id, null: false
+      t.integer :warehouse_id, null: false
+      t.integer :quantity, null: false
+    end
+    add_index :order_allocations, :order_line_id
+    add_foreign_key :order_allocations, :order_lines, on_delete: :cascade
+    add_foreign_key :order_allocations, :warehouses
+
+    add_column :stock_movements, :warehouse_id, :integer
+    add_column :stock_reservations, :warehouse_id, :integer
+    add_column :orders, :shipping_latitude, :float
+    add_column :orders, :shipping_longitude, :float
+
+    # Everything kept so far was in the one warehouse we had, it becomes the default warehouse
+    execute <<-'SQL'
+      INSERT INTO warehouses (code, name, latitude, longitude, created_at, updated_at)
+      VALUES ('MAIN', 'Main Warehouse', 0, 0, NOW(), NOW());
+
+      INSERT INTO warehouse_stocks (warehouse_id, book_id, stock, reserved)
+      SELECT w.id, b.id, b.stock, b.reserved
+      FROM books b CROSS JOIN warehouses w
+      WHERE w.code = 'MAIN';
+
+      UPDATE stock_movements SET warehouse_id = (SELECT id FROM warehouses WHERE code = 'MAIN');
+      UPDATE stock_reservations SET warehouse_id = (SELECT id FROM warehouses WHERE code = 'MAIN');
+
+      INSERT INTO order_allocations (order_line_id, warehouse_id, quantity)
+      SELECT l.id, r.warehouse_id, r.quantity
+      FROM stock_reservations r
+      JOIN order_lines l ON l.order_id = r.order_id AND l.book_id = r.book_id;
+
+      ALTER TABLE warehouse_stocks ADD CONSTRAINT warehouse_stocks_within_stock CHECK (reserved >= 0 AND reserved <= stock);
+    SQL
+
+    change_column_null :stock_movements, :warehouse_id, false
+    change_column_null :stock_reservations, :warehouse_id, false
+    add_index :stock_reservations, [:warehouse_id, :book_id]
+  end
+
+  def down
+    remove_index :stock_reservations, [:warehouse_id, :book_id]
+    remove_column :orders, :shipping_longitude
+    remove_column :orders, :shipping_latitude
+    remove_column :stock_reservations, :warehouse_id
+    remove_column :stock_movements, :warehouse_id
+    drop_table :order_allocations
+    drop_table :warehouse_stocks
+    drop_table :warehouses
+  end
+end
@@ -0,0 +1,17 @@
+class CreateReorderPoints < ActiveRecord::Migration[5.2]
+  def up
+    create_table :reorder_points do |t|
+      t.integer :book_id, null: false
+      t.integer :threshold, null: false
+      t.integer :reorder_quantity, null: false, default: 0
+      t.datetime :alerted_at
+      t.timestamps
+    end
+    add_index :reorder_points, :book_id, unique: true
+    add_foreign_key :reorder_points, :books, on_delete: :cascade
+  end
+
+  def down
+    drop_table :reorder_points
+  end
+end
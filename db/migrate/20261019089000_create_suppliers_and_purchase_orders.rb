@@ -0,0 +1,49 @@
+class CreateSuppliersAndPurchaseOrders < ActiveRecord::Migration[5.2]
+  def up
+    create_table :suppliers do |t|
+      t.string :name, null: false
+      t.integer :publisher_id
+      t.string :email, null: false, default: ""
+      t.string :phone_number, null: false, default: ""
+      t.string :address, null: false, default: ""
+      t.timestamps
+    end
+    add_index :suppliers, :publisher_id
+    add_foreign_key :suppliers, :publishers, on_delete: :nullify
+
+    create_table :purchase_orders do |t|
+      t.integer :supplier_id, null: false
+      t.integer :warehouse_id, null: false
+      t.string :status, null: false
+      t.datetime :expected_at
+      t.text :notes, null: false, default: ""
+      t.timestamps
+    end
+    add_index :purchase_orders, :supplier_id
+    add_index :purchase_orders, :status
+    add_foreign_key :purchase_orders, :suppliers
+    add_foreign_key :purchase_orders, :warehouses
+
+    create_table :purchase_order_lines do |t|
+      t.integer :purchase_order_id, null: false
+      t.integer :book_id, null: false
+      t.integer :quantity, null: false
+      t.integer :received_quantity, null: false, default: 0
+      t.integer :unit_cost, null: false, default: 0
+    end
+    add_index :purchase_order_lines, [:purchase_order_id, :book_id], unique: true
+    add_index :purchase_order_lines, :book_id
+    add_foreign_key :purchase_order_lines, :purchase_orders, on_delete: :cascade
+    add_foreign_key :purchase_order_lines, :books
+
+    execute <<-'SQL'
+      ALTER TABLE purchase_order_lines ADD CONSTRAINT purchase_order_lines_within_quantity CHECK (received_quantity >= 0 AND received_quantity <= quantity);
+    SQL
+  end
+
+  def down
+    drop_table :purchase_order_lines
+    drop_table :purchase_orders
+    drop_table :suppliers
+  end
+end
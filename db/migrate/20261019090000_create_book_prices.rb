@@ -0,0 +1,31 @@
+class CreateBookPrices < ActiveRecord::Migration[5.2]
+  def up
+    create_table :book_prices do |t|
+      t.integer :book_id, null: false
+      t.integer :price, null: false
+      t.datetime :effective_from, null: false
+      t.datetime :effective_to
+      t.datetime :created_at, null: false
+    end
+    add_index :book_prices, [:book_id, :effective_from]
+    add_foreign_key :book_prices, :books, on_delete: :cascade
+
+    add_column :order_lines, :unit_price, :integer, null: false, default: 0
+
+    # The price kept so far is the start of every book's price history, orders placed before
+    # prices were recorded are priced at it
+    execute <<-'SQL'
+      INSERT INTO book_prices (book_id, price, effective_from, created_at)
+      SELECT id, price, created_at, NOW() FROM books;
+
+      UPDATE order_lines SET unit_price = books.price FROM books WHERE books.id = order_lines.book_id;
+
+      ALTER TABLE book_prices ADD CONSTRAINT book_prices_valid_range CHECK (price >= 0 AND (effective_to IS NULL OR effective_to > effective_from));
+    SQL
+  end
+
+  def down
+    remove_column :order_lines, :unit_price
+    drop_table :book_prices
+  end
+end
@@ -0,0 +1,52 @@
+class CreatePromotions < ActiveRecord::Migration[5.2]
+  def up
+    create_table :promotions do |t|
+      t.string :name, null: false
+      t.string :promotion_type, null: false
+      t.integer :value, null: false, default: 0
+      t.string :scope, null: false, default: "all"
+      t.integer :scope_ids, array: true, null: false, default: []
+      t.integer :buy_quantity, null: false, default: 0
+      t.integer :get_quantity, null: false, default: 0
+      t.integer :min_spend, null: false, default: 0
+      t.string :coupon_code
+      t.integer :usage_limit, null: false, default: 0
+      t.integer :per_customer_limit, null: false, default: 0
+      t.boolean :stackable, null: false, default: false
+      t.integer :priority, null: false, default: 0
+      t.datetime :starts_at
+      t.datetime :ends_at
+      t.timestamps
+    end
+    add_index :promotions, :coupon_code, unique: true
+
+    # Redemptions keep what the order got even when the promotion is changed or deleted later
+    create_table :promotion_redemptions do |t|
+      t.integer :promotion_id
+      t.integer :order_id, null: false
+      t.string :customer_email, null: false
+      t.string :name, null: false
+      t.string :coupon_code, null: false, default: ""
+      t.string :description, null: false, default: ""
+      t.integer :discount, null: false, default: 0
+      t.datetime :created_at, null: false
+    end
+    add_index :promotion_redemptions, :promotion_id
+    add_index :promotion_redemptions, :order_id
+    add_foreign_key :promotion_redemptions, :promotions, on_delete: :nullify
+    add_foreign_key :promotion_redemptions, :orders, on_delete: :cascade
+
+    add_column :order_lines, :discount, :integer, null: false, default: 0
+
+    execute <<-'SQL'
+      ALTER TABLE promotions ADD CONSTRAINT promotions_valid_values CHECK (value >= 0 AND min_spend >= 0 AND usage_limit >= 0 AND per_customer_limit >= 0 AND (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at));
+      ALTER TABLE order_lines ADD CONSTRAINT order_lines_discount_within_price CHECK (discount >= 0 AND discount <= unit_price * quantity);
+    SQL
+  end
+
+  def down
+    remove_column :order_lines, :discount
+    drop_table :promotion_redemptions
+    drop_table :promotions
+  end
+end
@@ -0,0 +1,21 @@
+class CreateBookCurrencyPrices < ActiveRecord::Migration[5.2]
+  def up
+    create_table :book_currency_prices do |t|
+      t.integer :book_id, null: false
+      t.string :currency, limit: 3, null: false
+      t.bigint :price, null: false
+      t.timestamps
+    end
+    add_index :book_currency_prices, [:book_id, :currency], unique: true
+    add_index :book_currency_prices, :currency
+    add_foreign_key :book_currency_prices, :books, on_delete: :cascade
+
+    execute <<-'SQL'
+      ALTER TABLE book_currency_prices ADD CONSTRAINT book_currency_prices_valid CHECK (price >= 0 AND currency ~ '^[A-Z]{3}$');
+    SQL
+  end
+
+  def down
+    drop_table :book_currency_prices
+  end
+end
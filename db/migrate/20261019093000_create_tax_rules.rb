@@ -0,0 +1,61 @@
+class CreateTaxRules < ActiveRecord::Migration[5.2]
+  def up
+    create_table :tax_categories do |t|
+      t.string :code, null: false
+      t.string :name, null: false
+      t.timestamps
+    end
+    add_index :tax_categories, :code, unique: true
+
+    # A rule with an empty region covers the whole country, one with no tax category covers the
+    # books of every category that has no rule of its own
+    create_table :tax_rules do |t|
+      t.string :country, limit: 2, null: false
+      t.string :region, null: false, default: ""
+      t.integer :tax_category_id
+      t.string :name, null: false
+      t.integer :rate, null: false
+      t.timestamps
+    end
+    add_index :tax_rules, [:country, :region, :tax_category_id], unique: true
+    add_index :tax_rules, :tax_category_id
+    add_foreign_key :tax_rules, :tax_categories, on_delete: :cascade
+
+    add_column :books, :tax_category_id, :integer
+    add_index :books, :tax_category_id
+    add_foreign_key :books, :tax_categories, on_delete: :nullify
+
+    add_column :orders, :shipping_country, :string, null: false, default: ""
+    add_column :orders, :shipping_region, :string, null: false, default: ""
+    add_column :orders, :prices_include_tax, :boolean, null: false, default: false
+    add_column :order_lines, :tax, :integer, null: false, default: 0
+
+    # Tax lines keep what the order was charged even when the rules are changed later
+    create_table :order_tax_lines do |t|
+      t.integer :order_id, null: false
+      t.string :name, null: false
+      t.integer :rate, null: false
+      t.integer :taxable, null: false
+      t.integer :amount, null: false
+    end
+    add_index :order_tax_lines, :order_id
+    add_foreign_key :order_tax_lines, :orders, on_delete: :cascade
+
+    execute <<-'SQL'
+      ALTER TABLE tax_rules ADD CONSTRAINT tax_rules_rate_range CHECK (rate >= 0 AND rate <= 10000);
+      ALTER TABLE order_lines ADD CONSTRAINT order_lines_tax_positive CHECK (tax >= 0);
+    SQL
+  end
+
+  def down
+    drop_table :order_tax_lines
+    remove_column :order_lines, :tax
+    remove_column :orders, :prices_include_tax
+    remove_column :orders, :shipping_region
+    remove_column :orders, :shipping_country
+    remove_foreign_key :books, :tax_categories
+    remove_column :books, :tax_category_id
+    drop_table :tax_rules
+    drop_table :tax_categories
+  end
+end
@@ -0,0 +1,31 @@
+class CreatePayments < ActiveRecord::Migration[5.2]
+  def up
+    # A payment is recorded before the gateway is asked for it, the unique idempotency key
+    # keeps retried attempts from charging the customer twice
+    create_table :payments do |t|
+      t.integer :order_id, null: false
+      t.string :idempotency_key, null: false
+      t.string :gateway, null: false
+      t.string :reference, null: false, default: ""
+      t.string :status, null: false
+      t.integer :amount, null: false
+      t.string :currency, limit: 3, null: false
+      t.integer :captured_amount, null: false, default: 0
+      t.integer :refunded_amount, null: false, default: 0
+      t.string :failure_reason, null: false, default: ""
+      t.timestamps
+    end
+    add_index :payments, :idempotency_key, unique: true
+    add_index :payments, :order_id
+    add_index :payments, [:gateway, :reference]
+    add_foreign_key :payments, :orders
+
+    execute <<-'SQL'
+      ALTER TABLE payments ADD CONSTRAINT payments_valid_amounts CHECK (amount > 0 AND captured_amount >= 0 AND captured_amount <= amount AND refunded_amount >= 0 AND refunded_amount <= captured_amount);
+    SQL
+  end
+
+  def down
+    drop_table :payments
+  end
+end
@@ -0,0 +1,45 @@
+class CreateReturns < ActiveRecord::Migration[5.2]
+  def up
+    create_table :returns do |t|
+      t.integer :order_id, null: false
+      t.string :status, null: false
+      t.text :customer_note, null: false, default: ""
+      t.text :staff_note, null: false, default: ""
+      t.integer :refund_amount, null: false, default: 0
+      t.timestamps
+    end
+    add_index :returns, :order_id
+    add_index :returns, :status
+    add_foreign_key :returns, :orders
+
+    # disposition and warehouse_id are set when the books are received
+    create_table :return_lines do |t|
+      t.integer :return_id, null: false
+      t.integer :order_line_id, null: false
+      t.integer :book_id, null: false
+      t.integer :quantity, null: false
+      t.string :reason, null: false
+      t.string :disposition, null: false, default: ""
+      t.integer :warehouse_id
+    end
+    add_index :return_lines, :return_id
+    add_index :return_lines, :order_line_id
+    add_foreign_key :return_lines, :returns
+    add_foreign_key :return_lines, :order_lines
+    add_foreign_key :return_lines, :books
+    add_foreign_key :return_lines, :warehouses
+
+    add_column :orders, :refunded_total, :integer, null: false, default: 0
+
+    execute <<-'SQL'
+      ALTER TABLE returns ADD CONSTRAINT returns_refund_amount_not_negative CHECK (refund_amount >= 0);
+      ALTER TABLE return_lines ADD CONSTRAINT return_lines_quantity_positive CHECK (quantity > 0);
+    SQL
+  end
+
+  def down
+    remove_column :orders, :refunded_total
+    drop_table :return_lines
+    drop_table :returns
+  end
+end
@@ -0,0 +1,70 @@
+class CreateShippingMethodsAndShipments < ActiveRecord::Migration[5.2]
+  def up
+    add_column :books, :weight, :integer, null: false, default: 0
+
+    create_table :shipping_methods do |t|
+      t.string :code, null: false
+      t.string :name, null: false
+      t.string :carrier, null: false
+      t.boolean :active, null: false, default: true
+      t.timestamps
+    end
+    add_index :shipping_methods, :code, unique: true
+
+    # an empty country is the zone of anywhere else, a max_weight of 0 has no limit
+    create_table :shipping_rates do |t|
+      t.integer :shipping_method_id, null: false
+      t.string :country, limit: 2, null: false, default: ""
+      t.string :region, null: false, default: ""
+      t.integer :max_weight, null: false, default: 0
+      t.integer :price, null: false
+      t.timestamps
+    end
+    add_index :shipping_rates, [:shipping_method_id, :country, :region]
+    add_foreign_key :shipping_rates, :shipping_methods, on_delete: :cascade
+
+    add_column :orders, :shipping_method_id, :integer
+    add_column :orders, :shipping_cost, :integer, null: false, default: 0
+    add_foreign_key :orders, :shipping_methods, on_delete: :nullify
+
+    create_table :shipments do |t|
+      t.integer :order_id, null: false
+      t.string :carrier, null: false
+      t.string :tracking_number, null: false
+      t.string :status, null: false
+      t.datetime :shipped_at, null: false
+      t.datetime :delivered_at
+      t.timestamps
+    end
+    add_index :shipments, :order_id
+    add_foreign_key :shipments, :orders
+
+    create_table :shipment_lines do |t|
+      t.integer :shipment_id, null: false
+      t.integer :order_line_id, null: false
+      t.integer :book_id, null: false
+      t.integer :quantity, null: false
+    end
+    add_index :shipment_lines, :shipment_id
+    add_index :shipment_lines, :order_line_id
+    add_foreign_key :shipment_lines, :shipments, on_delete: :cascade
+    add_foreign_key :shipment_lines, :order_lines
+    add_foreign_key :shipment_lines, :books
+
+    execute <<-'SQL'
+      ALTER TABLE books ADD CONSTRAINT books_weight_not_negative CHECK (weight >= 0);
+      ALTER TABLE shipping_rates ADD CONSTRAINT shipping_rates_valid CHECK (max_weight >= 0 AND price >= 0);
+      ALTER TABLE shipment_lines ADD CONSTRAINT shipment_lines_quantity_positive CHECK (quantity > 0);
+    SQL
+  end
+
+  def down
+    drop_table :shipment_lines
+    drop_table :shipments
+    remove_column :orders, :shipping_cost
+    remove_column :orders, :shipping_method_id
+    drop_table :shipping_rates
+    drop_table :shipping_methods
+    remove_column :books, :weight
+  end
+end
@@ -0,0 +1,76 @@
+class CreateInvoices < ActiveRecord::Migration[5.2]
+  def up
+    # the last number issued of every kind in every fiscal year, its row is held while an
+    # invoice is issued so numbers are gapless
+    create_table :invoice_sequences do |t|
+      t.string :kind, null: false
+      t.integer :fiscal_year, null: false
+      t.integer :last_sequence, null: false
+    end
+    add_index :invoice_sequences, [:kind, :fiscal_year], unique: true
+
+    # invoices are never changed once issued
+    create_table :invoices do |t|
+      t.integer :order_id, null: false
+      t.string :kind, null: false
+      t.string :number, null: false
+      t.integer :fiscal_year, null: false
+      t.integer :sequence, null: false
+      t.integer :credited_invoice_id
+      t.string :customer_email, null: false
+      t.string :shipping_country, null: false, default: ""
+      t.string :shipping_region, null: false, default: ""
+      t.string :currency, limit: 3, null: false
+      t.boolean :prices_include_tax, null: false, default: false
+      t.integer :subtotal, null: false
+      t.integer :tax, null: false
+      t.integer :shipping, null: false, default: 0
+      t.integer :total, null: false
+      t.string :source, null: false
+      t.datetime :issued_at, null: false
+      t.datetime :created_at, null: false
+    end
+    add_index :invoices, :order_id
+    add_index :invoices, :number, unique: true
+    add_index :invoices, [:kind, :fiscal_year, :sequence], unique: true
+    add_index :invoices, :source, unique: true
+    add_foreign_key :invoices, :orders
+    add_foreign_key :invoices, :invoices, column: :credited_invoice_id
+
+    create_table :invoice_lines do |t|
+      t.integer :invoice_id, null: false
+      t.integer :book_id
+      t.string :description, null: false
+      t.integer :quantity, null: false
+      t.integer :unit_price, null: false
+      t.integer :discount, null: false, default: 0
+      t.integer :tax, null: false, default: 0
+      t.integer :amount, null: false
+    end
+    add_index :invoice_lines, :invoice_id
+    add_foreign_key :invoice_lines, :invoices, on_delete: :cascade
+    add_foreign_key :invoice_lines, :books, on_delete: :nullify
+
+    create_table :invoice_tax_lines do |t|
+      t.integer :invoice_id, null: false
+      t.string :name, null: false
+      t.integer :rate, null: false
+      t.integer :taxable, null: false
+      t.integer :amount, null: false
+    end
+    add_index :invoice_tax_lines, :invoice_id
+    add_foreign_key :invoice_tax_lines, :invoices, on_delete: :cascade
+
+    execute <<-'SQL'
+      ALTER TABLE invoices ADD CONSTRAINT invoices_kind_valid CHECK (kind IN ('invoice', 'credit_note'));
+      ALTER TABLE invoices ADD CONSTRAINT invoices_sequence_positive CHECK (sequence > 0);
+    SQL
+  end
+
+  def down
+    drop_table :invoice_tax_lines
+    drop_table :invoice_lines
+    drop_table :invoices
+    drop_table :invoice_sequences
+  end
+end
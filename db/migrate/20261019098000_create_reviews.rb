@@ -0,0 +1,36 @@
+class CreateReviews < ActiveRecord::Migration[5.2]
+  def up
+    create_table :reviews do |t|
+      t.integer :book_id, null: false
+      t.string :customer_email, null: false
+      t.integer :rating, null: false
+      t.string :title, null: false, default: ""
+      t.text :body, null: false, default: ""
+      t.boolean :verified_purchase, null: false, default: false
+      t.string :status, null: false
+      t.string :moderated_by, null: false, default: ""
+      t.datetime :moderated_at
+      t.timestamps
+    end
+    add_index :reviews, [:book_id, :customer_email], unique: true
+    add_index :reviews, [:book_id, :status]
+    add_index :reviews, :status
+    add_foreign_key :reviews, :books, on_delete: :cascade
+
+    # the sum and count of the ratings of the approved reviews, kept as they are moderated so
+    # books can be sorted by their average
+    add_column :books, :rating_total, :integer, null: false, default: 0
+    add_column :books, :rating_count, :integer, null: false, default: 0
+
+    execute <<-'SQL'
+      ALTER TABLE reviews ADD CONSTRAINT reviews_rating_valid CHECK (rating BETWEEN 1 AND 5);
+      ALTER TABLE reviews ADD CONSTRAINT reviews_status_valid CHECK (status IN ('pending', 'approved', 'rejected'));
+    SQL
+  end
+
+  def down
+    remove_column :books, :rating_count
+    remove_column :books, :rating_total
+    drop_table :reviews
+  end
+end
@@ -0,0 +1,28 @@
+class CreateWishlistsAndStockSubscriptions < ActiveRecord::Migration[5.2]
+  def up
+    create_table :wishlist_items do |t|
+      t.string :customer_email, null: false
+      t.integer :book_id, null: false
+      t.datetime :created_at, null: false
+    end
+    add_index :wishlist_items, [:customer_email, :book_id], unique: true
+    add_index :wishlist_items, :book_id
+    add_foreign_key :wishlist_items, :books, on_delete: :cascade
+
+    # a subscription waits while notified_at is empty, only one per book and customer at a time
+    create_table :stock_subscriptions do |t|
+      t.integer :book_id, null: false
+      t.string :customer_email, null: false
+      t.datetime :notified_at
+      t.datetime :created_at, null: false
+    end
+    add_index :stock_subscriptions, [:book_id, :customer_email], unique: true, where: "notified_at IS NULL"
+    add_index :stock_subscriptions, :customer_email
+    add_foreign_key :stock_subscriptions, :books, on_delete: :cascade
+  end
+
+  def down
+    drop_table :stock_subscriptions
+    drop_table :wishlist_items
+  end
+end
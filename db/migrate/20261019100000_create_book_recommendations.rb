@@ -0,0 +1,24 @@
+class CreateBookRecommendations < ActiveRecord::Migration[5.2]
+  def up
+    # precomputed by the recommendation refresh, which replaces every row on each run
+    create_table :book_recommendations do |t|
+      t.integer :book_id, null: false
+      t.integer :recommended_book_id, null: false
+      t.string :reason, null: false
+      t.integer :score, null: false
+      t.datetime :computed_at, null: false
+    end
+    add_index :book_recommendations, [:book_id, :recommended_book_id], unique: true
+    add_index :book_recommendations, [:book_id, :score]
+    add_foreign_key :book_recommendations, :books, on_delete: :cascade
+    add_foreign_key :book_recommendations, :books, column: :recommended_book_id, on_delete: :cascade
+
+    execute <<-'SQL'
+      ALTER TABLE book_recommendations ADD CONSTRAINT book_recommendations_reason_valid CHECK (reason IN ('bought_together', 'similar'));
+    SQL
+  end
+
+  def down
+    drop_table :book_recommendations
+  end
+end
@@ -0,0 +1,15 @@
+class AddReleaseDateAndPreorders < ActiveRecord::Migration[5.2]
+  def up
+    # books with a release date in the future can only be pre-ordered
+    add_column :books, :release_date, :date
+    add_column :orders, :preorder, :boolean, default: false, null: false
+    # pre-orders are fulfilled in the order they were placed
+    add_index :orders, [:status, :created_at]
+  end
+
+  def down
+    remove_index :orders, [:status, :created_at]
+    remove_column :orders, :preorder
+    remove_column :books, :release_date
+  end
+end
@@ -0,0 +1,36 @@
+class CreateBundles < ActiveRecord::Migration[5.2]
+  def up
+    create_table :bundles do |t|
+      t.string :title, null: false
+      t.integer :price, null: false
+      t.timestamps
+    end
+
+    create_table :bundle_items do |t|
+      t.integer :bundle_id, null: false
+      t.integer :book_id, null: false
+      t.integer :quantity, null: false
+    end
+    add_index :bundle_items, [:bundle_id, :book_id], unique: true
+    add_index :bundle_items, :book_id
+    add_foreign_key :bundle_items, :bundles, on_delete: :cascade
+    add_foreign_key :bundle_items, :books
+
+    # the books of an ordered bundle are order lines of their own, marked with the bundle
+    add_column :order_lines, :bundle_id, :integer
+    add_index :order_lines, :bundle_id
+    add_foreign_key :order_lines, :bundles, on_delete: :nullify
+
+    execute <<-'SQL'
+      ALTER TABLE bundle_items ADD CONSTRAINT bundle_items_quantity_positive CHECK (quantity > 0);
+    SQL
+  end
+
+  def down
+    remove_foreign_key :order_lines, :bundles
+    remove_index :order_lines, :bundle_id
+    remove_column :order_lines, :bundle_id
+    drop_table :bundle_items
+    drop_table :bundles
+  end
+end
@@ -0,0 +1,30 @@
+class CreateSeries < ActiveRecord::Migration[5.2]
+  def up
+    create_table :series do |t|
+      t.string :name, null: false
+      t.text :description, null: false, default: ""
+      t.timestamps
+    end
+
+    # position is the reading order, fractional for books read between two others
+    create_table :book_series do |t|
+      t.integer :book_id, null: false
+      t.integer :series_id, null: false
+      t.decimal :position, precision: 8, scale: 3, null: false
+    end
+    add_index :book_series, [:series_id, :book_id], unique: true
+    add_index :book_series, [:series_id, :position]
+    add_index :book_series, :book_id
+    add_foreign_key :book_series, :books, on_delete: :cascade
+    add_foreign_key :book_series, :series, on_delete: :cascade
+
+    execute <<-'SQL'
+      ALTER TABLE book_series ADD CONSTRAINT book_series_position_not_negative CHECK (position >= 0);
+    SQL
+  end
+
+  def down
+    drop_table :book_series
+    drop_table :series
+  end
+end
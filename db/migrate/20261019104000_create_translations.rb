@@ -0,0 +1,45 @@
+class CreateTranslations < ActiveRecord::Migration[5.2]
+  def up
+    add_column :books, :description, :text, null: false, default: ""
+    add_column :books, :language, :string, limit: 35, null: false, default: ""
+    add_column :books, :original_language, :string, limit: 35, null: false, default: ""
+
+    # an empty field is not translated and falls back to the next locale asked for
+    create_table :book_translations do |t|
+      t.integer :book_id, null: false
+      t.string :locale, limit: 35, null: false
+      t.string :title, null: false, default: ""
+      t.text :description, null: false, default: ""
+      t.timestamps
+    end
+    add_index :book_translations, [:book_id, :locale], unique: true
+    add_foreign_key :book_translations, :books, on_delete: :cascade
+
+    create_table :category_translations do |t|
+      t.integer :category_id, null: false
+      t.string :locale, limit: 35, null: false
+      t.string :name, null: false, default: ""
+      t.timestamps
+    end
+    add_index :category_translations, [:category_id, :locale], unique: true
+    add_foreign_key :category_translations, :categories, on_delete: :cascade
+
+    create_table :publisher_translations do |t|
+      t.integer :publisher_id, null: false
+      t.string :locale, limit: 35, null: false
+      t.string :name, null: false, default: ""
+      t.timestamps
+    end
+    add_index :publisher_translations, [:publisher_id, :locale], unique: true
+    add_foreign_key :publisher_translations, :publishers, on_delete: :cascade
+  end
+
+  def down
+    drop_table :publisher_translations
+    drop_table :category_translations
+    drop_table :book_translations
+    remove_column :books, :original_language
+    remove_column :books, :language
+    remove_column :books, :description
+  end
+end
@@ -0,0 +1,36 @@
+class AddSkusToOrders < ActiveRecord::Migration[5.2]
+  def up
+    # the stock of a SKU is the part of its book's stock in that edition, it is reserved by
+    # orders and changed by the stock ledger together with the stock of the book
+    add_column :skus, :reserved, :integer, null: false, default: 0
+
+    add_column :order_lines, :sku_id, :integer
+    add_index :order_lines, :sku_id
+    add_foreign_key :order_lines, :skus
+
+    add_column :stock_reservations, :sku_id, :integer
+    add_foreign_key :stock_reservations, :skus
+
+    add_column :stock_movements, :sku_id, :integer
+    add_foreign_key :stock_movements, :skus
+
+    execute <<-'SQL'
+      ALTER TABLE skus ADD CONSTRAINT skus_reserved_within_stock CHECK (reserved >= 0 AND reserved <= stock);
+    SQL
+  end
+
+  def down
+    execute <<-'SQL'
+      ALTER TABLE skus DROP CONSTRAINT skus_reserved_within_stock;
+    SQL
+
+    remove_foreign_key :stock_movements, :skus
+    remove_column :stock_movements, :sku_id
+    remove_foreign_key :stock_reservations, :skus
+    remove_column :stock_reservations, :sku_id
+    remove_foreign_key :order_lines, :skus
+    remove_index :order_lines, :sku_id
+    remove_column :order_lines, :sku_id
+    remove_column :skus, :reserved
+  end
+end
@@ -0,0 +1,10 @@
+class LowercaseRedemptionEmails < ActiveRecord::Migration[5.2]
+  def up
+    execute <<-'SQL'
+      UPDATE promotion_redemptions SET customer_email = LOWER(customer_email);
+    SQL
+  end
+
+  def down
+  end
+end
@@ -0,0 +1,17 @@
+class RenameBooksWeightToWeightGrams < ActiveRecord::Migration[5.2]
+  def up
+    rename_column :books, :weight, :weight_grams
+
+    execute <<-'SQL'
+      ALTER TABLE books RENAME CONSTRAINT books_weight_not_negative TO books_weight_grams_not_negative;
+    SQL
+  end
+
+  def down
+    execute <<-'SQL'
+      ALTER TABLE books RENAME CONSTRAINT books_weight_grams_not_negative TO books_weight_not_negative;
+    SQL
+
+    rename_column :books, :weight_grams, :weight
+  end
+end
@@ -0,0 +1,12 @@
+class RestrictDeletingSoldBundles < ActiveRecord::Migration[5.2]
+  def up
+    # orders keep the bundles their lines were sold in
+    remove_foreign_key :order_lines, :bundles
+    add_foreign_key :order_lines, :bundles
+  end
+
+  def down
+    remove_foreign_key :order_lines, :bundles
+    add_foreign_key :order_lines, :bundles, on_delete: :nullify
+  end
+end
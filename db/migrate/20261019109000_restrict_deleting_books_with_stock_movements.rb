@@ -0,0 +1,12 @@
+class RestrictDeletingBooksWithStockMovements < ActiveRecord::Migration[5.2]
+  def up
+    # the stock ledger outlives the books it records
+    remove_foreign_key :stock_movements, :books
+    add_foreign_key :stock_movements, :books
+  end
+
+  def down
+    remove_foreign_key :stock_movements, :books
+    add_foreign_key :stock_movements, :books, on_delete: :cascade
+  end
+end
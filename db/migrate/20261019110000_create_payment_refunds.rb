@@ -0,0 +1,22 @@
+class CreatePaymentRefunds < ActiveRecord::Migration[5.2]
+  def up
+    # A refund is recorded, and its amount held on the payment, before the gateway is asked for
+    # it. Its id keys the gateway request, so concurrent refunds are never taken for retries.
+    create_table :payment_refunds do |t|
+      t.integer :payment_id, null: false
+      t.integer :amount, null: false
+      t.string :status, null: false
+      t.timestamps
+    end
+    add_index :payment_refunds, :payment_id
+    add_foreign_key :payment_refunds, :payments
+
+    execute <<-'SQL'
+      ALTER TABLE payment_refunds ADD CONSTRAINT payment_refunds_positive_amount CHECK (amount > 0);
+    SQL
+  end
+
+  def down
+    drop_table :payment_refunds
+  end
+end
@@ -0,0 +1,13 @@
+class AddTaxCategoryIdToSkus < ActiveRecord::Migration[5.2]
+  def up
+    # an edition without a tax category of its own is taxed like its book
+    add_column :skus, :tax_category_id, :integer
+    add_index :skus, :tax_category_id
+    add_foreign_key :skus, :tax_categories, on_delete: :nullify
+  end
+
+  def down
+    remove_foreign_key :skus, :tax_categories
+    remove_column :skus, :tax_category_id
+  end
+end
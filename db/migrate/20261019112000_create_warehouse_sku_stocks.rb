@@ -0,0 +1,42 @@
+class CreateWarehouseSkuStocks < ActiveRecord::Migration[5.2]
+  def up
+    # the stock of an edition kept in each warehouse, a part of the stock of its book there
+    create_table :warehouse_sku_stocks do |t|
+      t.integer :warehouse_id, null: false
+      t.integer :sku_id, null: false
+      t.integer :stock, null: false, default: 0
+      t.integer :reserved, null: false, default: 0
+    end
+    add_index :warehouse_sku_stocks, [:warehouse_id, :sku_id], unique: true
+    add_index :warehouse_sku_stocks, :sku_id
+    add_foreign_key :warehouse_sku_stocks, :warehouses, on_delete: :cascade
+    add_foreign_key :warehouse_sku_stocks, :skus, on_delete: :cascade
+
+    # Movements of editions name their warehouse, stock received before they named the edition
+    # is in the default warehouse like every unplaced movement
+    execute <<-'SQL'
+      INSERT INTO warehouse_sku_stocks (warehouse_id, sku_id, stock, reserved)
+      SELECT warehouse_id, sku_id, SUM(quantity), 0
+      FROM stock_movements
+      WHERE sku_id IS NOT NULL
+      GROUP BY warehouse_id, sku_id;
+
+      INSERT INTO warehouse_sku_stocks (warehouse_id, sku_id, stock, reserved)
+      SELECT (SELECT id FROM warehouses ORDER BY id LIMIT 1), s.id, s.stock - COALESCE(SUM(ws.stock), 0), 0
+      FROM skus s LEFT JOIN warehouse_sku_stocks ws ON ws.sku_id = s.id
+      GROUP BY s.id, s.stock
+      HAVING s.stock <> COALESCE(SUM(ws.stock), 0)
+      ON CONFLICT (warehouse_id, sku_id) DO UPDATE SET stock = warehouse_sku_stocks.stock + EXCLUDED.stock;
+
+      UPDATE warehouse_sku_stocks ws SET reserved = r.quantity
+      FROM (SELECT warehouse_id, sku_id, SUM(quantity) AS quantity FROM stock_reservations WHERE sku_id IS NOT NULL GROUP BY warehouse_id, sku_id) r
+      WHERE ws.warehouse_id = r.warehouse_id AND ws.sku_id = r.sku_id;
+
+      ALTER TABLE warehouse_sku_stocks ADD CONSTRAINT warehouse_sku_stocks_within_stock CHECK (reserved >= 0 AND reserved <= stock);
+    SQL
+  end
+
+  def down
+    drop_table :warehouse_sku_stocks
+  end
+end
@@ -10,27 +10,327 @@
 #
 # It's strongly recommended that you check this file into your version control system.
 
-ActiveRecord::Schema.define(version: 2021_12_10_001412) do
+ActiveRecord::Schema.define(version: 2026_10_19_112000) do
 
   # These are extensions that must be enabled in order to support this database
   enable_extension "plpgsql"
 
+  create_table "authors", force: :cascade do |t|
+    t.string "name"
+    t.string "normalized_name"
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["normalized_name"], name: "index_authors_on_normalized_name", unique: true
+  end
+
+  create_table "book_authors", force: :cascade do |t|
+    t.integer "book_id", null: false
+    t.integer "author_id", null: false
+    t.string "role", default: "author", null: false
+    t.integer "position", default: 1, null: false
+    t.index ["author_id"], name: "index_book_authors_on_author_id"
+    t.index ["book_id", "author_id", "role"], name: "index_book_authors_on_book_id_and_author_id_and_role", unique: true
+  end
+
+  create_table "book_categories", force: :cascade do |t|
+    t.integer "book_id", null: false
+    t.integer "category_id", null: false
+    t.boolean "is_primary", default: false, null: false
+    t.index ["book_id", "category_id"], name: "index_book_categories_on_book_id_and_category_id", unique: true
+    t.index ["book_id"], name: "index_book_categories_on_primary", unique: true, where: "is_primary"
+    t.index ["category_id"], name: "index_book_categories_on_category_id"
+  end
+
+  create_table "book_covers", force: :cascade do |t|
+    t.integer "book_id", null: false
+    t.string "rendition", null: false
+    t.string "storage_key", null: false
+    t.string "content_type", null: false
+    t.integer "width", null: false
+    t.integer "height", null: false
+    t.datetime "created_at", null: false
+    t.index ["book_id", "rendition"], name: "index_book_covers_on_book_id_and_rendition", unique: true
+  end
+
+  create_table "book_currency_prices", force: :cascade do |t|
+    t.integer "book_id", null: false
+    t.string "currency", limit: 3, null: false
+    t.bigint "price", null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["book_id", "currency"], name: "index_book_currency_prices_on_book_id_and_currency", unique: true
+    t.index ["currency"], name: "index_book_currency_prices_on_currency"
+  end
+
+  create_table "book_prices", force: :cascade do |t|
+    t.integer "book_id", null: false
+    t.integer "price", null: false
+    t.datetime "effective_from", null: false
+    t.datetime "effective_to"
+    t.datetime "created_at", null: false
+    t.index ["book_id", "effective_from"], name: "index_book_prices_on_book_id_and_effective_from"
+  end
+
+  create_table "book_recommendations", force: :cascade do |t|
+    t.integer "book_id", null: false
+    t.integer "recommended_book_id", null: false
+    t.string "reason", null: false
+    t.integer "score", null: false
+    t.datetime "computed_at", null: false
+    t.index ["book_id", "recommended_book_id"], name: "index_book_recommendations_on_book_id_and_recommended_book_id", unique: true
+    t.index ["book_id", "score"], name: "index_book_recommendations_on_book_id_and_score"
+  end
+
+  create_table "book_series", force: :cascade do |t|
+    t.integer "book_id", null: false
+    t.integer "series_id", null: false
+    t.decimal "position", precision: 8, scale: 3, null: false
+    t.index ["book_id"], name: "index_book_series_on_book_id"
+    t.index ["series_id", "book_id"], name: "index_book_series_on_series_id_and_book_id", unique: true
+    t.index ["series_id", "position"], name: "index_book_series_on_series_id_and_position"
+  end
+
+  create_table "book_tags", force: :cascade do |t|
+    t.integer "book_id", null: false
+    t.integer "tag_id", null: false
+    t.index ["book_id", "tag_id"], name: "index_book_tags_on_book_id_and_tag_id", unique: true
+    t.index ["tag_id"], name: "index_book_tags_on_tag_id"
+  end
+
+  create_table "book_translations", force: :cascade do |t|
+    t.integer "book_id", null: false
+    t.string "locale", limit: 35, null: false
+    t.string "title", default: "", null: false
+    t.text "description", default: "", null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["book_id", "locale"], name: "index_book_translations_on_book_id_and_locale", unique: true
+  end
+
   create_table "books", force: :cascade do |t|
     t.integer "publisher_id"
     t.integer "category_id"
     t.string "title"
-    t.string "author"
     t.integer "year_of_publication"
     t.integer "stock"
     t.integer "price"
     t.datetime "created_at", null: false
     t.datetime "updated_at", null: false
+    t.integer "reserved", default: 0, null: false
+    t.integer "tax_category_id"
+    t.integer "weight_grams", default: 0, null: false
+    t.integer "rating_total", default: 0, null: false
+    t.integer "rating_count", default: 0, null: false
+    t.date "release_date"
+    t.text "description", default: "", null: false
+    t.string "language", limit: 35, default: "", null: false
+    t.string "original_language", limit: 35, default: "", null: false
+    t.index ["tax_category_id"], name: "index_books_on_tax_category_id"
+  end
+
+  create_table "bundle_items", force: :cascade do |t|
+    t.integer "bundle_id", null: false
+    t.integer "book_id", null: false
+    t.integer "quantity", null: false
+    t.index ["book_id"], name: "index_bundle_items_on_book_id"
+    t.index ["bundle_id", "book_id"], name: "index_bundle_items_on_bundle_id_and_book_id", unique: true
+  end
+
+  create_table "bundles", force: :cascade do |t|
+    t.string "title", null: false
+    t.integer "price", null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
   end
 
   create_table "categories", force: :cascade do |t|
     t.string "name"
     t.datetime "created_at", null: false
     t.datetime "updated_at", null: false
+    t.integer "parent_id"
+    t.index ["parent_id"], name: "index_categories_on_parent_id"
+  end
+
+  create_table "category_translations", force: :cascade do |t|
+    t.integer "category_id", null: false
+    t.string "locale", limit: 35, null: false
+    t.string "name", default: "", null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["category_id", "locale"], name: "index_category_translations_on_category_id_and_locale", unique: true
+  end
+
+  create_table "invoice_lines", force: :cascade do |t|
+    t.integer "invoice_id", null: false
+    t.integer "book_id"
+    t.string "description", null: false
+    t.integer "quantity", null: false
+    t.integer "unit_price", null: false
+    t.integer "discount", default: 0, null: false
+    t.integer "tax", default: 0, null: false
+    t.integer "amount", null: false
+    t.index ["invoice_id"], name: "index_invoice_lines_on_invoice_id"
+  end
+
+  create_table "invoice_sequences", force: :cascade do |t|
+    t.string "kind", null: false
+    t.integer "fiscal_year", null: false
+    t.integer "last_sequence", null: false
+    t.index ["kind", "fiscal_year"], name: "index_invoice_sequences_on_kind_and_fiscal_year", unique: true
+  end
+
+  create_table "invoice_tax_lines", force: :cascade do |t|
+    t.integer "invoice_id", null: false
+    t.string "name", null: false
+    t.integer "rate", null: false
+    t.integer "taxable", null: false
+    t.integer "amount", null: false
+    t.index ["invoice_id"], name: "index_invoice_tax_lines_on_invoice_id"
+  end
+
+  create_table "invoices", force: :cascade do |t|
+    t.integer "order_id", null: false
+    t.string "kind", null: false
+    t.string "number", null: false
+    t.integer "fiscal_year", null: false
+    t.integer "sequence", null: false
+    t.integer "credited_invoice_id"
+    t.string "customer_email", null: false
+    t.string "shipping_country", default: "", null: false
+    t.string "shipping_region", default: "", null: false
+    t.string "currency", limit: 3, null: false
+    t.boolean "prices_include_tax", default: false, null: false
+    t.integer "subtotal", null: false
+    t.integer "tax", null: false
+    t.integer "shipping", default: 0, null: false
+    t.integer "total", null: false
+    t.string "source", null: false
+    t.datetime "issued_at", null: false
+    t.datetime "created_at", null: false
+    t.index ["kind", "fiscal_year", "sequence"], name: "index_invoices_on_kind_and_fiscal_year_and_sequence", unique: true
+    t.index ["number"], name: "index_invoices_on_number", unique: true
+    t.index ["order_id"], name: "index_invoices_on_order_id"
+    t.index ["source"], name: "index_invoices_on_source", unique: true
+  end
+
+  create_table "order_allocations", force: :cascade do |t|
+    t.integer "order_line_id", null: false
+    t.integer "warehouse_id", null: false
+    t.integer "quantity", null: false
+    t.index ["order_line_id"], name: "index_order_allocations_on_order_line_id"
+  end
+
+  create_table "order_lines", force: :cascade do |t|
+    t.integer "order_id", null: false
+    t.integer "book_id", null: false
+    t.integer "quantity", null: false
+    t.integer "unit_price", default: 0, null: false
+    t.integer "discount", default: 0, null: false
+    t.integer "tax", default: 0, null: false
+    t.integer "bundle_id"
+    t.integer "sku_id"
+    t.index ["book_id"], name: "index_order_lines_on_book_id"
+    t.index ["bundle_id"], name: "index_order_lines_on_bundle_id"
+    t.index ["order_id"], name: "index_order_lines_on_order_id"
+    t.index ["sku_id"], name: "index_order_lines_on_sku_id"
+  end
+
+  create_table "order_tax_lines", force: :cascade do |t|
+    t.integer "order_id", null: false
+    t.string "name", null: false
+    t.integer "rate", null: false
+    t.integer "taxable", null: false
+    t.integer "amount", null: false
+    t.index ["order_id"], name: "index_order_tax_lines_on_order_id"
+  end
+
+  create_table "orders", force: :cascade do |t|
+    t.string "customer_email", null: false
+    t.string "status", null: false
+    t.datetime "expires_at", null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.float "shipping_latitude"
+    t.float "shipping_longitude"
+    t.string "shipping_country", default: "", null: false
+    t.string "shipping_region", default: "", null: false
+    t.boolean "prices_include_tax", default: false, null: false
+    t.integer "refunded_total", default: 0, null: false
+    t.integer "shipping_method_id"
+    t.integer "shipping_cost", default: 0, null: false
+    t.boolean "preorder", default: false, null: false
+    t.index ["status", "created_at"], name: "index_orders_on_status_and_created_at"
+    t.index ["status", "expires_at"], name: "index_orders_on_status_and_expires_at"
+  end
+
+  create_table "payment_refunds", force: :cascade do |t|
+    t.integer "payment_id", null: false
+    t.integer "amount", null: false
+    t.string "status", null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["payment_id"], name: "index_payment_refunds_on_payment_id"
+  end
+
+  create_table "payments", force: :cascade do |t|
+    t.integer "order_id", null: false
+    t.string "idempotency_key", null: false
+    t.string "gateway", null: false
+    t.string "reference", default: "", null: false
+    t.string "status", null: false
+    t.integer "amount", null: false
+    t.string "currency", limit: 3, null: false
+    t.integer "captured_amount", default: 0, null: false
+    t.integer "refunded_amount", default: 0, null: false
+    t.string "failure_reason", default: "", null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["gateway", "reference"], name: "index_payments_on_gateway_and_reference"
+    t.index ["idempotency_key"], name: "index_payments_on_idempotency_key", unique: true
+    t.index ["order_id"], name: "index_payments_on_order_id"
+  end
+
+  create_table "promotion_redemptions", force: :cascade do |t|
+    t.integer "promotion_id"
+    t.integer "order_id", null: false
+    t.string "customer_email", null: false
+    t.string "name", null: false
+    t.string "coupon_code", default: "", null: false
+    t.string "description", default: "", null: false
+    t.integer "discount", default: 0, null: false
+    t.datetime "created_at", null: false
+    t.index ["order_id"], name: "index_promotion_redemptions_on_order_id"
+    t.index ["promotion_id"], name: "index_promotion_redemptions_on_promotion_id"
+  end
+
+  create_table "promotions", force: :cascade do |t|
+    t.string "name", null: false
+    t.string "promotion_type", null: false
+    t.integer "value", default: 0, null: false
+    t.string "scope", default: "all", null: false
+    t.integer "scope_ids", default: [], null: false, array: true
+    t.integer "buy_quantity", default: 0, null: false
+    t.integer "get_quantity", default: 0, null: false
+    t.integer "min_spend", default: 0, null: false
+    t.string "coupon_code"
+    t.integer "usage_limit", default: 0, null: false
+    t.integer "per_customer_limit", default: 0, null: false
+    t.boolean "stackable", default: false, null: false
+    t.integer "priority", default: 0, null: false
+    t.datetime "starts_at"
+    t.datetime "ends_at"
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["coupon_code"], name: "index_promotions_on_coupon_code", unique: true
+  end
+
+  create_table "publisher_translations", force: :cascade do |t|
+    t.integer "publisher_id", null: false
+    t.string "locale", limit: 35, null: false
+    t.string "name", default: "", null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["publisher_id", "locale"], name: "index_publisher_translations_on_publisher_id_and_locale", unique: true
   end
 
   create_table "publishers", force: :cascade do |t|
@@ -41,4 +341,322 @@ ActiveRecord::Schema.define(version: 2021_12_10_001412) do
     t.datetime "updated_at", null: false
   end
 
+  create_table "purchase_order_lines", force: :cascade do |t|
+    t.integer "purchase_order_id", null: false
+    t.integer "book_id", null: false
+    t.integer "quantity", null: false
+    t.integer "received_quantity", default: 0, null: false
+    t.integer "unit_cost", default: 0, null: false
+    t.index ["book_id"], name: "index_purchase_order_lines_on_book_id"
+    t.index ["purchase_order_id", "book_id"], name: "index_purchase_order_lines_on_purchase_order_id_and_book_id", unique: true
+  end
+
+  create_table "purchase_orders", force: :cascade do |t|
+    t.integer "supplier_id", null: false
+    t.integer "warehouse_id", null: false
+    t.string "status", null: false
+    t.datetime "expected_at"
+    t.text "notes", default: "", null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["status"], name: "index_purchase_orders_on_status"
+    t.index ["supplier_id"], name: "index_purchase_orders_on_supplier_id"
+  end
+
+  create_table "reorder_points", force: :cascade do |t|
+    t.integer "book_id", null: false
+    t.integer "threshold", null: false
+    t.integer "reorder_quantity", default: 0, null: false
+    t.datetime "alerted_at"
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["book_id"], name: "index_reorder_points_on_book_id", unique: true
+  end
+
+  create_table "return_lines", force: :cascade do |t|
+    t.integer "return_id", null: false
+    t.integer "order_line_id", null: false
+    t.integer "book_id", null: false
+    t.integer "quantity", null: false
+    t.string "reason", null: false
+    t.string "disposition", default: "", null: false
+    t.integer "warehouse_id"
+    t.index ["order_line_id"], name: "index_return_lines_on_order_line_id"
+    t.index ["return_id"], name: "index_return_lines_on_return_id"
+  end
+
+  create_table "returns", force: :cascade do |t|
+    t.integer "order_id", null: false
+    t.string "status", null: false
+    t.text "customer_note", default: "", null: false
+    t.text "staff_note", default: "", null: false
+    t.integer "refund_amount", default: 0, null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["order_id"], name: "index_returns_on_order_id"
+    t.index ["status"], name: "index_returns_on_status"
+  end
+
+  create_table "reviews", force: :cascade do |t|
+    t.integer "book_id", null: false
+    t.string "customer_email", null: false
+    t.integer "rating", null: false
+    t.string "title", default: "", null: false
+    t.text "body", default: "", null: false
+    t.boolean "verified_purchase", default: false, null: false
+    t.string "status", null: false
+    t.string "moderated_by", default: "", null: false
+    t.datetime "moderated_at"
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["book_id", "customer_email"], name: "index_reviews_on_book_id_and_customer_email", unique: true
+    t.index ["book_id", "status"], name: "index_reviews_on_book_id_and_status"
+    t.index ["status"], name: "index_reviews_on_status"
+  end
+
+  create_table "series", force: :cascade do |t|
+    t.string "name", null: false
+    t.text "description", default: "", null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+  end
+
+  create_table "shipment_lines", force: :cascade do |t|
+    t.integer "shipment_id", null: false
+    t.integer "order_line_id", null: false
+    t.integer "book_id", null: false
+    t.integer "quantity", null: false
+    t.index ["order_line_id"], name: "index_shipment_lines_on_order_line_id"
+    t.index ["shipment_id"], name: "index_shipment_lines_on_shipment_id"
+  end
+
+  create_table "shipments", force: :cascade do |t|
+    t.integer "order_id", null: false
+    t.string "carrier", null: false
+    t.string "tracking_number", null: false
+    t.string "status", null: false
+    t.datetime "shipped_at", null: false
+    t.datetime "delivered_at"
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["order_id"], name: "index_shipments_on_order_id"
+  end
+
+  create_table "shipping_methods", force: :cascade do |t|
+    t.string "code", null: false
+    t.string "name", null: false
+    t.string "carrier", null: false
+    t.boolean "active", default: true, null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["code"], name: "index_shipping_methods_on_code", unique: true
+  end
+
+  create_table "shipping_rates", force: :cascade do |t|
+    t.integer "shipping_method_id", null: false
+    t.string "country", limit: 2, default: "", null: false
+    t.string "region", default: "", null: false
+    t.integer "max_weight", default: 0, null: false
+    t.integer "price", null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["shipping_method_id", "country", "region"], name: "index_shipping_rates_on_shipping_method_id_and_country_and_region"
+  end
+
+  create_table "skus", force: :cascade do |t|
+    t.integer "book_id", null: false
+    t.string "format", null: false
+    t.string "isbn", default: "", null: false
+    t.integer "price", default: 0, null: false
+    t.integer "stock", default: 0, null: false
+    t.integer "weight_grams", default: 0, null: false
+    t.integer "width_mm", default: 0, null: false
+    t.integer "height_mm", default: 0, null: false
+    t.integer "depth_mm", default: 0, null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.integer "reserved", default: 0, null: false
+    t.integer "tax_category_id"
+    t.index ["book_id"], name: "index_skus_on_book_id"
+    t.index ["isbn"], name: "index_skus_on_isbn", unique: true, where: "((isbn)::text <> ''::text)"
+    t.index ["tax_category_id"], name: "index_skus_on_tax_category_id"
+  end
+
+  create_table "stock_movements", force: :cascade do |t|
+    t.integer "book_id", null: false
+    t.string "movement_type", null: false
+    t.integer "quantity", null: false
+    t.string "reason", default: "", null: false
+    t.string "actor", null: false
+    t.integer "stock_after", null: false
+    t.datetime "created_at", null: false
+    t.integer "warehouse_id", null: false
+    t.integer "sku_id"
+    t.index ["book_id", "id"], name: "index_stock_movements_on_book_id_and_id"
+  end
+
+  create_table "stock_reservations", force: :cascade do |t|
+    t.integer "order_id", null: false
+    t.integer "book_id", null: false
+    t.integer "quantity", null: false
+    t.datetime "expires_at", null: false
+    t.datetime "created_at", null: false
+    t.integer "warehouse_id", null: false
+    t.integer "sku_id"
+    t.index ["book_id"], name: "index_stock_reservations_on_book_id"
+    t.index ["order_id"], name: "index_stock_reservations_on_order_id"
+    t.index ["warehouse_id", "book_id"], name: "index_stock_reservations_on_warehouse_id_and_book_id"
+  end
+
+  create_table "stock_subscriptions", force: :cascade do |t|
+    t.integer "book_id", null: false
+    t.string "customer_email", null: false
+    t.datetime "notified_at"
+    t.datetime "created_at", null: false
+    t.index ["book_id", "customer_email"], name: "index_stock_subscriptions_on_book_id_and_customer_email", unique: true, where: "(notified_at IS NULL)"
+    t.index ["customer_email"], name: "index_stock_subscriptions_on_customer_email"
+  end
+
+  create_table "suppliers", force: :cascade do |t|
+    t.string "name", null: false
+    t.integer "publisher_id"
+    t.string "email", default: "", null: false
+    t.string "phone_number", default: "", null: false
+    t.string "address", default: "", null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["publisher_id"], name: "index_suppliers_on_publisher_id"
+  end
+
+  create_table "tags", force: :cascade do |t|
+    t.string "name", null: false
+    t.string "slug", null: false
+    t.datetime "created_at", null: false
+    t.index ["slug"], name: "index_tags_on_slug", unique: true
+  end
+
+  create_table "tax_categories", force: :cascade do |t|
+    t.string "code", null: false
+    t.string "name", null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["code"], name: "index_tax_categories_on_code", unique: true
+  end
+
+  create_table "tax_rules", force: :cascade do |t|
+    t.string "country", limit: 2, null: false
+    t.string "region", default: "", null: false
+    t.integer "tax_category_id"
+    t.string "name", null: false
+    t.integer "rate", null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["country", "region", "tax_category_id"], name: "index_tax_rules_on_country_and_region_and_tax_category_id", unique: true
+    t.index ["tax_category_id"], name: "index_tax_rules_on_tax_category_id"
+  end
+
+  create_table "warehouse_sku_stocks", force: :cascade do |t|
+    t.integer "warehouse_id", null: false
+    t.integer "sku_id", null: false
+    t.integer "stock", default: 0, null: false
+    t.integer "reserved", default: 0, null: false
+    t.index ["sku_id"], name: "index_warehouse_sku_stocks_on_sku_id"
+    t.index ["warehouse_id", "sku_id"], name: "index_warehouse_sku_stocks_on_warehouse_id_and_sku_id", unique: true
+  end
+
+  create_table "warehouse_stocks", force: :cascade do |t|
+    t.integer "warehouse_id", null: false
+    t.integer "book_id", null: false
+    t.integer "stock", default: 0, null: false
+    t.integer "reserved", default: 0, null: false
+    t.index ["book_id"], name: "index_warehouse_stocks_on_book_id"
+    t.index ["warehouse_id", "book_id"], name: "index_warehouse_stocks_on_warehouse_id_and_book_id", unique: true
+  end
+
+  create_table "warehouses", force: :cascade do |t|
+    t.string "code", null: false
+    t.string "name", null: false
+    t.float "latitude", default: 0.0, null: false
+    t.float "longitude", default: 0.0, null: false
+    t.datetime "created_at", null: false
+    t.datetime "updated_at", null: false
+    t.index ["code"], name: "index_warehouses_on_code", unique: true
+  end
+
+  create_table "wishlist_items", force: :cascade do |t|
+    t.string "customer_email", null: false
+    t.integer "book_id", null: false
+    t.datetime "created_at", null: false
+    t.index ["book_id"], name: "index_wishlist_items_on_book_id"
+    t.index ["customer_email", "book_id"], name: "index_wishlist_items_on_customer_email_and_book_id", unique: true
+  end
+
+  add_foreign_key "book_authors", "authors", on_delete: :cascade
+  add_foreign_key "book_authors", "books", on_delete: :cascade
+  add_foreign_key "book_categories", "books", on_delete: :cascade
+  add_foreign_key "book_categories", "categories", on_delete: :cascade
+  add_foreign_key "book_covers", "books", on_delete: :cascade
+  add_foreign_key "book_currency_prices", "books", on_delete: :cascade
+  add_foreign_key "book_prices", "books", on_delete: :cascade
+  add_foreign_key "book_recommendations", "books", on_delete: :cascade
+  add_foreign_key "book_recommendations", "books", column: "recommended_book_id", on_delete: :cascade
+  add_foreign_key "book_series", "books", on_delete: :cascade
+  add_foreign_key "book_series", "series", on_delete: :cascade
+  add_foreign_key "book_tags", "books", on_delete: :cascade
+  add_foreign_key "book_tags", "tags", on_delete: :cascade
+  add_foreign_key "book_translations", "books", on_delete: :cascade
+  add_foreign_key "books", "tax_categories", on_delete: :nullify
+  add_foreign_key "bundle_items", "books"
+  add_foreign_key "bundle_items", "bundles", on_delete: :cascade
+  add_foreign_key "categories", "categories", column: "parent_id"
+  add_foreign_key "category_translations", "categories", on_delete: :cascade
+  add_foreign_key "invoice_lines", "books", on_delete: :nullify
+  add_foreign_key "invoice_lines", "invoices", on_delete: :cascade
+  add_foreign_key "invoice_tax_lines", "invoices", on_delete: :cascade
+  add_foreign_key "invoices", "invoices", column: "credited_invoice_id"
+  add_foreign_key "invoices", "orders"
+  add_foreign_key "order_allocations", "order_lines", on_delete: :cascade
+  add_foreign_key "order_allocations", "warehouses"
+  add_foreign_key "order_lines", "books"
+  add_foreign_key "order_lines", "bundles"
+  add_foreign_key "order_lines", "orders", on_delete: :cascade
+  add_foreign_key "order_lines", "skus"
+  add_foreign_key "order_tax_lines", "orders", on_delete: :cascade
+  add_foreign_key "orders", "shipping_methods", on_delete: :nullify
+  add_foreign_key "payment_refunds", "payments"
+  add_foreign_key "payments", "orders"
+  add_foreign_key "promotion_redemptions", "orders", on_delete: :cascade
+  add_foreign_key "promotion_redemptions", "promotions", on_delete: :nullify
+  add_foreign_key "publisher_translations", "publishers", on_delete: :cascade
+  add_foreign_key "purchase_order_lines", "books"
+  add_foreign_key "purchase_order_lines", "purchase_orders", on_delete: :cascade
+  add_foreign_key "purchase_orders", "suppliers"
+  add_foreign_key "purchase_orders", "warehouses"
+  add_foreign_key "reorder_points", "books", on_delete: :cascade
+  add_foreign_key "return_lines", "books"
+  add_foreign_key "return_lines", "order_lines"
+  add_foreign_key "return_lines", "returns"
+  add_foreign_key "return_lines", "warehouses"
+  add_foreign_key "returns", "orders"
+  add_foreign_key "reviews", "books", on_delete: :cascade
+  add_foreign_key "shipment_lines", "books"
+  add_foreign_key "shipment_lines", "order_lines"
+  add_foreign_key "shipment_lines", "shipments", on_delete: :cascade
+  add_foreign_key "shipments", "orders"
+  add_foreign_key "shipping_rates", "shipping_methods", on_delete: :cascade
+  add_foreign_key "skus", "books", on_delete: :cascade
+  add_foreign_key "skus", "tax_categories", on_delete: :nullify
+  add_foreign_key "stock_movements", "books"
+  add_foreign_key "stock_movements", "skus"
+  add_foreign_key "stock_reservations", "books", on_delete: :cascade
+  add_foreign_key "stock_reservations", "orders", on_delete: :cascade
+  add_foreign_key "stock_reservations", "skus"
+  add_foreign_key "stock_subscriptions", "books", on_delete: :cascade
+  add_foreign_key "suppliers", "publishers", on_delete: :nullify
+  add_foreign_key "tax_rules", "tax_categories", on_delete: :cascade
+  add_foreign_key "warehouse_sku_stocks", "skus", on_delete: :cascade
+  add_foreign_key "warehouse_sku_stocks", "warehouses", on_delete: :cascade
+  add_foreign_key "warehouse_stocks", "books", on_delete: :cascade
+  add_foreign_key "warehouse_stocks", "warehouses", on_delete: :cascade
+  add_foreign_key "wishlist_items", "books", on_delete: :cascade
 end
@@ -0,0 +1,135 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type AuthorHandler struct {
+	uc       usecase.AuthorUsecase
+	username string
+	password string
+}
+
+func NewAuthorHandler(usecase usecase.AuthorUsecase, username string, password string) AuthorHandler {
+	return AuthorHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *AuthorHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/author", handler.Decorate(h.GetAuthors, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/author/:id", handler.Decorate(h.GetAuthor, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/author", handler.Decorate(h.CreateAuthor, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/author/:id", handler.Decorate(h.UpdateAuthor, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/author/:id", handler.Decorate(h.DeleteAuthor, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *AuthorHandler) GetAuthors(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetAuthors(ctx)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Author is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *AuthorHandler) GetAuthor(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetAuthor(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Author ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *AuthorHandler) CreateAuthor(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	var author entity.Author
+	decoder := json.NewDecoder(r.Body)
+
+	if err := decoder.Decode(&author); err != nil {
+		response.FailedResponse(w, 1, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.CreateAuthor(ctx, &author)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, "Created")
+	return nil
+}
+
+func (h *AuthorHandler) UpdateAuthor(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var author entity.Author
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&author); err != nil {
+		response.FailedResponse(w, 1, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.UpdateAuthor(ctx, id, &author)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Author Has Been Updated")
+	return nil
+}
+
+func (h *AuthorHandler) DeleteAuthor(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeleteAuthor(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Author Has Been Deleted")
+	return nil
+}
@@ -0,0 +1,242 @@
+package delivery_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/logger"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	logger.Init()
+}
+
+func newAuthorHandler() (http.Handler, *mocks.AuthorUsecase) {
+	os.Setenv("BOOKSTORE_USERNAME", fixture.DummyUsername)
+	os.Setenv("BOOKSTORE_PASSWORD", fixture.DummyPassword)
+
+	username := fixture.DummyUsername
+	password := fixture.DummyPassword
+
+	uc := new(mocks.AuthorUsecase)
+	author := delivery.NewAuthorHandler(uc, username, password)
+	h := handler.NewHandler(&author)
+	return h, uc
+}
+
+func TestGetAuthors(t *testing.T) {
+	testCases := []struct {
+		name      string
+		endpoint  string
+		author    []entity.Author
+		wantError bool
+		getError  error
+	}{
+		{
+			name:      "success",
+			endpoint:  "/bookstore/author",
+			author:    []entity.Author{{ID: 1, Name: "Terry Pratchett"}, {ID: 2, Name: "Neil Gaiman"}},
+			wantError: false,
+			getError:  nil,
+		},
+		{
+			name:      "success with no data",
+			endpoint:  "/bookstore/author",
+			author:    []entity.Author{},
+			wantError: false,
+			getError:  nil,
+		},
+		{
+			name:      "failed get author data",
+			endpoint:  "/bookstore/author",
+			author:    []entity.Author{},
+			wantError: true,
+			getError:  errors.New("failed get author data"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, author := newAuthorHandler()
+			author.On("GetAuthors", mock.Anything).Return(test.author, test.getError)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, test.endpoint, fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantError, recoder.Code != http.StatusOK)
+		})
+	}
+}
+
+func TestGetAuthor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		id       int64
+		author   entity.Author
+		wantErr  bool
+		getError error
+	}{
+		{
+			name:     "success",
+			id:       1,
+			author:   entity.Author{ID: 1, Name: "Terry Pratchett"},
+			wantErr:  false,
+			getError: nil,
+		},
+		{
+			name:     "failed with author not found",
+			id:       1,
+			author:   entity.Author{},
+			wantErr:  true,
+			getError: nil,
+		},
+		{
+			name:     "failed to get author",
+			id:       1,
+			author:   entity.Author{ID: 1, Name: "Terry Pratchett"},
+			wantErr:  true,
+			getError: errors.New("failed to get author"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, author := newAuthorHandler()
+			author.On("GetAuthor", mock.Anything, mock.Anything).Return(test.author, test.getError)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, fmt.Sprintf("/bookstore/author/%v", test.id), fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
+
+func TestCreateAuthor(t *testing.T) {
+	testCases := []struct {
+		name      string
+		endpoint  string
+		author    entity.Author
+		wantError bool
+		createErr error
+	}{
+		{
+			name:      "success",
+			endpoint:  "/bookstore/author",
+			author:    entity.Author{ID: 1, Name: "Terry Pratchett"},
+			wantError: false,
+			createErr: nil,
+		},
+		{
+			name:      "failed created author",
+			endpoint:  "/bookstore/author",
+			author:    entity.Author{ID: 1, Name: "Terry Pratchett"},
+			wantError: true,
+			createErr: errors.New("failed created author"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, author := newAuthorHandler()
+			author.On("CreateAuthor", mock.Anything, mock.Anything).Return(test.createErr)
+
+			body, _ := json.Marshal(test.author)
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, test.endpoint, fixture.DummyUsername, fixture.DummyPassword, body)
+
+			handler.ServeHTTP(recoder, request)
+			assert.Equal(t, test.wantError, recoder.Code != http.StatusCreated)
+		})
+	}
+}
+
+func TestUpdateAuthor(t *testing.T) {
+	testcases := []struct {
+		name      string
+		id        int64
+		author    entity.Author
+		wantError bool
+		updateErr error
+	}{
+		{
+			name:      "success",
+			id:        1,
+			author:    entity.Author{ID: 1, Name: "Terry Pratchett"},
+			wantError: false,
+			updateErr: nil,
+		},
+		{
+			name:      "failed update author",
+			id:        1,
+			author:    entity.Author{ID: 1, Name: "Terry Pratchett"},
+			wantError: true,
+			updateErr: errors.New("fail update author"),
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, author := newAuthorHandler()
+			author.On("UpdateAuthor", mock.Anything, mock.Anything, mock.Anything).Return(test.updateErr)
+
+			body, _ := json.Marshal(test.author)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPut, fmt.Sprintf("/bookstore/author/%d", test.id), fixture.DummyUsername, fixture.DummyPassword, body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantError, recoder.Code != http.StatusOK)
+		})
+	}
+}
+
+func TestDeleteAuthor(t *testing.T) {
+	testcases := []struct {
+		name      string
+		id        int64
+		wantErr   bool
+		deleteErr error
+	}{
+		{
+			name:      "success",
+			id:        1,
+			wantErr:   false,
+			deleteErr: nil,
+		},
+		{
+			name:      "failed to delete author",
+			id:        1,
+			wantErr:   true,
+			deleteErr: errors.New("failed to delete author"),
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, author := newAuthorHandler()
+			author.On("DeleteAuthor", mock.Anything, mock.Anything).Return(test.deleteErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodDelete, fmt.Sprintf("/bookstore/author/%d", test.id), fixture.DummyUsername, fixture.DummyPassword, nil)
+
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
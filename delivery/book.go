@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"winartodev/book-store-be/entity"
 	"winartodev/book-store-be/handler"
 	"winartodev/book-store-be/middleware"
@@ -16,16 +17,20 @@ import (
 )
 
 type BookHandler struct {
-	uc       usecase.BookUsecase
-	username string
-	password string
+	uc          usecase.BookUsecase
+	currency    usecase.CurrencyUsecase
+	translation usecase.TranslationUsecase
+	username    string
+	password    string
 }
 
-func NewBookHandler(usecase usecase.BookUsecase, username string, password string) BookHandler {
+func NewBookHandler(usecase usecase.BookUsecase, currency usecase.CurrencyUsecase, translation usecase.TranslationUsecase, username string, password string) BookHandler {
 	return BookHandler{
-		uc:       usecase,
-		username: username,
-		password: password,
+		uc:          usecase,
+		currency:    currency,
+		translation: translation,
+		username:    username,
+		password:    password,
 	}
 }
 
@@ -39,25 +44,30 @@ func (h *BookHandler) Register(r *httprouter.Router) error {
 	r.POST("/bookstore/book", handler.Decorate(h.CreateBook, middleware.MiddlewareBasicAuth(h.username, h.password)))
 	r.PUT("/bookstore/book/:id", handler.Decorate(h.UpdateBook, middleware.MiddlewareBasicAuth(h.username, h.password)))
 	r.DELETE("/bookstore/book/:id", handler.Decorate(h.DeleteBook, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/series/:id/books", handler.Decorate(h.GetSeriesBooks, middleware.MiddlewareBasicAuth(h.username, h.password)))
 
 	return nil
 }
 
 func (h *BookHandler) GetBooks(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
-	ctx := r.Context()
-	data, err := h.uc.GetBooks(ctx)
-	if err != nil {
-		response.FailedResponse(w, http.StatusForbidden, err.Error())
-		return err
+	var filter entity.BookFilter
+	filter.AuthorID, _ = strconv.ParseInt(r.URL.Query().Get("author_id"), 10, 64)
+	filter.CategoryID, _ = strconv.ParseInt(r.URL.Query().Get("category_id"), 10, 64)
+	filter.SeriesID, _ = strconv.ParseInt(r.URL.Query().Get("series_id"), 10, 64)
+	filter.IncludeDescendants, _ = strconv.ParseBool(r.URL.Query().Get("include_descendants"))
+	filter.Sort = r.URL.Query().Get("sort")
+	for _, tags := range r.URL.Query()["tag"] {
+		filter.Tags = append(filter.Tags, strings.Split(tags, ",")...)
 	}
 
-	if len(data) == 0 {
-		response.SuccessResponse(w, http.StatusOK, "Book is empty")
-		return nil
-	}
+	return h.getBooks(w, r, filter)
+}
 
-	response.SuccessResponse(w, http.StatusOK, data)
-	return nil
+// GetSeriesBooks lists the books of a series in reading order
+func (h *BookHandler) GetSeriesBooks(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	return h.getBooks(w, r, entity.BookFilter{SeriesID: id})
 }
 
 func (h *BookHandler) GetBook(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
@@ -75,7 +85,20 @@ func (h *BookHandler) GetBook(w http.ResponseWriter, r *http.Request, param http
 		return nil
 	}
 
-	response.SuccessResponse(w, http.StatusOK, data)
+	books := []entity.Book{data}
+	err = h.translation.LocalizeBooks(ctx, books, requestedLocales(r))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	err = h.currency.LocalizeBooks(ctx, books, requestedCurrency(r))
+	if err != nil {
+		localizeFailure(w, err)
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, books[0])
 	return nil
 }
 
@@ -133,3 +156,32 @@ func (h *BookHandler) DeleteBook(w http.ResponseWriter, r *http.Request, param h
 	response.SuccessResponse(w, http.StatusOK, "Book Has Been Deleted")
 	return nil
 }
+
+func (h *BookHandler) getBooks(w http.ResponseWriter, r *http.Request, filter entity.BookFilter) error {
+	ctx := r.Context()
+	data, err := h.uc.GetBooks(ctx, filter)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Book is empty")
+		return nil
+	}
+
+	err = h.translation.LocalizeBooks(ctx, data, requestedLocales(r))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	err = h.currency.LocalizeBooks(ctx, data, requestedCurrency(r))
+	if err != nil {
+		localizeFailure(w, err)
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
@@ -31,7 +31,11 @@ func newBookHandler() (http.Handler, *mocks.BookUsecase) {
 	password := fixture.DummyPassword
 
 	uc := new(mocks.BookUsecase)
-	book := delivery.NewBookHandler(uc, username, password)
+	currency := new(mocks.CurrencyUsecase)
+	currency.On("LocalizeBooks", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	translation := new(mocks.TranslationUsecase)
+	translation.On("LocalizeBooks", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	book := delivery.NewBookHandler(uc, currency, translation, username, password)
 	h := handler.NewHandler(&book)
 
 	return h, uc
@@ -52,7 +56,7 @@ func TestGetBooks(t *testing.T) {
 					PublisherID: 1,
 					CategoryID:  1,
 					Title:       "Clean Architecture: A Craftsman's Guide to Software Structure and Design",
-					Author:      "Robert C. Martin",
+					Authors:     []entity.BookAuthor{{AuthorID: 1, Name: "Robert C. Martin", Role: entity.AuthorRoleAuthor, Position: 1}},
 					Publication: 2020,
 					Stock:       10,
 				},
@@ -73,7 +77,7 @@ func TestGetBooks(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			handler, book := newBookHandler()
-			book.On("GetBooks", mock.Anything).Return(test.books, test.getError)
+			book.On("GetBooks", mock.Anything, mock.Anything).Return(test.books, test.getError)
 
 			recoder := httptest.NewRecorder()
 			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/book", fixture.DummyUsername, fixture.DummyPassword, nil)
@@ -101,7 +105,7 @@ func TestGetBook(t *testing.T) {
 				PublisherID: 1,
 				CategoryID:  1,
 				Title:       "Clean Architecture: A Craftsman's Guide to Software Structure and Design",
-				Author:      "Robert C. Martin",
+				Authors:     []entity.BookAuthor{{AuthorID: 1, Name: "Robert C. Martin", Role: entity.AuthorRoleAuthor, Position: 1}},
 				Publication: 2020,
 				Stock:       10,
 			},
@@ -150,7 +154,7 @@ func TestCreateBook(t *testing.T) {
 				PublisherID: 1,
 				CategoryID:  1,
 				Title:       "Clean Architecture: A Craftsman's Guide to Software Structure and Design",
-				Author:      "Robert C. Martin",
+				Authors:     []entity.BookAuthor{{AuthorID: 1, Name: "Robert C. Martin", Role: entity.AuthorRoleAuthor, Position: 1}},
 				Publication: 2020,
 				Stock:       10,
 			},
@@ -162,7 +166,7 @@ func TestCreateBook(t *testing.T) {
 				PublisherID: 1,
 				CategoryID:  1,
 				Title:       "Clean Architecture: A Craftsman's Guide to Software Structure and Design",
-				Author:      "Robert C. Martin",
+				Authors:     []entity.BookAuthor{{AuthorID: 1, Name: "Robert C. Martin", Role: entity.AuthorRoleAuthor, Position: 1}},
 				Publication: 2020,
 				Stock:       10,
 			},
@@ -201,7 +205,7 @@ func TestUpdateBook(t *testing.T) {
 				PublisherID: 1,
 				CategoryID:  1,
 				Title:       "Clean Architecture: A Craftsman's Guide to Software Structure and Design",
-				Author:      "Robert C. Martin",
+				Authors:     []entity.BookAuthor{{AuthorID: 1, Name: "Robert C. Martin", Role: entity.AuthorRoleAuthor, Position: 1}},
 				Publication: 2020,
 				Stock:       10,
 			},
@@ -214,7 +218,7 @@ func TestUpdateBook(t *testing.T) {
 				PublisherID: 1,
 				CategoryID:  1,
 				Title:       "Clean Architecture: A Craftsman's Guide to Software Structure and Design",
-				Author:      "Robert C. Martin",
+				Authors:     []entity.BookAuthor{{AuthorID: 1, Name: "Robert C. Martin", Role: entity.AuthorRoleAuthor, Position: 1}},
 				Publication: 2020,
 				Stock:       10,
 			},
@@ -272,3 +276,55 @@ func TestDeleteBook(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBooksFilterByAuthor(t *testing.T) {
+	handler, book := newBookHandler()
+	book.On("GetBooks", mock.Anything, entity.BookFilter{AuthorID: 3}).Return([]entity.Book{{ID: 1, Title: "Good Omens"}}, nil)
+
+	recoder := httptest.NewRecorder()
+	request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/book?author_id=3", fixture.DummyUsername, fixture.DummyPassword, nil)
+
+	handler.ServeHTTP(recoder, request)
+
+	assert.Equal(t, http.StatusOK, recoder.Code)
+	book.AssertExpectations(t)
+}
+
+func TestGetBooksFilterByCategory(t *testing.T) {
+	handler, book := newBookHandler()
+	book.On("GetBooks", mock.Anything, entity.BookFilter{CategoryID: 2, IncludeDescendants: true}).Return([]entity.Book{{ID: 1, Title: "The Way of Kings"}}, nil)
+
+	recoder := httptest.NewRecorder()
+	request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/book?category_id=2&include_descendants=true", fixture.DummyUsername, fixture.DummyPassword, nil)
+
+	handler.ServeHTTP(recoder, request)
+
+	assert.Equal(t, http.StatusOK, recoder.Code)
+	book.AssertExpectations(t)
+}
+
+func TestGetBooksFilterByTags(t *testing.T) {
+	handler, book := newBookHandler()
+	book.On("GetBooks", mock.Anything, entity.BookFilter{Tags: []string{"cookbook", "travel guide", "italy"}}).Return([]entity.Book{{ID: 1, Title: "Tuscan Kitchen"}}, nil)
+
+	recoder := httptest.NewRecorder()
+	request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/book?tag=cookbook&tag=travel%20guide,italy", fixture.DummyUsername, fixture.DummyPassword, nil)
+
+	handler.ServeHTTP(recoder, request)
+
+	assert.Equal(t, http.StatusOK, recoder.Code)
+	book.AssertExpectations(t)
+}
+
+func TestGetSeriesBooks(t *testing.T) {
+	handler, book := newBookHandler()
+	book.On("GetBooks", mock.Anything, entity.BookFilter{SeriesID: 5}).Return([]entity.Book{{ID: 1, Title: "The Colour of Magic", Series: []entity.BookSeries{{SeriesID: 5, Name: "Discworld", Position: 1}}}}, nil)
+
+	recoder := httptest.NewRecorder()
+	request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/series/5/books", fixture.DummyUsername, fixture.DummyPassword, nil)
+
+	handler.ServeHTTP(recoder, request)
+
+	assert.Equal(t, http.StatusOK, recoder.Code)
+	book.AssertExpectations(t)
+}
@@ -0,0 +1,151 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type BundleHandler struct {
+	uc       usecase.BundleUsecase
+	currency usecase.CurrencyUsecase
+	username string
+	password string
+}
+
+func NewBundleHandler(usecase usecase.BundleUsecase, currency usecase.CurrencyUsecase, username string, password string) BundleHandler {
+	return BundleHandler{
+		uc:       usecase,
+		currency: currency,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *BundleHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/bundle", handler.Decorate(h.GetBundles, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/bundle/:id", handler.Decorate(h.GetBundle, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/bundle", handler.Decorate(h.CreateBundle, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/bundle/:id", handler.Decorate(h.UpdateBundle, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/bundle/:id", handler.Decorate(h.DeleteBundle, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+// GetBundles lists the bundles with how many of each the stock of their books makes up
+func (h *BundleHandler) GetBundles(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetBundles(ctx)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Bundle is empty")
+		return nil
+	}
+
+	err = h.currency.LocalizeBundles(ctx, data, requestedCurrency(r))
+	if err != nil {
+		localizeFailure(w, err)
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *BundleHandler) GetBundle(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetBundle(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Bundle ID %d Was Not Found", id))
+		return nil
+	}
+
+	bundles := []entity.Bundle{data}
+	err = h.currency.LocalizeBundles(ctx, bundles, requestedCurrency(r))
+	if err != nil {
+		localizeFailure(w, err)
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, bundles[0])
+	return nil
+}
+
+func (h *BundleHandler) CreateBundle(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	var bundle entity.Bundle
+	decoder := json.NewDecoder(r.Body)
+
+	if err := decoder.Decode(&bundle); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.CreateBundle(ctx, &bundle)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, bundle)
+	return nil
+}
+
+func (h *BundleHandler) UpdateBundle(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var bundle entity.Bundle
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&bundle); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.UpdateBundle(ctx, id, &bundle)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Bundle Has Been Updated")
+	return nil
+}
+
+func (h *BundleHandler) DeleteBundle(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeleteBundle(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Bundle Has Been Deleted")
+	return nil
+}
@@ -0,0 +1,75 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newBundleHandler() (http.Handler, *mocks.BundleUsecase) {
+	uc := new(mocks.BundleUsecase)
+	currency := new(mocks.CurrencyUsecase)
+	currency.On("LocalizeBundles", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	bundle := delivery.NewBundleHandler(uc, currency, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&bundle)
+	return h, uc
+}
+
+func TestGetBundle(t *testing.T) {
+	testCases := []struct {
+		name     string
+		bundle   entity.Bundle
+		wantCode int
+	}{
+		{name: "success", bundle: entity.Bundle{ID: 1, Title: "Foundation Trilogy", Price: 250000, Available: 4}, wantCode: http.StatusOK},
+		{name: "not found", bundle: entity.Bundle{}, wantCode: http.StatusNotFound},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newBundleHandler()
+			uc.On("GetBundle", mock.Anything, int64(1)).Return(test.bundle, nil)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/bundle/1", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestCreateBundle(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      []byte
+		createErr error
+		wantCode  int
+	}{
+		{name: "success", body: []byte(`{"title":"Foundation Trilogy","price":250000,"items":[{"book_id":1,"quantity":1},{"book_id":2,"quantity":1}]}`), wantCode: http.StatusCreated},
+		{name: "invalid body", body: []byte(`{"price":"cheap"}`), wantCode: http.StatusBadRequest},
+		{name: "failed to create bundle", body: []byte(`{"title":"Foundation Trilogy"}`), createErr: errors.New("bundle price must be greater than zero"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newBundleHandler()
+			uc.On("CreateBundle", mock.Anything, mock.Anything).Return(test.createErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/bundle", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
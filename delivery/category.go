@@ -16,16 +16,18 @@ import (
 )
 
 type CategoryHandler struct {
-	uc       usecase.CategoryUsecase
-	username string
-	passwrod string
+	uc          usecase.CategoryUsecase
+	translation usecase.TranslationUsecase
+	username    string
+	passwrod    string
 }
 
-func NewCategoryHandler(usecase usecase.CategoryUsecase, username string, password string) CategoryHandler {
+func NewCategoryHandler(usecase usecase.CategoryUsecase, translation usecase.TranslationUsecase, username string, password string) CategoryHandler {
 	return CategoryHandler{
-		uc:       usecase,
-		username: username,
-		passwrod: password,
+		uc:          usecase,
+		translation: translation,
+		username:    username,
+		passwrod:    password,
 	}
 }
 
@@ -37,7 +39,9 @@ func (h *CategoryHandler) Register(r *httprouter.Router) error {
 	r.GET("/bookstore/category", handler.Decorate(h.GetCategories, middleware.MiddlewareBasicAuth(h.username, h.passwrod)))
 	r.GET("/bookstore/category/:id", handler.Decorate(h.GetCategory, middleware.MiddlewareBasicAuth(h.username, h.passwrod)))
 	r.POST("/bookstore/category", handler.Decorate(h.CreateCategory, middleware.MiddlewareBasicAuth(h.username, h.passwrod)))
+	r.GET("/bookstore/category/:id/breadcrumb", handler.Decorate(h.GetCategoryBreadcrumbs, middleware.MiddlewareBasicAuth(h.username, h.passwrod)))
 	r.PUT("/bookstore/category/:id", handler.Decorate(h.UpdateCategory, middleware.MiddlewareBasicAuth(h.username, h.passwrod)))
+	r.PUT("/bookstore/category/:id/move", handler.Decorate(h.MoveCategory, middleware.MiddlewareBasicAuth(h.username, h.passwrod)))
 	r.DELETE("/bookstore/category/:id", handler.Decorate(h.DeleteCategory, middleware.MiddlewareBasicAuth(h.username, h.passwrod)))
 
 	return nil
@@ -56,11 +60,22 @@ func (h *CategoryHandler) GetCategories(w http.ResponseWriter, r *http.Request,
 		return nil
 	}
 
+	err = h.translation.LocalizeCategories(ctx, data, requestedLocales(r))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
 	response.SuccessResponse(w, http.StatusOK, data)
 	return nil
 }
 
 func (h *CategoryHandler) GetCategory(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	// httprouter cannot register a static /tree segment next to :id
+	if param.ByName("id") == "tree" {
+		return h.GetCategoryTree(w, r, param)
+	}
+
 	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
 
 	ctx := r.Context()
@@ -75,6 +90,61 @@ func (h *CategoryHandler) GetCategory(w http.ResponseWriter, r *http.Request, pa
 		return nil
 	}
 
+	categories := []entity.Category{data}
+	err = h.translation.LocalizeCategories(ctx, categories, requestedLocales(r))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, categories[0])
+	return nil
+}
+
+func (h *CategoryHandler) GetCategoryTree(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetCategoryTree(ctx)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Category is empty")
+		return nil
+	}
+
+	err = h.translation.LocalizeCategories(ctx, data, requestedLocales(r))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *CategoryHandler) GetCategoryBreadcrumbs(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetCategoryBreadcrumbs(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Category ID %d Was Not Found", id))
+		return nil
+	}
+
+	err = h.translation.LocalizeCategories(ctx, data, requestedLocales(r))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
 	response.SuccessResponse(w, http.StatusOK, data)
 	return nil
 }
@@ -120,6 +190,27 @@ func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request,
 	return nil
 }
 
+func (h *CategoryHandler) MoveCategory(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var category entity.Category
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&category); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.MoveCategory(ctx, id, category.ParentID)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Category Has Been Moved")
+	return nil
+}
+
 func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
 	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
 
@@ -31,7 +31,9 @@ func newCategoryHandler() (http.Handler, *mocks.CategoryUsecase) {
 	password := fixture.DummyPassword
 
 	uc := new(mocks.CategoryUsecase)
-	category := delivery.NewCategoryHandler(uc, username, password)
+	translation := new(mocks.TranslationUsecase)
+	translation.On("LocalizeCategories", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	category := delivery.NewCategoryHandler(uc, translation, username, password)
 	h := handler.NewHandler(&category)
 	return h, uc
 }
@@ -240,3 +242,111 @@ func TestDeleteCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCategoryTree(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tree     []entity.Category
+		wantErr  bool
+		getError error
+	}{
+		{
+			name:     "success",
+			tree:     []entity.Category{{ID: 1, Name: "Fiction", Children: []entity.Category{{ID: 2, Name: "Fantasy"}}}},
+			wantErr:  false,
+			getError: nil,
+		},
+		{
+			name:     "failed to get category tree",
+			tree:     nil,
+			wantErr:  true,
+			getError: errors.New("failed to get category tree"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, category := newCategoryHandler()
+			category.On("GetCategoryTree", mock.Anything).Return(test.tree, test.getError)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/category/tree", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+			category.AssertNotCalled(t, "GetCategory", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetCategoryBreadcrumbs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     []entity.Category
+		wantErr  bool
+		getError error
+	}{
+		{
+			name:     "success",
+			path:     []entity.Category{{ID: 1, Name: "Fiction"}, {ID: 2, Name: "Fantasy"}},
+			wantErr:  false,
+			getError: nil,
+		},
+		{
+			name:     "failed with category not found",
+			path:     nil,
+			wantErr:  true,
+			getError: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, category := newCategoryHandler()
+			category.On("GetCategoryBreadcrumbs", mock.Anything, int64(2)).Return(test.path, test.getError)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/category/2/breadcrumb", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
+
+func TestMoveCategory(t *testing.T) {
+	parentID := int64(1)
+
+	testCases := []struct {
+		name    string
+		body    string
+		wantErr bool
+		moveErr error
+	}{
+		{
+			name:    "success",
+			body:    `{"parent_id": 1}`,
+			wantErr: false,
+			moveErr: nil,
+		},
+		{
+			name:    "failed to move category",
+			body:    `{"parent_id": 1}`,
+			wantErr: true,
+			moveErr: errors.New("category cannot be moved under itself or one of its descendants"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, category := newCategoryHandler()
+			category.On("MoveCategory", mock.Anything, int64(3), &parentID).Return(test.moveErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPut, "/bookstore/category/3/move", fixture.DummyUsername, fixture.DummyPassword, []byte(test.body))
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
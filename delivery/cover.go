@@ -0,0 +1,114 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// multipartOverhead leaves room for the multipart boundaries and headers around the cover file
+const multipartOverhead = 64 << 10
+
+// coverContentTypes are the image types accepted as cover, detected from the uploaded bytes
+var coverContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+type CoverHandler struct {
+	uc       usecase.CoverUsecase
+	maxSize  int64
+	username string
+	password string
+}
+
+func NewCoverHandler(usecase usecase.CoverUsecase, maxSize int64, username string, password string) CoverHandler {
+	return CoverHandler{
+		uc:       usecase,
+		maxSize:  maxSize,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *CoverHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.POST("/bookstore/book/:id/cover", handler.Decorate(h.UploadCover, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/book/:id/cover", handler.Decorate(h.DeleteCover, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+// UploadCover accepts the image in the "cover" field of a multipart form
+func (h *CoverHandler) UploadCover(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	tooLarge := fmt.Sprintf("cover cannot be larger than %d bytes", h.maxSize)
+	if r.ContentLength > h.maxSize+multipartOverhead {
+		response.FailedResponse(w, http.StatusRequestEntityTooLarge, tooLarge)
+		return errors.New(tooLarge)
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxSize+multipartOverhead)
+	file, _, err := r.FormFile("cover")
+	if err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, h.maxSize+1))
+	if err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	if int64(len(data)) > h.maxSize {
+		response.FailedResponse(w, http.StatusRequestEntityTooLarge, tooLarge)
+		return errors.New(tooLarge)
+	}
+
+	// the declared content type is not trusted, the type is sniffed from the content itself
+	contentType := http.DetectContentType(data)
+	if !coverContentTypes[contentType] {
+		msg := fmt.Sprintf("unsupported cover type %s", contentType)
+		response.FailedResponse(w, http.StatusUnsupportedMediaType, msg)
+		return errors.New(msg)
+	}
+
+	ctx := r.Context()
+	covers, err := h.uc.UploadCover(ctx, bookID, data, contentType)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, covers)
+	return nil
+}
+
+func (h *CoverHandler) DeleteCover(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeleteCover(ctx, bookID)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Cover Has Been Deleted")
+	return nil
+}
@@ -0,0 +1,152 @@
+package delivery_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const dummyMaxCoverSize = 1 << 20
+
+func newCoverHandler() (http.Handler, *mocks.CoverUsecase) {
+	uc := new(mocks.CoverUsecase)
+	cover := delivery.NewCoverHandler(uc, dummyMaxCoverSize, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&cover)
+	return h, uc
+}
+
+func coverRequest(field string, content []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile(field, "cover.png")
+	part.Write(content)
+	writer.Close()
+
+	request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/book/1/cover", fixture.DummyUsername, fixture.DummyPassword, body.Bytes())
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return request
+}
+
+func dummyPNG() []byte {
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 6)))
+	return buf.Bytes()
+}
+
+func TestUploadCover(t *testing.T) {
+	testCases := []struct {
+		name      string
+		field     string
+		content   []byte
+		uploadErr error
+		wantCode  int
+	}{
+		{
+			name:     "success",
+			field:    "cover",
+			content:  dummyPNG(),
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:     "missing cover field",
+			field:    "image",
+			content:  dummyPNG(),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unsupported type",
+			field:    "cover",
+			content:  []byte("%PDF-1.4 not an image"),
+			wantCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:     "too large",
+			field:    "cover",
+			content:  append(dummyPNG(), make([]byte, dummyMaxCoverSize)...),
+			wantCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:      "failed to upload cover",
+			field:     "cover",
+			content:   dummyPNG(),
+			uploadErr: errors.New("book ID 1 was not found"),
+			wantCode:  http.StatusForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, cover := newCoverHandler()
+			cover.On("UploadCover", mock.Anything, int64(1), mock.Anything, "image/png").Return([]entity.CoverImage{{Rendition: entity.RenditionOriginal}}, test.uploadErr)
+
+			recoder := httptest.NewRecorder()
+			handler.ServeHTTP(recoder, coverRequest(test.field, test.content))
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestDeleteCover(t *testing.T) {
+	testCases := []struct {
+		name      string
+		wantErr   bool
+		deleteErr error
+	}{
+		{
+			name:      "success",
+			wantErr:   false,
+			deleteErr: nil,
+		},
+		{
+			name:      "failed to delete cover",
+			wantErr:   true,
+			deleteErr: errors.New("failed to delete cover"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, cover := newCoverHandler()
+			cover.On("DeleteCover", mock.Anything, int64(1)).Return(test.deleteErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodDelete, "/bookstore/book/1/cover", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
+
+func TestStaticHandler(t *testing.T) {
+	dir := t.TempDir()
+	static := delivery.NewStaticHandler("/covers/", http.Dir(dir))
+	h := handler.NewHandler(&static)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.jpg"), []byte("content"), 0644))
+
+	recoder := httptest.NewRecorder()
+	h.ServeHTTP(recoder, httptest.NewRequest(http.MethodGet, "http://localhost/covers/a.jpg", nil))
+	assert.Equal(t, http.StatusOK, recoder.Code)
+	assert.Equal(t, "content", recoder.Body.String())
+
+	recoder = httptest.NewRecorder()
+	h.ServeHTTP(recoder, httptest.NewRequest(http.MethodGet, "http://localhost/covers/missing.jpg", nil))
+	assert.Equal(t, http.StatusNotFound, recoder.Code)
+}
@@ -0,0 +1,131 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/exchange"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type CurrencyHandler struct {
+	uc       usecase.CurrencyUsecase
+	username string
+	password string
+}
+
+func NewCurrencyHandler(usecase usecase.CurrencyUsecase, username string, password string) CurrencyHandler {
+	return CurrencyHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *CurrencyHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/price-list/:currency", handler.Decorate(h.GetPriceList, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/price-list/:currency/:book_id", handler.Decorate(h.SetCurrencyPrice, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/price-list/:currency/:book_id", handler.Decorate(h.DeleteCurrencyPrice, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *CurrencyHandler) GetPriceList(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetPriceList(ctx, param.ByName("currency"))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Price list is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+// SetCurrencyPrice takes the price as a decimal in the currency of the price list, like
+// {"price": "12.99"}
+func (h *CurrencyHandler) SetCurrencyPrice(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("book_id"), 10, 64)
+	currency := strings.ToUpper(param.ByName("currency"))
+
+	var body struct {
+		Price json.Number `json:"price"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&body); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	amount, err := entity.ParseAmount(body.Price.String(), currency)
+	if err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	price := entity.CurrencyPrice{BookID: bookID, Price: entity.Money{Amount: amount, Currency: currency}}
+
+	ctx := r.Context()
+	err = h.uc.SetCurrencyPrice(ctx, &price)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, price)
+	return nil
+}
+
+func (h *CurrencyHandler) DeleteCurrencyPrice(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("book_id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeleteCurrencyPrice(ctx, bookID, param.ByName("currency"))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Price Has Been Deleted")
+	return nil
+}
+
+// requestedCurrency returns the currency the client wants prices in: the currency query
+// parameter, or else the first currency of the Accept-Currency header, or nothing for the
+// store's own currency
+func requestedCurrency(r *http.Request) string {
+	if currency := r.URL.Query().Get("currency"); currency != "" {
+		return currency
+	}
+
+	accepted := strings.Split(r.Header.Get("Accept-Currency"), ",")[0]
+	return strings.TrimSpace(strings.Split(accepted, ";")[0])
+}
+
+// localizeFailure answers a request for prices in a currency the store cannot price in with
+// 406, other failures are the usual 403
+func localizeFailure(w http.ResponseWriter, err error) {
+	if errors.Is(err, exchange.ErrUnsupportedCurrency) {
+		response.FailedResponse(w, http.StatusNotAcceptable, err.Error())
+		return
+	}
+
+	response.FailedResponse(w, http.StatusForbidden, err.Error())
+}
@@ -0,0 +1,96 @@
+package delivery_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/exchange"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newCurrencyHandler() (http.Handler, *mocks.CurrencyUsecase) {
+	uc := new(mocks.CurrencyUsecase)
+	currency := delivery.NewCurrencyHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&currency)
+	return h, uc
+}
+
+func TestSetCurrencyPrice(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      []byte
+		wantPrice entity.Money
+		wantCode  int
+	}{
+		{name: "decimal string", body: []byte(`{"price":"12.99"}`), wantPrice: entity.Money{Amount: 1299, Currency: "USD"}, wantCode: http.StatusOK},
+		{name: "number", body: []byte(`{"price":12}`), wantPrice: entity.Money{Amount: 1200, Currency: "USD"}, wantCode: http.StatusOK},
+		{name: "too many decimals", body: []byte(`{"price":"12.999"}`), wantCode: http.StatusBadRequest},
+		{name: "invalid body", body: []byte(`{"price":true}`), wantCode: http.StatusBadRequest},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, currency := newCurrencyHandler()
+			currency.On("SetCurrencyPrice", mock.Anything, mock.Anything).Return(nil)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPut, "/bookstore/price-list/usd/1", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+			if test.wantCode == http.StatusOK {
+				currency.AssertCalled(t, "SetCurrencyPrice", mock.Anything, &entity.CurrencyPrice{BookID: 1, Price: test.wantPrice})
+			}
+		})
+	}
+}
+
+func TestGetBookInCurrency(t *testing.T) {
+	testCases := []struct {
+		name         string
+		url          string
+		header       string
+		wantCurrency string
+		localizeErr  error
+		wantCode     int
+	}{
+		{name: "store currency", url: "/bookstore/book/1", wantCurrency: "", wantCode: http.StatusOK},
+		{name: "query parameter", url: "/bookstore/book/1?currency=USD", header: "SGD", wantCurrency: "USD", wantCode: http.StatusOK},
+		{name: "accept currency header", url: "/bookstore/book/1", header: "SGD;q=1, USD;q=0.5", wantCurrency: "SGD", wantCode: http.StatusOK},
+		{name: "unsupported currency", url: "/bookstore/book/1?currency=EUR", wantCurrency: "EUR", localizeErr: fmt.Errorf("%w EUR", exchange.ErrUnsupportedCurrency), wantCode: http.StatusNotAcceptable},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			book := new(mocks.BookUsecase)
+			book.On("GetBook", mock.Anything, int64(1)).Return(entity.Book{ID: 1, Price: 125000}, nil)
+
+			currency := new(mocks.CurrencyUsecase)
+			currency.On("LocalizeBooks", mock.Anything, mock.Anything, test.wantCurrency).Return(test.localizeErr)
+
+			translation := new(mocks.TranslationUsecase)
+			translation.On("LocalizeBooks", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			bookHandler := delivery.NewBookHandler(book, currency, translation, fixture.DummyUsername, fixture.DummyPassword)
+			h := handler.NewHandler(&bookHandler)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, test.url, fixture.DummyUsername, fixture.DummyPassword, nil)
+			if test.header != "" {
+				request.Header.Set("Accept-Currency", test.header)
+			}
+			h.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+			currency.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type InvoiceHandler struct {
+	uc       usecase.InvoiceUsecase
+	username string
+	password string
+}
+
+func NewInvoiceHandler(usecase usecase.InvoiceUsecase, username string, password string) InvoiceHandler {
+	return InvoiceHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *InvoiceHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/invoice/:id", handler.Decorate(h.GetInvoice, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/invoice/:id/pdf", handler.Decorate(h.GetInvoicePDF, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/order/:id/invoice", handler.Decorate(h.GetOrderInvoices, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/order/:id/invoice.pdf", handler.Decorate(h.GetOrderInvoicePDF, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *InvoiceHandler) GetInvoice(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetInvoice(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Invoice ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *InvoiceHandler) GetInvoicePDF(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetInvoicePDF(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data == nil {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Invoice ID %d Was Not Found", id))
+		return nil
+	}
+
+	return writePDF(w, fmt.Sprintf("invoice-%d.pdf", id), data)
+}
+
+// GetOrderInvoices lists the invoice of an order and its credit notes
+func (h *InvoiceHandler) GetOrderInvoices(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetOrderInvoices(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Invoice is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *InvoiceHandler) GetOrderInvoicePDF(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetOrderInvoicePDF(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data == nil {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Invoice Of Order ID %d Was Not Found", id))
+		return nil
+	}
+
+	return writePDF(w, fmt.Sprintf("order-%d-invoice.pdf", id), data)
+}
+
+func writePDF(w http.ResponseWriter, filename string, data []byte) error {
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(http.StatusOK)
+
+	_, err := w.Write(data)
+	return err
+}
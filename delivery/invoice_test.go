@@ -0,0 +1,77 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newInvoiceHandler() (http.Handler, *mocks.InvoiceUsecase) {
+	uc := new(mocks.InvoiceUsecase)
+	ih := delivery.NewInvoiceHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&ih)
+	return h, uc
+}
+
+func TestGetOrderInvoicePDF(t *testing.T) {
+	testCases := []struct {
+		name            string
+		data            []byte
+		err             error
+		wantCode        int
+		wantContentType string
+	}{
+		{name: "success", data: []byte("%PDF-1.4\n"), wantCode: http.StatusOK, wantContentType: "application/pdf"},
+		{name: "order not paid", data: nil, wantCode: http.StatusNotFound, wantContentType: "application/json"},
+		{name: "order not found", err: errors.New("order ID 1 was not found"), wantCode: http.StatusForbidden, wantContentType: "application/json"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newInvoiceHandler()
+			uc.On("GetOrderInvoicePDF", mock.Anything, int64(1)).Return(test.data, test.err)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/order/1/invoice.pdf", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+			assert.Equal(t, test.wantContentType, recoder.Header().Get("Content-Type"))
+			if test.data != nil {
+				assert.Equal(t, test.data, recoder.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestGetInvoicePDF(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     []byte
+		wantCode int
+	}{
+		{name: "success", data: []byte("%PDF-1.4\n"), wantCode: http.StatusOK},
+		{name: "not found", data: nil, wantCode: http.StatusNotFound},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newInvoiceHandler()
+			uc.On("GetInvoicePDF", mock.Anything, int64(5)).Return(test.data, nil)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/invoice/5/pdf", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
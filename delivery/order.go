@@ -0,0 +1,130 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type OrderHandler struct {
+	uc       usecase.OrderUsecase
+	username string
+	password string
+}
+
+func NewOrderHandler(usecase usecase.OrderUsecase, username string, password string) OrderHandler {
+	return OrderHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *OrderHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/order", handler.Decorate(h.GetOrders, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/order/:id", handler.Decorate(h.GetOrder, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/order", handler.Decorate(h.CreateOrder, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/order/:id/confirm", handler.Decorate(h.ConfirmOrder, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/order/:id/cancel", handler.Decorate(h.CancelOrder, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *OrderHandler) GetOrders(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetOrders(ctx)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Order is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetOrder(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Order ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+// CreateOrder starts a checkout, the ordered books stay reserved until the order is confirmed,
+// cancelled or expires
+func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	var order entity.Order
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&order); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.CreateOrder(ctx, &order)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, order)
+	return nil
+}
+
+func (h *OrderHandler) ConfirmOrder(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+	actor, _, _ := r.BasicAuth()
+
+	ctx := r.Context()
+	err := h.uc.ConfirmOrder(ctx, id, actor)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Order Has Been Confirmed")
+	return nil
+}
+
+func (h *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.CancelOrder(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Order Has Been Cancelled")
+	return nil
+}
@@ -0,0 +1,196 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newOrderHandler() (http.Handler, *mocks.OrderUsecase) {
+	uc := new(mocks.OrderUsecase)
+	order := delivery.NewOrderHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&order)
+	return h, uc
+}
+
+func TestGetOrders(t *testing.T) {
+	testCases := []struct {
+		name     string
+		orders   []entity.Order
+		wantErr  bool
+		getError error
+	}{
+		{
+			name:     "success",
+			orders:   []entity.Order{{ID: 1, Status: entity.OrderPending}},
+			wantErr:  false,
+			getError: nil,
+		},
+		{
+			name:     "failed to get orders",
+			orders:   nil,
+			wantErr:  true,
+			getError: errors.New("failed to get orders"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, order := newOrderHandler()
+			order.On("GetOrders", mock.Anything).Return(test.orders, test.getError)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/order", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
+
+func TestGetOrder(t *testing.T) {
+	testCases := []struct {
+		name     string
+		order    entity.Order
+		wantErr  bool
+		getError error
+	}{
+		{
+			name:     "success",
+			order:    entity.Order{ID: 1, Status: entity.OrderPending},
+			wantErr:  false,
+			getError: nil,
+		},
+		{
+			name:     "failed with order not found",
+			order:    entity.Order{},
+			wantErr:  true,
+			getError: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, order := newOrderHandler()
+			order.On("GetOrder", mock.Anything, int64(1)).Return(test.order, test.getError)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/order/1", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
+
+func TestCreateOrder(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      []byte
+		wantErr   bool
+		createErr error
+	}{
+		{
+			name:    "success",
+			body:    []byte(`{"customer_email":"jane@example.com","lines":[{"book_id":1,"quantity":2}]}`),
+			wantErr: false,
+		},
+		{
+			name:    "invalid body",
+			body:    []byte(`{"lines":"none"}`),
+			wantErr: true,
+		},
+		{
+			name:      "failed to reserve stock",
+			body:      []byte(`{"customer_email":"jane@example.com","lines":[{"book_id":1,"quantity":2}]}`),
+			wantErr:   true,
+			createErr: errors.New("book ID 1: insufficient stock"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, order := newOrderHandler()
+			order.On("CreateOrder", mock.Anything, mock.Anything).Return(test.createErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/order", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusCreated)
+		})
+	}
+}
+
+func TestConfirmOrder(t *testing.T) {
+	testCases := []struct {
+		name       string
+		wantErr    bool
+		confirmErr error
+	}{
+		{
+			name:       "success",
+			wantErr:    false,
+			confirmErr: nil,
+		},
+		{
+			name:       "order no longer pending",
+			wantErr:    true,
+			confirmErr: errors.New("order is no longer pending"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, order := newOrderHandler()
+			order.On("ConfirmOrder", mock.Anything, int64(1), fixture.DummyUsername).Return(test.confirmErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/order/1/confirm", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	testCases := []struct {
+		name      string
+		wantErr   bool
+		cancelErr error
+	}{
+		{
+			name:      "success",
+			wantErr:   false,
+			cancelErr: nil,
+		},
+		{
+			name:      "order no longer pending",
+			wantErr:   true,
+			cancelErr: errors.New("order is no longer pending"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, order := newOrderHandler()
+			order.On("CancelOrder", mock.Anything, int64(1)).Return(test.cancelErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/order/1/cancel", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/payment"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// maxWebhookSize is the largest webhook payload read
+const maxWebhookSize = 1 << 20
+
+type PaymentHandler struct {
+	uc       usecase.PaymentUsecase
+	username string
+	password string
+}
+
+func NewPaymentHandler(usecase usecase.PaymentUsecase, username string, password string) PaymentHandler {
+	return PaymentHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+// Register adds the payment routes. The webhook is called by the gateway, which cannot use
+// basic auth, its requests are verified by their signature instead.
+func (h *PaymentHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/order/:id/payment", handler.Decorate(h.GetOrderPayments, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/order/:id/payment", handler.Decorate(h.PayOrder, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/payment/:id", handler.Decorate(h.GetPayment, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/payment/:id/capture", handler.Decorate(h.CapturePayment, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/payment/:id/void", handler.Decorate(h.VoidPayment, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/payment/:id/refund", handler.Decorate(h.RefundPayment, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/webhook/payment", handler.Decorate(h.HandleWebhook))
+
+	return nil
+}
+
+func (h *PaymentHandler) GetOrderPayments(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetOrderPayments(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Payment is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *PaymentHandler) GetPayment(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetPayment(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Payment ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+// PayOrder pays an order with the payment method in the body. The Idempotency-Key header
+// identifies the attempt, retrying it with the same key returns the first response. A pending
+// payment is answered 202 Accepted and a declined one 402 Payment Required.
+func (h *PaymentHandler) PayOrder(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var body struct {
+		PaymentMethod string `json:"payment_method"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&body); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	data, err := h.uc.PayOrder(ctx, id, r.Header.Get("Idempotency-Key"), body.PaymentMethod)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	switch data.Status {
+	case entity.PaymentPending:
+		response.SuccessResponse(w, http.StatusAccepted, data)
+	case entity.PaymentFailed:
+		response.FailedResponse(w, http.StatusPaymentRequired, fmt.Sprintf("Payment ID %d Failed: %s", data.ID, data.FailureReason))
+	default:
+		response.SuccessResponse(w, http.StatusCreated, data)
+	}
+	return nil
+}
+
+func (h *PaymentHandler) CapturePayment(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	amount, err := decodeAmount(r)
+	if err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	data, err := h.uc.CapturePayment(ctx, id, amount)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *PaymentHandler) VoidPayment(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.VoidPayment(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *PaymentHandler) RefundPayment(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	amount, err := decodeAmount(r)
+	if err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	data, err := h.uc.RefundPayment(ctx, id, amount)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+// HandleWebhook applies the payment status updates the gateway sends. Webhooks that are not
+// signed by the gateway are answered 401, the gateway retries those answered other than 2xx.
+func (h *PaymentHandler) HandleWebhook(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookSize))
+	if err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err = h.uc.HandleWebhook(ctx, payload, r.Header)
+	if err != nil {
+		switch {
+		case errors.Is(err, payment.ErrInvalidSignature):
+			response.FailedResponse(w, http.StatusUnauthorized, err.Error())
+		case errors.Is(err, usecase.ErrUnknownPayment):
+			response.FailedResponse(w, http.StatusNotFound, err.Error())
+		default:
+			response.FailedResponse(w, http.StatusForbidden, err.Error())
+		}
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Received")
+	return nil
+}
+
+// decodeAmount reads the optional {"amount": n} body of captures and refunds, no body is 0
+func decodeAmount(r *http.Request) (int, error) {
+	var body struct {
+		Amount int `json:"amount"`
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+
+	return body.Amount, nil
+}
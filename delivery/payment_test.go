@@ -0,0 +1,114 @@
+package delivery_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/payment"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newPaymentHandler() (http.Handler, *mocks.PaymentUsecase) {
+	uc := new(mocks.PaymentUsecase)
+	p := delivery.NewPaymentHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&p)
+	return h, uc
+}
+
+func TestPayOrder(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     []byte
+		payment  entity.Payment
+		payErr   error
+		wantCode int
+	}{
+		{name: "authorized", body: []byte(`{"payment_method":"tok_visa"}`), payment: entity.Payment{ID: 1, Status: entity.PaymentAuthorized}, wantCode: http.StatusCreated},
+		{name: "pending", body: []byte(`{"payment_method":"tok_pending"}`), payment: entity.Payment{ID: 1, Status: entity.PaymentPending}, wantCode: http.StatusAccepted},
+		{name: "declined", body: []byte(`{"payment_method":"tok_decline"}`), payment: entity.Payment{ID: 1, Status: entity.PaymentFailed, FailureReason: "card declined"}, wantCode: http.StatusPaymentRequired},
+		{name: "invalid body", body: []byte(`{"payment_method":1}`), wantCode: http.StatusBadRequest},
+		{name: "order not pending", body: []byte(`{"payment_method":"tok_visa"}`), payErr: errors.New("order ID 1 is confirmed, only pending orders can be paid"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newPaymentHandler()
+			uc.On("PayOrder", mock.Anything, int64(1), "key-1", mock.Anything).Return(test.payment, test.payErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/order/1/payment", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			request.Header.Set("Idempotency-Key", "key-1")
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestRefundPaymentAmount(t *testing.T) {
+	testCases := []struct {
+		name       string
+		body       []byte
+		wantAmount int
+		wantCode   int
+	}{
+		{name: "partial", body: []byte(`{"amount":40000}`), wantAmount: 40000, wantCode: http.StatusOK},
+		{name: "no body refunds all", body: nil, wantAmount: 0, wantCode: http.StatusOK},
+		{name: "invalid body", body: []byte(`{"amount":"all"}`), wantCode: http.StatusBadRequest},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newPaymentHandler()
+			uc.On("RefundPayment", mock.Anything, int64(1), test.wantAmount).Return(entity.Payment{ID: 1}, nil)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/payment/1/refund", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+			if test.wantCode == http.StatusOK {
+				uc.AssertCalled(t, "RefundPayment", mock.Anything, int64(1), test.wantAmount)
+			}
+		})
+	}
+}
+
+func TestHandlePaymentWebhook(t *testing.T) {
+	testCases := []struct {
+		name       string
+		webhookErr error
+		wantCode   int
+	}{
+		{name: "applied", wantCode: http.StatusOK},
+		{name: "invalid signature", webhookErr: payment.ErrInvalidSignature, wantCode: http.StatusUnauthorized},
+		{name: "payment not saved yet", webhookErr: fmt.Errorf("%w: fake_1", usecase.ErrUnknownPayment), wantCode: http.StatusNotFound},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newPaymentHandler()
+			payload := []byte(`{"id":"evt_fake_1","reference":"fake_1","status":"authorized"}`)
+			uc.On("HandleWebhook", mock.Anything, payload, mock.Anything).Return(test.webhookErr)
+
+			// the gateway does not use basic auth
+			recoder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/bookstore/webhook/payment", bytes.NewReader(payload))
+			request.Header.Set(payment.SignatureHeader, "t=1,v1=abc")
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type PriceHandler struct {
+	uc       usecase.PriceUsecase
+	username string
+	password string
+}
+
+func NewPriceHandler(usecase usecase.PriceUsecase, username string, password string) PriceHandler {
+	return PriceHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *PriceHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/book/:id/price", handler.Decorate(h.GetPrice, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/book/:id/prices", handler.Decorate(h.GetBookPrices, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/book/:id/prices", handler.Decorate(h.SchedulePrice, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/book/:id/prices/:price_id", handler.Decorate(h.CancelScheduledPrice, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+// GetPrice returns the price of a book at the time given as RFC 3339 in the at query
+// parameter, or right now without it
+func (h *PriceHandler) GetPrice(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	at := time.Now()
+	if value := r.URL.Query().Get("at"); value != "" {
+		var err error
+		at, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			response.FailedResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid at %q, expected RFC 3339", value))
+			return err
+		}
+	}
+
+	ctx := r.Context()
+	data, err := h.uc.GetPriceAt(ctx, bookID, at)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Book ID %d Had No Price At %s", bookID, at.Format(time.RFC3339)))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *PriceHandler) GetBookPrices(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetBookPrices(ctx, bookID)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Price History is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *PriceHandler) SchedulePrice(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var price entity.BookPrice
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&price); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	price.BookID = bookID
+
+	ctx := r.Context()
+	err := h.uc.SchedulePrice(ctx, &price)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, price)
+	return nil
+}
+
+func (h *PriceHandler) CancelScheduledPrice(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+	id, _ := strconv.ParseInt(param.ByName("price_id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.CancelScheduledPrice(ctx, bookID, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Scheduled Price Has Been Cancelled")
+	return nil
+}
@@ -0,0 +1,92 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newPriceHandler() (http.Handler, *mocks.PriceUsecase) {
+	uc := new(mocks.PriceUsecase)
+	price := delivery.NewPriceHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&price)
+	return h, uc
+}
+
+func TestGetPrice(t *testing.T) {
+	lastMonth := time.Date(2026, 9, 19, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		url      string
+		at       interface{}
+		price    entity.BookPrice
+		wantCode int
+	}{
+		{name: "current price", url: "/bookstore/book/1/price", at: mock.Anything, price: entity.BookPrice{ID: 3, BookID: 1, Price: 45000}, wantCode: http.StatusOK},
+		{name: "price last month", url: "/bookstore/book/1/price?at=2026-09-19T00:00:00Z", at: lastMonth, price: entity.BookPrice{ID: 2, BookID: 1, Price: 50000}, wantCode: http.StatusOK},
+		{name: "no price yet", url: "/bookstore/book/1/price?at=2026-09-19T00:00:00Z", at: lastMonth, price: entity.BookPrice{}, wantCode: http.StatusNotFound},
+		{name: "invalid time", url: "/bookstore/book/1/price?at=yesterday", at: mock.Anything, wantCode: http.StatusBadRequest},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, price := newPriceHandler()
+			price.On("GetPriceAt", mock.Anything, int64(1), test.at).Return(test.price, nil)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, test.url, fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestSchedulePrice(t *testing.T) {
+	testCases := []struct {
+		name        string
+		body        []byte
+		scheduleErr error
+		wantCode    int
+	}{
+		{name: "success", body: []byte(`{"price":30000,"effective_from":"2026-11-01T00:00:00Z","effective_to":"2026-11-08T00:00:00Z"}`), wantCode: http.StatusCreated},
+		{name: "invalid body", body: []byte(`{"effective_from":"next week"}`), wantCode: http.StatusBadRequest},
+		{name: "failed to schedule price", body: []byte(`{"price":-1}`), scheduleErr: errors.New("price cannot be negative"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, price := newPriceHandler()
+			price.On("SchedulePrice", mock.Anything, mock.Anything).Return(test.scheduleErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/book/1/prices", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestCancelScheduledPrice(t *testing.T) {
+	handler, price := newPriceHandler()
+	price.On("CancelScheduledPrice", mock.Anything, int64(1), int64(4)).Return(nil)
+
+	recoder := httptest.NewRecorder()
+	request := fixture.HTTPBasicAuth(http.MethodDelete, "/bookstore/book/1/prices/4", fixture.DummyUsername, fixture.DummyPassword, nil)
+	handler.ServeHTTP(recoder, request)
+
+	assert.Equal(t, http.StatusOK, recoder.Code)
+	price.AssertExpectations(t)
+}
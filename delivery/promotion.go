@@ -0,0 +1,165 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type PromotionHandler struct {
+	uc       usecase.PromotionUsecase
+	currency usecase.CurrencyUsecase
+	username string
+	password string
+}
+
+func NewPromotionHandler(usecase usecase.PromotionUsecase, currency usecase.CurrencyUsecase, username string, password string) PromotionHandler {
+	return PromotionHandler{
+		uc:       usecase,
+		currency: currency,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *PromotionHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/promotion", handler.Decorate(h.GetPromotions, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/promotion/:id", handler.Decorate(h.GetPromotion, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/promotion", handler.Decorate(h.CreatePromotion, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/promotion/:id", handler.Decorate(h.UpdatePromotion, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/promotion/:id", handler.Decorate(h.DeletePromotion, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/quote", handler.Decorate(h.QuoteCart, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *PromotionHandler) GetPromotions(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetPromotions(ctx)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Promotion is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *PromotionHandler) GetPromotion(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetPromotion(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Promotion ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *PromotionHandler) CreatePromotion(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	var promotion entity.Promotion
+	decoder := json.NewDecoder(r.Body)
+
+	if err := decoder.Decode(&promotion); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.CreatePromotion(ctx, &promotion)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, "Created")
+	return nil
+}
+
+func (h *PromotionHandler) UpdatePromotion(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var promotion entity.Promotion
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&promotion); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.UpdatePromotion(ctx, id, &promotion)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Promotion Has Been Updated")
+	return nil
+}
+
+func (h *PromotionHandler) DeletePromotion(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeletePromotion(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Promotion Has Been Deleted")
+	return nil
+}
+
+// QuoteCart prices a cart with the running promotions and its coupon codes without placing an
+// order. Carts are quoted in the store's currency only, asking for another one is refused.
+func (h *PromotionHandler) QuoteCart(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	err := h.currency.CheckQuoteCurrency(requestedCurrency(r))
+	if err != nil {
+		localizeFailure(w, err)
+		return err
+	}
+
+	var cart entity.Cart
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&cart); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	data, err := h.uc.QuoteCart(ctx, &cart)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
@@ -0,0 +1,106 @@
+package delivery_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/exchange"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newPromotionHandler() (http.Handler, *mocks.PromotionUsecase) {
+	uc := new(mocks.PromotionUsecase)
+	currency := new(mocks.CurrencyUsecase)
+	currency.On("CheckQuoteCurrency", "").Return(nil)
+	currency.On("CheckQuoteCurrency", "USD").Return(fmt.Errorf("%w USD", exchange.ErrUnsupportedCurrency))
+	promotion := delivery.NewPromotionHandler(uc, currency, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&promotion)
+	return h, uc
+}
+
+func TestGetPromotion(t *testing.T) {
+	testCases := []struct {
+		name      string
+		promotion entity.Promotion
+		wantCode  int
+	}{
+		{name: "success", promotion: entity.Promotion{ID: 1, Name: "Fiction Week"}, wantCode: http.StatusOK},
+		{name: "not found", promotion: entity.Promotion{}, wantCode: http.StatusNotFound},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, promotion := newPromotionHandler()
+			promotion.On("GetPromotion", mock.Anything, int64(1)).Return(test.promotion, nil)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/promotion/1", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestCreatePromotion(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      []byte
+		createErr error
+		wantCode  int
+	}{
+		{name: "success", body: []byte(`{"name":"Welcome","type":"fixed","value":10000,"coupon_code":"WELCOME"}`), wantCode: http.StatusCreated},
+		{name: "invalid body", body: []byte(`{"value":"ten"}`), wantCode: http.StatusBadRequest},
+		{name: "failed to create promotion", body: []byte(`{"name":""}`), createErr: errors.New("promotion needs a name"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, promotion := newPromotionHandler()
+			promotion.On("CreatePromotion", mock.Anything, mock.Anything).Return(test.createErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/promotion", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestQuoteCart(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		body     []byte
+		quoteErr error
+		wantCode int
+	}{
+		{name: "success", body: []byte(`{"coupon_codes":["WELCOME"],"lines":[{"book_id":1,"quantity":2}]}`), wantCode: http.StatusOK},
+		{name: "quoted in another currency", path: "?currency=USD", body: []byte(`{"lines":[{"book_id":1,"quantity":2}]}`), wantCode: http.StatusNotAcceptable},
+		{name: "invalid body", body: []byte(`{"lines":"none"}`), wantCode: http.StatusBadRequest},
+		{name: "unknown book", body: []byte(`{"lines":[{"book_id":9,"quantity":1}]}`), quoteErr: errors.New("book ID 9 was not found"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, promotion := newPromotionHandler()
+			promotion.On("QuoteCart", mock.Anything, mock.Anything).Return(entity.Quote{Subtotal: 100000, Discount: 10000, Total: 90000}, test.quoteErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/quote"+test.path, fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
@@ -16,16 +16,18 @@ import (
 )
 
 type PublsiherHandler struct {
-	uc       usecase.PublisherUsecase
-	username string
-	pasword  string
+	uc          usecase.PublisherUsecase
+	translation usecase.TranslationUsecase
+	username    string
+	pasword     string
 }
 
-func NewPublisherHandler(usecase usecase.PublisherUsecase, username string, password string) PublsiherHandler {
+func NewPublisherHandler(usecase usecase.PublisherUsecase, translation usecase.TranslationUsecase, username string, password string) PublsiherHandler {
 	return PublsiherHandler{
-		uc:       usecase,
-		username: username,
-		pasword:  password,
+		uc:          usecase,
+		translation: translation,
+		username:    username,
+		pasword:     password,
 	}
 }
 
@@ -56,6 +58,12 @@ func (h *PublsiherHandler) GetPublishers(w http.ResponseWriter, r *http.Request,
 		return nil
 	}
 
+	err = h.translation.LocalizePublishers(ctx, data, requestedLocales(r))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
 	response.SuccessResponse(w, http.StatusOK, data)
 	return nil
 }
@@ -75,7 +83,14 @@ func (h *PublsiherHandler) GetPublisher(w http.ResponseWriter, r *http.Request,
 		return nil
 	}
 
-	response.SuccessResponse(w, http.StatusOK, data)
+	publishers := []entity.Publisher{data}
+	err = h.translation.LocalizePublishers(ctx, publishers, requestedLocales(r))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, publishers[0])
 	return nil
 }
 
@@ -31,7 +31,9 @@ func newPublisherHandler() (http.Handler, *mocks.PublisherUsecase) {
 	password := fixture.DummyPassword
 
 	uc := new(mocks.PublisherUsecase)
-	publisher := delivery.NewPublisherHandler(uc, username, password)
+	translation := new(mocks.TranslationUsecase)
+	translation.On("LocalizePublishers", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	publisher := delivery.NewPublisherHandler(uc, translation, username, password)
 	h := handler.NewHandler(&publisher)
 	return h, uc
 }
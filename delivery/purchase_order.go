@@ -0,0 +1,178 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type PurchaseOrderHandler struct {
+	uc       usecase.PurchaseOrderUsecase
+	username string
+	password string
+}
+
+func NewPurchaseOrderHandler(usecase usecase.PurchaseOrderUsecase, username string, password string) PurchaseOrderHandler {
+	return PurchaseOrderHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *PurchaseOrderHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/purchase-order", handler.Decorate(h.GetPurchaseOrders, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/purchase-order/:id", handler.Decorate(h.GetPurchaseOrder, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/purchase-order", handler.Decorate(h.CreatePurchaseOrder, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/purchase-order/:id", handler.Decorate(h.UpdatePurchaseOrder, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/purchase-order/:id/place", handler.Decorate(h.PlacePurchaseOrder, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/purchase-order/:id/cancel", handler.Decorate(h.CancelPurchaseOrder, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/purchase-order/:id/receive", handler.Decorate(h.ReceivePurchaseOrder, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *PurchaseOrderHandler) GetPurchaseOrders(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetPurchaseOrders(ctx)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Purchase Order is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *PurchaseOrderHandler) GetPurchaseOrder(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Purchase Order ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	var order entity.PurchaseOrder
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&order); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.CreatePurchaseOrder(ctx, &order)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, order)
+	return nil
+}
+
+func (h *PurchaseOrderHandler) UpdatePurchaseOrder(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var order entity.PurchaseOrder
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&order); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.UpdatePurchaseOrder(ctx, id, &order)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Purchase Order Has Been Updated")
+	return nil
+}
+
+func (h *PurchaseOrderHandler) PlacePurchaseOrder(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.PlacePurchaseOrder(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Purchase Order Has Been Placed")
+	return nil
+}
+
+func (h *PurchaseOrderHandler) CancelPurchaseOrder(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.CancelPurchaseOrder(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Purchase Order Has Been Cancelled")
+	return nil
+}
+
+// ReceivePurchaseOrder books a delivery into the purchase order's warehouse. The actor defaults
+// to the authenticated user.
+func (h *PurchaseOrderHandler) ReceivePurchaseOrder(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var receipt entity.PurchaseOrderReceipt
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&receipt); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	receipt.PurchaseOrderID = id
+	if receipt.Actor == "" {
+		receipt.Actor, _, _ = r.BasicAuth()
+	}
+
+	ctx := r.Context()
+	err := h.uc.ReceivePurchaseOrder(ctx, &receipt)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, receipt)
+	return nil
+}
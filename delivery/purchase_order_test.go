@@ -0,0 +1,116 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newPurchaseOrderHandler() (http.Handler, *mocks.PurchaseOrderUsecase) {
+	uc := new(mocks.PurchaseOrderUsecase)
+	purchaseOrder := delivery.NewPurchaseOrderHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&purchaseOrder)
+	return h, uc
+}
+
+func TestGetPurchaseOrders(t *testing.T) {
+	testCases := []struct {
+		name     string
+		orders   []entity.PurchaseOrder
+		getError error
+		wantCode int
+	}{
+		{name: "success", orders: []entity.PurchaseOrder{{ID: 1, Status: entity.PurchaseOrderDraft}}, wantCode: http.StatusOK},
+		{name: "empty", wantCode: http.StatusOK},
+		{name: "failed to get purchase orders", getError: errors.New("failed to get purchase orders"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, purchaseOrder := newPurchaseOrderHandler()
+			purchaseOrder.On("GetPurchaseOrders", mock.Anything).Return(test.orders, test.getError)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/purchase-order", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestCreatePurchaseOrder(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      []byte
+		createErr error
+		wantCode  int
+	}{
+		{name: "success", body: []byte(`{"supplier_id":1,"warehouse_id":1,"lines":[{"book_id":1,"quantity":10,"unit_cost":5000}]}`), wantCode: http.StatusCreated},
+		{name: "invalid body", body: []byte(`{"lines":"many"}`), wantCode: http.StatusBadRequest},
+		{name: "failed to create purchase order", body: []byte(`{"supplier_id":9}`), createErr: errors.New("supplier ID 9 was not found"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, purchaseOrder := newPurchaseOrderHandler()
+			purchaseOrder.On("CreatePurchaseOrder", mock.Anything, mock.Anything).Return(test.createErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/purchase-order", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestPlaceAndCancelPurchaseOrder(t *testing.T) {
+	testCases := []struct {
+		name     string
+		action   string
+		method   string
+		err      error
+		wantCode int
+	}{
+		{name: "place", action: "place", method: "PlacePurchaseOrder", wantCode: http.StatusOK},
+		{name: "place received order", action: "place", method: "PlacePurchaseOrder", err: errors.New("purchase order ID 5 is not a draft"), wantCode: http.StatusForbidden},
+		{name: "cancel", action: "cancel", method: "CancelPurchaseOrder", wantCode: http.StatusOK},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, purchaseOrder := newPurchaseOrderHandler()
+			purchaseOrder.On(test.method, mock.Anything, int64(5)).Return(test.err)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/purchase-order/5/"+test.action, fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestReceivePurchaseOrder(t *testing.T) {
+	handler, purchaseOrder := newPurchaseOrderHandler()
+	purchaseOrder.On("ReceivePurchaseOrder", mock.Anything, mock.MatchedBy(func(receipt *entity.PurchaseOrderReceipt) bool {
+		return receipt.PurchaseOrderID == 5 && receipt.Actor == fixture.DummyUsername && len(receipt.Lines) == 1
+	})).Return(nil)
+
+	recoder := httptest.NewRecorder()
+	request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/purchase-order/5/receive", fixture.DummyUsername, fixture.DummyPassword, []byte(`{"lines":[{"book_id":1,"quantity":4}]}`))
+	handler.ServeHTTP(recoder, request)
+
+	assert.Equal(t, http.StatusCreated, recoder.Code)
+	purchaseOrder.AssertExpectations(t)
+}
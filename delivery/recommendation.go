@@ -0,0 +1,58 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type RecommendationHandler struct {
+	uc       usecase.RecommendationUsecase
+	username string
+	password string
+}
+
+func NewRecommendationHandler(usecase usecase.RecommendationUsecase, username string, password string) RecommendationHandler {
+	return RecommendationHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *RecommendationHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/book/:id/recommendations", handler.Decorate(h.GetRecommendations, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+// GetRecommendations lists the books customers also bought with the book, or similar books
+// when it was not bought together with any yet
+func (h *RecommendationHandler) GetRecommendations(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetRecommendations(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Recommendation is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
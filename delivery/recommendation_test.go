@@ -0,0 +1,44 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetRecommendations(t *testing.T) {
+	testCases := []struct {
+		name            string
+		recommendations []entity.Recommendation
+		err             error
+		wantCode        int
+	}{
+		{name: "success", recommendations: []entity.Recommendation{{BookID: 6, Reason: entity.RecommendationBoughtTogether, Score: 12}}, wantCode: http.StatusOK},
+		{name: "empty", wantCode: http.StatusOK},
+		{name: "book not found", err: errors.New("book ID 5 was not found"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			uc := new(mocks.RecommendationUsecase)
+			uc.On("GetRecommendations", mock.Anything, int64(5)).Return(test.recommendations, test.err)
+			rh := delivery.NewRecommendationHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+			handler := handler.NewHandler(&rh)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/book/5/recommendations", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
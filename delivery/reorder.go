@@ -0,0 +1,117 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ReorderHandler struct {
+	uc       usecase.ReorderUsecase
+	username string
+	password string
+}
+
+func NewReorderHandler(usecase usecase.ReorderUsecase, username string, password string) ReorderHandler {
+	return ReorderHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *ReorderHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/book/:id/reorder-point", handler.Decorate(h.GetReorderPoint, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/book/:id/reorder-point", handler.Decorate(h.SetReorderPoint, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/book/:id/reorder-point", handler.Decorate(h.DeleteReorderPoint, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/stock/low", handler.Decorate(h.GetLowStock, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *ReorderHandler) GetReorderPoint(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetReorderPoint(ctx, bookID)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.BookID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Reorder Point Of Book ID %d Was Not Found", bookID))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *ReorderHandler) SetReorderPoint(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var point entity.ReorderPoint
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&point); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	point.BookID = bookID
+
+	ctx := r.Context()
+	err := h.uc.SetReorderPoint(ctx, &point)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, point)
+	return nil
+}
+
+func (h *ReorderHandler) DeleteReorderPoint(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeleteReorderPoint(ctx, bookID)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Reorder Point Has Been Deleted")
+	return nil
+}
+
+// GetLowStock lists every book at or below its reorder point, whether it was alerted or not
+func (h *ReorderHandler) GetLowStock(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetLowStock(ctx)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Low Stock is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
@@ -0,0 +1,112 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newReorderHandler() (http.Handler, *mocks.ReorderUsecase) {
+	uc := new(mocks.ReorderUsecase)
+	reorder := delivery.NewReorderHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&reorder)
+	return h, uc
+}
+
+func TestGetReorderPoint(t *testing.T) {
+	testCases := []struct {
+		name     string
+		point    entity.ReorderPoint
+		wantCode int
+	}{
+		{name: "success", point: entity.ReorderPoint{BookID: 1, Threshold: 5}, wantCode: http.StatusOK},
+		{name: "not found", point: entity.ReorderPoint{}, wantCode: http.StatusNotFound},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, reorder := newReorderHandler()
+			reorder.On("GetReorderPoint", mock.Anything, int64(1)).Return(test.point, nil)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/book/1/reorder-point", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestSetReorderPoint(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     []byte
+		setErr   error
+		wantCode int
+	}{
+		{name: "success", body: []byte(`{"threshold":5,"reorder_quantity":20}`), wantCode: http.StatusOK},
+		{name: "invalid body", body: []byte(`{"threshold":"five"}`), wantCode: http.StatusBadRequest},
+		{name: "failed to set reorder point", body: []byte(`{"threshold":-1}`), setErr: errors.New("reorder threshold cannot be negative"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, reorder := newReorderHandler()
+			reorder.On("SetReorderPoint", mock.Anything, mock.MatchedBy(func(point *entity.ReorderPoint) bool {
+				return point.BookID == 1
+			})).Return(test.setErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPut, "/bookstore/book/1/reorder-point", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestDeleteReorderPoint(t *testing.T) {
+	handler, reorder := newReorderHandler()
+	reorder.On("DeleteReorderPoint", mock.Anything, int64(1)).Return(nil)
+
+	recoder := httptest.NewRecorder()
+	request := fixture.HTTPBasicAuth(http.MethodDelete, "/bookstore/book/1/reorder-point", fixture.DummyUsername, fixture.DummyPassword, nil)
+	handler.ServeHTTP(recoder, request)
+
+	assert.Equal(t, http.StatusOK, recoder.Code)
+}
+
+func TestGetLowStock(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lowStock []entity.LowStock
+		getError error
+		wantErr  bool
+	}{
+		{name: "success", lowStock: []entity.LowStock{{BookID: 1, Title: "Dune", Available: 0, Threshold: 3}}, wantErr: false},
+		{name: "nothing below threshold", lowStock: nil, wantErr: false},
+		{name: "failed to get low stock", getError: errors.New("failed to get low stock"), wantErr: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, reorder := newReorderHandler()
+			reorder.On("GetLowStock", mock.Anything).Return(test.lowStock, test.getError)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/stock/low", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
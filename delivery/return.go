@@ -0,0 +1,204 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ReturnHandler struct {
+	uc       usecase.ReturnUsecase
+	username string
+	password string
+}
+
+func NewReturnHandler(usecase usecase.ReturnUsecase, username string, password string) ReturnHandler {
+	return ReturnHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *ReturnHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/return", handler.Decorate(h.GetReturns, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/return/:id", handler.Decorate(h.GetReturn, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/order/:id/return", handler.Decorate(h.GetOrderReturns, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/order/:id/return", handler.Decorate(h.CreateReturn, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/return/:id/approve", handler.Decorate(h.ApproveReturn, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/return/:id/reject", handler.Decorate(h.RejectReturn, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/return/:id/receive", handler.Decorate(h.ReceiveReturn, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/return/:id/refund", handler.Decorate(h.RefundReturn, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *ReturnHandler) GetReturns(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetReturns(ctx)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Return is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *ReturnHandler) GetReturn(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetReturn(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Return ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *ReturnHandler) GetOrderReturns(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetOrderReturns(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Return is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *ReturnHandler) CreateReturn(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var ret entity.Return
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&ret); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.CreateReturn(ctx, id, &ret)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, ret)
+	return nil
+}
+
+func (h *ReturnHandler) ApproveReturn(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	return h.review(w, r, param, h.uc.ApproveReturn)
+}
+
+func (h *ReturnHandler) RejectReturn(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	return h.review(w, r, param, h.uc.RejectReturn)
+}
+
+// ReceiveReturn records the books of a return arriving back, the optional body lists the
+// disposition and warehouse of its lines: {"lines": [{"id": 1, "disposition": "write_off"}]}
+func (h *ReturnHandler) ReceiveReturn(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var body struct {
+		Lines []entity.ReturnLine `json:"lines"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil && err != io.EOF {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	actor, _, _ := r.BasicAuth()
+
+	ctx := r.Context()
+	data, err := h.uc.ReceiveReturn(ctx, id, body.Lines, actor)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *ReturnHandler) RefundReturn(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	amount, err := decodeAmount(r)
+	if err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	data, err := h.uc.RefundReturn(ctx, id, amount)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+// review approves or rejects a return with the optional {"staff_note": "..."} body
+func (h *ReturnHandler) review(w http.ResponseWriter, r *http.Request, param httprouter.Params, decide func(ctx context.Context, id int64, staffNote string) (entity.Return, error)) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var body struct {
+		StaffNote string `json:"staff_note"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil && err != io.EOF {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	data, err := decide(ctx, id, body.StaffNote)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
@@ -0,0 +1,99 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newReturnHandler() (http.Handler, *mocks.ReturnUsecase) {
+	uc := new(mocks.ReturnUsecase)
+	rh := delivery.NewReturnHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&rh)
+	return h, uc
+}
+
+func TestCreateReturn(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      []byte
+		createErr error
+		wantCode  int
+	}{
+		{name: "success", body: []byte(`{"customer_note":"cover torn","lines":[{"order_line_id":3,"quantity":1,"reason":"damaged"}]}`), wantCode: http.StatusCreated},
+		{name: "invalid body", body: []byte(`{"lines":"all"}`), wantCode: http.StatusBadRequest},
+		{name: "order not confirmed", body: []byte(`{"lines":[{"order_line_id":3,"quantity":1,"reason":"damaged"}]}`), createErr: errors.New("order ID 1 is pending, only confirmed orders can be returned"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newReturnHandler()
+			uc.On("CreateReturn", mock.Anything, int64(1), mock.Anything).Return(test.createErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/order/1/return", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestGetReturn(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ret      entity.Return
+		wantCode int
+	}{
+		{name: "success", ret: entity.Return{ID: 7, OrderID: 1, Status: entity.ReturnRequested}, wantCode: http.StatusOK},
+		{name: "not found", ret: entity.Return{}, wantCode: http.StatusNotFound},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newReturnHandler()
+			uc.On("GetReturn", mock.Anything, int64(7)).Return(test.ret, nil)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/return/7", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestReceiveReturn(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      []byte
+		wantLines []entity.ReturnLine
+		wantCode  int
+	}{
+		{name: "restock all", body: nil, wantLines: nil, wantCode: http.StatusOK},
+		{name: "write off a line", body: []byte(`{"lines":[{"id":9,"disposition":"write_off"}]}`), wantLines: []entity.ReturnLine{{ID: 9, Disposition: entity.DispositionWriteOff}}, wantCode: http.StatusOK},
+		{name: "invalid body", body: []byte(`{"lines":9}`), wantCode: http.StatusBadRequest},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newReturnHandler()
+			uc.On("ReceiveReturn", mock.Anything, int64(7), test.wantLines, fixture.DummyUsername).Return(entity.Return{ID: 7, Status: entity.ReturnReceived}, nil)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/return/7/receive", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
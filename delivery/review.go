@@ -0,0 +1,149 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ReviewHandler struct {
+	uc       usecase.ReviewUsecase
+	username string
+	password string
+}
+
+func NewReviewHandler(usecase usecase.ReviewUsecase, username string, password string) ReviewHandler {
+	return ReviewHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *ReviewHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/book/:id/review", handler.Decorate(h.GetBookReviews, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/book/:id/review", handler.Decorate(h.CreateReview, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/review", handler.Decorate(h.GetReviews, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/review/:id", handler.Decorate(h.GetReview, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/review/:id/approve", handler.Decorate(h.ApproveReview, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/review/:id/reject", handler.Decorate(h.RejectReview, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+// GetBookReviews lists the approved reviews of a book, newest first, or those in ?status=
+func (h *ReviewHandler) GetBookReviews(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	filter := entity.ReviewFilter{BookID: id, Status: r.URL.Query().Get("status")}
+	if filter.Status == "" {
+		filter.Status = entity.ReviewApproved
+	}
+
+	return h.getReviews(w, r, filter)
+}
+
+// GetReviews lists the reviews of every book for moderation, ?status=pending lists those waiting for it
+func (h *ReviewHandler) GetReviews(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	var filter entity.ReviewFilter
+	filter.BookID, _ = strconv.ParseInt(r.URL.Query().Get("book_id"), 10, 64)
+	filter.Status = r.URL.Query().Get("status")
+
+	return h.getReviews(w, r, filter)
+}
+
+func (h *ReviewHandler) GetReview(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetReview(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Review ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+// CreateReview records the review of a customer the storefront signed in, who is identified by
+// the customer_email of the body
+func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var review entity.Review
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&review); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.CreateReview(ctx, id, &review)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, review)
+	return nil
+}
+
+func (h *ReviewHandler) ApproveReview(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	return h.moderate(w, r, param, h.uc.ApproveReview)
+}
+
+func (h *ReviewHandler) RejectReview(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	return h.moderate(w, r, param, h.uc.RejectReview)
+}
+
+func (h *ReviewHandler) getReviews(w http.ResponseWriter, r *http.Request, filter entity.ReviewFilter) error {
+	ctx := r.Context()
+	data, err := h.uc.GetReviews(ctx, filter)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Review is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *ReviewHandler) moderate(w http.ResponseWriter, r *http.Request, param httprouter.Params, decide func(ctx context.Context, id int64, actor string) (entity.Review, error)) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+	actor, _, _ := r.BasicAuth()
+
+	ctx := r.Context()
+	data, err := decide(ctx, id, actor)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
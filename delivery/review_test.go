@@ -0,0 +1,87 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newReviewHandler() (http.Handler, *mocks.ReviewUsecase) {
+	uc := new(mocks.ReviewUsecase)
+	rh := delivery.NewReviewHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&rh)
+	return h, uc
+}
+
+func TestCreateReview(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      []byte
+		createErr error
+		wantCode  int
+	}{
+		{name: "success", body: []byte(`{"customer_email":"buyer@mail.com","rating":5,"body":"Loved it"}`), wantCode: http.StatusCreated},
+		{name: "invalid body", body: []byte(`{"rating":"five"}`), wantCode: http.StatusBadRequest},
+		{name: "already reviewed", body: []byte(`{"customer_email":"buyer@mail.com","rating":5}`), createErr: errors.New("buyer@mail.com already reviewed book ID 1"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newReviewHandler()
+			uc.On("CreateReview", mock.Anything, int64(1), mock.Anything).Return(test.createErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/book/1/review", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestGetBookReviews(t *testing.T) {
+	testCases := []struct {
+		name       string
+		url        string
+		wantStatus string
+	}{
+		{name: "approved by default", url: "/bookstore/book/1/review", wantStatus: entity.ReviewApproved},
+		{name: "pending", url: "/bookstore/book/1/review?status=pending", wantStatus: entity.ReviewPending},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newReviewHandler()
+			filter := entity.ReviewFilter{BookID: 1, Status: test.wantStatus}
+			uc.On("GetReviews", mock.Anything, filter).Return([]entity.Review{{ID: 3, BookID: 1, Rating: 5, Status: test.wantStatus}}, nil)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, test.url, fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, http.StatusOK, recoder.Code)
+			uc.AssertCalled(t, "GetReviews", mock.Anything, filter)
+		})
+	}
+}
+
+func TestApproveReview(t *testing.T) {
+	handler, uc := newReviewHandler()
+	uc.On("ApproveReview", mock.Anything, int64(3), fixture.DummyUsername).Return(entity.Review{ID: 3, Status: entity.ReviewApproved}, nil)
+
+	recoder := httptest.NewRecorder()
+	request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/review/3/approve", fixture.DummyUsername, fixture.DummyPassword, nil)
+	handler.ServeHTTP(recoder, request)
+
+	assert.Equal(t, http.StatusOK, recoder.Code)
+	uc.AssertCalled(t, "ApproveReview", mock.Anything, int64(3), fixture.DummyUsername)
+}
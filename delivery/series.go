@@ -0,0 +1,135 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type SeriesHandler struct {
+	uc       usecase.SeriesUsecase
+	username string
+	password string
+}
+
+func NewSeriesHandler(usecase usecase.SeriesUsecase, username string, password string) SeriesHandler {
+	return SeriesHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *SeriesHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/series", handler.Decorate(h.GetSeriesList, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/series/:id", handler.Decorate(h.GetSeries, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/series", handler.Decorate(h.CreateSeries, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/series/:id", handler.Decorate(h.UpdateSeries, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/series/:id", handler.Decorate(h.DeleteSeries, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *SeriesHandler) GetSeriesList(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetSeriesList(ctx)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Series is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *SeriesHandler) GetSeries(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetSeries(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Series ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *SeriesHandler) CreateSeries(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	var series entity.Series
+	decoder := json.NewDecoder(r.Body)
+
+	if err := decoder.Decode(&series); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.CreateSeries(ctx, &series)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, series)
+	return nil
+}
+
+func (h *SeriesHandler) UpdateSeries(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var series entity.Series
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&series); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.UpdateSeries(ctx, id, &series)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Series Has Been Updated")
+	return nil
+}
+
+func (h *SeriesHandler) DeleteSeries(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeleteSeries(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Series Has Been Deleted")
+	return nil
+}
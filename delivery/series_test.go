@@ -0,0 +1,64 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSeriesHandler() (http.Handler, *mocks.SeriesUsecase) {
+	uc := new(mocks.SeriesUsecase)
+	series := delivery.NewSeriesHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&series)
+	return h, uc
+}
+
+func TestGetSeries(t *testing.T) {
+	testCases := []struct {
+		name     string
+		series   entity.Series
+		wantCode int
+		getError error
+	}{
+		{
+			name:     "success",
+			series:   entity.Series{ID: 5, Name: "Discworld"},
+			wantCode: http.StatusOK,
+			getError: nil,
+		},
+		{
+			name:     "series not found",
+			series:   entity.Series{},
+			wantCode: http.StatusNotFound,
+			getError: nil,
+		},
+		{
+			name:     "failed to get series",
+			series:   entity.Series{},
+			wantCode: http.StatusForbidden,
+			getError: errors.New("failed to get series"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, series := newSeriesHandler()
+			series.On("GetSeries", mock.Anything, int64(5)).Return(test.series, test.getError)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/series/5", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ShipmentHandler struct {
+	uc       usecase.ShipmentUsecase
+	username string
+	password string
+}
+
+func NewShipmentHandler(usecase usecase.ShipmentUsecase, username string, password string) ShipmentHandler {
+	return ShipmentHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *ShipmentHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/order/:id/shipment", handler.Decorate(h.GetOrderShipments, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/order/:id/shipment", handler.Decorate(h.CreateShipment, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/shipment/:id", handler.Decorate(h.GetShipment, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/shipment/:id/status", handler.Decorate(h.UpdateShipmentStatus, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *ShipmentHandler) GetOrderShipments(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetOrderShipments(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Shipment is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *ShipmentHandler) GetShipment(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetShipment(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Shipment ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *ShipmentHandler) CreateShipment(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var shipment entity.Shipment
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&shipment); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.CreateShipment(ctx, id, &shipment)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, shipment)
+	return nil
+}
+
+// UpdateShipmentStatus records the tracking of a shipment from the {"status": "delivered"} body
+func (h *ShipmentHandler) UpdateShipmentStatus(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var body struct {
+		Status string `json:"status"`
+	}
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&body); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	data, err := h.uc.UpdateShipmentStatus(ctx, id, body.Status)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
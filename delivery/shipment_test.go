@@ -0,0 +1,75 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newShipmentHandler() (http.Handler, *mocks.ShipmentUsecase) {
+	uc := new(mocks.ShipmentUsecase)
+	shipment := delivery.NewShipmentHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&shipment)
+	return h, uc
+}
+
+func TestCreateShipment(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      []byte
+		createErr error
+		wantCode  int
+	}{
+		{name: "success", body: []byte(`{"carrier":"JNE","tracking_number":"JNE123"}`), wantCode: http.StatusCreated},
+		{name: "invalid body", body: []byte(`{"lines":1}`), wantCode: http.StatusBadRequest},
+		{name: "order not confirmed", body: []byte(`{"tracking_number":"JNE123"}`), createErr: errors.New("order ID 1 is pending, only confirmed orders can be shipped"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newShipmentHandler()
+			uc.On("CreateShipment", mock.Anything, int64(1), mock.Anything).Return(test.createErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/order/1/shipment", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestUpdateShipmentStatus(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      []byte
+		updateErr error
+		wantCode  int
+	}{
+		{name: "delivered", body: []byte(`{"status":"delivered"}`), wantCode: http.StatusOK},
+		{name: "invalid body", body: []byte(`{"status":true}`), wantCode: http.StatusBadRequest},
+		{name: "backwards", body: []byte(`{"status":"shipped"}`), updateErr: errors.New("shipment ID 7 cannot go from delivered to \"shipped\""), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newShipmentHandler()
+			uc.On("UpdateShipmentStatus", mock.Anything, int64(7), mock.Anything).Return(entity.Shipment{ID: 7, Status: entity.ShipmentDelivered}, test.updateErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPut, "/bookstore/shipment/7/status", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
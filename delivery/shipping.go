@@ -0,0 +1,215 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ShippingHandler struct {
+	uc       usecase.ShippingUsecase
+	username string
+	password string
+}
+
+func NewShippingHandler(usecase usecase.ShippingUsecase, username string, password string) ShippingHandler {
+	return ShippingHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+// Register adds the shipping method and rate routes, the rates of a method are listed with it
+func (h *ShippingHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/shipping-method", handler.Decorate(h.GetShippingMethods, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/shipping-method/:id", handler.Decorate(h.GetShippingMethod, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/shipping-method", handler.Decorate(h.CreateShippingMethod, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/shipping-method/:id", handler.Decorate(h.UpdateShippingMethod, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/shipping-method/:id", handler.Decorate(h.DeleteShippingMethod, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	r.GET("/bookstore/shipping-rate/:id", handler.Decorate(h.GetShippingRate, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/shipping-rate", handler.Decorate(h.CreateShippingRate, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/shipping-rate/:id", handler.Decorate(h.UpdateShippingRate, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/shipping-rate/:id", handler.Decorate(h.DeleteShippingRate, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *ShippingHandler) GetShippingMethods(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetShippingMethods(ctx)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Shipping Method is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *ShippingHandler) GetShippingMethod(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetShippingMethod(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Shipping Method ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *ShippingHandler) CreateShippingMethod(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	var method entity.ShippingMethod
+	decoder := json.NewDecoder(r.Body)
+
+	if err := decoder.Decode(&method); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.CreateShippingMethod(ctx, &method)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, "Created")
+	return nil
+}
+
+func (h *ShippingHandler) UpdateShippingMethod(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var method entity.ShippingMethod
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&method); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.UpdateShippingMethod(ctx, id, &method)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Shipping Method Has Been Updated")
+	return nil
+}
+
+func (h *ShippingHandler) DeleteShippingMethod(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeleteShippingMethod(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Shipping Method Has Been Deleted")
+	return nil
+}
+
+func (h *ShippingHandler) GetShippingRate(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetShippingRate(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Shipping Rate ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *ShippingHandler) CreateShippingRate(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	var rate entity.ShippingRate
+	decoder := json.NewDecoder(r.Body)
+
+	if err := decoder.Decode(&rate); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.CreateShippingRate(ctx, &rate)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, "Created")
+	return nil
+}
+
+func (h *ShippingHandler) UpdateShippingRate(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var rate entity.ShippingRate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&rate); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.UpdateShippingRate(ctx, id, &rate)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Shipping Rate Has Been Updated")
+	return nil
+}
+
+func (h *ShippingHandler) DeleteShippingRate(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeleteShippingRate(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Shipping Rate Has Been Deleted")
+	return nil
+}
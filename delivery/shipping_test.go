@@ -0,0 +1,73 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newShippingHandler() (http.Handler, *mocks.ShippingUsecase) {
+	uc := new(mocks.ShippingUsecase)
+	shipping := delivery.NewShippingHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&shipping)
+	return h, uc
+}
+
+func TestGetShippingMethod(t *testing.T) {
+	testCases := []struct {
+		name     string
+		method   entity.ShippingMethod
+		wantCode int
+	}{
+		{name: "success", method: entity.ShippingMethod{ID: 1, Code: "standard", Name: "Standard", Carrier: "JNE", Active: true}, wantCode: http.StatusOK},
+		{name: "not found", method: entity.ShippingMethod{}, wantCode: http.StatusNotFound},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newShippingHandler()
+			uc.On("GetShippingMethod", mock.Anything, int64(1)).Return(test.method, nil)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/shipping-method/1", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestCreateShippingRate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      []byte
+		createErr error
+		wantCode  int
+	}{
+		{name: "success", body: []byte(`{"shipping_method_id":1,"country":"ID","max_weight":1000,"price":10000}`), wantCode: http.StatusCreated},
+		{name: "invalid body", body: []byte(`{"price":"free"}`), wantCode: http.StatusBadRequest},
+		{name: "method not found", body: []byte(`{"shipping_method_id":9,"price":10000}`), createErr: errors.New("shipping method ID 9 was not found"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newShippingHandler()
+			uc.On("CreateShippingRate", mock.Anything, mock.Anything).Return(test.createErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/shipping-rate", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type SKUHandler struct {
+	uc       usecase.SKUUsecase
+	currency usecase.CurrencyUsecase
+	username string
+	password string
+}
+
+func NewSKUHandler(usecase usecase.SKUUsecase, currency usecase.CurrencyUsecase, username string, password string) SKUHandler {
+	return SKUHandler{
+		uc:       usecase,
+		currency: currency,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *SKUHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/book/:id/sku", handler.Decorate(h.GetBookSKUs, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/book/:id/sku/:sku_id", handler.Decorate(h.GetSKU, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/book/:id/sku", handler.Decorate(h.CreateSKU, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/book/:id/sku/:sku_id", handler.Decorate(h.UpdateSKU, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/book/:id/sku/:sku_id", handler.Decorate(h.DeleteSKU, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *SKUHandler) GetBookSKUs(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetBookSKUs(ctx, bookID)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "SKU is empty")
+		return nil
+	}
+
+	err = h.currency.LocalizeSKUs(ctx, data, requestedCurrency(r))
+	if err != nil {
+		localizeFailure(w, err)
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *SKUHandler) GetSKU(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+	id, _ := strconv.ParseInt(param.ByName("sku_id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetSKU(ctx, bookID, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("SKU ID %d Was Not Found", id))
+		return nil
+	}
+
+	skus := []entity.SKU{data}
+	err = h.currency.LocalizeSKUs(ctx, skus, requestedCurrency(r))
+	if err != nil {
+		localizeFailure(w, err)
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, skus[0])
+	return nil
+}
+
+func (h *SKUHandler) CreateSKU(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var sku entity.SKU
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&sku); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	sku.BookID = bookID
+	ctx := r.Context()
+	err := h.uc.CreateSKU(ctx, &sku)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, sku)
+	return nil
+}
+
+func (h *SKUHandler) UpdateSKU(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+	id, _ := strconv.ParseInt(param.ByName("sku_id"), 10, 64)
+
+	var sku entity.SKU
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&sku); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.UpdateSKU(ctx, bookID, id, &sku)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "SKU Has Been Updated")
+	return nil
+}
+
+func (h *SKUHandler) DeleteSKU(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+	id, _ := strconv.ParseInt(param.ByName("sku_id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeleteSKU(ctx, bookID, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "SKU Has Been Deleted")
+	return nil
+}
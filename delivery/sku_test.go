@@ -0,0 +1,176 @@
+package delivery_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSKUHandler() (http.Handler, *mocks.SKUUsecase) {
+	uc := new(mocks.SKUUsecase)
+	currency := new(mocks.CurrencyUsecase)
+	currency.On("LocalizeSKUs", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	sku := delivery.NewSKUHandler(uc, currency, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&sku)
+	return h, uc
+}
+
+func TestGetBookSKUs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		skus     []entity.SKU
+		wantErr  bool
+		getError error
+	}{
+		{
+			name:     "success",
+			skus:     []entity.SKU{{ID: 1, BookID: 1, Format: entity.FormatHardcover}},
+			wantErr:  false,
+			getError: nil,
+		},
+		{
+			name:     "failed to get SKUs",
+			skus:     nil,
+			wantErr:  true,
+			getError: errors.New("failed to get SKUs"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, sku := newSKUHandler()
+			sku.On("GetBookSKUs", mock.Anything, int64(1)).Return(test.skus, test.getError)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/book/1/sku", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
+
+func TestGetSKU(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sku      entity.SKU
+		wantErr  bool
+		getError error
+	}{
+		{
+			name:     "success",
+			sku:      entity.SKU{ID: 2, BookID: 1, Format: entity.FormatEbook},
+			wantErr:  false,
+			getError: nil,
+		},
+		{
+			name:     "failed with SKU not found",
+			sku:      entity.SKU{},
+			wantErr:  true,
+			getError: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, sku := newSKUHandler()
+			sku.On("GetSKU", mock.Anything, int64(1), int64(2)).Return(test.sku, test.getError)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/book/1/sku/2", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
+
+func TestCreateSKU(t *testing.T) {
+	testCases := []struct {
+		name      string
+		sku       entity.SKU
+		wantErr   bool
+		createErr error
+	}{
+		{
+			name:      "success",
+			sku:       entity.SKU{Format: entity.FormatPaperback, ISBN: "9780306406157", Price: 120000},
+			wantErr:   false,
+			createErr: nil,
+		},
+		{
+			name:      "failed to create SKU",
+			sku:       entity.SKU{Format: "scroll"},
+			wantErr:   true,
+			createErr: errors.New("invalid format"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, sku := newSKUHandler()
+			sku.On("CreateSKU", mock.Anything, mock.MatchedBy(func(s *entity.SKU) bool { return s.BookID == 1 })).Return(test.createErr)
+
+			body, _ := json.Marshal(test.sku)
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/book/1/sku", fixture.DummyUsername, fixture.DummyPassword, body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusCreated)
+		})
+	}
+}
+
+func TestUpdateSKU(t *testing.T) {
+	handler, sku := newSKUHandler()
+	sku.On("UpdateSKU", mock.Anything, int64(1), int64(2), mock.Anything).Return(nil)
+
+	body, _ := json.Marshal(entity.SKU{Format: entity.FormatPaperback, Price: 99000})
+	recoder := httptest.NewRecorder()
+	request := fixture.HTTPBasicAuth(http.MethodPut, "/bookstore/book/1/sku/2", fixture.DummyUsername, fixture.DummyPassword, body)
+	handler.ServeHTTP(recoder, request)
+
+	assert.Equal(t, http.StatusOK, recoder.Code)
+}
+
+func TestDeleteSKU(t *testing.T) {
+	testCases := []struct {
+		name      string
+		wantErr   bool
+		deleteErr error
+	}{
+		{
+			name:      "success",
+			wantErr:   false,
+			deleteErr: nil,
+		},
+		{
+			name:      "failed to delete SKU",
+			wantErr:   true,
+			deleteErr: errors.New("SKU ID 2 was not found"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, sku := newSKUHandler()
+			sku.On("DeleteSKU", mock.Anything, int64(1), int64(2)).Return(test.deleteErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodDelete, "/bookstore/book/1/sku/2", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
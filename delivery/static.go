@@ -0,0 +1,32 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// StaticHandler serves stored files, such as book covers kept on the local filesystem
+type StaticHandler struct {
+	path string
+	fs   http.FileSystem
+}
+
+func NewStaticHandler(path string, fs http.FileSystem) StaticHandler {
+	return StaticHandler{
+		path: strings.TrimRight(path, "/"),
+		fs:   fs,
+	}
+}
+
+func (h *StaticHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.ServeFiles(h.path+"/*filepath", h.fs)
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type StockHandler struct {
+	uc       usecase.StockUsecase
+	username string
+	password string
+}
+
+func NewStockHandler(usecase usecase.StockUsecase, username string, password string) StockHandler {
+	return StockHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *StockHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/book/:id/stock", handler.Decorate(h.GetStockMovements, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/book/:id/stock", handler.Decorate(h.RecordMovement, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/book/:id/stock/transfer", handler.Decorate(h.TransferStock, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *StockHandler) GetStockMovements(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetStockMovements(ctx, bookID)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Stock History is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+// RecordMovement appends a movement to the stock ledger of a book. The actor defaults to the
+// authenticated user when the body does not name one.
+func (h *StockHandler) RecordMovement(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var movement entity.StockMovement
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&movement); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	movement.BookID = bookID
+	if movement.Actor == "" {
+		movement.Actor, _, _ = r.BasicAuth()
+	}
+
+	ctx := r.Context()
+	err := h.uc.RecordMovement(ctx, &movement)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, movement)
+	return nil
+}
+
+// TransferStock moves stock of a book, or of the edition sku_id, between two warehouses. The
+// actor defaults to the authenticated user when the body does not name one.
+func (h *StockHandler) TransferStock(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var transfer entity.StockTransfer
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&transfer); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	transfer.BookID = bookID
+	if transfer.Actor == "" {
+		transfer.Actor, _, _ = r.BasicAuth()
+	}
+
+	ctx := r.Context()
+	err := h.uc.TransferStock(ctx, &transfer)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, transfer)
+	return nil
+}
@@ -0,0 +1,152 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newStockHandler() (http.Handler, *mocks.StockUsecase) {
+	uc := new(mocks.StockUsecase)
+	stock := delivery.NewStockHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&stock)
+	return h, uc
+}
+
+func TestGetStockMovements(t *testing.T) {
+	testCases := []struct {
+		name      string
+		movements []entity.StockMovement
+		wantErr   bool
+		getError  error
+	}{
+		{
+			name:      "success",
+			movements: []entity.StockMovement{{ID: 1, BookID: 1, Type: entity.MovementReceipt, Quantity: 5, StockAfter: 5}},
+			wantErr:   false,
+			getError:  nil,
+		},
+		{
+			name:      "failed to get stock history",
+			movements: nil,
+			wantErr:   true,
+			getError:  errors.New("failed to get stock history"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, stock := newStockHandler()
+			stock.On("GetStockMovements", mock.Anything, int64(1)).Return(test.movements, test.getError)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/book/1/stock", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
+
+func TestRecordMovement(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      []byte
+		wantActor string
+		wantErr   bool
+		recordErr error
+	}{
+		{
+			name:      "actor defaults to authenticated user",
+			body:      []byte(`{"type":"receipt","quantity":5}`),
+			wantActor: fixture.DummyUsername,
+			wantErr:   false,
+		},
+		{
+			name:      "explicit actor",
+			body:      []byte(`{"type":"damage","quantity":1,"reason":"torn","actor":"jane"}`),
+			wantActor: "jane",
+			wantErr:   false,
+		},
+		{
+			name:    "invalid body",
+			body:    []byte(`{"quantity":"five"}`),
+			wantErr: true,
+		},
+		{
+			name:      "failed to record movement",
+			body:      []byte(`{"type":"sale","quantity":5}`),
+			wantActor: fixture.DummyUsername,
+			wantErr:   true,
+			recordErr: errors.New("insufficient stock"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, stock := newStockHandler()
+			stock.On("RecordMovement", mock.Anything, mock.MatchedBy(func(movement *entity.StockMovement) bool {
+				return movement.BookID == 1 && movement.Actor == test.wantActor
+			})).Return(test.recordErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/book/1/stock", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusCreated)
+		})
+	}
+}
+
+func TestTransferStock(t *testing.T) {
+	testCases := []struct {
+		name        string
+		body        []byte
+		wantActor   string
+		wantErr     bool
+		transferErr error
+	}{
+		{
+			name:      "success",
+			body:      []byte(`{"from_warehouse_id":1,"to_warehouse_id":2,"quantity":3}`),
+			wantActor: fixture.DummyUsername,
+			wantErr:   false,
+		},
+		{
+			name:    "invalid body",
+			body:    []byte(`{"quantity":"three"}`),
+			wantErr: true,
+		},
+		{
+			name:        "failed to transfer stock",
+			body:        []byte(`{"from_warehouse_id":1,"to_warehouse_id":2,"quantity":30}`),
+			wantActor:   fixture.DummyUsername,
+			wantErr:     true,
+			transferErr: errors.New("warehouse ID 1 does not have 30 of book ID 1 available"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, stock := newStockHandler()
+			stock.On("TransferStock", mock.Anything, mock.MatchedBy(func(transfer *entity.StockTransfer) bool {
+				return transfer.BookID == 1 && transfer.Actor == test.wantActor
+			})).Return(test.transferErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/book/1/stock/transfer", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusCreated)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type SupplierHandler struct {
+	uc       usecase.SupplierUsecase
+	username string
+	password string
+}
+
+func NewSupplierHandler(usecase usecase.SupplierUsecase, username string, password string) SupplierHandler {
+	return SupplierHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *SupplierHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/supplier", handler.Decorate(h.GetSuppliers, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/supplier/:id", handler.Decorate(h.GetSupplier, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/supplier", handler.Decorate(h.CreateSupplier, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/supplier/:id", handler.Decorate(h.UpdateSupplier, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/supplier/:id", handler.Decorate(h.DeleteSupplier, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *SupplierHandler) GetSuppliers(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetSuppliers(ctx)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Supplier is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *SupplierHandler) GetSupplier(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetSupplier(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Supplier ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *SupplierHandler) CreateSupplier(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	var supplier entity.Supplier
+	decoder := json.NewDecoder(r.Body)
+
+	if err := decoder.Decode(&supplier); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.CreateSupplier(ctx, &supplier)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, "Created")
+	return nil
+}
+
+func (h *SupplierHandler) UpdateSupplier(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var supplier entity.Supplier
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&supplier); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.UpdateSupplier(ctx, id, &supplier)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Supplier Has Been Updated")
+	return nil
+}
+
+func (h *SupplierHandler) DeleteSupplier(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeleteSupplier(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Supplier Has Been Deleted")
+	return nil
+}
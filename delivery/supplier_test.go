@@ -0,0 +1,73 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSupplierHandler() (http.Handler, *mocks.SupplierUsecase) {
+	uc := new(mocks.SupplierUsecase)
+	supplier := delivery.NewSupplierHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&supplier)
+	return h, uc
+}
+
+func TestGetSupplier(t *testing.T) {
+	testCases := []struct {
+		name     string
+		supplier entity.Supplier
+		wantCode int
+	}{
+		{name: "success", supplier: entity.Supplier{ID: 1, Name: "Gramedia Distribution"}, wantCode: http.StatusOK},
+		{name: "not found", supplier: entity.Supplier{}, wantCode: http.StatusNotFound},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, supplier := newSupplierHandler()
+			supplier.On("GetSupplier", mock.Anything, int64(1)).Return(test.supplier, nil)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/supplier/1", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestCreateSupplier(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      []byte
+		createErr error
+		wantCode  int
+	}{
+		{name: "success", body: []byte(`{"name":"Gramedia Distribution","publisher_id":1}`), wantCode: http.StatusCreated},
+		{name: "invalid body", body: []byte(`{"publisher_id":"one"}`), wantCode: http.StatusBadRequest},
+		{name: "failed to create supplier", body: []byte(`{"name":""}`), createErr: errors.New("supplier needs a name"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, supplier := newSupplierHandler()
+			supplier.On("CreateSupplier", mock.Anything, mock.Anything).Return(test.createErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/supplier", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
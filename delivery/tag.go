@@ -0,0 +1,53 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type TagHandler struct {
+	uc       usecase.TagUsecase
+	username string
+	password string
+}
+
+func NewTagHandler(usecase usecase.TagUsecase, username string, password string) TagHandler {
+	return TagHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *TagHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/tag", handler.Decorate(h.GetTags, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *TagHandler) GetTags(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetTags(ctx, r.URL.Query().Get("q"))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Tag is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
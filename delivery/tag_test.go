@@ -0,0 +1,64 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTagHandler() (http.Handler, *mocks.TagUsecase) {
+	uc := new(mocks.TagUsecase)
+	tag := delivery.NewTagHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&tag)
+	return h, uc
+}
+
+func TestGetTags(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tags     []entity.Tag
+		wantErr  bool
+		getError error
+	}{
+		{
+			name:     "success",
+			tags:     []entity.Tag{{ID: 1, Name: "Travel Guide", Slug: "travel-guide"}},
+			wantErr:  false,
+			getError: nil,
+		},
+		{
+			name:     "success with no data",
+			tags:     []entity.Tag{},
+			wantErr:  false,
+			getError: nil,
+		},
+		{
+			name:     "failed to get tags",
+			tags:     nil,
+			wantErr:  true,
+			getError: errors.New("failed to get tags"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, tag := newTagHandler()
+			tag.On("GetTags", mock.Anything, "trav").Return(test.tags, test.getError)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/tag?q=trav", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
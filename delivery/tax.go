@@ -0,0 +1,232 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type TaxHandler struct {
+	uc       usecase.TaxUsecase
+	username string
+	password string
+}
+
+func NewTaxHandler(usecase usecase.TaxUsecase, username string, password string) TaxHandler {
+	return TaxHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *TaxHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/tax-category", handler.Decorate(h.GetTaxCategories, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/tax-category/:id", handler.Decorate(h.GetTaxCategory, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/tax-category", handler.Decorate(h.CreateTaxCategory, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/tax-category/:id", handler.Decorate(h.UpdateTaxCategory, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/tax-category/:id", handler.Decorate(h.DeleteTaxCategory, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	r.GET("/bookstore/tax-rule", handler.Decorate(h.GetTaxRules, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/tax-rule/:id", handler.Decorate(h.GetTaxRule, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/tax-rule", handler.Decorate(h.CreateTaxRule, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/tax-rule/:id", handler.Decorate(h.UpdateTaxRule, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/tax-rule/:id", handler.Decorate(h.DeleteTaxRule, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *TaxHandler) GetTaxCategories(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetTaxCategories(ctx)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Tax Category is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *TaxHandler) GetTaxCategory(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetTaxCategory(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Tax Category ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *TaxHandler) CreateTaxCategory(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	var category entity.TaxCategory
+	decoder := json.NewDecoder(r.Body)
+
+	if err := decoder.Decode(&category); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.CreateTaxCategory(ctx, &category)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, "Created")
+	return nil
+}
+
+func (h *TaxHandler) UpdateTaxCategory(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var category entity.TaxCategory
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&category); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.UpdateTaxCategory(ctx, id, &category)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Tax Category Has Been Updated")
+	return nil
+}
+
+func (h *TaxHandler) DeleteTaxCategory(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeleteTaxCategory(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Tax Category Has Been Deleted")
+	return nil
+}
+
+func (h *TaxHandler) GetTaxRules(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetTaxRules(ctx)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Tax Rule is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *TaxHandler) GetTaxRule(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetTaxRule(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Tax Rule ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *TaxHandler) CreateTaxRule(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	var rule entity.TaxRule
+	decoder := json.NewDecoder(r.Body)
+
+	if err := decoder.Decode(&rule); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.CreateTaxRule(ctx, &rule)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, "Created")
+	return nil
+}
+
+func (h *TaxHandler) UpdateTaxRule(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var rule entity.TaxRule
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&rule); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.UpdateTaxRule(ctx, id, &rule)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Tax Rule Has Been Updated")
+	return nil
+}
+
+func (h *TaxHandler) DeleteTaxRule(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeleteTaxRule(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Tax Rule Has Been Deleted")
+	return nil
+}
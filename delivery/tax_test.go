@@ -0,0 +1,85 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTaxHandler() (http.Handler, *mocks.TaxUsecase) {
+	uc := new(mocks.TaxUsecase)
+	tax := delivery.NewTaxHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&tax)
+	return h, uc
+}
+
+func TestGetTaxRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     entity.TaxRule
+		wantCode int
+	}{
+		{name: "success", rule: entity.TaxRule{ID: 1, Country: "ID", Name: "PPN", Rate: 1100}, wantCode: http.StatusOK},
+		{name: "not found", rule: entity.TaxRule{}, wantCode: http.StatusNotFound},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, tax := newTaxHandler()
+			tax.On("GetTaxRule", mock.Anything, int64(1)).Return(test.rule, nil)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/tax-rule/1", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestCreateTaxRule(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      []byte
+		createErr error
+		wantCode  int
+	}{
+		{name: "success", body: []byte(`{"country":"GB","tax_category_id":1,"name":"VAT","rate":0}`), wantCode: http.StatusCreated},
+		{name: "invalid body", body: []byte(`{"rate":"20%"}`), wantCode: http.StatusBadRequest},
+		{name: "failed to create tax rule", body: []byte(`{"country":"GB","name":"VAT","rate":20000}`), createErr: errors.New("rate must be between 0 and 10000 basis points"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, tax := newTaxHandler()
+			tax.On("CreateTaxRule", mock.Anything, mock.Anything).Return(test.createErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/tax-rule", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestDeleteTaxCategory(t *testing.T) {
+	handler, tax := newTaxHandler()
+	tax.On("DeleteTaxCategory", mock.Anything, int64(1)).Return(nil)
+
+	recoder := httptest.NewRecorder()
+	request := fixture.HTTPBasicAuth(http.MethodDelete, "/bookstore/tax-category/1", fixture.DummyUsername, fixture.DummyPassword, nil)
+	handler.ServeHTTP(recoder, request)
+
+	assert.Equal(t, http.StatusOK, recoder.Code)
+	tax.AssertCalled(t, "DeleteTaxCategory", mock.Anything, int64(1))
+}
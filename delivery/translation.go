@@ -0,0 +1,258 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type TranslationHandler struct {
+	uc       usecase.TranslationUsecase
+	username string
+	password string
+}
+
+func NewTranslationHandler(usecase usecase.TranslationUsecase, username string, password string) TranslationHandler {
+	return TranslationHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *TranslationHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/book/:id/translations", handler.Decorate(h.GetBookTranslations, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/book/:id/translations/:locale", handler.Decorate(h.SetBookTranslation, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/book/:id/translations/:locale", handler.Decorate(h.DeleteBookTranslation, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/category/:id/translations", handler.Decorate(h.GetCategoryTranslations, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/category/:id/translations/:locale", handler.Decorate(h.SetCategoryTranslation, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/category/:id/translations/:locale", handler.Decorate(h.DeleteCategoryTranslation, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/publisher/:id/translations", handler.Decorate(h.GetPublisherTranslations, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/publisher/:id/translations/:locale", handler.Decorate(h.SetPublisherTranslation, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/publisher/:id/translations/:locale", handler.Decorate(h.DeletePublisherTranslation, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *TranslationHandler) GetBookTranslations(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetBookTranslations(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Translation is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+// SetBookTranslation takes the book's text in the locale, like {"title": "...", "description": "..."}
+func (h *TranslationHandler) SetBookTranslation(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	var translation entity.BookTranslation
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&translation); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	translation.BookID, _ = strconv.ParseInt(param.ByName("id"), 10, 64)
+	translation.Locale = param.ByName("locale")
+
+	ctx := r.Context()
+	err := h.uc.SetBookTranslation(ctx, &translation)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, translation)
+	return nil
+}
+
+func (h *TranslationHandler) DeleteBookTranslation(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeleteBookTranslation(ctx, id, param.ByName("locale"))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Translation Has Been Deleted")
+	return nil
+}
+
+func (h *TranslationHandler) GetCategoryTranslations(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetCategoryTranslations(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Translation is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+// SetCategoryTranslation takes the category's name in the locale, like {"name": "..."}
+func (h *TranslationHandler) SetCategoryTranslation(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	var translation entity.CategoryTranslation
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&translation); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	translation.CategoryID, _ = strconv.ParseInt(param.ByName("id"), 10, 64)
+	translation.Locale = param.ByName("locale")
+
+	ctx := r.Context()
+	err := h.uc.SetCategoryTranslation(ctx, &translation)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, translation)
+	return nil
+}
+
+func (h *TranslationHandler) DeleteCategoryTranslation(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeleteCategoryTranslation(ctx, id, param.ByName("locale"))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Translation Has Been Deleted")
+	return nil
+}
+
+func (h *TranslationHandler) GetPublisherTranslations(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetPublisherTranslations(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Translation is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+// SetPublisherTranslation takes the publisher's name in the locale, like {"name": "..."}
+func (h *TranslationHandler) SetPublisherTranslation(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	var translation entity.PublisherTranslation
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&translation); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	translation.PublisherID, _ = strconv.ParseInt(param.ByName("id"), 10, 64)
+	translation.Locale = param.ByName("locale")
+
+	ctx := r.Context()
+	err := h.uc.SetPublisherTranslation(ctx, &translation)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, translation)
+	return nil
+}
+
+func (h *TranslationHandler) DeletePublisherTranslation(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeletePublisherTranslation(ctx, id, param.ByName("locale"))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Translation Has Been Deleted")
+	return nil
+}
+
+// requestedLocales returns the locales the client wants the catalog in, best first: the lang
+// query parameter, or else the languages of the Accept-Language header by their quality
+func requestedLocales(r *http.Request) []string {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		return []string{lang}
+	}
+
+	type languageRange struct {
+		tag     string
+		quality float64
+	}
+
+	var ranges []languageRange
+	for _, accepted := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		params := strings.Split(accepted, ";")
+		lr := languageRange{tag: strings.TrimSpace(params[0]), quality: 1}
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				lr.quality, _ = strconv.ParseFloat(param[2:], 64)
+			}
+		}
+
+		if lr.tag == "" || lr.tag == "*" || lr.quality <= 0 {
+			continue
+		}
+		ranges = append(ranges, lr)
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	locales := make([]string, len(ranges))
+	for i, lr := range ranges {
+		locales[i] = lr.tag
+	}
+
+	return locales
+}
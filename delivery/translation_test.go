@@ -0,0 +1,88 @@
+package delivery_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTranslationHandler() (http.Handler, *mocks.TranslationUsecase) {
+	uc := new(mocks.TranslationUsecase)
+	translation := delivery.NewTranslationHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&translation)
+	return h, uc
+}
+
+func TestSetBookTranslation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     []byte
+		wantCode int
+	}{
+		{name: "success", body: []byte(`{"title":"La Couleur de la magie","description":"Un roman du Disque-monde"}`), wantCode: http.StatusOK},
+		{name: "invalid body", body: []byte(`{"title":1}`), wantCode: http.StatusBadRequest},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, translation := newTranslationHandler()
+			translation.On("SetBookTranslation", mock.Anything, mock.Anything).Return(nil)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPut, "/bookstore/book/1/translations/fr", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+			if test.wantCode == http.StatusOK {
+				translation.AssertCalled(t, "SetBookTranslation", mock.Anything, &entity.BookTranslation{BookID: 1, Locale: "fr", Title: "La Couleur de la magie", Description: "Un roman du Disque-monde"})
+			}
+		})
+	}
+}
+
+func TestGetBookInLocale(t *testing.T) {
+	testCases := []struct {
+		name        string
+		url         string
+		header      string
+		wantLocales []string
+	}{
+		{name: "catalog locale", url: "/bookstore/book/1", wantLocales: []string{}},
+		{name: "query parameter", url: "/bookstore/book/1?lang=fr", header: "de", wantLocales: []string{"fr"}},
+		{name: "accept language header", url: "/bookstore/book/1", header: "de;q=0.5, fr-CA, *;q=0.1, en;q=0", wantLocales: []string{"fr-CA", "de"}},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			book := new(mocks.BookUsecase)
+			book.On("GetBook", mock.Anything, int64(1)).Return(entity.Book{ID: 1, Title: "The Colour of Magic"}, nil)
+
+			currency := new(mocks.CurrencyUsecase)
+			currency.On("LocalizeBooks", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			translation := new(mocks.TranslationUsecase)
+			translation.On("LocalizeBooks", mock.Anything, mock.Anything, test.wantLocales).Return(nil)
+
+			bookHandler := delivery.NewBookHandler(book, currency, translation, fixture.DummyUsername, fixture.DummyPassword)
+			h := handler.NewHandler(&bookHandler)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, test.url, fixture.DummyUsername, fixture.DummyPassword, nil)
+			if test.header != "" {
+				request.Header.Set("Accept-Language", test.header)
+			}
+			h.ServeHTTP(recoder, request)
+
+			assert.Equal(t, http.StatusOK, recoder.Code)
+			translation.AssertExpectations(t)
+		})
+	}
+}
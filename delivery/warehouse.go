@@ -0,0 +1,135 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type WarehouseHandler struct {
+	uc       usecase.WarehouseUsecase
+	username string
+	password string
+}
+
+func NewWarehouseHandler(usecase usecase.WarehouseUsecase, username string, password string) WarehouseHandler {
+	return WarehouseHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+func (h *WarehouseHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/warehouse", handler.Decorate(h.GetWarehouses, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.GET("/bookstore/warehouse/:id", handler.Decorate(h.GetWarehouse, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/warehouse", handler.Decorate(h.CreateWarehouse, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.PUT("/bookstore/warehouse/:id", handler.Decorate(h.UpdateWarehouse, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/warehouse/:id", handler.Decorate(h.DeleteWarehouse, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+func (h *WarehouseHandler) GetWarehouses(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetWarehouses(ctx)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Warehouse is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *WarehouseHandler) GetWarehouse(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	data, err := h.uc.GetWarehouse(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if data.ID == 0 {
+		response.FailedResponse(w, http.StatusNotFound, fmt.Sprintf("Warehouse ID %d Was Not Found", id))
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *WarehouseHandler) CreateWarehouse(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	var warehouse entity.Warehouse
+	decoder := json.NewDecoder(r.Body)
+
+	if err := decoder.Decode(&warehouse); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.CreateWarehouse(ctx, &warehouse)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, "Created")
+	return nil
+}
+
+func (h *WarehouseHandler) UpdateWarehouse(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var warehouse entity.Warehouse
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&warehouse); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	err := h.uc.UpdateWarehouse(ctx, id, &warehouse)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Warehouse Has Been Updated")
+	return nil
+}
+
+func (h *WarehouseHandler) DeleteWarehouse(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	id, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.DeleteWarehouse(ctx, id)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Warehouse Has Been Deleted")
+	return nil
+}
@@ -0,0 +1,141 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newWarehouseHandler() (http.Handler, *mocks.WarehouseUsecase) {
+	uc := new(mocks.WarehouseUsecase)
+	warehouse := delivery.NewWarehouseHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&warehouse)
+	return h, uc
+}
+
+func TestGetWarehouses(t *testing.T) {
+	testCases := []struct {
+		name       string
+		warehouses []entity.Warehouse
+		wantErr    bool
+		getError   error
+	}{
+		{
+			name:       "success",
+			warehouses: []entity.Warehouse{{ID: 1, Code: "MAIN", Name: "Main Warehouse"}},
+			wantErr:    false,
+		},
+		{
+			name:     "failed to get warehouses",
+			wantErr:  true,
+			getError: errors.New("failed to get warehouses"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, warehouse := newWarehouseHandler()
+			warehouse.On("GetWarehouses", mock.Anything).Return(test.warehouses, test.getError)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/warehouse", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantErr, recoder.Code != http.StatusOK)
+		})
+	}
+}
+
+func TestGetWarehouse(t *testing.T) {
+	testCases := []struct {
+		name      string
+		warehouse entity.Warehouse
+		wantCode  int
+	}{
+		{name: "success", warehouse: entity.Warehouse{ID: 1, Code: "MAIN"}, wantCode: http.StatusOK},
+		{name: "not found", warehouse: entity.Warehouse{}, wantCode: http.StatusNotFound},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, warehouse := newWarehouseHandler()
+			warehouse.On("GetWarehouse", mock.Anything, int64(1)).Return(test.warehouse, nil)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/warehouse/1", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestCreateWarehouse(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      []byte
+		createErr error
+		wantCode  int
+	}{
+		{name: "success", body: []byte(`{"code":"SBY","name":"Surabaya","latitude":-7.25,"longitude":112.75}`), wantCode: http.StatusCreated},
+		{name: "invalid body", body: []byte(`{"latitude":"south"}`), wantCode: http.StatusBadRequest},
+		{name: "failed to create warehouse", body: []byte(`{"code":"SBY"}`), createErr: errors.New("warehouse needs a code and a name"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, warehouse := newWarehouseHandler()
+			warehouse.On("CreateWarehouse", mock.Anything, mock.Anything).Return(test.createErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/warehouse", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
+
+func TestUpdateWarehouse(t *testing.T) {
+	handler, warehouse := newWarehouseHandler()
+	warehouse.On("UpdateWarehouse", mock.Anything, int64(2), mock.Anything).Return(nil)
+
+	recoder := httptest.NewRecorder()
+	request := fixture.HTTPBasicAuth(http.MethodPut, "/bookstore/warehouse/2", fixture.DummyUsername, fixture.DummyPassword, []byte(`{"code":"SBY","name":"Surabaya"}`))
+	handler.ServeHTTP(recoder, request)
+
+	assert.Equal(t, http.StatusOK, recoder.Code)
+}
+
+func TestDeleteWarehouse(t *testing.T) {
+	testCases := []struct {
+		name      string
+		deleteErr error
+		wantCode  int
+	}{
+		{name: "success", wantCode: http.StatusOK},
+		{name: "warehouse still keeps stock", deleteErr: errors.New("warehouse ID 2 still keeps 4 books"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, warehouse := newWarehouseHandler()
+			warehouse.On("DeleteWarehouse", mock.Anything, int64(2)).Return(test.deleteErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodDelete, "/bookstore/warehouse/2", fixture.DummyUsername, fixture.DummyPassword, nil)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
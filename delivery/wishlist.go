@@ -0,0 +1,138 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/middleware"
+	"winartodev/book-store-be/response"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type WishlistHandler struct {
+	uc       usecase.WishlistUsecase
+	username string
+	password string
+}
+
+func NewWishlistHandler(usecase usecase.WishlistUsecase, username string, password string) WishlistHandler {
+	return WishlistHandler{
+		uc:       usecase,
+		username: username,
+		password: password,
+	}
+}
+
+// wishlistRequest names the customer the storefront signed in and the book they picked
+type wishlistRequest struct {
+	CustomerEmail string `json:"customer_email"`
+	BookID        int64  `json:"book_id"`
+}
+
+func (h *WishlistHandler) Register(r *httprouter.Router) error {
+	if r == nil {
+		return errors.New("router cannot be empty")
+	}
+
+	r.GET("/bookstore/wishlist", handler.Decorate(h.GetWishlist, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/wishlist", handler.Decorate(h.AddToWishlist, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/wishlist/:id", handler.Decorate(h.RemoveFromWishlist, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.POST("/bookstore/book/:id/notify-me", handler.Decorate(h.SubscribeBackInStock, middleware.MiddlewareBasicAuth(h.username, h.password)))
+	r.DELETE("/bookstore/book/:id/notify-me", handler.Decorate(h.UnsubscribeBackInStock, middleware.MiddlewareBasicAuth(h.username, h.password)))
+
+	return nil
+}
+
+// GetWishlist lists the books the customer of ?customer_email= saved, newest first
+func (h *WishlistHandler) GetWishlist(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	ctx := r.Context()
+	data, err := h.uc.GetWishlist(ctx, r.URL.Query().Get("customer_email"))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	if len(data) == 0 {
+		response.SuccessResponse(w, http.StatusOK, "Wishlist is empty")
+		return nil
+	}
+
+	response.SuccessResponse(w, http.StatusOK, data)
+	return nil
+}
+
+func (h *WishlistHandler) AddToWishlist(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	var req wishlistRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&req); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	data, err := h.uc.AddToWishlist(ctx, req.CustomerEmail, req.BookID)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, data)
+	return nil
+}
+
+// RemoveFromWishlist takes the book of the path off the wishlist of ?customer_email=
+func (h *WishlistHandler) RemoveFromWishlist(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.RemoveFromWishlist(ctx, r.URL.Query().Get("customer_email"), bookID)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Book Has Been Removed From The Wishlist")
+	return nil
+}
+
+// SubscribeBackInStock asks for a notice to the customer_email of the body once the
+// out-of-stock book can be ordered again
+func (h *WishlistHandler) SubscribeBackInStock(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	var req wishlistRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&req); err != nil {
+		response.FailedResponse(w, http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	ctx := r.Context()
+	data, err := h.uc.SubscribeBackInStock(ctx, bookID, req.CustomerEmail)
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusCreated, data)
+	return nil
+}
+
+// UnsubscribeBackInStock cancels the notice ?customer_email= waits for
+func (h *WishlistHandler) UnsubscribeBackInStock(w http.ResponseWriter, r *http.Request, param httprouter.Params) error {
+	bookID, _ := strconv.ParseInt(param.ByName("id"), 10, 64)
+
+	ctx := r.Context()
+	err := h.uc.UnsubscribeBackInStock(ctx, bookID, r.URL.Query().Get("customer_email"))
+	if err != nil {
+		response.FailedResponse(w, http.StatusForbidden, err.Error())
+		return err
+	}
+
+	response.SuccessResponse(w, http.StatusOK, "Back In Stock Notice Has Been Cancelled")
+	return nil
+}
@@ -0,0 +1,61 @@
+package delivery_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/delivery"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/fixture"
+	"winartodev/book-store-be/handler"
+	"winartodev/book-store-be/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newWishlistHandler() (http.Handler, *mocks.WishlistUsecase) {
+	uc := new(mocks.WishlistUsecase)
+	wh := delivery.NewWishlistHandler(uc, fixture.DummyUsername, fixture.DummyPassword)
+	h := handler.NewHandler(&wh)
+	return h, uc
+}
+
+func TestGetWishlist(t *testing.T) {
+	handler, uc := newWishlistHandler()
+	uc.On("GetWishlist", mock.Anything, "reader@mail.com").Return([]entity.WishlistItem{{ID: 2, CustomerEmail: "reader@mail.com", BookID: 5}}, nil)
+
+	recoder := httptest.NewRecorder()
+	request := fixture.HTTPBasicAuth(http.MethodGet, "/bookstore/wishlist?customer_email=reader@mail.com", fixture.DummyUsername, fixture.DummyPassword, nil)
+	handler.ServeHTTP(recoder, request)
+
+	assert.Equal(t, http.StatusOK, recoder.Code)
+	uc.AssertCalled(t, "GetWishlist", mock.Anything, "reader@mail.com")
+}
+
+func TestSubscribeBackInStock(t *testing.T) {
+	testCases := []struct {
+		name         string
+		body         []byte
+		subscribeErr error
+		wantCode     int
+	}{
+		{name: "success", body: []byte(`{"customer_email":"reader@mail.com"}`), wantCode: http.StatusCreated},
+		{name: "invalid body", body: []byte(`{"customer_email":5}`), wantCode: http.StatusBadRequest},
+		{name: "in stock", body: []byte(`{"customer_email":"reader@mail.com"}`), subscribeErr: errors.New("book ID 5 is in stock"), wantCode: http.StatusForbidden},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler, uc := newWishlistHandler()
+			uc.On("SubscribeBackInStock", mock.Anything, int64(5), "reader@mail.com").Return(entity.StockSubscription{ID: 7, BookID: 5, CustomerEmail: "reader@mail.com"}, test.subscribeErr)
+
+			recoder := httptest.NewRecorder()
+			request := fixture.HTTPBasicAuth(http.MethodPost, "/bookstore/book/5/notify-me", fixture.DummyUsername, fixture.DummyPassword, test.body)
+			handler.ServeHTTP(recoder, request)
+
+			assert.Equal(t, test.wantCode, recoder.Code)
+		})
+	}
+}
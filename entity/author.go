@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+const (
+	AuthorRoleAuthor      = "author"
+	AuthorRoleEditor      = "editor"
+	AuthorRoleTranslator  = "translator"
+	AuthorRoleIllustrator = "illustrator"
+)
+
+type Author struct {
+	ID             int64     `json:"id"`
+	Name           string    `json:"name"`
+	NormalizedName string    `json:"-"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// BookAuthor is a contributor of a book with its role and ordering
+type BookAuthor struct {
+	AuthorID int64  `json:"author_id"`
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+	Position int    `json:"position"`
+}
@@ -2,15 +2,48 @@ package entity
 
 import "time"
 
+// Book is a work, every edition sold separately is one of its SKUs
 type Book struct {
-	ID          int64     `json:"id"`
-	PublisherID int64     `json:"publisher_id"`
-	CategoryID  int64     `json:"category_id"`
-	Title       string    `json:"title"`
-	Author      string    `json:"author"`
-	Publication int       `json:"year_of_publication"`
-	Stock       int       `json:"stock"`
-	Price       int       `json:"price"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID               int64            `json:"id"`
+	PublisherID      int64            `json:"publisher_id"`
+	CategoryID       int64            `json:"category_id"`
+	Categories       []BookCategory   `json:"categories"`
+	Title            string           `json:"title"`
+	Description      string           `json:"description"`
+	Language         string           `json:"language"`
+	OriginalLanguage string           `json:"original_language"`
+	Authors          []BookAuthor     `json:"authors"`
+	Tags             []string         `json:"tags"`
+	Series           []BookSeries     `json:"series"`
+	Publication      int              `json:"year_of_publication"`
+	Stock            int              `json:"stock"`
+	Reserved         int              `json:"reserved"` // held by pending orders
+	Available        int              `json:"available"`
+	Warehouses       []WarehouseStock `json:"warehouses"`
+	Price            int              `json:"price"`                   // in effect now, in the store's currency
+	DisplayPrice     *Money           `json:"display_price,omitempty"` // in the client's currency
+	TaxCategoryID    int64            `json:"tax_category_id"`
+	WeightGrams      int              `json:"weight_grams"`
+	ReleaseDate      *time.Time       `json:"release_date"` // a PreOrder until then
+	PreOrder         bool             `json:"preorder"`
+	Rating           float64          `json:"rating"` // average of the approved reviews
+	RatingCount      int              `json:"rating_count"`
+	SKUs             []SKU            `json:"skus"`
+	Covers           []CoverImage     `json:"covers"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+}
+
+// BookSortRating lists the best rated books first
+const BookSortRating = "rating"
+
+// BookFilter holds optional conditions used when listing books and the order they are listed
+// in, by ID unless Sort says otherwise. The books of a series are listed in reading order.
+type BookFilter struct {
+	AuthorID           int64
+	CategoryID         int64
+	SeriesID           int64
+	IncludeDescendants bool
+	Tags               []string
+	Sort               string
 }
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// Bundle is a box set of books sold together at its own Price. Ordering a bundle orders its
+// books, what they cost separately over the bundle price is taken off them as a discount, so
+// their stock is reserved and sold book by book. Available is how many bundles the unreserved
+// stock of the books makes up.
+type Bundle struct {
+	ID           int64        `json:"id"`
+	Title        string       `json:"title"`
+	Price        int          `json:"price"`
+	DisplayPrice *Money       `json:"display_price,omitempty"` // in the client's currency
+	Available    int          `json:"available"`
+	Items        []BundleItem `json:"items"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// BundleItem is a book in a bundle and how many copies of it one bundle holds
+type BundleItem struct {
+	BookID   int64 `json:"book_id"`
+	Quantity int   `json:"quantity"`
+}
@@ -3,8 +3,17 @@ package entity
 import "time"
 
 type Category struct {
-	ID        int64     `json:"id"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        int64      `json:"id"`
+	ParentID  *int64     `json:"parent_id"`
+	Name      string     `json:"name"`
+	Children  []Category `json:"children,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// BookCategory is a category a book is filed under, one of them being the primary
+type BookCategory struct {
+	CategoryID int64  `json:"category_id"`
+	Name       string `json:"name"`
+	Primary    bool   `json:"primary"`
 }
@@ -0,0 +1,18 @@
+package entity
+
+const (
+	RenditionOriginal  = "original"
+	RenditionThumbnail = "thumbnail"
+	RenditionMedium    = "medium"
+	RenditionLarge     = "large"
+)
+
+// CoverImage is one stored rendition of a book cover
+type CoverImage struct {
+	Rendition   string `json:"rendition"`
+	Key         string `json:"-"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+}
@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// currencyMinorUnits holds the ISO 4217 number of decimals of the currencies the store can
+// price in. The rupiah is kept without decimals: sen are not used and book prices have always
+// been whole rupiah.
+var currencyMinorUnits = map[string]int{
+	"AUD": 2,
+	"BHD": 3,
+	"BND": 2,
+	"CAD": 2,
+	"CHF": 2,
+	"CNY": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"HKD": 2,
+	"IDR": 0,
+	"INR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"MYR": 2,
+	"NZD": 2,
+	"PHP": 2,
+	"SGD": 2,
+	"THB": 2,
+	"TWD": 2,
+	"USD": 2,
+	"VND": 0,
+}
+
+// KnownCurrency tells whether code is an ISO 4217 currency the store can price in
+func KnownCurrency(code string) bool {
+	_, ok := currencyMinorUnits[code]
+	return ok
+}
+
+// MinorUnits returns how many decimals amounts of the currency have
+func MinorUnits(code string) int {
+	return currencyMinorUnits[code]
+}
+
+// Money is an amount in the minor units of its currency, 1250 USD is 12.50 dollars. It is
+// written to and read from JSON as a decimal string so no precision is lost on the way.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// String formats the amount as a decimal followed by the currency code, like 12.50 USD
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// Decimal formats the amount in major units with the decimals of the currency
+func (m Money) Decimal() string {
+	units := MinorUnits(m.Currency)
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if units == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= units {
+		digits = strings.Repeat("0", units-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-units] + "." + digits[len(digits)-units:]
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{Amount: m.Decimal(), Currency: m.Currency})
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Amount   json.Number `json:"amount"`
+		Currency string      `json:"currency"`
+	}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	m.Currency = strings.ToUpper(strings.TrimSpace(raw.Currency))
+	m.Amount, err = ParseAmount(raw.Amount.String(), m.Currency)
+	return err
+}
+
+// ParseAmount reads a decimal amount, like 12.5, into the minor units of the currency. An
+// amount with more decimals than the currency has is refused rather than rounded.
+func ParseAmount(decimal string, currency string) (int64, error) {
+	if !KnownCurrency(currency) {
+		return 0, fmt.Errorf("unknown currency %q", currency)
+	}
+
+	if decimal == "" {
+		return 0, nil
+	}
+
+	units := MinorUnits(currency)
+	whole, fraction := decimal, ""
+	if i := strings.IndexByte(decimal, '.'); i >= 0 {
+		whole, fraction = decimal[:i], decimal[i+1:]
+	}
+
+	if len(fraction) > units {
+		return 0, fmt.Errorf("amount %s has more than the %d decimals of %s", decimal, units, currency)
+	}
+
+	amount, err := strconv.ParseInt(whole+fraction+strings.Repeat("0", units-len(fraction)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("amount %s is not a decimal number", decimal)
+	}
+
+	return amount, nil
+}
+
+// CurrencyPrice is the price of a book in one currency's price list. It takes precedence over
+// converting the book's price with the exchange rates.
+type CurrencyPrice struct {
+	BookID    int64     `json:"book_id"`
+	Price     Money     `json:"price"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
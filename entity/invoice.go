@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+const (
+	InvoiceKindInvoice    = "invoice"
+	InvoiceKindCreditNote = "credit_note"
+)
+
+// Invoice is the accounting record of a paid order, or a credit note of what was refunded of
+// it. Invoices and credit notes are numbered apart without gaps in every fiscal year, Number
+// is like INV-2026-000001 or CN-2026-000001. The buyer, lines, taxes and totals are copied
+// from the order when it is issued so later changes to the order or its books do not alter it.
+// Amounts are in minor units of Currency, Total is Subtotal plus Shipping, plus Tax unless the
+// prices include it.
+type Invoice struct {
+	ID                int64         `json:"id"`
+	OrderID           int64         `json:"order_id"`
+	Kind              string        `json:"kind"`
+	Number            string        `json:"number"`
+	FiscalYear        int           `json:"fiscal_year"`
+	Sequence          int           `json:"sequence"`
+	CreditedInvoiceID int64         `json:"credited_invoice_id,omitempty"`
+	CustomerEmail     string        `json:"customer_email"`
+	ShippingCountry   string        `json:"shipping_country"`
+	ShippingRegion    string        `json:"shipping_region"`
+	Currency          string        `json:"currency"`
+	PricesIncludeTax  bool          `json:"prices_include_tax"`
+	Lines             []InvoiceLine `json:"lines"`
+	TaxLines          []TaxLine     `json:"tax_lines"`
+	Subtotal          int           `json:"subtotal"`
+	Tax               int           `json:"tax"`
+	Shipping          int           `json:"shipping"`
+	Total             int           `json:"total"`
+	// Source identifies what the invoice was issued for so it is never issued twice
+	Source    string    `json:"-"`
+	IssuedAt  time.Time `json:"issued_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// InvoiceLine is a book of an invoiced order, or what a credit note refunds. Amount is the
+// line price less its Discount.
+type InvoiceLine struct {
+	ID          int64  `json:"id"`
+	InvoiceID   int64  `json:"invoice_id"`
+	BookID      int64  `json:"book_id,omitempty"`
+	Description string `json:"description"`
+	Quantity    int    `json:"quantity"`
+	UnitPrice   int    `json:"unit_price"`
+	Discount    int    `json:"discount"`
+	Tax         int    `json:"tax"`
+	Amount      int    `json:"amount"`
+}
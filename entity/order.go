@@ -0,0 +1,68 @@
+package entity
+
+import "time"
+
+const (
+	OrderPending   = "pending"
+	OrderConfirmed = "confirmed"
+	OrderCancelled = "cancelled"
+	OrderExpired   = "expired"
+	// Confirmed orders are partially shipped until all their books are shipped and delivered
+	// once all their shipments are. OrderPartiallyReturned and OrderReturned are orders some or
+	// all of whose books were returned and refunded.
+	OrderPartiallyShipped  = "partially_shipped"
+	OrderShipped           = "shipped"
+	OrderDelivered         = "delivered"
+	OrderPartiallyReturned = "partially_returned"
+	OrderReturned          = "returned"
+	// OrderPreordered is a confirmed pre-order waiting for its books to be in stock
+	OrderPreordered = "preordered"
+)
+
+// Order is a checkout, while pending its lines hold stock until ExpiresAt
+type Order struct {
+	ID                 int64              `json:"id"`
+	CustomerEmail      string             `json:"customer_email"`
+	Status             string             `json:"status"`
+	ShippingLatitude   *float64           `json:"shipping_latitude,omitempty"`
+	ShippingLongitude  *float64           `json:"shipping_longitude,omitempty"`
+	ShippingCountry    string             `json:"shipping_country"`
+	ShippingRegion     string             `json:"shipping_region"`
+	AllocationStrategy string             `json:"allocation_strategy,omitempty"`
+	CouponCodes        []string           `json:"coupon_codes,omitempty"`
+	PricesIncludeTax   bool               `json:"prices_include_tax"`
+	PreOrder           bool               `json:"preorder"` // of unreleased books, reserves nothing
+	ShippingMethodID   int64              `json:"shipping_method_id"`
+	ShippingCost       int                `json:"shipping_cost"` // not taxed
+	Lines              []OrderLine        `json:"lines"`
+	Promotions         []AppliedPromotion `json:"promotions"`
+	TaxLines           []TaxLine          `json:"tax_lines"`
+	RefundedTotal      int                `json:"refunded_total"` // paid back for returned books
+	ExpiresAt          time.Time          `json:"expires_at"`
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+}
+
+// OrderLine is a book, or the SKUID edition of it, in an order. UnitPrice is its price
+// when the order was placed, later price changes do not affect it, Discount is what
+// promotions took off the whole line and Tax the tax charged on what is left. A line
+// ordering a BundleID without a book stands for a line of every book in the bundle, those
+// lines keep the BundleID and the bundle saving is part of their Discount.
+type OrderLine struct {
+	ID          int64        `json:"id"`
+	OrderID     int64        `json:"order_id"`
+	BookID      int64        `json:"book_id"`
+	SKUID       int64        `json:"sku_id,omitempty"`
+	BundleID    int64        `json:"bundle_id,omitempty"`
+	Quantity    int          `json:"quantity"`
+	UnitPrice   int          `json:"unit_price"`
+	Discount    int          `json:"discount"`
+	Tax         int          `json:"tax"`
+	Allocations []Allocation `json:"allocations"`
+}
+
+// Allocation is the part of an order line shipped from one warehouse
+type Allocation struct {
+	WarehouseID int64 `json:"warehouse_id"`
+	Quantity    int   `json:"quantity"`
+}
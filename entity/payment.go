@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+const (
+	PaymentPending    = "pending"
+	PaymentAuthorized = "authorized"
+	PaymentCaptured   = "captured"
+	PaymentVoided     = "voided"
+	PaymentRefunded   = "refunded"
+	PaymentFailed     = "failed"
+)
+
+const (
+	RefundPending   = "pending"
+	RefundSucceeded = "succeeded"
+	RefundFailed    = "failed"
+)
+
+// Payment is an attempt to pay an order through a payment gateway. Retrying an attempt with
+// the same IdempotencyKey returns it rather than charging the customer again. Reference is the
+// gateway's ID of the authorization, which is pending until the gateway decides on it. What
+// was captured can be refunded in parts, the payment is refunded once all of it is.
+type Payment struct {
+	ID             int64     `json:"id"`
+	OrderID        int64     `json:"order_id"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	Gateway        string    `json:"gateway"`
+	Reference      string    `json:"reference"`
+	Status         string    `json:"status"`
+	Amount         int       `json:"amount"`
+	Currency       string    `json:"currency"`
+	CapturedAmount int       `json:"captured_amount"`
+	RefundedAmount int       `json:"refunded_amount"`
+	FailureReason  string    `json:"failure_reason,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// PaymentRefund pays back part of a captured payment. It is recorded pending, its amount held
+// on the payment, before the gateway is asked for it, and its ID keys the gateway request.
+type PaymentRefund struct {
+	ID        int64     `json:"id"`
+	PaymentID int64     `json:"payment_id"`
+	Amount    int       `json:"amount"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
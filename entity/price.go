@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// BookPrice is the price of a book from EffectiveFrom until EffectiveTo, or for good when
+// EffectiveTo is empty. Entries may overlap: at any time the active entry that started last
+// wins, so a sale with an end date temporarily overrides the regular price.
+type BookPrice struct {
+	ID            int64      `json:"id"`
+	BookID        int64      `json:"book_id"`
+	Price         int        `json:"price"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
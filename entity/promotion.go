@@ -0,0 +1,101 @@
+package entity
+
+import "time"
+
+const (
+	PromotionPercentage = "percentage"
+	PromotionFixed      = "fixed"
+	PromotionBuyXGetY   = "buy_x_get_y"
+
+	ScopeAll       = "all"
+	ScopeBook      = "book"
+	ScopeCategory  = "category"
+	ScopePublisher = "publisher"
+)
+
+// Promotion is a discount on the books it is scoped to. Value is a percentage for percentage
+// promotions and an amount in the smallest currency unit for fixed ones, buy-X-get-Y
+// promotions give GetQuantity of every BuyQuantity + GetQuantity scoped books for free,
+// the cheapest first. Promotions with a CouponCode only apply when the code is given, the
+// others apply automatically. Stackable promotions combine with each other, the others only
+// apply on their own. Zero limits and empty dates mean no limit.
+type Promotion struct {
+	ID               int64      `json:"id"`
+	Name             string     `json:"name"`
+	Type             string     `json:"type"`
+	Value            int        `json:"value"`
+	Scope            string     `json:"scope"`
+	ScopeIDs         []int64    `json:"scope_ids"`
+	BuyQuantity      int        `json:"buy_quantity"`
+	GetQuantity      int        `json:"get_quantity"`
+	MinSpend         int        `json:"min_spend"`
+	CouponCode       string     `json:"coupon_code"`
+	UsageLimit       int        `json:"usage_limit"`
+	PerCustomerLimit int        `json:"per_customer_limit"`
+	Stackable        bool       `json:"stackable"`
+	Priority         int        `json:"priority"`
+	StartsAt         *time.Time `json:"starts_at"`
+	EndsAt           *time.Time `json:"ends_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// Redemptions counts the orders a promotion was used in, in total and by one customer
+type Redemptions struct {
+	Total    int
+	Customer int
+}
+
+// Cart is what a customer is about to order, the shipping address decides the taxes charged
+// and, with the shipping method, the shipping cost
+type Cart struct {
+	CustomerEmail    string      `json:"customer_email"`
+	CouponCodes      []string    `json:"coupon_codes"`
+	ShippingCountry  string      `json:"shipping_country"`
+	ShippingRegion   string      `json:"shipping_region"`
+	ShippingMethodID int64       `json:"shipping_method_id"`
+	Lines            []OrderLine `json:"lines"`
+}
+
+// Quote prices a cart and explains the promotions applied to it and the coupons that were not.
+// When PricesIncludeTax the tax is part of the prices, otherwise it is added to the total.
+// Shipping is added to the total, WeightGrams is what the books weigh together.
+type Quote struct {
+	Lines            []QuoteLine        `json:"lines"`
+	Subtotal         int                `json:"subtotal"`
+	Discount         int                `json:"discount"`
+	Tax              int                `json:"tax"`
+	Shipping         int                `json:"shipping"`
+	WeightGrams      int                `json:"weight_grams"`
+	Total            int                `json:"total"`
+	PricesIncludeTax bool               `json:"prices_include_tax"`
+	Promotions       []AppliedPromotion `json:"promotions"`
+	TaxLines         []TaxLine          `json:"tax_lines"`
+	RejectedCoupons  []RejectedCoupon   `json:"rejected_coupons"`
+}
+
+type QuoteLine struct {
+	BookID    int64 `json:"book_id"`
+	SKUID     int64 `json:"sku_id,omitempty"`
+	BundleID  int64 `json:"bundle_id,omitempty"`
+	Quantity  int   `json:"quantity"`
+	UnitPrice int   `json:"unit_price"`
+	Subtotal  int   `json:"subtotal"`
+	Discount  int   `json:"discount"`
+	Tax       int   `json:"tax"`
+	Total     int   `json:"total"`
+}
+
+// AppliedPromotion is a promotion that discounted a cart
+type AppliedPromotion struct {
+	PromotionID int64  `json:"promotion_id"`
+	Name        string `json:"name"`
+	CouponCode  string `json:"coupon_code,omitempty"`
+	Description string `json:"description"`
+	Discount    int    `json:"discount"`
+}
+
+type RejectedCoupon struct {
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+const (
+	PurchaseOrderDraft             = "draft"
+	PurchaseOrderOrdered           = "ordered"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"
+	PurchaseOrderCancelled         = "cancelled"
+)
+
+// PurchaseOrder restocks books from a supplier into a warehouse. Drafts can be edited until
+// they are placed, received stock is recorded line by line as it arrives.
+type PurchaseOrder struct {
+	ID          int64               `json:"id"`
+	SupplierID  int64               `json:"supplier_id"`
+	WarehouseID int64               `json:"warehouse_id"`
+	Status      string              `json:"status"`
+	ExpectedAt  *time.Time          `json:"expected_at"`
+	Notes       string              `json:"notes"`
+	Lines       []PurchaseOrderLine `json:"lines"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// PurchaseOrderLine is a book ordered from the supplier. UnitCost is in the smallest
+// currency unit, like the book price.
+type PurchaseOrderLine struct {
+	ID               int64 `json:"id"`
+	PurchaseOrderID  int64 `json:"purchase_order_id"`
+	BookID           int64 `json:"book_id"`
+	Quantity         int   `json:"quantity"`
+	ReceivedQuantity int   `json:"received_quantity"`
+	UnitCost         int   `json:"unit_cost"`
+}
+
+// PurchaseOrderReceipt is a delivery of some or all of the ordered books. Every received
+// line becomes a receipt in the stock ledger.
+type PurchaseOrderReceipt struct {
+	PurchaseOrderID int64           `json:"purchase_order_id"`
+	Lines           []ReceiptLine   `json:"lines"`
+	Actor           string          `json:"actor"`
+	Status          string          `json:"status"`
+	Movements       []StockMovement `json:"movements"`
+}
+
+type ReceiptLine struct {
+	BookID   int64 `json:"book_id"`
+	Quantity int   `json:"quantity"`
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// reasons a book is recommended for another one
+const (
+	RecommendationBoughtTogether = "bought_together"
+	RecommendationSimilar        = "similar"
+)
+
+// Recommendation is a book related to the one a customer looks at. Books bought together in
+// the same orders are recommended first, books without purchase data get similar books by
+// the same author, in the same category or from the same publisher. Score ranks the
+// recommendations of a book, highest first: how many orders had both books, or how much the
+// books have in common.
+type Recommendation struct {
+	BookID     int64     `json:"book_id"`
+	Title      string    `json:"title"`
+	Price      int       `json:"price"`
+	Available  int       `json:"available"`
+	Rating     float64   `json:"rating"`
+	Reason     string    `json:"reason"`
+	Score      int       `json:"score"`
+	ComputedAt time.Time `json:"computed_at"`
+}
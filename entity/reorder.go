@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// ReorderPoint is the available stock at or below which a book has to be restocked.
+// AlertedAt is set once a low-stock alert went out and cleared when the book is
+// restocked above the threshold, so every shortage is reported once.
+type ReorderPoint struct {
+	BookID          int64      `json:"book_id"`
+	Threshold       int        `json:"threshold"`
+	ReorderQuantity int        `json:"reorder_quantity"`
+	AlertedAt       *time.Time `json:"alerted_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// LowStock is a book whose available stock fell to its reorder point
+type LowStock struct {
+	BookID          int64      `json:"book_id"`
+	Title           string     `json:"title"`
+	Stock           int        `json:"stock"`
+	Reserved        int        `json:"reserved"`
+	Available       int        `json:"available"`
+	Threshold       int        `json:"threshold"`
+	ReorderQuantity int        `json:"reorder_quantity"`
+	AlertedAt       *time.Time `json:"alerted_at"`
+}
@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+const (
+	ReturnRequested = "requested"
+	ReturnApproved  = "approved"
+	ReturnRejected  = "rejected"
+	ReturnReceived  = "received"
+	ReturnRefunded  = "refunded"
+)
+
+// Reasons a customer returns books for
+const (
+	ReturnDamaged        = "damaged"
+	ReturnWrongItem      = "wrong_item"
+	ReturnNotAsDescribed = "not_as_described"
+	ReturnChangedMind    = "changed_mind"
+	ReturnOther          = "other"
+)
+
+// What is done with returned books once they are back: restocked into a warehouse to be sold
+// again or written off
+const (
+	DispositionRestock  = "restock"
+	DispositionWriteOff = "write_off"
+)
+
+// Return is a customer's request to send back books of a confirmed order. Staff approve or
+// reject it, receive the books and refund them through the order's payment, in either order.
+// RefundAmount is what was refunded, which can be less than the books are worth.
+type Return struct {
+	ID           int64        `json:"id"`
+	OrderID      int64        `json:"order_id"`
+	Status       string       `json:"status"`
+	CustomerNote string       `json:"customer_note"`
+	StaffNote    string       `json:"staff_note"`
+	RefundAmount int          `json:"refund_amount"`
+	Lines        []ReturnLine `json:"lines"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// ReturnLine is a quantity of an order line sent back. Disposition and WarehouseID are set
+// when the books are received, WarehouseID is where restocked books go.
+type ReturnLine struct {
+	ID          int64  `json:"id"`
+	ReturnID    int64  `json:"return_id"`
+	OrderLineID int64  `json:"order_line_id"`
+	BookID      int64  `json:"book_id"`
+	Quantity    int    `json:"quantity"`
+	Reason      string `json:"reason"`
+	Disposition string `json:"disposition"`
+	WarehouseID int64  `json:"warehouse_id"`
+}
@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+// Review is a customer's star Rating of a book, from 1 to 5, with an optional text. A customer
+// reviews a book once. VerifiedPurchase tells the customer bought the book and kept it. Reviews are
+// pending until moderated, only approved reviews are shown and count toward the book's rating.
+type Review struct {
+	ID               int64      `json:"id"`
+	BookID           int64      `json:"book_id"`
+	CustomerEmail    string     `json:"customer_email"`
+	Rating           int        `json:"rating"`
+	Title            string     `json:"title"`
+	Body             string     `json:"body"`
+	VerifiedPurchase bool       `json:"verified_purchase"`
+	Status           string     `json:"status"`
+	ModeratedBy      string     `json:"moderated_by,omitempty"`
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// ReviewFilter holds optional conditions used when listing reviews
+type ReviewFilter struct {
+	BookID int64
+	Status string
+}
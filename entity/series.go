@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// Series is a set of books meant to be read in order, like Discworld
+type Series struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// BookSeries places a book in a series. Position is its place in the reading order and may
+// be fractional, a novella read between the second and third books is at 2.5.
+type BookSeries struct {
+	SeriesID int64   `json:"series_id"`
+	Name     string  `json:"name"`
+	Position float64 `json:"position"`
+}
@@ -0,0 +1,59 @@
+package entity
+
+import "time"
+
+const (
+	ShipmentShipped   = "shipped"
+	ShipmentInTransit = "in_transit"
+	ShipmentDelivered = "delivered"
+)
+
+// ShippingMethod is a way orders are shipped, like a carrier's standard or express service.
+// Its Rates price the parcels it ships, inactive methods cannot be chosen at checkout.
+type ShippingMethod struct {
+	ID        int64          `json:"id"`
+	Code      string         `json:"code"`
+	Name      string         `json:"name"`
+	Carrier   string         `json:"carrier"`
+	Active    bool           `json:"active"`
+	Rates     []ShippingRate `json:"rates"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// ShippingRate is the Price of shipping a parcel of up to MaxWeight grams, or of any weight
+// when it is 0, to a destination zone: a region of a country, a whole country or, when Country
+// is empty, anywhere the method has no rate of its own for.
+type ShippingRate struct {
+	ID               int64     `json:"id"`
+	ShippingMethodID int64     `json:"shipping_method_id"`
+	Country          string    `json:"country"`
+	Region           string    `json:"region"`
+	MaxWeight        int       `json:"max_weight"`
+	Price            int       `json:"price"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// Shipment is a parcel of an order handed to a carrier. An order can ship over several
+// shipments, each with some of the books of its lines.
+type Shipment struct {
+	ID             int64          `json:"id"`
+	OrderID        int64          `json:"order_id"`
+	Carrier        string         `json:"carrier"`
+	TrackingNumber string         `json:"tracking_number"`
+	Status         string         `json:"status"`
+	Lines          []ShipmentLine `json:"lines"`
+	ShippedAt      time.Time      `json:"shipped_at"`
+	DeliveredAt    *time.Time     `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+type ShipmentLine struct {
+	ID          int64 `json:"id"`
+	ShipmentID  int64 `json:"shipment_id"`
+	OrderLineID int64 `json:"order_line_id"`
+	BookID      int64 `json:"book_id"`
+	Quantity    int   `json:"quantity"`
+}
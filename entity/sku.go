@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+const (
+	FormatHardcover = "hardcover"
+	FormatPaperback = "paperback"
+	FormatEbook     = "ebook"
+	FormatAudiobook = "audiobook"
+)
+
+// SKU is a sellable edition of a book in a specific format. Digital editions hold no stock.
+// An edition with no TaxCategoryID is taxed in the category of its book.
+type SKU struct {
+	ID            int64     `json:"id"`
+	BookID        int64     `json:"book_id"`
+	Format        string    `json:"format"`
+	ISBN          string    `json:"isbn"`
+	Price         int       `json:"price"`
+	DisplayPrice  *Money    `json:"display_price,omitempty"` // in the client's currency
+	TaxCategoryID int64     `json:"tax_category_id"`
+	Stock         int       `json:"stock"`
+	Reserved      int       `json:"reserved"`
+	WeightGrams   int       `json:"weight_grams"`
+	WidthMM       int       `json:"width_mm"`
+	HeightMM      int       `json:"height_mm"`
+	DepthMM       int       `json:"depth_mm"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Digital tells whether the edition is downloaded rather than shipped
+func (s SKU) Digital() bool {
+	return s.Format == FormatEbook || s.Format == FormatAudiobook
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+const (
+	MovementReceipt    = "receipt"
+	MovementSale       = "sale"
+	MovementReturn     = "return"
+	MovementAdjustment = "adjustment"
+	MovementDamage     = "damage"
+	MovementTransfer   = "transfer"
+)
+
+// StockMovement is an entry of the append-only inventory ledger of a book. Quantity is
+// the signed change applied to the stock of the warehouse, and of the SKUID edition when
+// given, and StockAfter the total stock of the book it resulted in.
+type StockMovement struct {
+	ID          int64     `json:"id"`
+	BookID      int64     `json:"book_id"`
+	SKUID       int64     `json:"sku_id,omitempty"`
+	WarehouseID int64     `json:"warehouse_id"`
+	Type        string    `json:"type"`
+	Quantity    int       `json:"quantity"`
+	Reason      string    `json:"reason"`
+	Actor       string    `json:"actor"`
+	StockAfter  int       `json:"stock_after"`
+	CreatedAt   time.Time `json:"created_at"`
+}
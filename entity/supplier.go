@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// Supplier is a publisher or distributor stock is bought from. PublisherID links suppliers
+// that are also the publisher of books in the catalogue.
+type Supplier struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	PublisherID *int64    `json:"publisher_id"`
+	Email       string    `json:"email"`
+	PhoneNumber string    `json:"phone_number"`
+	Address     string    `json:"address"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+// TaxCategory groups the books taxed alike, such as printed books that are zero-rated in some
+// countries and ebooks that are standard-rated
+type TaxCategory struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TaxRule is the tax charged on books shipped to a country, or to a region of it when Region
+// is set. A rule with no TaxCategoryID covers the books of every category that has no rule of
+// its own. Rate is in basis points, 1100 is 11%.
+type TaxRule struct {
+	ID            int64     `json:"id"`
+	Country       string    `json:"country"`
+	Region        string    `json:"region"`
+	TaxCategoryID int64     `json:"tax_category_id"`
+	Name          string    `json:"name"`
+	Rate          int       `json:"rate"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// TaxLine is one tax charged on a cart or an order: Amount is the tax charged at Rate on the
+// Taxable amount of the lines it applies to
+type TaxLine struct {
+	Name    string `json:"name"`
+	Rate    int    `json:"rate"`
+	Taxable int    `json:"taxable"`
+	Amount  int    `json:"amount"`
+}
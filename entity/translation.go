@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// BookTranslation is the title and description of a book in another locale. A field left
+// empty is not translated and falls back like a missing translation, see LocaleFallbacks.
+type BookTranslation struct {
+	BookID      int64     `json:"book_id"`
+	Locale      string    `json:"locale"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CategoryTranslation is the name of a category in another locale
+type CategoryTranslation struct {
+	CategoryID int64     `json:"category_id"`
+	Locale     string    `json:"locale"`
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// PublisherTranslation is the name of a publisher in another locale
+type PublisherTranslation struct {
+	PublisherID int64     `json:"publisher_id"`
+	Locale      string    `json:"locale"`
+	Name        string    `json:"name"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// NormalizeLocale returns a BCP 47 language tag in its usual case, pt_br is pt-BR and
+// zh-hant-tw is zh-Hant-TW, or nothing when tag is not one
+func NormalizeLocale(tag string) string {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if !localePattern.MatchString(tag) {
+		return ""
+	}
+
+	subtags := strings.Split(tag, "-")
+	for i := 1; i < len(subtags); i++ {
+		switch len(subtags[i]) {
+		case 2:
+			subtags[i] = strings.ToUpper(subtags[i])
+		case 4:
+			subtags[i] = strings.ToUpper(subtags[i][:1]) + subtags[i][1:]
+		}
+	}
+
+	return strings.Join(subtags, "-")
+}
+
+// LocaleFallbacks decides which translations stand in for a locale. The catalog is written
+// in Default and needs no translation into it. A locale falls back to the one Next sends it
+// to, or else to its parent: pt-BR falls back to pt.
+type LocaleFallbacks struct {
+	Default string
+	Next    map[string]string
+}
+
+// Chain returns the locales to look translations up in, best first, for the locales a client
+// asked for in order of preference. It ends where a fallback reaches the catalog's own locale,
+// the text of the catalog is preferred to any translation after it.
+func (f LocaleFallbacks) Chain(requested []string) []string {
+	var chain []string
+	seen := make(map[string]bool)
+
+	for _, tag := range requested {
+		for locale := NormalizeLocale(tag); locale != "" && !seen[locale]; locale = f.next(locale) {
+			if locale == f.Default {
+				return chain
+			}
+
+			seen[locale] = true
+			chain = append(chain, locale)
+		}
+	}
+
+	return chain
+}
+
+func (f LocaleFallbacks) next(locale string) string {
+	if next, ok := f.Next[locale]; ok {
+		return next
+	}
+
+	if i := strings.LastIndex(locale, "-"); i > 0 {
+		return locale[:i]
+	}
+
+	return ""
+}
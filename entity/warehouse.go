@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+const (
+	AllocationNearest   = "nearest"
+	AllocationMostStock = "most_stock"
+	AllocationSplit     = "split"
+)
+
+// Warehouse is a location stock is kept and shipped from
+type Warehouse struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WarehouseStock is the stock of a book, or of one of its editions when SKUID is set, kept in
+// one warehouse
+type WarehouseStock struct {
+	WarehouseID   int64  `json:"warehouse_id"`
+	WarehouseCode string `json:"warehouse_code"`
+	BookID        int64  `json:"-"`
+	SKUID         int64  `json:"-"`
+	Stock         int    `json:"stock"`
+	Reserved      int    `json:"reserved"`
+	Available     int    `json:"available"`
+}
+
+// StockTransfer moves stock of a book, of its edition SKUID when set, between two warehouses.
+// It is recorded in the ledger as a pair of transfer movements.
+type StockTransfer struct {
+	BookID          int64           `json:"book_id"`
+	SKUID           int64           `json:"sku_id"`
+	FromWarehouseID int64           `json:"from_warehouse_id"`
+	ToWarehouseID   int64           `json:"to_warehouse_id"`
+	Quantity        int             `json:"quantity"`
+	Reason          string          `json:"reason"`
+	Actor           string          `json:"actor"`
+	Movements       []StockMovement `json:"movements"`
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// WishlistItem is a book a customer saved for later
+type WishlistItem struct {
+	ID            int64     `json:"id"`
+	CustomerEmail string    `json:"customer_email"`
+	BookID        int64     `json:"book_id"`
+	Title         string    `json:"title"`
+	Available     int       `json:"available"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// StockSubscription asks for a notice when an out-of-stock book can be ordered again.
+// NotifiedAt is set once the customer was told, which ends the subscription.
+type StockSubscription struct {
+	ID            int64      `json:"id"`
+	BookID        int64      `json:"book_id"`
+	CustomerEmail string     `json:"customer_email"`
+	NotifiedAt    *time.Time `json:"notified_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// BackInStock is a book a customer subscribed to that has stock again
+type BackInStock struct {
+	SubscriptionID int64  `json:"subscription_id"`
+	CustomerEmail  string `json:"customer_email"`
+	BookID         int64  `json:"book_id"`
+	Title          string `json:"title"`
+	Available      int    `json:"available"`
+}
@@ -0,0 +1,212 @@
+package exchange
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sync"
+	"winartodev/book-store-be/entity"
+)
+
+// Rounding modes, up and down round toward positive and negative infinity
+const (
+	RoundHalfUp   = "half_up"
+	RoundHalfEven = "half_even"
+	RoundUp       = "up"
+	RoundDown     = "down"
+)
+
+// ErrUnsupportedCurrency is returned when there is no rate to convert to or from a currency
+var ErrUnsupportedCurrency = errors.New("unsupported currency")
+
+// Rounding tells how converted amounts of a currency are rounded: to a multiple of Increment
+// minor units, 5 rounds cents to the nickel, following Mode
+type Rounding struct {
+	Increment int64  `json:"increment"`
+	Mode      string `json:"mode"`
+}
+
+// table is one version of the exchange rates file. Rates are how much of a currency one unit
+// of the base currency buys, both in major units.
+type table struct {
+	rates    map[string]*big.Rat
+	rounding map[string]Rounding
+}
+
+// Rates converts money from the base currency of the store to the currencies it has a rate
+// for. The rates are loaded from a file and can be reloaded while they are being used.
+type Rates struct {
+	base  string
+	mu    sync.RWMutex
+	table table
+}
+
+// NewRates returns rates with no other currency than base until a file is loaded
+func NewRates(base string) *Rates {
+	return &Rates{base: base, table: table{rates: map[string]*big.Rat{}, rounding: map[string]Rounding{}}}
+}
+
+// Base returns the currency book prices are kept in
+func (r *Rates) Base() string {
+	return r.base
+}
+
+// Load replaces the rates with those of the file at path, see Read for its format
+func (r *Rates) Load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return r.Read(f)
+}
+
+// Read replaces the rates with a JSON document such as
+//
+//	{
+//	  "base": "IDR",
+//	  "rates": {"USD": "0.000063", "SGD": "0.000085"},
+//	  "rounding": {"SGD": {"increment": 5}, "USD": {"increment": 1, "mode": "half_even"}}
+//	}
+//
+// Rates may be written as numbers or strings, strings keep every digit. The current rates are
+// kept when the document is invalid.
+func (r *Rates) Read(reader io.Reader) error {
+	var doc struct {
+		Base     string                 `json:"base"`
+		Rates    map[string]json.Number `json:"rates"`
+		Rounding map[string]Rounding    `json:"rounding"`
+	}
+
+	err := json.NewDecoder(reader).Decode(&doc)
+	if err != nil {
+		return err
+	}
+
+	if doc.Base != r.base {
+		return fmt.Errorf("rates are based on %s but prices are kept in %s", doc.Base, r.base)
+	}
+
+	t := table{rates: make(map[string]*big.Rat), rounding: make(map[string]Rounding)}
+	for code, value := range doc.Rates {
+		if !entity.KnownCurrency(code) {
+			return fmt.Errorf("rate of unknown currency %s", code)
+		}
+
+		rate, ok := new(big.Rat).SetString(value.String())
+		if !ok || rate.Sign() <= 0 {
+			return fmt.Errorf("rate %s of %s is not a positive number", value, code)
+		}
+
+		t.rates[code] = rate
+	}
+
+	for code, rounding := range doc.Rounding {
+		if rounding.Increment == 0 {
+			rounding.Increment = 1
+		}
+
+		if rounding.Mode == "" {
+			rounding.Mode = RoundHalfUp
+		}
+
+		if rounding.Increment < 0 {
+			return fmt.Errorf("rounding increment of %s cannot be negative", code)
+		}
+
+		switch rounding.Mode {
+		case RoundHalfUp, RoundHalfEven, RoundUp, RoundDown:
+		default:
+			return fmt.Errorf("unknown rounding mode %q of %s", rounding.Mode, code)
+		}
+
+		t.rounding[code] = rounding
+	}
+
+	r.mu.Lock()
+	r.table = t
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Supports tells whether amounts can be converted to the currency
+func (r *Rates) Supports(currency string) bool {
+	if currency == r.base {
+		return true
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.table.rates[currency]
+	return ok
+}
+
+// Convert returns the amount in the base currency converted to the currency and rounded with
+// its rounding rule
+func (r *Rates) Convert(amount int64, currency string) (entity.Money, error) {
+	if currency == r.base {
+		return entity.Money{Amount: amount, Currency: currency}, nil
+	}
+
+	r.mu.RLock()
+	rate, ok := r.table.rates[currency]
+	rounding := r.table.rounding[currency]
+	r.mu.RUnlock()
+
+	if !ok {
+		return entity.Money{}, fmt.Errorf("%w %s", ErrUnsupportedCurrency, currency)
+	}
+
+	// minor units of the base currency to major units, through the rate, to minor units of the currency
+	converted := new(big.Rat).SetInt64(amount)
+	converted.Mul(converted, rate)
+	converted.Mul(converted, scale(entity.MinorUnits(currency)))
+	converted.Quo(converted, scale(entity.MinorUnits(r.base)))
+
+	return entity.Money{Amount: Round(converted, rounding), Currency: currency}, nil
+}
+
+// Round rounds an amount in minor units to a whole multiple of the rounding increment
+func Round(amount *big.Rat, rounding Rounding) int64 {
+	increment := rounding.Increment
+	if increment <= 0 {
+		increment = 1
+	}
+
+	steps := new(big.Rat).Quo(amount, new(big.Rat).SetInt64(increment))
+	quotient, remainder := new(big.Int).QuoRem(steps.Num(), steps.Denom(), new(big.Int))
+
+	// quotient is truncated toward zero, the remainder carries the sign of the amount
+	if remainder.Sign() != 0 {
+		away := false
+		twice := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2))
+		half := twice.Cmp(steps.Denom())
+
+		switch rounding.Mode {
+		case RoundUp:
+			away = amount.Sign() > 0
+		case RoundDown:
+			away = amount.Sign() < 0
+		case RoundHalfEven:
+			away = half > 0 || (half == 0 && quotient.Bit(0) == 1)
+		default:
+			away = half >= 0
+		}
+
+		if away {
+			quotient.Add(quotient, big.NewInt(int64(amount.Sign())))
+		}
+	}
+
+	return quotient.Int64() * increment
+}
+
+func scale(units int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(units)), nil))
+}
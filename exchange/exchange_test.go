@@ -0,0 +1,98 @@
+package exchange_test
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/exchange"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const ratesFile = `{
+	"base": "IDR",
+	"rates": {"USD": "0.0000625", "JPY": 0.0095, "SGD": "0.000085"},
+	"rounding": {"JPY": {"increment": 10}, "SGD": {"increment": 5, "mode": "up"}}
+}`
+
+func TestConvert(t *testing.T) {
+	rates := exchange.NewRates("IDR")
+	assert.NoError(t, rates.Read(strings.NewReader(ratesFile)))
+
+	testCases := []struct {
+		name     string
+		amount   int64
+		currency string
+		want     entity.Money
+		err      error
+	}{
+		{name: "base currency", amount: 125000, currency: "IDR", want: entity.Money{Amount: 125000, Currency: "IDR"}},
+		{name: "to cents", amount: 125000, currency: "USD", want: entity.Money{Amount: 781, Currency: "USD"}},
+		{name: "half rounds up", amount: 100, currency: "USD", want: entity.Money{Amount: 1, Currency: "USD"}},
+		{name: "to an increment", amount: 125000, currency: "JPY", want: entity.Money{Amount: 1190, Currency: "JPY"}},
+		{name: "always up", amount: 100000, currency: "SGD", want: entity.Money{Amount: 850, Currency: "SGD"}},
+		{name: "always up to the next increment", amount: 100001, currency: "SGD", want: entity.Money{Amount: 855, Currency: "SGD"}},
+		{name: "no rate", amount: 125000, currency: "EUR", err: exchange.ErrUnsupportedCurrency},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			money, err := rates.Convert(test.amount, test.currency)
+
+			assert.True(t, errors.Is(err, test.err))
+			assert.Equal(t, test.want, money)
+		})
+	}
+}
+
+func TestRound(t *testing.T) {
+	testCases := []struct {
+		name     string
+		amount   *big.Rat
+		rounding exchange.Rounding
+		want     int64
+	}{
+		{name: "half up", amount: big.NewRat(25, 10), rounding: exchange.Rounding{Mode: exchange.RoundHalfUp}, want: 3},
+		{name: "half up negative", amount: big.NewRat(-25, 10), rounding: exchange.Rounding{Mode: exchange.RoundHalfUp}, want: -3},
+		{name: "half even down", amount: big.NewRat(25, 10), rounding: exchange.Rounding{Mode: exchange.RoundHalfEven}, want: 2},
+		{name: "half even up", amount: big.NewRat(35, 10), rounding: exchange.Rounding{Mode: exchange.RoundHalfEven}, want: 4},
+		{name: "up", amount: big.NewRat(21, 10), rounding: exchange.Rounding{Mode: exchange.RoundUp}, want: 3},
+		{name: "down negative", amount: big.NewRat(-21, 10), rounding: exchange.Rounding{Mode: exchange.RoundDown}, want: -3},
+		{name: "increment", amount: big.NewRat(12345, 1), rounding: exchange.Rounding{Increment: 100, Mode: exchange.RoundHalfUp}, want: 12300},
+		{name: "exact", amount: big.NewRat(12300, 1), rounding: exchange.Rounding{Increment: 100, Mode: exchange.RoundUp}, want: 12300},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, exchange.Round(test.amount, test.rounding))
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	assert.NoError(t, os.WriteFile(path, []byte(ratesFile), 0o644))
+
+	rates := exchange.NewRates("IDR")
+	assert.False(t, rates.Supports("USD"))
+	assert.NoError(t, rates.Load(path))
+	assert.True(t, rates.Supports("USD"))
+
+	invalid := []string{
+		`{"base": "USD", "rates": {}}`,
+		`{"base": "IDR", "rates": {"XXX": "1"}}`,
+		`{"base": "IDR", "rates": {"USD": "-1"}}`,
+		`{"base": "IDR", "rates": {"USD": "0.0000625"}, "rounding": {"USD": {"mode": "sideways"}}}`,
+	}
+
+	for _, doc := range invalid {
+		assert.Error(t, rates.Read(strings.NewReader(doc)))
+	}
+
+	// a bad file leaves the rates loaded before in place
+	assert.True(t, rates.Supports("JPY"))
+}
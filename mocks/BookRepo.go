@@ -14,13 +14,13 @@ type BookRepository struct {
 	mock.Mock
 }
 
-// CreateBook provides a mock function with given fields: ctx, book
-func (_m *BookRepository) CreateBook(ctx context.Context, book *entity.Book) error {
-	ret := _m.Called(ctx, book)
+// CreateBook provides a mock function with given fields: ctx, book, tags, receipt
+func (_m *BookRepository) CreateBook(ctx context.Context, book *entity.Book, tags []entity.Tag, receipt *entity.StockMovement) error {
+	ret := _m.Called(ctx, book, tags, receipt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.Book) error); ok {
-		r0 = rf(ctx, book)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Book, []entity.Tag, *entity.StockMovement) error); ok {
+		r0 = rf(ctx, book, tags, receipt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -63,13 +63,34 @@ func (_m *BookRepository) GetBook(ctx context.Context, id int64) (entity.Book, e
 	return r0, r1
 }
 
-// GetBooks provides a mock function with given fields: ctx
-func (_m *BookRepository) GetBooks(ctx context.Context) ([]entity.Book, error) {
-	ret := _m.Called(ctx)
+// GetBookMovementCount provides a mock function with given fields: ctx, id
+func (_m *BookRepository) GetBookMovementCount(ctx context.Context, id int64) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBooks provides a mock function with given fields: ctx, filter
+func (_m *BookRepository) GetBooks(ctx context.Context, filter entity.BookFilter) ([]entity.Book, error) {
+	ret := _m.Called(ctx, filter)
 
 	var r0 []entity.Book
-	if rf, ok := ret.Get(0).(func(context.Context) []entity.Book); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.BookFilter) []entity.Book); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Book)
@@ -77,8 +98,8 @@ func (_m *BookRepository) GetBooks(ctx context.Context) ([]entity.Book, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.BookFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -86,13 +107,13 @@ func (_m *BookRepository) GetBooks(ctx context.Context) ([]entity.Book, error) {
 	return r0, r1
 }
 
-// UpdateBook provides a mock function with given fields: ctx, id, book
-func (_m *BookRepository) UpdateBook(ctx context.Context, id int64, book *entity.Book) error {
-	ret := _m.Called(ctx, id, book)
+// UpdateBook provides a mock function with given fields: ctx, id, book, tags, price, adjustment
+func (_m *BookRepository) UpdateBook(ctx context.Context, id int64, book *entity.Book, tags []entity.Tag, price *entity.BookPrice, adjustment *entity.StockMovement) error {
+	ret := _m.Called(ctx, id, book, tags, price, adjustment)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, *entity.Book) error); ok {
-		r0 = rf(ctx, id, book)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *entity.Book, []entity.Tag, *entity.BookPrice, *entity.StockMovement) error); ok {
+		r0 = rf(ctx, id, book, tags, price, adjustment)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -63,13 +63,13 @@ func (_m *BookUsecase) GetBook(ctx context.Context, id int64) (entity.Book, erro
 	return r0, r1
 }
 
-// GetBooks provides a mock function with given fields: ctx
-func (_m *BookUsecase) GetBooks(ctx context.Context) ([]entity.Book, error) {
-	ret := _m.Called(ctx)
+// GetBooks provides a mock function with given fields: ctx, filter
+func (_m *BookUsecase) GetBooks(ctx context.Context, filter entity.BookFilter) ([]entity.Book, error) {
+	ret := _m.Called(ctx, filter)
 
 	var r0 []entity.Book
-	if rf, ok := ret.Get(0).(func(context.Context) []entity.Book); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.BookFilter) []entity.Book); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Book)
@@ -77,8 +77,8 @@ func (_m *BookUsecase) GetBooks(ctx context.Context) ([]entity.Book, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.BookFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
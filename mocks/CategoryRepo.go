@@ -42,6 +42,29 @@ func (_m *CategoryRepository) DeleteCategory(ctx context.Context, id int64) erro
 	return r0
 }
 
+// GetBookCategories provides a mock function with given fields: ctx, bookIDs
+func (_m *CategoryRepository) GetBookCategories(ctx context.Context, bookIDs []int64) (map[int64][]entity.BookCategory, error) {
+	ret := _m.Called(ctx, bookIDs)
+
+	var r0 map[int64][]entity.BookCategory
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) map[int64][]entity.BookCategory); ok {
+		r0 = rf(ctx, bookIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64][]entity.BookCategory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, bookIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCategories provides a mock function with given fields: ctx
 func (_m *CategoryRepository) GetCategories(ctx context.Context) ([]entity.Category, error) {
 	ret := _m.Called(ctx)
@@ -86,6 +109,43 @@ func (_m *CategoryRepository) GetCategory(ctx context.Context, id int64) (entity
 	return r0, r1
 }
 
+// GetCategoryPath provides a mock function with given fields: ctx, id
+func (_m *CategoryRepository) GetCategoryPath(ctx context.Context, id int64) ([]entity.Category, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []entity.Category
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []entity.Category); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Category)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MoveCategory provides a mock function with given fields: ctx, id, parentID
+func (_m *CategoryRepository) MoveCategory(ctx context.Context, id int64, parentID *int64) error {
+	ret := _m.Called(ctx, id, parentID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *int64) error); ok {
+		r0 = rf(ctx, id, parentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateCategory provides a mock function with given fields: ctx, id, category
 func (_m *CategoryRepository) UpdateCategory(ctx context.Context, id int64, category *entity.Category) error {
 	ret := _m.Called(ctx, id, category)
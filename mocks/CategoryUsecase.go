@@ -86,6 +86,66 @@ func (_m *CategoryUsecase) GetCategory(ctx context.Context, id int64) (entity.Ca
 	return r0, r1
 }
 
+// GetCategoryBreadcrumbs provides a mock function with given fields: ctx, id
+func (_m *CategoryUsecase) GetCategoryBreadcrumbs(ctx context.Context, id int64) ([]entity.Category, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []entity.Category
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []entity.Category); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Category)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCategoryTree provides a mock function with given fields: ctx
+func (_m *CategoryUsecase) GetCategoryTree(ctx context.Context) ([]entity.Category, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entity.Category
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.Category); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Category)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MoveCategory provides a mock function with given fields: ctx, id, parentID
+func (_m *CategoryUsecase) MoveCategory(ctx context.Context, id int64, parentID *int64) error {
+	ret := _m.Called(ctx, id, parentID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *int64) error); ok {
+		r0 = rf(ctx, id, parentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateCategory provides a mock function with given fields: ctx, id, category
 func (_m *CategoryUsecase) UpdateCategory(ctx context.Context, id int64, category *entity.Category) error {
 	ret := _m.Called(ctx, id, category)
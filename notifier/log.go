@@ -0,0 +1,41 @@
+package notifier
+
+import (
+	"context"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/logger"
+)
+
+type logNotifier struct{}
+
+// NewLog writes the alerts to the application log
+func NewLog() Notifier {
+	return logNotifier{}
+}
+
+func (logNotifier) Notify(ctx context.Context, books []entity.LowStock) error {
+	for _, book := range books {
+		logger.Info("low stock", logger.Fields{
+			"book_id":          book.BookID,
+			"title":            book.Title,
+			"available":        book.Available,
+			"threshold":        book.Threshold,
+			"reorder_quantity": book.ReorderQuantity,
+		})
+	}
+
+	return nil
+}
+
+func (logNotifier) NotifyBackInStock(ctx context.Context, customerEmail string, books []entity.BackInStock) error {
+	for _, book := range books {
+		logger.Info("back in stock", logger.Fields{
+			"customer_email": customerEmail,
+			"book_id":        book.BookID,
+			"title":          book.Title,
+			"available":      book.Available,
+		})
+	}
+
+	return nil
+}
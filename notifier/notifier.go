@@ -0,0 +1,71 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"winartodev/book-store-be/entity"
+)
+
+// Notifier delivers low-stock alerts to the people restocking the books, and back-in-stock
+// notices to the customer who asked for them
+type Notifier interface {
+	Notify(ctx context.Context, books []entity.LowStock) error
+	NotifyBackInStock(ctx context.Context, customerEmail string, books []entity.BackInStock) error
+}
+
+type multi []Notifier
+
+// NewMulti sends the alerts through every notifier. All of them are tried even when one
+// fails, the failures are reported together.
+func NewMulti(notifiers ...Notifier) Notifier {
+	return multi(notifiers)
+}
+
+func (m multi) Notify(ctx context.Context, books []entity.LowStock) error {
+	return m.each(func(n Notifier) error { return n.Notify(ctx, books) })
+}
+
+func (m multi) NotifyBackInStock(ctx context.Context, customerEmail string, books []entity.BackInStock) error {
+	return m.each(func(n Notifier) error { return n.NotifyBackInStock(ctx, customerEmail, books) })
+}
+
+func (m multi) each(notify func(n Notifier) error) error {
+	var failures []string
+	for _, n := range m {
+		err := notify(n)
+		if err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d notifiers failed: %s", len(failures), len(m), strings.Join(failures, "; "))
+	}
+
+	return nil
+}
+
+// summary describes the alerts in plain text, one book per line
+func summary(books []entity.LowStock) string {
+	var b strings.Builder
+	for _, book := range books {
+		fmt.Fprintf(&b, "%s (book ID %d): %d available, reorder point %d", book.Title, book.BookID, book.Available, book.Threshold)
+		if book.ReorderQuantity > 0 {
+			fmt.Fprintf(&b, ", reorder %d", book.ReorderQuantity)
+		}
+		b.WriteString("\r\n")
+	}
+
+	return b.String()
+}
+
+// backInStockSummary describes the books in plain text, one book per line
+func backInStockSummary(books []entity.BackInStock) string {
+	var b strings.Builder
+	for _, book := range books {
+		fmt.Fprintf(&b, "%s (book ID %d): %d available\r\n", book.Title, book.BookID, book.Available)
+	}
+
+	return b.String()
+}
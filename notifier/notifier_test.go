@@ -0,0 +1,87 @@
+package notifier_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/logger"
+	"winartodev/book-store-be/notifier"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	logger.Init()
+}
+
+var lowStock = []entity.LowStock{
+	{BookID: 1, Title: "Dune", Stock: 3, Reserved: 1, Available: 2, Threshold: 5, ReorderQuantity: 20},
+}
+
+var backInStock = []entity.BackInStock{
+	{SubscriptionID: 7, CustomerEmail: "reader@mail.com", BookID: 1, Title: "Dune", Available: 4},
+}
+
+type recorder struct {
+	calls int
+	err   error
+}
+
+func (r *recorder) Notify(ctx context.Context, books []entity.LowStock) error {
+	r.calls++
+	return r.err
+}
+
+func (r *recorder) NotifyBackInStock(ctx context.Context, customerEmail string, books []entity.BackInStock) error {
+	r.calls++
+	return r.err
+}
+
+func TestMulti(t *testing.T) {
+	testCases := []struct {
+		name    string
+		errs    []error
+		isError bool
+	}{
+		{name: "all delivered", errs: []error{nil, nil}, isError: false},
+		{name: "one failed", errs: []error{errors.New("connection refused"), nil}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			var recorders []*recorder
+			var notifiers []notifier.Notifier
+			for _, err := range test.errs {
+				r := &recorder{err: err}
+				recorders = append(recorders, r)
+				notifiers = append(notifiers, r)
+			}
+
+			err := notifier.NewMulti(notifiers...).Notify(context.Background(), lowStock)
+
+			assert.Equal(t, test.isError, err != nil)
+			for _, r := range recorders {
+				assert.Equal(t, 1, r.calls)
+			}
+		})
+	}
+}
+
+func TestMultiBackInStock(t *testing.T) {
+	failing, delivered := &recorder{err: errors.New("connection refused")}, &recorder{}
+
+	err := notifier.NewMulti(failing, delivered).NotifyBackInStock(context.Background(), "reader@mail.com", backInStock)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, failing.calls)
+	assert.Equal(t, 1, delivered.calls)
+}
+
+func TestLog(t *testing.T) {
+	err := notifier.NewLog().Notify(context.Background(), lowStock)
+	assert.NoError(t, err)
+
+	err = notifier.NewLog().NotifyBackInStock(context.Background(), "reader@mail.com", backInStock)
+	assert.NoError(t, err)
+}
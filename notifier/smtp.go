@@ -0,0 +1,156 @@
+package notifier
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+	"winartodev/book-store-be/entity"
+)
+
+// SMTPConfig holds the mail server and addresses the alerts are mailed with
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// headerValue keeps book titles from breaking out of a mail header
+var headerValue = strings.NewReplacer("\r", " ", "\n", " ")
+
+type smtpNotifier struct {
+	cfg SMTPConfig
+	now func() time.Time
+}
+
+// NewSMTP mails the alerts to the configured recipients and back-in-stock notices to the
+// customer. Authentication is only used when a username is configured, so a local stand-in
+// such as MailHog works without credentials.
+func NewSMTP(cfg SMTPConfig) Notifier {
+	return &smtpNotifier{cfg: cfg, now: time.Now}
+}
+
+func (s *smtpNotifier) Notify(ctx context.Context, books []entity.LowStock) error {
+	if len(s.cfg.To) == 0 {
+		return fmt.Errorf("smtp notifier has no recipients")
+	}
+
+	return s.send(ctx, s.cfg.To, s.message(books))
+}
+
+func (s *smtpNotifier) NotifyBackInStock(ctx context.Context, customerEmail string, books []entity.BackInStock) error {
+	return s.send(ctx, []string{customerEmail}, s.backInStockMessage(customerEmail, books))
+}
+
+// send delivers msg to the recipients like smtp.SendMail but gives up when ctx is done
+func (s *smtpNotifier) send(ctx context.Context, recipients []string, msg []byte) error {
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		err = c.StartTLS(&tls.Config{ServerName: s.cfg.Host})
+		if err != nil {
+			return err
+		}
+	}
+
+	if auth != nil {
+		err = c.Auth(auth)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.Mail(s.cfg.From)
+	if err != nil {
+		return err
+	}
+
+	for _, to := range recipients {
+		err = c.Rcpt(to)
+		if err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(msg)
+	if err != nil {
+		return err
+	}
+
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+func (s *smtpNotifier) message(books []entity.LowStock) []byte {
+	subject := fmt.Sprintf("Low stock: %d books need restocking", len(books))
+	if len(books) == 1 {
+		subject = fmt.Sprintf("Low stock: %s", books[0].Title)
+	}
+
+	var b strings.Builder
+	s.header(&b, s.cfg.To, subject)
+	b.WriteString("The following books are at or below their reorder point:\r\n\r\n")
+	b.WriteString(summary(books))
+
+	return []byte(b.String())
+}
+
+func (s *smtpNotifier) backInStockMessage(customerEmail string, books []entity.BackInStock) []byte {
+	subject := fmt.Sprintf("Back in stock: %d books you asked about", len(books))
+	if len(books) == 1 {
+		subject = fmt.Sprintf("Back in stock: %s", books[0].Title)
+	}
+
+	var b strings.Builder
+	s.header(&b, []string{customerEmail}, subject)
+	b.WriteString("You asked us to tell you when these books can be ordered again:\r\n\r\n")
+	b.WriteString(backInStockSummary(books))
+
+	return []byte(b.String())
+}
+
+func (s *smtpNotifier) header(b *strings.Builder, to []string, subject string) {
+	fmt.Fprintf(b, "From: %s\r\n", s.cfg.From)
+	fmt.Fprintf(b, "To: %s\r\n", headerValue.Replace(strings.Join(to, ", ")))
+	fmt.Fprintf(b, "Subject: %s\r\n", headerValue.Replace(subject))
+	fmt.Fprintf(b, "Date: %s\r\n", s.now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,134 @@
+package notifier_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/notifier"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSMTP is a minimal stand-in for a mail server that accepts a single message
+type fakeSMTP struct {
+	listener   net.Listener
+	from       string
+	recipients []string
+	data       string
+	done       chan struct{}
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &fakeSMTP{listener: listener, done: make(chan struct{})}
+	go f.serve()
+	return f
+}
+
+func (f *fakeSMTP) serve() {
+	defer close(f.done)
+
+	conn, err := f.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		command := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			f.from = strings.Trim(strings.TrimPrefix(command, "MAIL FROM:"), "<>")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			f.recipients = append(f.recipients, strings.Trim(strings.TrimPrefix(command, "RCPT TO:"), "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil || line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			f.data = data.String()
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTP(t *testing.T) {
+	server := newFakeSMTP(t)
+	defer server.listener.Close()
+
+	addr := server.listener.Addr().(*net.TCPAddr)
+	smtp := notifier.NewSMTP(notifier.SMTPConfig{
+		Host: "127.0.0.1",
+		Port: addr.Port,
+		From: "bookstore@example.com",
+		To:   []string{"purchasing@example.com", "owner@example.com"},
+	})
+
+	books := []entity.LowStock{{BookID: 1, Title: "Dune\r\nBcc: everyone@example.com", Available: 2, Threshold: 5, ReorderQuantity: 20}}
+	err := smtp.Notify(context.Background(), books)
+	<-server.done
+
+	assert.NoError(t, err)
+	assert.Equal(t, "bookstore@example.com", server.from)
+	assert.Equal(t, []string{"purchasing@example.com", "owner@example.com"}, server.recipients)
+	assert.Contains(t, server.data, "Subject: Low stock: Dune  Bcc: everyone@example.com\r\n")
+	assert.Contains(t, server.data, "book ID 1): 2 available, reorder point 5, reorder 20")
+}
+
+func TestSMTPBackInStock(t *testing.T) {
+	server := newFakeSMTP(t)
+	defer server.listener.Close()
+
+	addr := server.listener.Addr().(*net.TCPAddr)
+	smtp := notifier.NewSMTP(notifier.SMTPConfig{
+		Host: "127.0.0.1",
+		Port: addr.Port,
+		From: "bookstore@example.com",
+		To:   []string{"purchasing@example.com"},
+	})
+
+	err := smtp.NotifyBackInStock(context.Background(), "reader@mail.com", backInStock)
+	<-server.done
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"reader@mail.com"}, server.recipients)
+	assert.Contains(t, server.data, "To: reader@mail.com\r\n")
+	assert.Contains(t, server.data, "Subject: Back in stock: Dune\r\n")
+	assert.Contains(t, server.data, "Dune (book ID 1): 4 available")
+}
+
+func TestSMTPWithoutRecipients(t *testing.T) {
+	err := notifier.NewSMTP(notifier.SMTPConfig{Host: "127.0.0.1", Port: 25}).Notify(context.Background(), lowStock)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"winartodev/book-store-be/entity"
+)
+
+// events named by the webhook payload
+const (
+	EventLowStock    = "low_stock"
+	EventBackInStock = "back_in_stock"
+)
+
+// WebhookPayload is the JSON body posted to the webhook
+type WebhookPayload struct {
+	Event string            `json:"event"`
+	Books []entity.LowStock `json:"books"`
+}
+
+// BackInStockPayload is the JSON body posted to the webhook when books a customer waited for
+// have stock again
+type BackInStockPayload struct {
+	Event         string               `json:"event"`
+	CustomerEmail string               `json:"customer_email"`
+	Books         []entity.BackInStock `json:"books"`
+}
+
+type webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook posts the alerts as JSON to url. Any response other than 2xx is a failure.
+func NewWebhook(url string, client *http.Client) Notifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &webhook{url: url, client: client}
+}
+
+func (w *webhook) Notify(ctx context.Context, books []entity.LowStock) error {
+	return w.post(ctx, WebhookPayload{Event: EventLowStock, Books: books})
+}
+
+func (w *webhook) NotifyBackInStock(ctx context.Context, customerEmail string, books []entity.BackInStock) error {
+	return w.post(ctx, BackInStockPayload{Event: EventBackInStock, CustomerEmail: customerEmail, Books: books})
+}
+
+func (w *webhook) post(ctx context.Context, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("webhook responded %s: %s", res.Status, bytes.TrimSpace(message))
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package notifier_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"winartodev/book-store-be/notifier"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook(t *testing.T) {
+	testCases := []struct {
+		name    string
+		status  int
+		isError bool
+	}{
+		{name: "success", status: http.StatusNoContent, isError: false},
+		{name: "rejected", status: http.StatusInternalServerError, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			var payload notifier.WebhookPayload
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				json.NewDecoder(r.Body).Decode(&payload)
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+
+			err := notifier.NewWebhook(server.URL, server.Client()).Notify(context.Background(), lowStock)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, notifier.EventLowStock, payload.Event)
+			assert.Equal(t, lowStock, payload.Books)
+		})
+	}
+}
+
+func TestWebhookBackInStock(t *testing.T) {
+	var payload notifier.BackInStockPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := notifier.NewWebhook(server.URL, server.Client()).NotifyBackInStock(context.Background(), "reader@mail.com", backInStock)
+
+	assert.NoError(t, err)
+	assert.Equal(t, notifier.EventBackInStock, payload.Event)
+	assert.Equal(t, "reader@mail.com", payload.CustomerEmail)
+	assert.Equal(t, backInStock, payload.Books)
+}
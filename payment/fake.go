@@ -0,0 +1,193 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+	"winartodev/book-store-be/entity"
+)
+
+// Payment methods the fake gateway treats specially, any other is authorized at once
+const (
+	FakeDeclined = "tok_decline"
+	FakePending  = "tok_pending"
+)
+
+type fakeAuthorization struct {
+	status   string
+	amount   int
+	captured int
+	refunded int
+}
+
+// Fake is a gateway that keeps its authorizations in memory, for development and tests. It
+// declines the FakeDeclined payment method and leaves FakePending ones pending until Resolve.
+// Its webhooks are signed with the secret like those of a real gateway.
+type Fake struct {
+	secret string
+	now    func() time.Time
+
+	mu             sync.Mutex
+	next           int
+	authorizations map[string]*fakeAuthorization
+	keys           map[string]Authorization
+	refunds        map[string]bool
+}
+
+// NewFake returns a fake gateway signing its webhooks with secret
+func NewFake(secret string) *Fake {
+	return &Fake{
+		secret:         secret,
+		now:            time.Now,
+		authorizations: make(map[string]*fakeAuthorization),
+		keys:           make(map[string]Authorization),
+		refunds:        make(map[string]bool),
+	}
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) Authorize(ctx context.Context, req AuthorizeRequest) (Authorization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if auth, ok := f.keys[req.IdempotencyKey]; ok && req.IdempotencyKey != "" {
+		return auth, nil
+	}
+
+	if req.Amount <= 0 {
+		return Authorization{}, errors.New("amount must be greater than zero")
+	}
+
+	f.next++
+	auth := Authorization{Reference: fmt.Sprintf("fake_%d", f.next), Status: entity.PaymentAuthorized}
+	switch req.PaymentMethod {
+	case FakeDeclined:
+		auth.Status, auth.FailureReason = entity.PaymentFailed, "card declined"
+	case FakePending:
+		auth.Status = entity.PaymentPending
+	}
+
+	f.authorizations[auth.Reference] = &fakeAuthorization{status: auth.Status, amount: req.Amount}
+	f.keys[req.IdempotencyKey] = auth
+	return auth, nil
+}
+
+func (f *Fake) Capture(ctx context.Context, reference string, amount int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	a, err := f.authorization(reference, entity.PaymentAuthorized)
+	if err != nil {
+		return err
+	}
+
+	if amount <= 0 || amount > a.amount {
+		return fmt.Errorf("cannot capture %d of an authorization of %d", amount, a.amount)
+	}
+
+	a.status, a.captured = entity.PaymentCaptured, amount
+	return nil
+}
+
+func (f *Fake) Void(ctx context.Context, reference string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	a, err := f.authorization(reference, entity.PaymentAuthorized)
+	if err != nil {
+		return err
+	}
+
+	a.status = entity.PaymentVoided
+	return nil
+}
+
+func (f *Fake) Refund(ctx context.Context, reference string, amount int, idempotencyKey string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.refunds[idempotencyKey] && idempotencyKey != "" {
+		return nil
+	}
+
+	a, err := f.authorization(reference, entity.PaymentCaptured)
+	if err != nil {
+		return err
+	}
+
+	if amount <= 0 || amount > a.captured-a.refunded {
+		return fmt.Errorf("cannot refund %d of the %d left", amount, a.captured-a.refunded)
+	}
+
+	a.refunded += amount
+	f.refunds[idempotencyKey] = true
+	return nil
+}
+
+func (f *Fake) ParseWebhook(payload []byte, header http.Header) (Event, error) {
+	err := VerifySignature(f.secret, payload, header.Get(SignatureHeader), f.now())
+	if err != nil {
+		return Event{}, err
+	}
+
+	var event Event
+	err = json.Unmarshal(payload, &event)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return event, nil
+}
+
+// Resolve decides on a pending authorization like the provider would, and returns the signed
+// webhook it sends about it
+func (f *Fake) Resolve(reference string, authorized bool) ([]byte, http.Header, error) {
+	f.mu.Lock()
+	a, err := f.authorization(reference, entity.PaymentPending)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, nil, err
+	}
+
+	event := Event{ID: fmt.Sprintf("evt_%s", reference), Reference: reference, Status: entity.PaymentAuthorized}
+	if !authorized {
+		event.Status, event.FailureReason = entity.PaymentFailed, "authentication failed"
+	}
+	a.status = event.Status
+	f.mu.Unlock()
+
+	return f.Webhook(event)
+}
+
+// Webhook returns the payload and headers of a webhook notifying the event
+func (f *Fake) Webhook(event Event) ([]byte, http.Header, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	header.Set(SignatureHeader, Sign(f.secret, payload, f.now()))
+	return payload, header, nil
+}
+
+func (f *Fake) authorization(reference string, status string) (*fakeAuthorization, error) {
+	a, ok := f.authorizations[reference]
+	if !ok {
+		return nil, fmt.Errorf("no authorization %s", reference)
+	}
+
+	if a.status != status {
+		return nil, fmt.Errorf("authorization %s is %s, not %s", reference, a.status, status)
+	}
+
+	return a, nil
+}
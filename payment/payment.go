@@ -0,0 +1,57 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrInvalidSignature is returned for webhooks that were not signed by the gateway
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// PaymentGateway charges customers through a payment provider. Amounts are in minor units of
+// the currency. Authorizations are identified by the reference the gateway gives them.
+type PaymentGateway interface {
+	// Name identifies the gateway the payments were made through
+	Name() string
+	// Authorize holds the amount on the customer's payment method. Declines are reported in
+	// the authorization, an error means the outcome is unknown and the request can be retried
+	// with the same idempotency key.
+	Authorize(ctx context.Context, req AuthorizeRequest) (Authorization, error)
+	// Capture charges an authorized amount, capturing less than authorized releases the rest
+	Capture(ctx context.Context, reference string, amount int) error
+	// Void releases an authorization that was not captured
+	Void(ctx context.Context, reference string) error
+	// Refund pays back part or all of a captured amount
+	Refund(ctx context.Context, reference string, amount int, idempotencyKey string) error
+	// ParseWebhook verifies the signature of a webhook the gateway sent and returns its event
+	ParseWebhook(payload []byte, header http.Header) (Event, error)
+}
+
+// AuthorizeRequest asks for an amount to be held. Gateways return the first authorization
+// again when a request is retried with the same IdempotencyKey.
+type AuthorizeRequest struct {
+	IdempotencyKey string
+	Amount         int
+	Currency       string
+	// PaymentMethod is the token the gateway's checkout gave for the customer's card
+	PaymentMethod string
+	Description   string
+}
+
+// Authorization is the outcome of an authorize request. Its Status is entity.PaymentAuthorized,
+// entity.PaymentFailed when declined or entity.PaymentPending when the gateway sends the
+// outcome later through a webhook.
+type Authorization struct {
+	Reference     string
+	Status        string
+	FailureReason string
+}
+
+// Event is the change of a payment's status notified through a webhook
+type Event struct {
+	ID            string `json:"id"`
+	Reference     string `json:"reference"`
+	Status        string `json:"status"`
+	FailureReason string `json:"failure_reason,omitempty"`
+}
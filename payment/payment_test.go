@@ -0,0 +1,112 @@
+package payment_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/payment"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySignature(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","reference":"fake_1","status":"authorized"}`)
+	now := time.Now()
+	// gateways sign with the old and the new secret while it is rotated
+	rotated := payment.Sign("old", payload, now) + ",v1=" + strings.SplitN(payment.Sign("whsec", payload, now), "v1=", 2)[1]
+
+	testCases := []struct {
+		name    string
+		secret  string
+		payload []byte
+		header  string
+		isError bool
+	}{
+		{name: "valid", secret: "whsec", payload: payload, header: payment.Sign("whsec", payload, now.Add(-time.Minute)), isError: false},
+		{name: "one of several signatures", secret: "whsec", payload: payload, header: rotated, isError: false},
+		{name: "other secret", secret: "whsec", payload: payload, header: payment.Sign("other", payload, now), isError: true},
+		{name: "tampered payload", secret: "whsec", payload: []byte(`{"id":"evt_1","reference":"fake_2","status":"authorized"}`), header: payment.Sign("whsec", payload, now), isError: true},
+		{name: "replayed", secret: "whsec", payload: payload, header: payment.Sign("whsec", payload, now.Add(-time.Hour)), isError: true},
+		{name: "malformed header", secret: "whsec", payload: payload, header: "sha256=abc", isError: true},
+		{name: "no secret configured", secret: "", payload: payload, header: payment.Sign("", payload, now), isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := payment.VerifySignature(test.secret, test.payload, test.header, now)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				assert.True(t, errors.Is(err, payment.ErrInvalidSignature))
+			}
+		})
+	}
+}
+
+func TestFakeAuthorize(t *testing.T) {
+	testCases := []struct {
+		name          string
+		paymentMethod string
+		wantStatus    string
+	}{
+		{name: "authorized", paymentMethod: "tok_visa", wantStatus: entity.PaymentAuthorized},
+		{name: "declined", paymentMethod: payment.FakeDeclined, wantStatus: entity.PaymentFailed},
+		{name: "pending", paymentMethod: payment.FakePending, wantStatus: entity.PaymentPending},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			gateway := payment.NewFake("whsec")
+			req := payment.AuthorizeRequest{IdempotencyKey: "key-1", Amount: 150000, Currency: "IDR", PaymentMethod: test.paymentMethod}
+
+			auth, err := gateway.Authorize(context.Background(), req)
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantStatus, auth.Status)
+
+			retried, err := gateway.Authorize(context.Background(), req)
+			assert.NoError(t, err)
+			assert.Equal(t, auth, retried)
+		})
+	}
+}
+
+func TestFakeCaptureAndRefund(t *testing.T) {
+	ctx := context.Background()
+	gateway := payment.NewFake("whsec")
+
+	auth, err := gateway.Authorize(ctx, payment.AuthorizeRequest{IdempotencyKey: "key-1", Amount: 150000, Currency: "IDR", PaymentMethod: "tok_visa"})
+	assert.NoError(t, err)
+
+	assert.Error(t, gateway.Capture(ctx, auth.Reference, 200000))
+	assert.NoError(t, gateway.Capture(ctx, auth.Reference, 100000))
+	assert.Error(t, gateway.Void(ctx, auth.Reference))
+
+	assert.NoError(t, gateway.Refund(ctx, auth.Reference, 60000, "refund-1"))
+	assert.NoError(t, gateway.Refund(ctx, auth.Reference, 60000, "refund-1"))
+	assert.Error(t, gateway.Refund(ctx, auth.Reference, 60000, "refund-2"))
+	assert.NoError(t, gateway.Refund(ctx, auth.Reference, 40000, "refund-2"))
+}
+
+func TestFakeResolve(t *testing.T) {
+	gateway := payment.NewFake("whsec")
+
+	auth, err := gateway.Authorize(context.Background(), payment.AuthorizeRequest{IdempotencyKey: "key-1", Amount: 150000, Currency: "IDR", PaymentMethod: payment.FakePending})
+	assert.NoError(t, err)
+
+	payload, header, err := gateway.Resolve(auth.Reference, true)
+	assert.NoError(t, err)
+
+	event, err := gateway.ParseWebhook(payload, header)
+	assert.NoError(t, err)
+	assert.Equal(t, payment.Event{ID: "evt_" + auth.Reference, Reference: auth.Reference, Status: entity.PaymentAuthorized}, event)
+
+	_, _, err = gateway.Resolve(auth.Reference, false)
+	assert.Error(t, err)
+
+	_, err = gateway.ParseWebhook(payload, http.Header{})
+	assert.True(t, errors.Is(err, payment.ErrInvalidSignature))
+}
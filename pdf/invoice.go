@@ -0,0 +1,167 @@
+package pdf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"winartodev/book-store-be/entity"
+)
+
+// Seller is the business invoices are issued by
+type Seller struct {
+	Name    string
+	Address []string
+	TaxID   string
+}
+
+const (
+	marginLeft   = 50
+	marginRight  = PageWidth - 50
+	marginBottom = PageHeight - 70
+	rowHeight    = 16
+)
+
+// invoiceColumns are where the cells of the lines table end, the description starts at the margin
+var invoiceColumns = []struct {
+	title string
+	right float64
+}{
+	{"Qty", 300},
+	{"Unit price", 370},
+	{"Discount", 430},
+	{"Tax", 490},
+	{"Amount", marginRight},
+}
+
+// RenderInvoice lays an invoice or a credit note out as a PDF: the seller and the buyer, a
+// table of its lines that continues on as many pages as it needs, and the totals with the
+// taxes charged
+func RenderInvoice(invoice entity.Invoice, seller Seller) []byte {
+	doc := New()
+	page := doc.AddPage()
+
+	title := "INVOICE"
+	if invoice.Kind == entity.InvoiceKindCreditNote {
+		title = "CREDIT NOTE"
+	}
+	page.Text(marginLeft, 80, HelveticaBold, 20, title)
+
+	y := 70.0
+	page.TextRight(marginRight, y, HelveticaBold, 11, seller.Name)
+	for _, line := range seller.Address {
+		y += 13
+		page.TextRight(marginRight, y, Helvetica, 9, line)
+	}
+	if seller.TaxID != "" {
+		y += 13
+		page.TextRight(marginRight, y, Helvetica, 9, "Tax ID: "+seller.TaxID)
+	}
+
+	y = 130
+	details := [][2]string{
+		{"Number", invoice.Number},
+		{"Issued", invoice.IssuedAt.Format("2006-01-02")},
+		{"Order", fmt.Sprintf("#%d", invoice.OrderID)},
+		{"Currency", invoice.Currency},
+	}
+	for _, detail := range details {
+		page.Text(marginLeft, y, HelveticaBold, 9, detail[0])
+		page.Text(marginLeft+60, y, Helvetica, 9, detail[1])
+		y += 13
+	}
+
+	y = 130
+	page.Text(330, y, HelveticaBold, 9, "Bill to")
+	page.Text(330, y+13, Helvetica, 9, invoice.CustomerEmail)
+	if destination := strings.TrimSpace(invoice.ShippingCountry + " " + invoice.ShippingRegion); destination != "" {
+		page.Text(330, y+26, Helvetica, 9, destination)
+	}
+
+	y = invoiceHeader(page, 210)
+	for _, line := range invoice.Lines {
+		if y > marginBottom {
+			page = doc.AddPage()
+			y = invoiceHeader(page, 70)
+		}
+
+		description := line.Description
+		if line.BookID != 0 {
+			description = fmt.Sprintf("%s (book ID %d)", description, line.BookID)
+		}
+
+		page.Text(marginLeft, y, Helvetica, 9, fit(description, Helvetica, 9, invoiceColumns[0].right-40-marginLeft))
+		cells := []string{
+			strconv.Itoa(line.Quantity),
+			amount(line.UnitPrice, invoice.Currency),
+			amount(line.Discount, invoice.Currency),
+			amount(line.Tax, invoice.Currency),
+			amount(line.Amount, invoice.Currency),
+		}
+		for i, cell := range cells {
+			page.TextRight(invoiceColumns[i].right, y, Helvetica, 9, cell)
+		}
+		y += rowHeight
+	}
+
+	taxLabel := "Tax"
+	if invoice.PricesIncludeTax {
+		taxLabel = "Tax included"
+	}
+
+	totals := [][2]string{
+		{"Subtotal", amount(invoice.Subtotal, invoice.Currency)},
+		{"Shipping", amount(invoice.Shipping, invoice.Currency)},
+	}
+	for _, tax := range invoice.TaxLines {
+		label := fmt.Sprintf("%s %s%% of %s", tax.Name, strconv.FormatFloat(float64(tax.Rate)/100, 'f', -1, 64), amount(tax.Taxable, invoice.Currency))
+		totals = append(totals, [2]string{label, amount(tax.Amount, invoice.Currency)})
+	}
+	totals = append(totals, [2]string{taxLabel, amount(invoice.Tax, invoice.Currency)})
+
+	if y+float64(len(totals)+2)*rowHeight > marginBottom {
+		page = doc.AddPage()
+		y = 70
+	}
+
+	page.Line(330, y-4, marginRight, y-4)
+	y += 8
+	for _, total := range totals {
+		page.Text(330, y, Helvetica, 9, total[0])
+		page.TextRight(marginRight, y, Helvetica, 9, total[1])
+		y += rowHeight
+	}
+
+	page.Text(330, y, HelveticaBold, 10, "Total")
+	page.TextRight(marginRight, y, HelveticaBold, 10, entity.Money{Amount: int64(invoice.Total), Currency: invoice.Currency}.String())
+
+	return doc.Bytes()
+}
+
+// invoiceHeader writes the titles of the lines table at y and returns where its first row goes
+func invoiceHeader(page *Page, y float64) float64 {
+	page.Text(marginLeft, y, HelveticaBold, 9, "Description")
+	for _, column := range invoiceColumns {
+		page.TextRight(column.right, y, HelveticaBold, 9, column.title)
+	}
+	page.Line(marginLeft, y+5, marginRight, y+5)
+
+	return y + rowHeight + 4
+}
+
+// fit shortens s with an ellipsis until it is no wider than width
+func fit(s string, font Font, size float64, width float64) string {
+	if TextWidth(font, size, s) <= width {
+		return s
+	}
+
+	runes := []rune(s)
+	for len(runes) > 0 && TextWidth(font, size, string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes) + "..."
+}
+
+func amount(minor int, currency string) string {
+	return entity.Money{Amount: int64(minor), Currency: currency}.Decimal()
+}
@@ -0,0 +1,78 @@
+package pdf_test
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/pdf"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertWellFormed checks that startxref points at the cross-reference table and that every
+// entry of it points at the object it numbers
+func assertWellFormed(t *testing.T, data []byte) {
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	if !assert.NotNil(t, match) {
+		return
+	}
+
+	xref, _ := strconv.Atoi(string(match[1]))
+	assert.True(t, bytes.HasPrefix(data[xref:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xref:], -1)
+	assert.NotEmpty(t, entries)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(data[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+}
+
+func TestDocument(t *testing.T) {
+	doc := pdf.New()
+	page := doc.AddPage()
+	page.Text(50, 80, pdf.HelveticaBold, 20, `Invoice (copy) \ Café ✓`)
+	page.Line(50, 90, 545, 90)
+	doc.AddPage().TextRight(545, 80, pdf.Helvetica, 9, "Page 2")
+
+	data := doc.Bytes()
+
+	assertWellFormed(t, data)
+	assert.Contains(t, string(data), "/Count 2")
+	assert.Contains(t, string(data), `(Invoice \(copy\) \\ Caf`+"\xe9"+` ?) Tj`)
+}
+
+func TestTextWidth(t *testing.T) {
+	assert.Equal(t, 5.56, pdf.TextWidth(pdf.Helvetica, 10, "0"))
+	assert.Equal(t, 11.12, pdf.TextWidth(pdf.Helvetica, 10, "00"))
+	assert.Greater(t, pdf.TextWidth(pdf.HelveticaBold, 10, "bold"), pdf.TextWidth(pdf.Helvetica, 10, "bold"))
+}
+
+func TestRenderInvoice(t *testing.T) {
+	invoice := entity.Invoice{
+		OrderID: 1, Kind: entity.InvoiceKindInvoice, Number: "INV-2026-000001", CustomerEmail: "buyer@mail.com", ShippingCountry: "ID", Currency: "USD",
+		TaxLines: []entity.TaxLine{{Name: "VAT", Rate: 1100, Taxable: 9000, Amount: 990}},
+		Subtotal: 9000, Tax: 990, Shipping: 500, Total: 10490, IssuedAt: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+	}
+	// enough lines to continue on a second page
+	for i := 0; i < 50; i++ {
+		invoice.Lines = append(invoice.Lines, entity.InvoiceLine{BookID: int64(i + 1), Description: strings.Repeat("A very long title ", 5), Quantity: 1, UnitPrice: 180, Amount: 180})
+	}
+
+	data := pdf.RenderInvoice(invoice, pdf.Seller{Name: "Book Store", Address: []string{"Jl. Merdeka 1"}, TaxID: "01.234"})
+
+	assertWellFormed(t, data)
+	assert.Contains(t, string(data), "/Count 2")
+	assert.Contains(t, string(data), "(INV-2026-000001) Tj")
+	assert.Contains(t, string(data), "(VAT 11% of 90.00) Tj")
+	assert.Contains(t, string(data), "(104.90 USD) Tj")
+	assert.Contains(t, string(data), "...) Tj")
+}
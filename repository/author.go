@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+type AuthorRepository interface {
+	GetAuthors(ctx context.Context) ([]entity.Author, error)
+	GetAuthor(ctx context.Context, id int64) (entity.Author, error)
+	GetAuthorByNormalizedName(ctx context.Context, normalizedName string) (entity.Author, error)
+	CreateAuthor(ctx context.Context, author *entity.Author) error
+	UpdateAuthor(ctx context.Context, id int64, author *entity.Author) error
+	DeleteAuthor(ctx context.Context, id int64) error
+	GetBookAuthors(ctx context.Context, bookIDs []int64) (map[int64][]entity.BookAuthor, error)
+}
+
+type mysqlAuthor struct {
+	DB *sql.DB
+}
+
+func NewMysqlAuthor(db *sql.DB) AuthorRepository {
+	return &mysqlAuthor{DB: db}
+}
+
+func (ma *mysqlAuthor) GetAuthors(ctx context.Context) ([]entity.Author, error) {
+	var authors []entity.Author
+
+	rows, err := ma.DB.Query("SELECT id, name, normalized_name, created_at, updated_at FROM authors ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var author entity.Author
+
+		err := rows.Scan(&author.ID, &author.Name, &author.NormalizedName, &author.CreatedAt, &author.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		authors = append(authors, author)
+	}
+
+	return authors, nil
+}
+
+func (ma *mysqlAuthor) GetAuthor(ctx context.Context, id int64) (entity.Author, error) {
+	var author entity.Author
+
+	err := ma.DB.QueryRow("SELECT id, name, normalized_name, created_at, updated_at FROM authors WHERE id=$1", id).Scan(&author.ID, &author.Name, &author.NormalizedName, &author.CreatedAt, &author.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.Author{}, nil
+		}
+		return entity.Author{}, err
+	}
+
+	return author, nil
+}
+
+func (ma *mysqlAuthor) GetAuthorByNormalizedName(ctx context.Context, normalizedName string) (entity.Author, error) {
+	var author entity.Author
+
+	err := ma.DB.QueryRow("SELECT id, name, normalized_name, created_at, updated_at FROM authors WHERE normalized_name=$1", normalizedName).Scan(&author.ID, &author.Name, &author.NormalizedName, &author.CreatedAt, &author.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.Author{}, nil
+		}
+		return entity.Author{}, err
+	}
+
+	return author, nil
+}
+
+func (ma *mysqlAuthor) CreateAuthor(ctx context.Context, author *entity.Author) error {
+	stmt, err := ma.DB.Prepare("INSERT INTO authors (name, normalized_name, created_at, updated_at) VALUES($1, $2, $3, $4) RETURNING id")
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	author.CreatedAt = startTime
+	author.UpdatedAt = startTime
+
+	err = stmt.QueryRow(&author.Name, &author.NormalizedName, &author.CreatedAt, &author.UpdatedAt).Scan(&author.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ma *mysqlAuthor) UpdateAuthor(ctx context.Context, id int64, author *entity.Author) error {
+	stmt, err := ma.DB.Prepare("UPDATE authors SET name=$1, normalized_name=$2, updated_at=$3 WHERE id=$4")
+	if err != nil {
+		return err
+	}
+
+	author.UpdatedAt = time.Now()
+	_, err = stmt.Exec(&author.Name, &author.NormalizedName, &author.UpdatedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ma *mysqlAuthor) DeleteAuthor(ctx context.Context, id int64) error {
+	stmt, err := ma.DB.Prepare("DELETE FROM authors WHERE id=$1")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetBookAuthors returns the contributors of every given book keyed by book id
+func (ma *mysqlAuthor) GetBookAuthors(ctx context.Context, bookIDs []int64) (map[int64][]entity.BookAuthor, error) {
+	bookAuthors := make(map[int64][]entity.BookAuthor)
+
+	rows, err := ma.DB.Query("SELECT ba.book_id, ba.author_id, a.name, ba.role, ba.position FROM book_authors ba JOIN authors a ON a.id = ba.author_id WHERE ba.book_id = ANY($1) ORDER BY ba.book_id, ba.position", pq.Array(bookIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookID int64
+		var author entity.BookAuthor
+
+		err := rows.Scan(&bookID, &author.AuthorID, &author.Name, &author.Role, &author.Position)
+		if err != nil {
+			return nil, err
+		}
+
+		bookAuthors[bookID] = append(bookAuthors[bookID], author)
+	}
+
+	return bookAuthors, nil
+}
+
+// setBookAuthors replaces the contributors of a book
+func setBookAuthors(tx *sql.Tx, bookID int64, authors []entity.BookAuthor) error {
+	_, err := tx.Exec("DELETE FROM book_authors WHERE book_id=$1", bookID)
+	if err != nil {
+		return err
+	}
+
+	for _, author := range authors {
+		_, err = tx.Exec("INSERT INTO book_authors (book_id, author_id, role, position) VALUES($1, $2, $3, $4)", bookID, author.AuthorID, author.Role, author.Position)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
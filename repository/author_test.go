@@ -0,0 +1,222 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAuthors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rows    []entity.Author
+		isError bool
+		err     error
+	}{
+		{
+			name:    "success",
+			rows:    []entity.Author{{ID: 1, Name: "Terry Pratchett", NormalizedName: "terry pratchett", CreatedAt: time.Now(), UpdatedAt: time.Now()}},
+			isError: false,
+			err:     nil,
+		},
+		{
+			name:    "failed",
+			rows:    []entity.Author{},
+			isError: true,
+			err:     errors.New("Dummy Error"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			if !test.isError {
+				rows := sqlmock.NewRows([]string{"id", "name", "normalized_name", "created_at", "updated_at"})
+				for _, row := range test.rows {
+					rows.AddRow(row.ID, row.Name, row.NormalizedName, row.CreatedAt, row.UpdatedAt)
+				}
+				mock.ExpectQuery("SELECT (.+) FROM authors").WillReturnRows(rows)
+			} else {
+				mock.ExpectQuery("SELECT (.+) FROM authors").WillReturnError(test.err)
+			}
+
+			mysqlAuthor := repository.NewMysqlAuthor(db)
+			ret, err := mysqlAuthor.GetAuthors(context.Background())
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, len(test.rows), len(ret))
+		})
+	}
+}
+
+func TestGetAuthor(t *testing.T) {
+	testCases := []struct {
+		name    string
+		id      int64
+		row     entity.Author
+		isError bool
+		err     error
+	}{
+		{
+			name:    "success",
+			id:      1,
+			row:     entity.Author{ID: 1, Name: "Terry Pratchett", NormalizedName: "terry pratchett", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+			isError: false,
+			err:     nil,
+		},
+		{
+			name:    "success but data is empty",
+			id:      1,
+			row:     entity.Author{},
+			isError: false,
+			err:     sql.ErrNoRows,
+		},
+		{
+			name:    "failed",
+			id:      1,
+			row:     entity.Author{},
+			isError: true,
+			err:     errors.New("Dummy Error"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			if test.err == nil {
+				row := sqlmock.NewRows([]string{"id", "name", "normalized_name", "created_at", "updated_at"}).
+					AddRow(test.row.ID, test.row.Name, test.row.NormalizedName, test.row.CreatedAt, test.row.UpdatedAt)
+				mock.ExpectQuery("SELECT (.+) FROM authors WHERE id").WithArgs(test.id).WillReturnRows(row)
+			} else {
+				mock.ExpectQuery("SELECT (.+) FROM authors WHERE id").WithArgs(test.id).WillReturnError(test.err)
+			}
+
+			mysqlAuthor := repository.NewMysqlAuthor(db)
+			ret, err := mysqlAuthor.GetAuthor(context.Background(), test.id)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.row.ID, ret.ID)
+		})
+	}
+}
+
+func TestCreateAuthor(t *testing.T) {
+	testCases := []struct {
+		name    string
+		author  entity.Author
+		isError bool
+		err     error
+	}{
+		{
+			name:    "success",
+			author:  entity.Author{Name: "Terry Pratchett", NormalizedName: "terry pratchett"},
+			isError: false,
+			err:     nil,
+		},
+		{
+			name:    "failed",
+			author:  entity.Author{},
+			isError: true,
+			err:     errors.New("Dummy Error"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			if !test.isError {
+				mock.ExpectPrepare("INSERT INTO authors (.+)").
+					ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+			} else {
+				mock.ExpectPrepare("INSERT INTO authors (.+)").
+					ExpectQuery().WillReturnError(test.err)
+			}
+
+			mysqlAuthor := repository.NewMysqlAuthor(db)
+			err = mysqlAuthor.CreateAuthor(context.Background(), &test.author)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, int64(7), test.author.ID)
+			}
+		})
+	}
+}
+
+func TestUpdateAuthor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectPrepare("UPDATE authors (.+)").ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mysqlAuthor := repository.NewMysqlAuthor(db)
+	err = mysqlAuthor.UpdateAuthor(context.Background(), 1, &entity.Author{Name: "Terry Pratchett", NormalizedName: "terry pratchett"})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteAuthor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectPrepare("DELETE FROM authors (.+)").ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mysqlAuthor := repository.NewMysqlAuthor(db)
+	err = mysqlAuthor.DeleteAuthor(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBookAuthors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"book_id", "author_id", "name", "role", "position"}).
+		AddRow(1, 1, "Terry Pratchett", entity.AuthorRoleAuthor, 1).
+		AddRow(1, 2, "Neil Gaiman", entity.AuthorRoleAuthor, 2).
+		AddRow(2, 1, "Terry Pratchett", entity.AuthorRoleAuthor, 1)
+	mock.ExpectQuery("SELECT (.+) FROM book_authors (.+)").WillReturnRows(rows)
+
+	mysqlAuthor := repository.NewMysqlAuthor(db)
+	ret, err := mysqlAuthor.GetBookAuthors(context.Background(), []int64{1, 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, ret[1], 2)
+	assert.Len(t, ret[2], 1)
+	assert.Equal(t, "Neil Gaiman", ret[1][1].Name)
+}
@@ -3,17 +3,28 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
 )
 
+const bookColumns = "id, publisher_id, category_id, title, description, language, original_language, year_of_publication, stock, reserved, " + currentPriceColumn + ", COALESCE(tax_category_id, 0), weight_grams, release_date, COALESCE(release_date > CURRENT_DATE, false), " + bookRatingColumns + ", created_at, updated_at"
+
+// bookRatingColumns are the average of the approved reviews of a book, to two decimals, and their
+// count, which are kept on the book as reviews are moderated
+const bookRatingColumns = "COALESCE(ROUND(rating_total::numeric / NULLIF(rating_count, 0), 2), 0), rating_count"
+
 type BookRepository interface {
 	// seller
-	GetBooks(ctx context.Context) ([]entity.Book, error)
+	GetBooks(ctx context.Context, filter entity.BookFilter) ([]entity.Book, error)
 	GetBook(ctx context.Context, id int64) (entity.Book, error)
-	CreateBook(ctx context.Context, book *entity.Book) error
-	UpdateBook(ctx context.Context, id int64, book *entity.Book) error
+	CreateBook(ctx context.Context, book *entity.Book, tags []entity.Tag, receipt *entity.StockMovement) error
+	UpdateBook(ctx context.Context, id int64, book *entity.Book, tags []entity.Tag, price *entity.BookPrice, adjustment *entity.StockMovement) error
 	DeleteBook(ctx context.Context, id int64) error
+	GetBookMovementCount(ctx context.Context, id int64) (int, error)
 }
 
 type mysqlBook struct {
@@ -24,18 +35,20 @@ func NewMysqlBook(db *sql.DB) BookRepository {
 	return &mysqlBook{DB: db}
 }
 
-func (mb *mysqlBook) GetBooks(ctx context.Context) ([]entity.Book, error) {
+func (mb *mysqlBook) GetBooks(ctx context.Context, filter entity.BookFilter) ([]entity.Book, error) {
 	var books []entity.Book
 
-	rows, err := mb.DB.Query("SELECT * FROM books")
+	query, args := bookFilterQuery(filter)
+	rows, err := mb.DB.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var book entity.Book
 
-		err := rows.Scan(&book.ID, &book.PublisherID, &book.CategoryID, &book.Title, &book.Author, &book.Publication, &book.Stock, &book.Price, &book.CreatedAt, &book.UpdatedAt)
+		err := rows.Scan(&book.ID, &book.PublisherID, &book.CategoryID, &book.Title, &book.Description, &book.Language, &book.OriginalLanguage, &book.Publication, &book.Stock, &book.Reserved, &book.Price, &book.TaxCategoryID, &book.WeightGrams, &book.ReleaseDate, &book.PreOrder, &book.Rating, &book.RatingCount, &book.CreatedAt, &book.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -49,7 +62,7 @@ func (mb *mysqlBook) GetBooks(ctx context.Context) ([]entity.Book, error) {
 func (mb *mysqlBook) GetBook(ctx context.Context, id int64) (entity.Book, error) {
 	var book entity.Book
 
-	err := mb.DB.QueryRow("SELECT * FROM books WHERE id=$1", id).Scan(&book.ID, &book.PublisherID, &book.CategoryID, &book.Title, &book.Author, &book.Publication, &book.Stock, &book.Price, &book.CreatedAt, &book.UpdatedAt)
+	err := mb.DB.QueryRow("SELECT "+bookColumns+" FROM books WHERE id=$1", id).Scan(&book.ID, &book.PublisherID, &book.CategoryID, &book.Title, &book.Description, &book.Language, &book.OriginalLanguage, &book.Publication, &book.Stock, &book.Reserved, &book.Price, &book.TaxCategoryID, &book.WeightGrams, &book.ReleaseDate, &book.PreOrder, &book.Rating, &book.RatingCount, &book.CreatedAt, &book.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return entity.Book{}, nil
@@ -60,44 +73,104 @@ func (mb *mysqlBook) GetBook(ctx context.Context, id int64) (entity.Book, error)
 	return book, nil
 }
 
-func (mb *mysqlBook) CreateBook(ctx context.Context, book *entity.Book) error {
-	stmt, err := mb.DB.Prepare("INSERT INTO books (publisher_id, category_id, title, author, year_of_publication, stock, price, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)")
-
+// CreateBook stores a book with its details, its opening price and, when given, the receipt of
+// its opening stock in a single transaction
+func (mb *mysqlBook) CreateBook(ctx context.Context, book *entity.Book, tags []entity.Tag, receipt *entity.StockMovement) error {
+	tx, err := mb.DB.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
 	startTime := time.Now()
 	book.CreatedAt = startTime
 	book.UpdatedAt = startTime
 
-	res, err := stmt.Exec(&book.PublisherID, &book.CategoryID, &book.Title, &book.Author, &book.Publication, &book.Stock, &book.Price, &book.CreatedAt, &book.UpdatedAt)
+	err = tx.QueryRow("INSERT INTO books (publisher_id, category_id, title, description, language, original_language, year_of_publication, release_date, stock, price, tax_category_id, weight_grams, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, 0), $12, $13, $14) RETURNING id", &book.PublisherID, &book.CategoryID, &book.Title, &book.Description, &book.Language, &book.OriginalLanguage, &book.Publication, book.ReleaseDate, &book.Stock, &book.Price, &book.TaxCategoryID, &book.WeightGrams, &book.CreatedAt, &book.UpdatedAt).Scan(&book.ID)
 	if err != nil {
 		return err
 	}
 
-	if row, _ := res.RowsAffected(); row == 1 {
-		return nil
+	err = setBookDetails(tx, book.ID, book, tags)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	price := entity.BookPrice{BookID: book.ID, Price: book.Price, EffectiveFrom: book.CreatedAt}
+	err = insertBookPrice(tx, &price)
+	if err != nil {
+		return err
+	}
+
+	if receipt != nil {
+		receipt.BookID = book.ID
+		receipt.CreatedAt = startTime
+
+		err = recordStockMovement(tx, receipt)
+		if err != nil {
+			return err
+		}
+
+		book.Stock = receipt.StockAfter
+	}
+
+	return tx.Commit()
 }
 
-func (mb *mysqlBook) UpdateBook(ctx context.Context, id int64, book *entity.Book) error {
-	stmt, err := mb.DB.Prepare("UPDATE books SET publisher_id=$1, category_id=$2, title=$3, author=$4, year_of_publication=$5, stock=$6, price=$7, updated_at=$8 WHERE id=$9")
+// UpdateBook stores a book with its details and, when given, a new entry in its price history
+// in a single transaction. When an adjustment is given a different stock of the book is
+// recorded as that movement, stock only changes through recorded stock movements.
+func (mb *mysqlBook) UpdateBook(ctx context.Context, id int64, book *entity.Book, tags []entity.Tag, price *entity.BookPrice, adjustment *entity.StockMovement) error {
+	tx, err := mb.DB.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
 	startTime := time.Now()
 	book.UpdatedAt = startTime
 
-	_, err = stmt.Exec(&book.PublisherID, &book.CategoryID, &book.Title, &book.Author, &book.Publication, &book.Stock, &book.Price, &book.UpdatedAt, id)
+	_, err = tx.Exec("UPDATE books SET publisher_id=$1, category_id=$2, title=$3, description=$4, language=$5, original_language=$6, year_of_publication=$7, release_date=$8, price=$9, tax_category_id=NULLIF($10, 0), weight_grams=$11, updated_at=$12 WHERE id=$13", &book.PublisherID, &book.CategoryID, &book.Title, &book.Description, &book.Language, &book.OriginalLanguage, &book.Publication, book.ReleaseDate, &book.Price, &book.TaxCategoryID, &book.WeightGrams, &book.UpdatedAt, id)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	err = setBookDetails(tx, id, book, tags)
+	if err != nil {
+		return err
+	}
+
+	if price != nil {
+		price.BookID = id
+		price.EffectiveFrom = startTime
+
+		err = insertBookPrice(tx, price)
+		if err != nil {
+			return err
+		}
+	}
+
+	if adjustment != nil {
+		// the book row is locked by the update above, so the stock cannot change in between
+		var stock int
+		err = tx.QueryRow("SELECT stock FROM books WHERE id=$1", id).Scan(&stock)
+		if err != nil {
+			return err
+		}
+
+		if book.Stock != stock {
+			adjustment.BookID = id
+			adjustment.Quantity = book.Stock - stock
+			adjustment.CreatedAt = startTime
+
+			err = recordStockMovement(tx, adjustment)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
 }
 
 func (mb *mysqlBook) DeleteBook(ctx context.Context, id int64) error {
@@ -113,3 +186,85 @@ func (mb *mysqlBook) DeleteBook(ctx context.Context, id int64) error {
 
 	return nil
 }
+
+// GetBookMovementCount returns the number of stock movements in the ledger of a book
+func (mb *mysqlBook) GetBookMovementCount(ctx context.Context, id int64) (int, error) {
+	var count int
+
+	err := mb.DB.QueryRow("SELECT COUNT(*) FROM stock_movements WHERE book_id=$1", id).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// setBookDetails replaces the contributors, categories, tags and series of a book
+func setBookDetails(tx *sql.Tx, id int64, book *entity.Book, tags []entity.Tag) error {
+	err := setBookAuthors(tx, id, book.Authors)
+	if err != nil {
+		return err
+	}
+
+	err = setBookCategories(tx, id, book.Categories)
+	if err != nil {
+		return err
+	}
+
+	err = setBookTags(tx, id, tags)
+	if err != nil {
+		return err
+	}
+
+	return setBookSeries(tx, id, book.Series)
+}
+
+// bookFilterQuery builds the book listing query and its arguments from filter
+func bookFilterQuery(filter entity.BookFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.AuthorID != 0 {
+		args = append(args, filter.AuthorID)
+		conditions = append(conditions, fmt.Sprintf("id IN (SELECT book_id FROM book_authors WHERE author_id=$%d)", len(args)))
+	}
+
+	if filter.CategoryID != 0 {
+		args = append(args, filter.CategoryID)
+		if filter.IncludeDescendants {
+			conditions = append(conditions, fmt.Sprintf(`id IN (SELECT book_id FROM book_categories WHERE category_id IN (WITH RECURSIVE descendants AS (
+				SELECT id FROM categories WHERE id=$%d
+				UNION ALL
+				SELECT c.id FROM categories c JOIN descendants d ON c.parent_id = d.id
+			) SELECT id FROM descendants))`, len(args)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("id IN (SELECT book_id FROM book_categories WHERE category_id=$%d)", len(args)))
+		}
+	}
+
+	if filter.SeriesID != 0 {
+		args = append(args, filter.SeriesID)
+		conditions = append(conditions, fmt.Sprintf("id IN (SELECT book_id FROM book_series WHERE series_id=$%d)", len(args)))
+	}
+	seriesArg := len(args)
+
+	if len(filter.Tags) > 0 {
+		args = append(args, pq.Array(filter.Tags), len(filter.Tags))
+		conditions = append(conditions, fmt.Sprintf("id IN (SELECT bt.book_id FROM book_tags bt JOIN tags t ON t.id = bt.tag_id WHERE t.slug = ANY($%d) GROUP BY bt.book_id HAVING COUNT(DISTINCT t.id) = $%d)", len(args)-1, len(args)))
+	}
+
+	query := "SELECT " + bookColumns + " FROM books"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	if filter.Sort == entity.BookSortRating {
+		return query + " ORDER BY rating_total::numeric / NULLIF(rating_count, 0) DESC NULLS LAST, rating_count DESC, id", args
+	}
+
+	if filter.SeriesID != 0 {
+		return query + fmt.Sprintf(" ORDER BY (SELECT position FROM book_series WHERE book_id = books.id AND series_id=$%d), id", seriesArg), args
+	}
+
+	return query + " ORDER BY id", args
+}
@@ -28,7 +28,7 @@ func TestGetBooks(t *testing.T) {
 	}{
 		{
 			name:    "success",
-			rows:    []entity.Book{{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Author: "Book Author", Publication: 2021, Stock: 4, Price: 100000, CreatedAt: time.Now(), UpdatedAt: time.Now()}},
+			rows:    []entity.Book{{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Publication: 2021, Stock: 4, Price: 100000, CreatedAt: time.Now(), UpdatedAt: time.Now()}},
 			query:   rightQuery,
 			isError: false,
 			err:     nil,
@@ -42,7 +42,7 @@ func TestGetBooks(t *testing.T) {
 		},
 		{
 			name:    "failed",
-			rows:    []entity.Book{{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Author: "Book Author", Publication: 2021, Stock: 4, Price: 100000, CreatedAt: time.Now(), UpdatedAt: time.Now()}},
+			rows:    []entity.Book{{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Publication: 2021, Stock: 4, Price: 100000, CreatedAt: time.Now(), UpdatedAt: time.Now()}},
 			query:   rightQuery,
 			isError: false,
 			err:     nil,
@@ -58,9 +58,9 @@ func TestGetBooks(t *testing.T) {
 			defer db.Close()
 
 			if !test.isError {
-				rows := sqlmock.NewRows([]string{"id", "publisher_id", "category_id", "title", "author", "year_of_publication", "stock", "price", "created_at", "updated_at"})
+				rows := sqlmock.NewRows([]string{"id", "publisher_id", "category_id", "title", "description", "language", "original_language", "year_of_publication", "stock", "reserved", "price", "tax_category_id", "weight_grams", "release_date", "preorder", "rating", "rating_count", "created_at", "updated_at"})
 				for _, row := range test.rows {
-					rows.AddRow(row.ID, row.PublisherID, row.CategoryID, row.Title, row.Author, row.Publication, row.Stock, row.Price, row.CreatedAt, row.UpdatedAt)
+					rows.AddRow(row.ID, row.PublisherID, row.CategoryID, row.Title, "", "", "", row.Publication, row.Stock, row.Reserved, row.Price, row.TaxCategoryID, 0, nil, false, 0, 0, row.CreatedAt, row.UpdatedAt)
 				}
 				mock.ExpectQuery(test.query).WillReturnRows(rows)
 			} else {
@@ -68,7 +68,7 @@ func TestGetBooks(t *testing.T) {
 			}
 
 			mysqlBook := repository.NewMysqlBook(db)
-			ret, err := mysqlBook.GetBooks(context.Background())
+			ret, err := mysqlBook.GetBooks(context.Background(), entity.BookFilter{})
 
 			assert.Equal(t, test.isError, err != nil)
 
@@ -98,7 +98,7 @@ func TestGetBook(t *testing.T) {
 		{
 			name:    "success",
 			id:      1,
-			row:     entity.Book{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Author: "Book Author", Publication: 2021, Stock: 4, Price: 100000, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+			row:     entity.Book{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Publication: 2021, Stock: 4, Price: 100000, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 			query:   rightQuery,
 			isError: false,
 			err:     nil,
@@ -138,8 +138,8 @@ func TestGetBook(t *testing.T) {
 			defer db.Close()
 
 			if !test.isError {
-				row := sqlmock.NewRows([]string{"id", "publisher_id", "category_id", "title", "author", "year_of_publication", "stock", "price", "created_at", "updated_at"}).
-					AddRow(test.row.ID, test.row.PublisherID, test.row.CategoryID, test.row.Title, test.row.Author, test.row.Publication, test.row.Stock, test.row.Price, test.row.CreatedAt, test.row.UpdatedAt)
+				row := sqlmock.NewRows([]string{"id", "publisher_id", "category_id", "title", "description", "language", "original_language", "year_of_publication", "stock", "reserved", "price", "tax_category_id", "weight_grams", "release_date", "preorder", "rating", "rating_count", "created_at", "updated_at"}).
+					AddRow(test.row.ID, test.row.PublisherID, test.row.CategoryID, test.row.Title, "", "", "", test.row.Publication, test.row.Stock, test.row.Reserved, test.row.Price, test.row.TaxCategoryID, 0, nil, false, 0, 0, test.row.CreatedAt, test.row.UpdatedAt)
 
 				mock.ExpectQuery(test.query).WithArgs(test.id).WillReturnRows(row)
 			} else {
@@ -162,36 +162,38 @@ func TestGetBook(t *testing.T) {
 }
 
 func TestCreateBook(t *testing.T) {
-	rightQuery := "INSERT INTO (.+)"
-	wrongQuery := "INSERT INTO"
-
 	testCases := []struct {
-		name    string
-		book    entity.Book
-		query   string
-		isError bool
-		err     error
+		name      string
+		book      entity.Book
+		tags      []entity.Tag
+		receipt   *entity.StockMovement
+		insertErr error
+		detailErr error
+		isError   bool
 	}{
 		{
-			name:    "success",
-			book:    entity.Book{PublisherID: 1, CategoryID: 1, Title: "Book Title", Author: "Book Author", Publication: 2021, Stock: 4, Price: 10000, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-			query:   rightQuery,
+			name:    "success with opening stock",
+			book:    entity.Book{PublisherID: 1, CategoryID: 1, Title: "Book Title", Publication: 2021, Price: 10000, Authors: []entity.BookAuthor{{AuthorID: 2, Role: entity.AuthorRoleAuthor, Position: 1}}, Categories: []entity.BookCategory{{CategoryID: 1, Primary: true}}, Series: []entity.BookSeries{{SeriesID: 3, Position: 1}}},
+			tags:    []entity.Tag{{Name: "Travel Guide", Slug: "travel-guide"}},
+			receipt: &entity.StockMovement{Type: entity.MovementReceipt, Quantity: 4, Reason: "initial stock", Actor: "system"},
 			isError: false,
-			err:     nil,
 		},
 		{
-			name:    "failed",
-			book:    entity.Book{},
-			query:   rightQuery,
-			isError: true,
-			err:     errors.New("Dummy Error"),
+			name:    "success without stock",
+			book:    entity.Book{PublisherID: 1, CategoryID: 1, Title: "Book Title", Publication: 2021, Price: 10000},
+			isError: false,
 		},
 		{
-			name:    "wrong query",
-			book:    entity.Book{},
-			query:   wrongQuery,
-			isError: true,
-			err:     errors.New("Dummy Error"),
+			name:      "failed",
+			book:      entity.Book{},
+			insertErr: errors.New("Dummy Error"),
+			isError:   true,
+		},
+		{
+			name:      "failed details roll the book back",
+			book:      entity.Book{Title: "Book Title", Authors: []entity.BookAuthor{{AuthorID: 2, Role: entity.AuthorRoleAuthor, Position: 1}}},
+			detailErr: errors.New("Dummy Error"),
+			isError:   true,
 		},
 	}
 
@@ -203,55 +205,97 @@ func TestCreateBook(t *testing.T) {
 			}
 			defer db.Close()
 
-			if !test.isError {
-				mock.ExpectPrepare(test.query).
-					ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectBegin()
+			insert := mock.ExpectQuery("INSERT INTO books (.+)")
+			if test.insertErr != nil {
+				insert.WillReturnError(test.insertErr)
+				mock.ExpectRollback()
 			} else {
-				mock.ExpectPrepare(test.query).
-					ExpectExec().WillReturnError(test.err)
+				insert.WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec("DELETE FROM book_authors (.+)").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				if test.detailErr != nil {
+					mock.ExpectExec("INSERT INTO book_authors (.+)").WillReturnError(test.detailErr)
+					mock.ExpectRollback()
+				} else {
+					expectSetBookDetails(mock, test.book, test.tags)
+					mock.ExpectQuery("INSERT INTO book_prices (.+)").WithArgs(1, test.book.Price, sqlmock.AnyArg(), nil, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+					if test.receipt != nil {
+						mock.ExpectQuery("SELECT id FROM warehouses (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+						mock.ExpectExec("INSERT INTO warehouse_stocks (.+)").WithArgs(1, 1, test.receipt.Quantity).WillReturnResult(sqlmock.NewResult(0, 1))
+						mock.ExpectQuery("UPDATE books SET stock = stock (.+)").WithArgs(test.receipt.Quantity, sqlmock.AnyArg(), 1).WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(test.receipt.Quantity))
+						mock.ExpectQuery("INSERT INTO stock_movements (.+)").WithArgs(1, 0, 1, entity.MovementReceipt, test.receipt.Quantity, "initial stock", "system", test.receipt.Quantity, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+					}
+					mock.ExpectCommit()
+				}
 			}
 
 			mysqlBook := repository.NewMysqlBook(db)
-			err = mysqlBook.CreateBook(context.Background(), &test.book)
+			err = mysqlBook.CreateBook(context.Background(), &test.book, test.tags, test.receipt)
+
 			assert.Equal(t, test.isError, err != nil)
+			if test.receipt != nil {
+				assert.Equal(t, test.receipt.Quantity, test.book.Stock)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
 
 func TestUpdateBook(t *testing.T) {
-	rightQuery := "UPDATE books (.+)"
-	wrongQuery := "UPDATEING books"
-
 	testCases := []struct {
-		name    string
-		id      int64
-		book    entity.Book
-		query   string
-		isError bool
-		err     error
+		name       string
+		id         int64
+		book       entity.Book
+		price      *entity.BookPrice
+		adjustment *entity.StockMovement
+		stock      int
+		updateErr  error
+		priceErr   error
+		isError    bool
 	}{
 		{
-			name:    "success",
+			name:    "success with a new price",
 			id:      1,
-			query:   rightQuery,
+			book:    entity.Book{Title: "Book Title", Price: 12000, Categories: []entity.BookCategory{{CategoryID: 1, Primary: true}}},
+			price:   &entity.BookPrice{Price: 12000},
 			isError: false,
-			err:     nil,
 		},
 		{
-			name:    "failed",
+			name:    "success with an unchanged price",
 			id:      1,
-			book:    entity.Book{},
-			query:   rightQuery,
-			isError: true,
-			err:     errors.New("Dummy Error"),
+			book:    entity.Book{Title: "Book Title", Price: 12000},
+			isError: false,
 		},
 		{
-			name:    "wrong query",
-			id:      1,
-			book:    entity.Book{},
-			query:   wrongQuery,
-			isError: true,
-			err:     errors.New("Dummy Error"),
+			name:       "stock back from zero is adjusted",
+			id:         1,
+			book:       entity.Book{Title: "Book Title", Price: 12000, Stock: 5},
+			adjustment: &entity.StockMovement{Type: entity.MovementAdjustment, Reason: "book update", Actor: "system"},
+			stock:      0,
+			isError:    false,
+		},
+		{
+			name:       "unchanged stock is not adjusted",
+			id:         1,
+			book:       entity.Book{Title: "Book Title", Price: 12000, Stock: 5},
+			adjustment: &entity.StockMovement{Type: entity.MovementAdjustment, Reason: "book update", Actor: "system"},
+			stock:      5,
+			isError:    false,
+		},
+		{
+			name:      "failed",
+			id:        1,
+			book:      entity.Book{},
+			updateErr: errors.New("Dummy Error"),
+			isError:   true,
+		},
+		{
+			name:     "failed price rolls the book back",
+			id:       1,
+			book:     entity.Book{Title: "Book Title", Price: 12000},
+			price:    &entity.BookPrice{Price: 12000},
+			priceErr: errors.New("Dummy Error"),
+			isError:  true,
 		},
 	}
 
@@ -263,22 +307,73 @@ func TestUpdateBook(t *testing.T) {
 			}
 			defer db.Close()
 
-			if !test.isError {
-				mock.ExpectPrepare(test.query).
-					ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectBegin()
+			update := mock.ExpectExec("UPDATE books (.+)")
+			if test.updateErr != nil {
+				update.WillReturnError(test.updateErr)
+				mock.ExpectRollback()
 			} else {
-				mock.ExpectPrepare(test.query).
-					ExpectExec().WillReturnError(test.err)
+				update.WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM book_authors (.+)").WithArgs(test.id).WillReturnResult(sqlmock.NewResult(0, 0))
+				expectSetBookDetails(mock, test.book, nil)
+				if test.price != nil {
+					insert := mock.ExpectQuery("INSERT INTO book_prices (.+)").WithArgs(test.id, test.price.Price, sqlmock.AnyArg(), nil, sqlmock.AnyArg())
+					if test.priceErr != nil {
+						insert.WillReturnError(test.priceErr)
+						mock.ExpectRollback()
+					} else {
+						insert.WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+						mock.ExpectCommit()
+					}
+				} else {
+					if test.adjustment != nil {
+						mock.ExpectQuery("SELECT stock FROM books (.+)").WithArgs(test.id).WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(test.stock))
+						if quantity := test.book.Stock - test.stock; quantity != 0 {
+							mock.ExpectQuery("SELECT id FROM warehouses (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+							mock.ExpectExec("INSERT INTO warehouse_stocks (.+)").WithArgs(1, test.id, quantity).WillReturnResult(sqlmock.NewResult(0, 1))
+							mock.ExpectQuery("UPDATE books SET stock = stock (.+)").WithArgs(quantity, sqlmock.AnyArg(), test.id).WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(test.book.Stock))
+							mock.ExpectQuery("INSERT INTO stock_movements (.+)").WithArgs(test.id, 0, 1, entity.MovementAdjustment, quantity, "book update", "system", test.book.Stock, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+						}
+					}
+					mock.ExpectCommit()
+				}
 			}
 
 			mysqlBook := repository.NewMysqlBook(db)
-			err = mysqlBook.UpdateBook(context.Background(), test.id, &test.book)
-			fmt.Println(err)
+			err = mysqlBook.UpdateBook(context.Background(), test.id, &test.book, nil, test.price, test.adjustment)
+
 			assert.Equal(t, test.isError, err != nil)
+			if test.adjustment != nil && test.stock != test.book.Stock {
+				assert.Equal(t, test.book.Stock-test.stock, test.adjustment.Quantity)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
 
+// expectSetBookDetails expects the details of book 1 to be replaced, after its authors are deleted
+func expectSetBookDetails(mock sqlmock.Sqlmock, book entity.Book, tags []entity.Tag) {
+	for _, author := range book.Authors {
+		mock.ExpectExec("INSERT INTO book_authors (.+)").WithArgs(1, author.AuthorID, author.Role, author.Position).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	mock.ExpectExec("DELETE FROM book_categories (.+)").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	for _, category := range book.Categories {
+		mock.ExpectExec("INSERT INTO book_categories (.+)").WithArgs(1, category.CategoryID, category.Primary).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	mock.ExpectExec("DELETE FROM book_tags (.+)").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	for i, tag := range tags {
+		mock.ExpectQuery("INSERT INTO tags (.+) ON CONFLICT \\(slug\\) (.+) RETURNING id").WithArgs(tag.Name, tag.Slug, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(i + 1))
+		mock.ExpectExec("INSERT INTO book_tags (.+)").WithArgs(1, i+1).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	mock.ExpectExec("DELETE FROM book_series (.+)").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	for _, s := range book.Series {
+		mock.ExpectExec("INSERT INTO book_series (.+)").WithArgs(1, s.SeriesID, s.Position).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+}
+
 func TestDeleteBook(t *testing.T) {
 	rightQuery := "DELETE (.+)"
 	wrongQuery := "DELETE (.+)"
@@ -334,3 +429,139 @@ func TestDeleteBook(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBookMovementCount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT COUNT(.+) FROM stock_movements WHERE book_id(.+)").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	mysqlBook := repository.NewMysqlBook(db)
+	count, err := mysqlBook.GetBookMovementCount(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestGetBooksByAuthor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "publisher_id", "category_id", "title", "description", "language", "original_language", "year_of_publication", "stock", "reserved", "price", "tax_category_id", "weight_grams", "release_date", "preorder", "rating", "rating_count", "created_at", "updated_at"}).
+		AddRow(1, 1, 1, "Book Title", "", "", "", 2021, 4, 0, 100000, 0, 0, nil, false, 0, 0, time.Now(), time.Now())
+	mock.ExpectQuery("SELECT (.+) FROM books WHERE id IN \\(SELECT book_id FROM book_authors WHERE author_id=\\$1\\)").WithArgs(int64(2)).WillReturnRows(rows)
+
+	mysqlBook := repository.NewMysqlBook(db)
+	ret, err := mysqlBook.GetBooks(context.Background(), entity.BookFilter{AuthorID: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, ret, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBooksByCategory(t *testing.T) {
+	testCases := []struct {
+		name   string
+		filter entity.BookFilter
+		query  string
+	}{
+		{
+			name:   "only the category itself",
+			filter: entity.BookFilter{CategoryID: 2},
+			query:  "SELECT (.+) FROM books WHERE id IN \\(SELECT book_id FROM book_categories WHERE category_id=\\$1\\) ORDER BY id",
+		},
+		{
+			name:   "including descendants",
+			filter: entity.BookFilter{CategoryID: 2, IncludeDescendants: true},
+			query:  "SELECT (.+) FROM books WHERE id IN \\(SELECT book_id FROM book_categories WHERE category_id IN \\(WITH RECURSIVE descendants (.+)",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"id", "publisher_id", "category_id", "title", "description", "language", "original_language", "year_of_publication", "stock", "reserved", "price", "tax_category_id", "weight_grams", "release_date", "preorder", "rating", "rating_count", "created_at", "updated_at"}).
+				AddRow(1, 1, 2, "Book Title", "", "", "", 2021, 4, 0, 100000, 0, 0, nil, false, 0, 0, time.Now(), time.Now())
+			mock.ExpectQuery(test.query).WithArgs(int64(2)).WillReturnRows(rows)
+
+			mysqlBook := repository.NewMysqlBook(db)
+			ret, err := mysqlBook.GetBooks(context.Background(), test.filter)
+
+			assert.NoError(t, err)
+			assert.Len(t, ret, 1)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetBooksByTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "publisher_id", "category_id", "title", "description", "language", "original_language", "year_of_publication", "stock", "reserved", "price", "tax_category_id", "weight_grams", "release_date", "preorder", "rating", "rating_count", "created_at", "updated_at"}).
+		AddRow(1, 1, 2, "Book Title", "", "", "", 2021, 4, 0, 100000, 0, 0, nil, false, 0, 0, time.Now(), time.Now())
+	mock.ExpectQuery("SELECT (.+) FROM books WHERE id IN \\(SELECT bt.book_id FROM book_tags (.+) HAVING COUNT\\(DISTINCT t.id\\) = \\$2\\)").
+		WithArgs(sqlmock.AnyArg(), 2).WillReturnRows(rows)
+
+	mysqlBook := repository.NewMysqlBook(db)
+	ret, err := mysqlBook.GetBooks(context.Background(), entity.BookFilter{Tags: []string{"cookbook", "travel-guide"}})
+
+	assert.NoError(t, err)
+	assert.Len(t, ret, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBooksByRating(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "publisher_id", "category_id", "title", "description", "language", "original_language", "year_of_publication", "stock", "reserved", "price", "tax_category_id", "weight_grams", "release_date", "preorder", "rating", "rating_count", "created_at", "updated_at"}).
+		AddRow(2, 1, 2, "Loved Book", "", "", "", 2021, 4, 0, 100000, 0, 0, nil, false, 4.67, 3, time.Now(), time.Now()).
+		AddRow(1, 1, 2, "Book Title", "", "", "", 2021, 4, 0, 100000, 0, 0, nil, false, 0, 0, time.Now(), time.Now())
+	mock.ExpectQuery("SELECT (.+) FROM books ORDER BY rating_total(.+) DESC NULLS LAST, rating_count DESC, id").WillReturnRows(rows)
+
+	mysqlBook := repository.NewMysqlBook(db)
+	ret, err := mysqlBook.GetBooks(context.Background(), entity.BookFilter{Sort: entity.BookSortRating})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4.67, ret[0].Rating)
+	assert.Equal(t, 3, ret[0].RatingCount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBooksBySeries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "publisher_id", "category_id", "title", "description", "language", "original_language", "year_of_publication", "stock", "reserved", "price", "tax_category_id", "weight_grams", "release_date", "preorder", "rating", "rating_count", "created_at", "updated_at"}).
+		AddRow(1, 1, 1, "The Colour of Magic", "", "", "", 1983, 4, 0, 100000, 0, 0, nil, false, 0, 0, time.Now(), time.Now()).
+		AddRow(2, 1, 1, "The Light Fantastic", "", "", "", 1986, 4, 0, 100000, 0, 0, nil, false, 0, 0, time.Now(), time.Now())
+	mock.ExpectQuery("SELECT (.+) FROM books WHERE id IN \\(SELECT book_id FROM book_series WHERE series_id=\\$1\\) ORDER BY \\(SELECT position FROM book_series WHERE book_id = books.id AND series_id=\\$1\\), id").WithArgs(int64(5)).WillReturnRows(rows)
+
+	mysqlBook := repository.NewMysqlBook(db)
+	ret, err := mysqlBook.GetBooks(context.Background(), entity.BookFilter{SeriesID: 5})
+
+	assert.NoError(t, err)
+	assert.Len(t, ret, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
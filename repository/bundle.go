@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+// bundleAvailableColumn is how many bundles the unreserved stock of their scarcest book makes up
+const bundleAvailableColumn = "COALESCE((SELECT MIN(GREATEST(b.stock - b.reserved, 0) / bi.quantity) FROM bundle_items bi JOIN books b ON b.id = bi.book_id WHERE bi.bundle_id = bundles.id), 0)"
+
+const bundleColumns = "id, title, price, " + bundleAvailableColumn + ", created_at, updated_at"
+
+type BundleRepository interface {
+	GetBundles(ctx context.Context) ([]entity.Bundle, error)
+	GetBundle(ctx context.Context, id int64) (entity.Bundle, error)
+	CreateBundle(ctx context.Context, bundle *entity.Bundle) error
+	UpdateBundle(ctx context.Context, id int64, bundle *entity.Bundle) error
+	DeleteBundle(ctx context.Context, id int64) error
+	GetBundleOrderCount(ctx context.Context, id int64) (int, error)
+}
+
+type mysqlBundle struct {
+	DB *sql.DB
+}
+
+func NewMysqlBundle(db *sql.DB) BundleRepository {
+	return &mysqlBundle{DB: db}
+}
+
+func (mb *mysqlBundle) GetBundles(ctx context.Context) ([]entity.Bundle, error) {
+	var bundles []entity.Bundle
+
+	rows, err := mb.DB.Query("SELECT " + bundleColumns + " FROM bundles ORDER BY title, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bundle entity.Bundle
+
+		err := rows.Scan(&bundle.ID, &bundle.Title, &bundle.Price, &bundle.Available, &bundle.CreatedAt, &bundle.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		bundles = append(bundles, bundle)
+	}
+
+	err = mb.attachBundleItems(bundles)
+	if err != nil {
+		return nil, err
+	}
+
+	return bundles, nil
+}
+
+func (mb *mysqlBundle) GetBundle(ctx context.Context, id int64) (entity.Bundle, error) {
+	var bundle entity.Bundle
+
+	err := mb.DB.QueryRow("SELECT "+bundleColumns+" FROM bundles WHERE id=$1", id).Scan(&bundle.ID, &bundle.Title, &bundle.Price, &bundle.Available, &bundle.CreatedAt, &bundle.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.Bundle{}, nil
+		}
+		return entity.Bundle{}, err
+	}
+
+	bundles := []entity.Bundle{bundle}
+	err = mb.attachBundleItems(bundles)
+	if err != nil {
+		return entity.Bundle{}, err
+	}
+
+	return bundles[0], nil
+}
+
+func (mb *mysqlBundle) CreateBundle(ctx context.Context, bundle *entity.Bundle) error {
+	tx, err := mb.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	startTime := time.Now()
+	bundle.CreatedAt = startTime
+	bundle.UpdatedAt = startTime
+
+	err = tx.QueryRow("INSERT INTO bundles (title, price, created_at, updated_at) VALUES($1, $2, $3, $4) RETURNING id", bundle.Title, bundle.Price, bundle.CreatedAt, bundle.UpdatedAt).Scan(&bundle.ID)
+	if err != nil {
+		return err
+	}
+
+	err = insertBundleItems(tx, bundle)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateBundle replaces a bundle and its books
+func (mb *mysqlBundle) UpdateBundle(ctx context.Context, id int64, bundle *entity.Bundle) error {
+	tx, err := mb.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	bundle.ID = id
+	bundle.UpdatedAt = time.Now()
+
+	_, err = tx.Exec("UPDATE bundles SET title=$1, price=$2, updated_at=$3 WHERE id=$4", bundle.Title, bundle.Price, bundle.UpdatedAt, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM bundle_items WHERE bundle_id=$1", id)
+	if err != nil {
+		return err
+	}
+
+	err = insertBundleItems(tx, bundle)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (mb *mysqlBundle) DeleteBundle(ctx context.Context, id int64) error {
+	stmt, err := mb.DB.Prepare("DELETE FROM bundles WHERE id=$1")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetBundleOrderCount returns the number of orders a bundle was sold in
+func (mb *mysqlBundle) GetBundleOrderCount(ctx context.Context, id int64) (int, error) {
+	var count int
+
+	err := mb.DB.QueryRow("SELECT COUNT(DISTINCT order_id) FROM order_lines WHERE bundle_id=$1", id).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (mb *mysqlBundle) attachBundleItems(bundles []entity.Bundle) error {
+	if len(bundles) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int)
+	ids := make([]int64, len(bundles))
+	for i, bundle := range bundles {
+		index[bundle.ID] = i
+		ids[i] = bundle.ID
+	}
+
+	rows, err := mb.DB.Query("SELECT bundle_id, book_id, quantity FROM bundle_items WHERE bundle_id = ANY($1) ORDER BY bundle_id, book_id", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bundleID int64
+		var item entity.BundleItem
+
+		err := rows.Scan(&bundleID, &item.BookID, &item.Quantity)
+		if err != nil {
+			return err
+		}
+
+		i := index[bundleID]
+		bundles[i].Items = append(bundles[i].Items, item)
+	}
+
+	return nil
+}
+
+func insertBundleItems(tx *sql.Tx, bundle *entity.Bundle) error {
+	for _, item := range bundle.Items {
+		_, err := tx.Exec("INSERT INTO bundle_items (bundle_id, book_id, quantity) VALUES($1, $2, $3)", bundle.ID, item.BookID, item.Quantity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	bundleRowColumns     = []string{"id", "title", "price", "available", "created_at", "updated_at"}
+	bundleItemRowColumns = []string{"bundle_id", "book_id", "quantity"}
+)
+
+func TestGetBundles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) MIN\\(GREATEST\\(b.stock - b.reserved, 0\\) / bi.quantity\\) (.+) FROM bundles ORDER BY title, id").WillReturnRows(sqlmock.NewRows(bundleRowColumns).
+		AddRow(2, "Foundation Trilogy", 250000, 4, time.Now(), time.Now()).
+		AddRow(1, "The Lord of the Rings", 300000, 0, time.Now(), time.Now()))
+	mock.ExpectQuery("SELECT (.+) FROM bundle_items WHERE bundle_id = ANY(.+)").WillReturnRows(sqlmock.NewRows(bundleItemRowColumns).
+		AddRow(1, 4, 1).
+		AddRow(1, 5, 1).
+		AddRow(2, 1, 1).
+		AddRow(2, 2, 1).
+		AddRow(2, 3, 1))
+
+	mysqlBundle := repository.NewMysqlBundle(db)
+	ret, err := mysqlBundle.GetBundles(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, ret, 2)
+	assert.Len(t, ret[0].Items, 3)
+	assert.Equal(t, 4, ret[0].Available)
+	assert.Len(t, ret[1].Items, 2)
+}
+
+func TestUpdateBundle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	bundle := entity.Bundle{Title: "Foundation Trilogy", Price: 240000, Items: []entity.BundleItem{{BookID: 1, Quantity: 1}, {BookID: 2, Quantity: 2}}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE bundles SET (.+) WHERE id(.+)").WithArgs("Foundation Trilogy", 240000, sqlmock.AnyArg(), 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM bundle_items WHERE bundle_id(.+)").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("INSERT INTO bundle_items (.+)").WithArgs(2, 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO bundle_items (.+)").WithArgs(2, 2, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mysqlBundle := repository.NewMysqlBundle(db)
+	err = mysqlBundle.UpdateBundle(context.Background(), 2, &bundle)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), bundle.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBundleOrderCount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT COUNT(.+) FROM order_lines WHERE bundle_id(.+)").WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	mysqlBundle := repository.NewMysqlBundle(db)
+	count, err := mysqlBundle.GetBundleOrderCount(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
@@ -3,17 +3,23 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
 )
 
 type CategoryRepository interface {
 	// seller
 	GetCategories(ctx context.Context) ([]entity.Category, error)
 	GetCategory(ctx context.Context, id int64) (entity.Category, error)
+	GetCategoryPath(ctx context.Context, id int64) ([]entity.Category, error)
 	CreateCategory(ctx context.Context, category *entity.Category) error
 	UpdateCategory(ctx context.Context, id int64, category *entity.Category) error
+	MoveCategory(ctx context.Context, id int64, parentID *int64) error
 	DeleteCategory(ctx context.Context, id int64) error
+	GetBookCategories(ctx context.Context, bookIDs []int64) (map[int64][]entity.BookCategory, error)
 }
 
 type mysqlCategory struct {
@@ -27,15 +33,16 @@ func NewMysqlCategory(db *sql.DB) CategoryRepository {
 func (mc *mysqlCategory) GetCategories(ctx context.Context) ([]entity.Category, error) {
 	var categories []entity.Category
 
-	rows, err := mc.DB.Query("SELECT * FROM categories")
+	rows, err := mc.DB.Query("SELECT id, parent_id, name, created_at, updated_at FROM categories ORDER BY id")
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var category entity.Category
 
-		err := rows.Scan(&category.ID, &category.Name, &category.CreatedAt, &category.UpdatedAt)
+		err := rows.Scan(&category.ID, &category.ParentID, &category.Name, &category.CreatedAt, &category.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -49,7 +56,7 @@ func (mc *mysqlCategory) GetCategories(ctx context.Context) ([]entity.Category,
 func (mc *mysqlCategory) GetCategory(ctx context.Context, id int64) (entity.Category, error) {
 	var category entity.Category
 
-	err := mc.DB.QueryRow("SELECT * FROM categories WHERE id=$1", id).Scan(&category.ID, &category.Name, &category.CreatedAt, &category.UpdatedAt)
+	err := mc.DB.QueryRow("SELECT id, parent_id, name, created_at, updated_at FROM categories WHERE id=$1", id).Scan(&category.ID, &category.ParentID, &category.Name, &category.CreatedAt, &category.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return entity.Category{}, nil
@@ -60,8 +67,37 @@ func (mc *mysqlCategory) GetCategory(ctx context.Context, id int64) (entity.Cate
 	return category, nil
 }
 
+// GetCategoryPath returns the ancestors of a category from the root down to the category itself
+func (mc *mysqlCategory) GetCategoryPath(ctx context.Context, id int64) ([]entity.Category, error) {
+	var categories []entity.Category
+
+	// the visited path stops the walk should the tree ever hold a loop
+	rows, err := mc.DB.Query(`WITH RECURSIVE ancestors AS (
+		SELECT id, parent_id, name, created_at, updated_at, 0 AS depth, ARRAY[id] AS path FROM categories WHERE id=$1
+		UNION ALL
+		SELECT c.id, c.parent_id, c.name, c.created_at, c.updated_at, a.depth + 1, a.path || c.id FROM categories c JOIN ancestors a ON c.id = a.parent_id WHERE NOT c.id = ANY(a.path)
+	) SELECT id, parent_id, name, created_at, updated_at FROM ancestors ORDER BY depth DESC`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var category entity.Category
+
+		err := rows.Scan(&category.ID, &category.ParentID, &category.Name, &category.CreatedAt, &category.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
 func (mc *mysqlCategory) CreateCategory(ctx context.Context, category *entity.Category) error {
-	stmt, err := mc.DB.Prepare("INSERT INTO categories (name, created_at, updated_at) VALUES($1, $2, $3)")
+	stmt, err := mc.DB.Prepare("INSERT INTO categories (name, parent_id, created_at, updated_at) VALUES($1, $2, $3, $4)")
 	if err != nil {
 		return err
 	}
@@ -70,7 +106,7 @@ func (mc *mysqlCategory) CreateCategory(ctx context.Context, category *entity.Ca
 	category.CreatedAt = startTime
 	category.UpdatedAt = startTime
 
-	res, err := stmt.Exec(&category.Name, &category.CreatedAt, &category.UpdatedAt)
+	res, err := stmt.Exec(&category.Name, category.ParentID, &category.CreatedAt, &category.UpdatedAt)
 	if err != nil {
 		return err
 	}
@@ -97,6 +133,39 @@ func (mc *mysqlCategory) UpdateCategory(ctx context.Context, id int64, category
 	return nil
 }
 
+// MoveCategory re-parents a category together with its whole subtree. Two moves checked
+// against the same snapshot could each pass the cycle check and loop together, so moves
+// lock the categories table against each other and run one at a time.
+func (mc *mysqlCategory) MoveCategory(ctx context.Context, id int64, parentID *int64) error {
+	tx, err := mc.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE")
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.Exec(`UPDATE categories SET parent_id=$1, updated_at=$2 WHERE id=$3 AND NOT EXISTS (
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id FROM categories WHERE id=$1
+			UNION ALL
+			SELECT c.id, c.parent_id FROM categories c JOIN ancestors a ON c.id = a.parent_id
+		) SELECT 1 FROM ancestors WHERE id=$3
+	)`, parentID, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	if row, _ := res.RowsAffected(); row == 0 {
+		return errors.New("category cannot be moved under itself or one of its descendants")
+	}
+
+	return tx.Commit()
+}
+
 func (mc *mysqlCategory) DeleteCategory(ctx context.Context, id int64) error {
 	stmt, err := mc.DB.Prepare("DELETE FROM categories WHERE id=$1")
 	if err != nil {
@@ -110,3 +179,45 @@ func (mc *mysqlCategory) DeleteCategory(ctx context.Context, id int64) error {
 
 	return nil
 }
+
+// GetBookCategories returns the categories of every given book keyed by book id, primary first
+func (mc *mysqlCategory) GetBookCategories(ctx context.Context, bookIDs []int64) (map[int64][]entity.BookCategory, error) {
+	bookCategories := make(map[int64][]entity.BookCategory)
+
+	rows, err := mc.DB.Query("SELECT bc.book_id, bc.category_id, c.name, bc.is_primary FROM book_categories bc JOIN categories c ON c.id = bc.category_id WHERE bc.book_id = ANY($1) ORDER BY bc.book_id, bc.is_primary DESC, c.name", pq.Array(bookIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookID int64
+		var category entity.BookCategory
+
+		err := rows.Scan(&bookID, &category.CategoryID, &category.Name, &category.Primary)
+		if err != nil {
+			return nil, err
+		}
+
+		bookCategories[bookID] = append(bookCategories[bookID], category)
+	}
+
+	return bookCategories, nil
+}
+
+// setBookCategories replaces the categories of a book
+func setBookCategories(tx *sql.Tx, bookID int64, categories []entity.BookCategory) error {
+	_, err := tx.Exec("DELETE FROM book_categories WHERE book_id=$1", bookID)
+	if err != nil {
+		return err
+	}
+
+	for _, category := range categories {
+		_, err = tx.Exec("INSERT INTO book_categories (book_id, category_id, is_primary) VALUES($1, $2, $3)", bookID, category.CategoryID, category.Primary)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
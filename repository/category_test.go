@@ -57,9 +57,9 @@ func TestGetCategories(t *testing.T) {
 			defer db.Close()
 
 			if !test.isError {
-				rows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"})
+				rows := sqlmock.NewRows([]string{"id", "parent_id", "name", "created_at", "updated_at"})
 				for _, row := range test.category {
-					rows.AddRow(&row.ID, &row.Name, &row.CreatedAt, &row.UpdatedAt)
+					rows.AddRow(&row.ID, row.ParentID, &row.Name, &row.CreatedAt, &row.UpdatedAt)
 				}
 
 				mock.ExpectQuery(test.query).WillReturnRows(rows)
@@ -137,7 +137,7 @@ func TestGetCategory(t *testing.T) {
 			defer db.Close()
 
 			if !test.isError {
-				rows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).AddRow(&test.category.ID, &test.category.Name, &test.category.CreatedAt, &test.category.UpdatedAt)
+				rows := sqlmock.NewRows([]string{"id", "parent_id", "name", "created_at", "updated_at"}).AddRow(&test.category.ID, test.category.ParentID, &test.category.Name, &test.category.CreatedAt, &test.category.UpdatedAt)
 				mock.ExpectQuery(test.query).WillReturnRows(rows)
 			} else {
 				mock.ExpectQuery(test.query).WillReturnError(test.err)
@@ -201,11 +201,11 @@ func TestCreateCategory(t *testing.T) {
 
 			if !test.isError {
 				mock.ExpectPrepare(test.query).
-					ExpectExec().WithArgs(&test.category.Name, &test.category.CreatedAt, &test.category.UpdatedAt).
+					ExpectExec().WithArgs(&test.category.Name, sqlmock.AnyArg(), &test.category.CreatedAt, &test.category.UpdatedAt).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			} else {
 				mock.ExpectPrepare(test.query).
-					ExpectExec().WithArgs(&test.category.Name, &test.category.CreatedAt, &test.category.UpdatedAt).
+					ExpectExec().WithArgs(&test.category.Name, sqlmock.AnyArg(), &test.category.CreatedAt, &test.category.UpdatedAt).
 					WillReturnError(test.err)
 			}
 
@@ -336,3 +336,106 @@ func TestDeleteCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCategoryPath(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	rootID := int64(1)
+	rows := sqlmock.NewRows([]string{"id", "parent_id", "name", "created_at", "updated_at"}).
+		AddRow(1, nil, "Fiction", time.Now(), time.Now()).
+		AddRow(2, rootID, "Fantasy", time.Now(), time.Now())
+	mock.ExpectQuery("WITH RECURSIVE ancestors (.+) WHERE NOT c.id = ANY\\(a.path\\)").WithArgs(2).WillReturnRows(rows)
+
+	mysqlCategory := repository.NewMysqlCategory(db)
+	ret, err := mysqlCategory.GetCategoryPath(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.Len(t, ret, 2)
+	assert.Nil(t, ret[0].ParentID)
+	assert.Equal(t, rootID, *ret[1].ParentID)
+}
+
+func TestMoveCategory(t *testing.T) {
+	parentID := int64(1)
+
+	testCases := []struct {
+		name         string
+		id           int64
+		parentID     *int64
+		rowsAffected int64
+		isError      bool
+	}{
+		{
+			name:         "success",
+			id:           3,
+			parentID:     &parentID,
+			rowsAffected: 1,
+			isError:      false,
+		},
+		{
+			name:         "success move to root",
+			id:           3,
+			parentID:     nil,
+			rowsAffected: 1,
+			isError:      false,
+		},
+		{
+			name:         "failed with cycle",
+			id:           1,
+			parentID:     &parentID,
+			rowsAffected: 0,
+			isError:      true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE").WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec("UPDATE categories SET parent_id(.+)").
+				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), test.id).
+				WillReturnResult(sqlmock.NewResult(0, test.rowsAffected))
+			if test.isError {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			mysqlCategory := repository.NewMysqlCategory(db)
+			err = mysqlCategory.MoveCategory(context.Background(), test.id, test.parentID)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetBookCategories(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"book_id", "category_id", "name", "is_primary"}).
+		AddRow(1, 3, "Cookbooks", true).
+		AddRow(1, 5, "Travel", false)
+	mock.ExpectQuery("SELECT (.+) FROM book_categories (.+)").WillReturnRows(rows)
+
+	mysqlCategory := repository.NewMysqlCategory(db)
+	ret, err := mysqlCategory.GetBookCategories(context.Background(), []int64{1})
+
+	assert.NoError(t, err)
+	assert.Len(t, ret[1], 2)
+	assert.True(t, ret[1][0].Primary)
+}
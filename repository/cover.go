@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+type CoverRepository interface {
+	GetBookCovers(ctx context.Context, bookIDs []int64) (map[int64][]entity.CoverImage, error)
+	SetBookCovers(ctx context.Context, bookID int64, covers []entity.CoverImage) error
+	DeleteBookCovers(ctx context.Context, bookID int64) error
+}
+
+type mysqlCover struct {
+	DB *sql.DB
+}
+
+func NewMysqlCover(db *sql.DB) CoverRepository {
+	return &mysqlCover{DB: db}
+}
+
+// GetBookCovers returns the cover renditions of every given book keyed by book id
+func (mc *mysqlCover) GetBookCovers(ctx context.Context, bookIDs []int64) (map[int64][]entity.CoverImage, error) {
+	covers := make(map[int64][]entity.CoverImage)
+
+	rows, err := mc.DB.Query("SELECT book_id, rendition, storage_key, content_type, width, height FROM book_covers WHERE book_id = ANY($1) ORDER BY book_id, width", pq.Array(bookIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookID int64
+		var cover entity.CoverImage
+
+		err := rows.Scan(&bookID, &cover.Rendition, &cover.Key, &cover.ContentType, &cover.Width, &cover.Height)
+		if err != nil {
+			return nil, err
+		}
+
+		covers[bookID] = append(covers[bookID], cover)
+	}
+
+	return covers, nil
+}
+
+// SetBookCovers replaces the cover renditions of a book
+func (mc *mysqlCover) SetBookCovers(ctx context.Context, bookID int64, covers []entity.CoverImage) error {
+	tx, err := mc.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM book_covers WHERE book_id=$1", bookID)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare("INSERT INTO book_covers (book_id, rendition, storage_key, content_type, width, height, created_at) VALUES($1, $2, $3, $4, $5, $6, $7)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	createdAt := time.Now()
+	for _, cover := range covers {
+		_, err = stmt.Exec(bookID, cover.Rendition, cover.Key, cover.ContentType, cover.Width, cover.Height, createdAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (mc *mysqlCover) DeleteBookCovers(ctx context.Context, bookID int64) error {
+	stmt, err := mc.DB.Prepare("DELETE FROM book_covers WHERE book_id=$1")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(bookID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
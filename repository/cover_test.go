@@ -0,0 +1,103 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBookCovers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"book_id", "rendition", "storage_key", "content_type", "width", "height"}).
+		AddRow(1, entity.RenditionThumbnail, "covers/1/a/thumbnail.jpg", "image/jpeg", 150, 225).
+		AddRow(1, entity.RenditionMedium, "covers/1/a/medium.jpg", "image/jpeg", 400, 600).
+		AddRow(2, entity.RenditionThumbnail, "covers/2/b/thumbnail.jpg", "image/jpeg", 150, 200)
+	mock.ExpectQuery("SELECT (.+) FROM book_covers (.+)").WillReturnRows(rows)
+
+	mysqlCover := repository.NewMysqlCover(db)
+	ret, err := mysqlCover.GetBookCovers(context.Background(), []int64{1, 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, ret[1], 2)
+	assert.Equal(t, "covers/2/b/thumbnail.jpg", ret[2][0].Key)
+}
+
+func TestSetBookCovers(t *testing.T) {
+	testCases := []struct {
+		name    string
+		covers  []entity.CoverImage
+		isError bool
+	}{
+		{
+			name: "success",
+			covers: []entity.CoverImage{
+				{Rendition: entity.RenditionOriginal, Key: "covers/1/a/original.png", ContentType: "image/png", Width: 1000, Height: 1500},
+				{Rendition: entity.RenditionThumbnail, Key: "covers/1/a/thumbnail.jpg", ContentType: "image/jpeg", Width: 150, Height: 225},
+			},
+			isError: false,
+		},
+		{
+			name:    "failed and rolled back",
+			covers:  []entity.CoverImage{{Rendition: entity.RenditionOriginal, Key: "covers/1/a/original.png"}},
+			isError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("DELETE FROM book_covers (.+)").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+			prep := mock.ExpectPrepare("INSERT INTO book_covers (.+)")
+			if !test.isError {
+				for _, cover := range test.covers {
+					prep.ExpectExec().WithArgs(1, cover.Rendition, cover.Key, cover.ContentType, cover.Width, cover.Height, sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				mock.ExpectCommit()
+			} else {
+				prep.ExpectExec().WillReturnError(errors.New("Dummy Error"))
+				mock.ExpectRollback()
+			}
+
+			mysqlCover := repository.NewMysqlCover(db)
+			err = mysqlCover.SetBookCovers(context.Background(), 1, test.covers)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeleteBookCovers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectPrepare("DELETE FROM book_covers (.+)").ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	mysqlCover := repository.NewMysqlCover(db)
+	err = mysqlCover.DeleteBookCovers(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
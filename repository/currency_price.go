@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+type CurrencyPriceRepository interface {
+	GetPriceList(ctx context.Context, currency string) ([]entity.CurrencyPrice, error)
+	GetCurrencyPrices(ctx context.Context, bookIDs []int64, currency string) (map[int64]entity.Money, error)
+	SetCurrencyPrice(ctx context.Context, price *entity.CurrencyPrice) error
+	DeleteCurrencyPrice(ctx context.Context, bookID int64, currency string) error
+}
+
+type mysqlCurrencyPrice struct {
+	DB *sql.DB
+}
+
+func NewMysqlCurrencyPrice(db *sql.DB) CurrencyPriceRepository {
+	return &mysqlCurrencyPrice{DB: db}
+}
+
+// GetPriceList returns the prices set for books in a currency
+func (mc *mysqlCurrencyPrice) GetPriceList(ctx context.Context, currency string) ([]entity.CurrencyPrice, error) {
+	var prices []entity.CurrencyPrice
+
+	rows, err := mc.DB.Query("SELECT book_id, price, currency, created_at, updated_at FROM book_currency_prices WHERE currency=$1 ORDER BY book_id", currency)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var price entity.CurrencyPrice
+
+		err := rows.Scan(&price.BookID, &price.Price.Amount, &price.Price.Currency, &price.CreatedAt, &price.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		prices = append(prices, price)
+	}
+
+	return prices, nil
+}
+
+// GetCurrencyPrices returns the prices of books in a currency keyed by book, books missing from
+// the currency's price list are missing from the map
+func (mc *mysqlCurrencyPrice) GetCurrencyPrices(ctx context.Context, bookIDs []int64, currency string) (map[int64]entity.Money, error) {
+	prices := make(map[int64]entity.Money)
+	if len(bookIDs) == 0 {
+		return prices, nil
+	}
+
+	rows, err := mc.DB.Query("SELECT book_id, price FROM book_currency_prices WHERE book_id = ANY($1) AND currency=$2", pq.Array(bookIDs), currency)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookID int64
+		price := entity.Money{Currency: currency}
+
+		err := rows.Scan(&bookID, &price.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		prices[bookID] = price
+	}
+
+	return prices, nil
+}
+
+// SetCurrencyPrice adds the book to the price list of the currency or changes its price there
+func (mc *mysqlCurrencyPrice) SetCurrencyPrice(ctx context.Context, price *entity.CurrencyPrice) error {
+	stmt, err := mc.DB.Prepare("INSERT INTO book_currency_prices (book_id, currency, price, created_at, updated_at) VALUES($1, $2, $3, $4, $4) ON CONFLICT (book_id, currency) DO UPDATE SET price=EXCLUDED.price, updated_at=EXCLUDED.updated_at RETURNING created_at, updated_at")
+	if err != nil {
+		return err
+	}
+
+	err = stmt.QueryRow(price.BookID, price.Price.Currency, price.Price.Amount, time.Now()).Scan(&price.CreatedAt, &price.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mc *mysqlCurrencyPrice) DeleteCurrencyPrice(ctx context.Context, bookID int64, currency string) error {
+	stmt, err := mc.DB.Prepare("DELETE FROM book_currency_prices WHERE book_id=$1 AND currency=$2")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(bookID, currency)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
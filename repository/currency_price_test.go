@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCurrencyPrices(t *testing.T) {
+	testCases := []struct {
+		name    string
+		ids     []int64
+		isError bool
+		err     error
+	}{
+		{name: "success", ids: []int64{1, 2}},
+		{name: "no books", ids: nil},
+		{name: "failed", ids: []int64{1}, isError: true, err: errors.New("Dummy Error")},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			if len(test.ids) > 0 {
+				query := mock.ExpectQuery("SELECT book_id, price FROM book_currency_prices WHERE book_id = ANY(.+) AND currency=(.+)").WithArgs(pq.Array(test.ids), "USD")
+				if test.isError {
+					query.WillReturnError(test.err)
+				} else {
+					query.WillReturnRows(sqlmock.NewRows([]string{"book_id", "price"}).AddRow(1, 1299))
+				}
+			}
+
+			mysqlCurrencyPrice := repository.NewMysqlCurrencyPrice(db)
+			ret, err := mysqlCurrencyPrice.GetCurrencyPrices(context.Background(), test.ids, "USD")
+
+			assert.Equal(t, test.isError, err != nil)
+			if len(test.ids) > 1 {
+				assert.Equal(t, map[int64]entity.Money{1: {Amount: 1299, Currency: "USD"}}, ret)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSetCurrencyPrice(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	created := time.Now().Add(-time.Hour)
+	mock.ExpectPrepare("INSERT INTO book_currency_prices (.+) ON CONFLICT \\(book_id, currency\\) DO UPDATE").ExpectQuery().
+		WithArgs(1, "USD", 1299, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(created, time.Now()))
+
+	price := entity.CurrencyPrice{BookID: 1, Price: entity.Money{Amount: 1299, Currency: "USD"}}
+	mysqlCurrencyPrice := repository.NewMysqlCurrencyPrice(db)
+	err = mysqlCurrencyPrice.SetCurrencyPrice(context.Background(), &price)
+
+	assert.NoError(t, err)
+	assert.Equal(t, created, price.CreatedAt)
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+const invoiceColumns = "id, order_id, kind, number, fiscal_year, sequence, COALESCE(credited_invoice_id, 0), customer_email, shipping_country, shipping_region, currency, prices_include_tax, subtotal, tax, shipping, total, source, issued_at, created_at"
+
+// ErrDuplicateInvoice is returned when an invoice is issued for a source that already has one
+var ErrDuplicateInvoice = errors.New("an invoice was already issued for this source")
+
+// invoicePrefixes start the numbers of every kind of invoice
+var invoicePrefixes = map[string]string{
+	entity.InvoiceKindInvoice:    "INV",
+	entity.InvoiceKindCreditNote: "CN",
+}
+
+type InvoiceRepository interface {
+	GetInvoice(ctx context.Context, id int64) (entity.Invoice, error)
+	GetOrderInvoices(ctx context.Context, orderID int64) ([]entity.Invoice, error)
+	CreateInvoice(ctx context.Context, invoice *entity.Invoice) error
+	GetUninvoicedOrders(ctx context.Context) ([]int64, error)
+}
+
+type mysqlInvoice struct {
+	DB *sql.DB
+}
+
+func NewMysqlInvoice(db *sql.DB) InvoiceRepository {
+	return &mysqlInvoice{DB: db}
+}
+
+func (mi *mysqlInvoice) GetInvoice(ctx context.Context, id int64) (entity.Invoice, error) {
+	invoices, err := mi.getInvoices("SELECT "+invoiceColumns+" FROM invoices WHERE id=$1", id)
+	if err != nil {
+		return entity.Invoice{}, err
+	}
+
+	if len(invoices) == 0 {
+		return entity.Invoice{}, nil
+	}
+
+	return invoices[0], nil
+}
+
+func (mi *mysqlInvoice) GetOrderInvoices(ctx context.Context, orderID int64) ([]entity.Invoice, error) {
+	return mi.getInvoices("SELECT "+invoiceColumns+" FROM invoices WHERE order_id=$1 ORDER BY id", orderID)
+}
+
+// CreateInvoice numbers and stores an invoice with its lines. The number is taken from the
+// sequence of its kind and fiscal year in the same transaction, which holds the sequence row
+// until it commits, so a failed invoice gives its number back and numbers have no gaps.
+func (mi *mysqlInvoice) CreateInvoice(ctx context.Context, invoice *entity.Invoice) error {
+	tx, err := mi.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow("INSERT INTO invoice_sequences (kind, fiscal_year, last_sequence) VALUES($1, $2, 1) ON CONFLICT (kind, fiscal_year) DO UPDATE SET last_sequence = invoice_sequences.last_sequence + 1 RETURNING last_sequence",
+		invoice.Kind, invoice.FiscalYear).Scan(&invoice.Sequence)
+	if err != nil {
+		return err
+	}
+
+	// issuing for the same source waits on the sequence row above, so this sees the invoice
+	// a concurrent request issued
+	var issued bool
+	err = tx.QueryRow("SELECT EXISTS (SELECT 1 FROM invoices WHERE source=$1)", invoice.Source).Scan(&issued)
+	if err != nil {
+		return err
+	}
+
+	if issued {
+		return ErrDuplicateInvoice
+	}
+
+	invoice.Number = fmt.Sprintf("%s-%d-%06d", invoicePrefixes[invoice.Kind], invoice.FiscalYear, invoice.Sequence)
+	invoice.CreatedAt = time.Now()
+
+	err = tx.QueryRow("INSERT INTO invoices (order_id, kind, number, fiscal_year, sequence, credited_invoice_id, customer_email, shipping_country, shipping_region, currency, prices_include_tax, subtotal, tax, shipping, total, source, issued_at, created_at) VALUES($1, $2, $3, $4, $5, NULLIF($6, 0), $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18) RETURNING id",
+		invoice.OrderID, invoice.Kind, invoice.Number, invoice.FiscalYear, invoice.Sequence, invoice.CreditedInvoiceID, invoice.CustomerEmail, invoice.ShippingCountry, invoice.ShippingRegion, invoice.Currency, invoice.PricesIncludeTax,
+		invoice.Subtotal, invoice.Tax, invoice.Shipping, invoice.Total, invoice.Source, invoice.IssuedAt, invoice.CreatedAt).Scan(&invoice.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := range invoice.Lines {
+		line := &invoice.Lines[i]
+		line.InvoiceID = invoice.ID
+
+		err = tx.QueryRow("INSERT INTO invoice_lines (invoice_id, book_id, description, quantity, unit_price, discount, tax, amount) VALUES($1, NULLIF($2, 0), $3, $4, $5, $6, $7, $8) RETURNING id",
+			line.InvoiceID, line.BookID, line.Description, line.Quantity, line.UnitPrice, line.Discount, line.Tax, line.Amount).Scan(&line.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, tax := range invoice.TaxLines {
+		_, err = tx.Exec("INSERT INTO invoice_tax_lines (invoice_id, name, rate, taxable, amount) VALUES($1, $2, $3, $4, $5)", invoice.ID, tax.Name, tax.Rate, tax.Taxable, tax.Amount)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetUninvoicedOrders returns the orders with a captured payment that have no invoice yet, or
+// that were refunded more than their credit notes credit
+func (mi *mysqlInvoice) GetUninvoicedOrders(ctx context.Context) ([]int64, error) {
+	var ids []int64
+
+	rows, err := mi.DB.Query(`SELECT p.order_id FROM payments p WHERE p.status IN ($1, $2) GROUP BY p.order_id
+		HAVING NOT EXISTS (SELECT 1 FROM invoices i WHERE i.order_id = p.order_id AND i.kind = $3)
+		OR SUM(p.refunded_amount) > COALESCE((SELECT SUM(i.total) FROM invoices i WHERE i.order_id = p.order_id AND i.kind = $4), 0)
+		ORDER BY p.order_id`, entity.PaymentCaptured, entity.PaymentRefunded, entity.InvoiceKindInvoice, entity.InvoiceKindCreditNote)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (mi *mysqlInvoice) getInvoices(query string, args ...interface{}) ([]entity.Invoice, error) {
+	var invoices []entity.Invoice
+
+	rows, err := mi.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var invoice entity.Invoice
+
+		err := rows.Scan(&invoice.ID, &invoice.OrderID, &invoice.Kind, &invoice.Number, &invoice.FiscalYear, &invoice.Sequence, &invoice.CreditedInvoiceID, &invoice.CustomerEmail, &invoice.ShippingCountry, &invoice.ShippingRegion, &invoice.Currency, &invoice.PricesIncludeTax,
+			&invoice.Subtotal, &invoice.Tax, &invoice.Shipping, &invoice.Total, &invoice.Source, &invoice.IssuedAt, &invoice.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		invoices = append(invoices, invoice)
+	}
+
+	err = mi.attachInvoiceLines(invoices)
+	if err != nil {
+		return nil, err
+	}
+
+	return invoices, nil
+}
+
+func (mi *mysqlInvoice) attachInvoiceLines(invoices []entity.Invoice) error {
+	if len(invoices) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int)
+	ids := make([]int64, len(invoices))
+	for i, invoice := range invoices {
+		index[invoice.ID] = i
+		ids[i] = invoice.ID
+	}
+
+	rows, err := mi.DB.Query("SELECT id, invoice_id, COALESCE(book_id, 0), description, quantity, unit_price, discount, tax, amount FROM invoice_lines WHERE invoice_id = ANY($1) ORDER BY invoice_id, id", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line entity.InvoiceLine
+
+		err := rows.Scan(&line.ID, &line.InvoiceID, &line.BookID, &line.Description, &line.Quantity, &line.UnitPrice, &line.Discount, &line.Tax, &line.Amount)
+		if err != nil {
+			return err
+		}
+
+		i := index[line.InvoiceID]
+		invoices[i].Lines = append(invoices[i].Lines, line)
+	}
+
+	taxes, err := mi.DB.Query("SELECT invoice_id, name, rate, taxable, amount FROM invoice_tax_lines WHERE invoice_id = ANY($1) ORDER BY invoice_id, id", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer taxes.Close()
+
+	for taxes.Next() {
+		var invoiceID int64
+		var tax entity.TaxLine
+
+		err := taxes.Scan(&invoiceID, &tax.Name, &tax.Rate, &tax.Taxable, &tax.Amount)
+		if err != nil {
+			return err
+		}
+
+		i := index[invoiceID]
+		invoices[i].TaxLines = append(invoices[i].TaxLines, tax)
+	}
+
+	return nil
+}
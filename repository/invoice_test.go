@@ -0,0 +1,89 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateInvoice(t *testing.T) {
+	testCases := []struct {
+		name       string
+		kind       string
+		sequence   int
+		issued     bool
+		wantNumber string
+		wantErr    error
+	}{
+		{name: "first invoice of the year", kind: entity.InvoiceKindInvoice, sequence: 1, wantNumber: "INV-2026-000001"},
+		{name: "credit note", kind: entity.InvoiceKindCreditNote, sequence: 12, wantNumber: "CN-2026-000012"},
+		{name: "issued meanwhile", kind: entity.InvoiceKindInvoice, sequence: 2, issued: true, wantErr: repository.ErrDuplicateInvoice},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("INSERT INTO invoice_sequences (.+) ON CONFLICT (.+) RETURNING last_sequence").WithArgs(test.kind, 2026).
+				WillReturnRows(sqlmock.NewRows([]string{"last_sequence"}).AddRow(test.sequence))
+			mock.ExpectQuery("SELECT EXISTS (.+) FROM invoices WHERE source(.+)").WithArgs("order-1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(test.issued))
+			if test.wantErr == nil {
+				mock.ExpectQuery("INSERT INTO invoices (.+)").WithArgs(1, test.kind, test.wantNumber, 2026, test.sequence, 0, "buyer@mail.com", "ID", "", "IDR", false, 90000, 9900, 10000, 109900, "order-1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				mock.ExpectQuery("INSERT INTO invoice_lines (.+)").WithArgs(4, 5, "Go Programming", 2, 50000, 10000, 9900, 90000).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+				mock.ExpectExec("INSERT INTO invoice_tax_lines (.+)").WithArgs(4, "VAT", 1100, 90000, 9900).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			invoice := entity.Invoice{
+				OrderID: 1, Kind: test.kind, FiscalYear: 2026, CustomerEmail: "buyer@mail.com", ShippingCountry: "ID", Currency: "IDR",
+				Lines:    []entity.InvoiceLine{{BookID: 5, Description: "Go Programming", Quantity: 2, UnitPrice: 50000, Discount: 10000, Tax: 9900, Amount: 90000}},
+				TaxLines: []entity.TaxLine{{Name: "VAT", Rate: 1100, Taxable: 90000, Amount: 9900}},
+				Subtotal: 90000, Tax: 9900, Shipping: 10000, Total: 109900, Source: "order-1", IssuedAt: time.Now(),
+			}
+			mysqlInvoice := repository.NewMysqlInvoice(db)
+			err = mysqlInvoice.CreateInvoice(context.Background(), &invoice)
+
+			assert.Equal(t, test.wantErr, err)
+			if test.wantErr == nil {
+				assert.Equal(t, int64(4), invoice.ID)
+				assert.Equal(t, test.wantNumber, invoice.Number)
+				assert.Equal(t, int64(8), invoice.Lines[0].ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetUninvoicedOrders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT p.order_id FROM payments p (.+) GROUP BY p.order_id HAVING (.+)").
+		WithArgs(entity.PaymentCaptured, entity.PaymentRefunded, entity.InvoiceKindInvoice, entity.InvoiceKindCreditNote).
+		WillReturnRows(sqlmock.NewRows([]string{"order_id"}).AddRow(1).AddRow(3))
+
+	mysqlInvoice := repository.NewMysqlInvoice(db)
+	ids, err := mysqlInvoice.GetUninvoicedOrders(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 3}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
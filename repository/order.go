@@ -0,0 +1,637 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+// preOrderedBookQuantity is what the waiting pre-orders need of the book updated, digital
+// editions take no stock
+const preOrderedBookQuantity = "(SELECT COALESCE(SUM(ol.quantity), 0) FROM order_lines ol JOIN orders o ON o.id = ol.order_id LEFT JOIN skus s ON s.id = ol.sku_id WHERE ol.book_id = books.id AND o.status = $3 AND COALESCE(s.format <> ALL($4), true))"
+
+const orderColumns = "id, customer_email, status, shipping_latitude, shipping_longitude, shipping_country, shipping_region, prices_include_tax, preorder, COALESCE(shipping_method_id, 0), shipping_cost, refunded_total, expires_at, created_at, updated_at"
+
+var (
+	// ErrOrderNotPending is returned when an order is confirmed or cancelled after it left the pending state
+	ErrOrderNotPending = errors.New("order is no longer pending")
+	// ErrOrderNotPreOrdered is returned when a pre-order is fulfilled after it left the preordered state
+	ErrOrderNotPreOrdered = errors.New("order is no longer a waiting pre-order")
+)
+
+type OrderRepository interface {
+	GetOrders(ctx context.Context) ([]entity.Order, error)
+	GetOrder(ctx context.Context, id int64) (entity.Order, error)
+	CreateOrder(ctx context.Context, order *entity.Order) error
+	ConfirmOrder(ctx context.Context, id int64, actor string) error
+	CancelOrder(ctx context.Context, id int64) error
+	ExpireOrders(ctx context.Context, now time.Time) (int64, error)
+	GetPreOrders(ctx context.Context) ([]entity.Order, error)
+	FulfillPreOrder(ctx context.Context, order *entity.Order, actor string) error
+}
+
+type mysqlOrder struct {
+	DB *sql.DB
+}
+
+func NewMysqlOrder(db *sql.DB) OrderRepository {
+	return &mysqlOrder{DB: db}
+}
+
+func (mo *mysqlOrder) GetOrders(ctx context.Context) ([]entity.Order, error) {
+	return mo.getOrders("SELECT " + orderColumns + " FROM orders ORDER BY id DESC")
+}
+
+// GetPreOrders lists the pre-orders waiting for their stock in the order they were placed
+func (mo *mysqlOrder) GetPreOrders(ctx context.Context) ([]entity.Order, error) {
+	return mo.getOrders("SELECT "+orderColumns+" FROM orders WHERE status=$1 ORDER BY created_at, id", entity.OrderPreordered)
+}
+
+func (mo *mysqlOrder) getOrders(query string, args ...interface{}) ([]entity.Order, error) {
+	var orders []entity.Order
+
+	rows, err := mo.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var order entity.Order
+
+		err := rows.Scan(&order.ID, &order.CustomerEmail, &order.Status, &order.ShippingLatitude, &order.ShippingLongitude, &order.ShippingCountry, &order.ShippingRegion, &order.PricesIncludeTax, &order.PreOrder, &order.ShippingMethodID, &order.ShippingCost, &order.RefundedTotal, &order.ExpiresAt, &order.CreatedAt, &order.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, order)
+	}
+
+	err = mo.attachOrderLines(orders)
+	if err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (mo *mysqlOrder) GetOrder(ctx context.Context, id int64) (entity.Order, error) {
+	var order entity.Order
+
+	err := mo.DB.QueryRow("SELECT "+orderColumns+" FROM orders WHERE id=$1", id).Scan(&order.ID, &order.CustomerEmail, &order.Status, &order.ShippingLatitude, &order.ShippingLongitude, &order.ShippingCountry, &order.ShippingRegion, &order.PricesIncludeTax, &order.PreOrder, &order.ShippingMethodID, &order.ShippingCost, &order.RefundedTotal, &order.ExpiresAt, &order.CreatedAt, &order.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.Order{}, nil
+		}
+		return entity.Order{}, err
+	}
+
+	orders := []entity.Order{order}
+	err = mo.attachOrderLines(orders)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	return orders[0], nil
+}
+
+// CreateOrder stores a pending order and reserves the stock of every line, and of its edition,
+// in the warehouses it is allocated to. The reservation is an atomic conditional update so
+// concurrent checkouts can never hold more than the stock, less what waiting pre-orders need.
+func (mo *mysqlOrder) CreateOrder(ctx context.Context, order *entity.Order) error {
+	tx, err := mo.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	startTime := time.Now()
+	order.CreatedAt = startTime
+	order.UpdatedAt = startTime
+
+	err = tx.QueryRow("INSERT INTO orders (customer_email, status, shipping_latitude, shipping_longitude, shipping_country, shipping_region, prices_include_tax, preorder, shipping_method_id, shipping_cost, expires_at, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, 0), $10, $11, $12, $13) RETURNING id",
+		order.CustomerEmail, order.Status, order.ShippingLatitude, order.ShippingLongitude, order.ShippingCountry, order.ShippingRegion, order.PricesIncludeTax, order.PreOrder, order.ShippingMethodID, order.ShippingCost, order.ExpiresAt, order.CreatedAt, order.UpdatedAt).Scan(&order.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := range order.Lines {
+		line := &order.Lines[i]
+		line.OrderID = order.ID
+
+		err = tx.QueryRow("INSERT INTO order_lines (order_id, book_id, sku_id, bundle_id, quantity, unit_price, discount, tax) VALUES($1, $2, NULLIF($3, 0), NULLIF($4, 0), $5, $6, $7, $8) RETURNING id", order.ID, line.BookID, line.SKUID, line.BundleID, line.Quantity, line.UnitPrice, line.Discount, line.Tax).Scan(&line.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, allocation := range line.Allocations {
+			res, err := tx.Exec("UPDATE warehouse_stocks SET reserved = reserved + $1 WHERE warehouse_id=$2 AND book_id=$3 AND stock - reserved >= $1", allocation.Quantity, allocation.WarehouseID, line.BookID)
+			if err != nil {
+				return err
+			}
+
+			if affected, err := res.RowsAffected(); err != nil {
+				return err
+			} else if affected == 0 {
+				return fmt.Errorf("book ID %d: %w", line.BookID, ErrInsufficientStock)
+			}
+
+			if line.SKUID != 0 {
+				res, err := tx.Exec("UPDATE warehouse_sku_stocks SET reserved = reserved + $1 WHERE warehouse_id=$2 AND sku_id=$3 AND stock - reserved >= $1", allocation.Quantity, allocation.WarehouseID, line.SKUID)
+				if err != nil {
+					return err
+				}
+
+				if affected, err := res.RowsAffected(); err != nil {
+					return err
+				} else if affected == 0 {
+					return fmt.Errorf("SKU ID %d: %w", line.SKUID, ErrInsufficientStock)
+				}
+
+				res, err = tx.Exec("UPDATE skus SET reserved = reserved + $1 WHERE id=$2 AND stock - reserved - (SELECT COALESCE(SUM(ol.quantity), 0) FROM order_lines ol JOIN orders o ON o.id = ol.order_id WHERE ol.sku_id = skus.id AND o.status = $3) >= $1",
+					allocation.Quantity, line.SKUID, entity.OrderPreordered)
+				if err != nil {
+					return err
+				}
+
+				if affected, err := res.RowsAffected(); err != nil {
+					return err
+				} else if affected == 0 {
+					return fmt.Errorf("SKU ID %d: %w", line.SKUID, ErrInsufficientStock)
+				}
+			}
+
+			res, err = tx.Exec("UPDATE books SET reserved = reserved + $1 WHERE id=$2 AND stock - reserved - "+preOrderedBookQuantity+" >= $1",
+				allocation.Quantity, line.BookID, entity.OrderPreordered, pq.Array([]string{entity.FormatEbook, entity.FormatAudiobook}))
+			if err != nil {
+				return err
+			}
+
+			if affected, err := res.RowsAffected(); err != nil {
+				return err
+			} else if affected == 0 {
+				return fmt.Errorf("book ID %d: %w", line.BookID, ErrInsufficientStock)
+			}
+
+			_, err = tx.Exec("INSERT INTO order_allocations (order_line_id, warehouse_id, quantity) VALUES($1, $2, $3)", line.ID, allocation.WarehouseID, allocation.Quantity)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Exec("INSERT INTO stock_reservations (order_id, book_id, sku_id, warehouse_id, quantity, expires_at, created_at) VALUES($1, $2, NULLIF($3, 0), $4, $5, $6, $7)", order.ID, line.BookID, line.SKUID, allocation.WarehouseID, allocation.Quantity, order.ExpiresAt, startTime)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, tax := range order.TaxLines {
+		_, err = tx.Exec("INSERT INTO order_tax_lines (order_id, name, rate, taxable, amount) VALUES($1, $2, $3, $4, $5)", order.ID, tax.Name, tax.Rate, tax.Taxable, tax.Amount)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, applied := range order.Promotions {
+		err = redeemPromotion(tx, order, applied)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ConfirmOrder turns the reservations of a pending order into sales recorded in the stock ledger.
+// A pre-order has nothing reserved, it is preordered until FulfillPreOrder allocates its stock.
+func (mo *mysqlOrder) ConfirmOrder(ctx context.Context, id int64, actor string) error {
+	tx, err := mo.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	var preorder bool
+	err = tx.QueryRow("UPDATE orders SET status = CASE WHEN preorder THEN $1 ELSE $2 END, updated_at=$3 WHERE id=$4 AND status=$5 AND expires_at > $3 RETURNING preorder",
+		entity.OrderPreordered, entity.OrderConfirmed, now, id, entity.OrderPending).Scan(&preorder)
+	if err == sql.ErrNoRows {
+		return ErrOrderNotPending
+	}
+	if err != nil {
+		return err
+	}
+
+	if preorder {
+		return tx.Commit()
+	}
+
+	reservations, err := orderReservations(tx, id)
+	if err != nil {
+		return err
+	}
+
+	for _, reservation := range reservations {
+		res, err := tx.Exec("UPDATE warehouse_stocks SET stock = stock - $1, reserved = reserved - $1 WHERE warehouse_id=$2 AND book_id=$3 AND stock >= $1", reservation.quantity, reservation.warehouseID, reservation.bookID)
+		if err != nil {
+			return err
+		}
+
+		if affected, err := res.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return fmt.Errorf("book ID %d: %w", reservation.bookID, ErrInsufficientStock)
+		}
+
+		if reservation.skuID != 0 {
+			res, err := tx.Exec("UPDATE warehouse_sku_stocks SET stock = stock - $1, reserved = reserved - $1 WHERE warehouse_id=$2 AND sku_id=$3 AND stock >= $1", reservation.quantity, reservation.warehouseID, reservation.skuID)
+			if err != nil {
+				return err
+			}
+
+			if affected, err := res.RowsAffected(); err != nil {
+				return err
+			} else if affected == 0 {
+				return fmt.Errorf("SKU ID %d: %w", reservation.skuID, ErrInsufficientStock)
+			}
+
+			_, err = tx.Exec("UPDATE skus SET stock = stock - $1, reserved = reserved - $1, updated_at=$2 WHERE id=$3", reservation.quantity, now, reservation.skuID)
+			if err != nil {
+				return err
+			}
+		}
+
+		movement := entity.StockMovement{BookID: reservation.bookID, SKUID: reservation.skuID, WarehouseID: reservation.warehouseID, Type: entity.MovementSale, Quantity: -reservation.quantity, Reason: fmt.Sprintf("order #%d", id), Actor: actor, CreatedAt: now}
+		err = tx.QueryRow("UPDATE books SET stock = stock - $1, reserved = reserved - $1, updated_at=$2 WHERE id=$3 RETURNING stock", reservation.quantity, now, reservation.bookID).Scan(&movement.StockAfter)
+		if err != nil {
+			return err
+		}
+
+		err = insertStockMovement(tx, &movement)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec("DELETE FROM stock_reservations WHERE order_id=$1", id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (mo *mysqlOrder) CancelOrder(ctx context.Context, id int64) error {
+	tx, err := mo.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = setOrderStatus(tx, id, entity.OrderCancelled, time.Now())
+	if err != nil {
+		return err
+	}
+
+	err = releaseReservations(tx, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ExpireOrders releases the reservations of every pending order that expired before now. Orders
+// locked by a concurrent confirmation are skipped and picked up by the next run if still pending.
+func (mo *mysqlOrder) ExpireOrders(ctx context.Context, now time.Time) (int64, error) {
+	tx, err := mo.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query("SELECT id FROM orders WHERE status=$1 AND expires_at <= $2 ORDER BY id FOR UPDATE SKIP LOCKED", entity.OrderPending, now)
+	if err != nil {
+		return 0, err
+	}
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	for _, id := range ids {
+		_, err := tx.Exec("UPDATE orders SET status=$1, updated_at=$2 WHERE id=$3", entity.OrderExpired, now, id)
+		if err != nil {
+			return 0, err
+		}
+
+		err = releaseReservations(tx, id)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return int64(len(ids)), tx.Commit()
+}
+
+// FulfillPreOrder sells the stock allocated to the lines of a preordered order and confirms it.
+// Stock is taken with atomic conditional updates so it is never sold twice, ErrInsufficientStock
+// is returned when it was taken meanwhile. Rows are locked by book and warehouse like the
+// reservations of other orders so concurrent transactions cannot deadlock.
+func (mo *mysqlOrder) FulfillPreOrder(ctx context.Context, order *entity.Order, actor string) error {
+	tx, err := mo.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	res, err := tx.Exec("UPDATE orders SET status=$1, updated_at=$2 WHERE id=$3 AND status=$4", entity.OrderConfirmed, now, order.ID, entity.OrderPreordered)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := res.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrOrderNotPreOrdered
+	}
+
+	var allocations []lineAllocation
+	for _, line := range order.Lines {
+		for _, allocation := range line.Allocations {
+			allocations = append(allocations, lineAllocation{lineID: line.ID, bookID: line.BookID, skuID: line.SKUID, warehouseID: allocation.WarehouseID, quantity: allocation.Quantity})
+		}
+	}
+
+	sort.Slice(allocations, func(i, j int) bool {
+		a, b := allocations[i], allocations[j]
+		if a.bookID != b.bookID {
+			return a.bookID < b.bookID
+		}
+		if a.warehouseID != b.warehouseID {
+			return a.warehouseID < b.warehouseID
+		}
+		return a.skuID < b.skuID
+	})
+
+	for _, allocation := range allocations {
+		res, err := tx.Exec("UPDATE warehouse_stocks SET stock = stock - $1 WHERE warehouse_id=$2 AND book_id=$3 AND stock - reserved >= $1", allocation.quantity, allocation.warehouseID, allocation.bookID)
+		if err != nil {
+			return err
+		}
+
+		if affected, err := res.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return fmt.Errorf("book ID %d: %w", allocation.bookID, ErrInsufficientStock)
+		}
+
+		if allocation.skuID != 0 {
+			err = changeWarehouseSKUStock(tx, allocation.warehouseID, allocation.skuID, -allocation.quantity)
+			if err != nil {
+				return fmt.Errorf("SKU ID %d: %w", allocation.skuID, err)
+			}
+
+			err = changeSKUStock(tx, allocation.skuID, allocation.bookID, -allocation.quantity, now)
+			if err != nil {
+				return fmt.Errorf("SKU ID %d: %w", allocation.skuID, err)
+			}
+		}
+
+		movement := entity.StockMovement{BookID: allocation.bookID, SKUID: allocation.skuID, WarehouseID: allocation.warehouseID, Type: entity.MovementSale, Quantity: -allocation.quantity, Reason: fmt.Sprintf("order #%d", order.ID), Actor: actor, CreatedAt: now}
+		err = tx.QueryRow("UPDATE books SET stock = stock - $1, updated_at=$2 WHERE id=$3 AND stock - reserved >= $1 RETURNING stock", allocation.quantity, now, allocation.bookID).Scan(&movement.StockAfter)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("book ID %d: %w", allocation.bookID, ErrInsufficientStock)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = insertStockMovement(tx, &movement)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec("INSERT INTO order_allocations (order_line_id, warehouse_id, quantity) VALUES($1, $2, $3)", allocation.lineID, allocation.warehouseID, allocation.quantity)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	order.Status, order.UpdatedAt = entity.OrderConfirmed, now
+	return nil
+}
+
+func (mo *mysqlOrder) attachOrderLines(orders []entity.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int)
+	ids := make([]int64, len(orders))
+	for i, order := range orders {
+		index[order.ID] = i
+		ids[i] = order.ID
+	}
+
+	rows, err := mo.DB.Query("SELECT id, order_id, book_id, COALESCE(sku_id, 0), COALESCE(bundle_id, 0), quantity, unit_price, discount, tax FROM order_lines WHERE order_id = ANY($1) ORDER BY order_id, id", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	lines := make(map[int64]*entity.OrderLine)
+	for rows.Next() {
+		var line entity.OrderLine
+
+		err := rows.Scan(&line.ID, &line.OrderID, &line.BookID, &line.SKUID, &line.BundleID, &line.Quantity, &line.UnitPrice, &line.Discount, &line.Tax)
+		if err != nil {
+			return err
+		}
+
+		i := index[line.OrderID]
+		orders[i].Lines = append(orders[i].Lines, line)
+	}
+
+	for i := range orders {
+		for j := range orders[i].Lines {
+			lines[orders[i].Lines[j].ID] = &orders[i].Lines[j]
+		}
+	}
+
+	allocations, err := mo.DB.Query("SELECT a.order_line_id, a.warehouse_id, a.quantity FROM order_allocations a JOIN order_lines l ON l.id = a.order_line_id WHERE l.order_id = ANY($1) ORDER BY a.order_line_id, a.warehouse_id", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer allocations.Close()
+
+	for allocations.Next() {
+		var lineID int64
+		var allocation entity.Allocation
+
+		err := allocations.Scan(&lineID, &allocation.WarehouseID, &allocation.Quantity)
+		if err != nil {
+			return err
+		}
+
+		if line, ok := lines[lineID]; ok {
+			line.Allocations = append(line.Allocations, allocation)
+		}
+	}
+
+	promotions, err := mo.DB.Query("SELECT order_id, promotion_id, name, coupon_code, description, discount FROM promotion_redemptions WHERE order_id = ANY($1) ORDER BY order_id, id", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer promotions.Close()
+
+	for promotions.Next() {
+		var orderID int64
+		var promotionID sql.NullInt64
+		var applied entity.AppliedPromotion
+
+		err := promotions.Scan(&orderID, &promotionID, &applied.Name, &applied.CouponCode, &applied.Description, &applied.Discount)
+		if err != nil {
+			return err
+		}
+
+		applied.PromotionID = promotionID.Int64
+		i := index[orderID]
+		orders[i].Promotions = append(orders[i].Promotions, applied)
+	}
+
+	taxes, err := mo.DB.Query("SELECT order_id, name, rate, taxable, amount FROM order_tax_lines WHERE order_id = ANY($1) ORDER BY order_id, id", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer taxes.Close()
+
+	for taxes.Next() {
+		var orderID int64
+		var tax entity.TaxLine
+
+		err := taxes.Scan(&orderID, &tax.Name, &tax.Rate, &tax.Taxable, &tax.Amount)
+		if err != nil {
+			return err
+		}
+
+		i := index[orderID]
+		orders[i].TaxLines = append(orders[i].TaxLines, tax)
+	}
+
+	return nil
+}
+
+// setOrderStatus moves a pending order to status, pending orders past their expiry can only expire
+func setOrderStatus(tx *sql.Tx, id int64, status string, now time.Time) error {
+	res, err := tx.Exec("UPDATE orders SET status=$1, updated_at=$2 WHERE id=$3 AND status=$4 AND expires_at > $2", status, now, id, entity.OrderPending)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrOrderNotPending
+	}
+
+	return nil
+}
+
+type reservation struct {
+	bookID      int64
+	skuID       int64
+	warehouseID int64
+	quantity    int
+}
+
+// lineAllocation is the part of an order line taken from one warehouse
+type lineAllocation struct {
+	lineID      int64
+	bookID      int64
+	skuID       int64
+	warehouseID int64
+	quantity    int
+}
+
+// orderReservations returns the reservations of an order ordered by book and warehouse so
+// concurrent transactions lock the stock rows in the same order
+func orderReservations(tx *sql.Tx, orderID int64) ([]reservation, error) {
+	var reservations []reservation
+
+	rows, err := tx.Query("SELECT book_id, COALESCE(sku_id, 0), warehouse_id, quantity FROM stock_reservations WHERE order_id=$1 ORDER BY book_id, warehouse_id, sku_id", orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r reservation
+
+		err := rows.Scan(&r.bookID, &r.skuID, &r.warehouseID, &r.quantity)
+		if err != nil {
+			return nil, err
+		}
+
+		reservations = append(reservations, r)
+	}
+
+	return reservations, nil
+}
+
+func releaseReservations(tx *sql.Tx, orderID int64) error {
+	reservations, err := orderReservations(tx, orderID)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range reservations {
+		_, err := tx.Exec("UPDATE warehouse_stocks SET reserved = reserved - $1 WHERE warehouse_id=$2 AND book_id=$3", r.quantity, r.warehouseID, r.bookID)
+		if err != nil {
+			return err
+		}
+
+		if r.skuID != 0 {
+			_, err = tx.Exec("UPDATE warehouse_sku_stocks SET reserved = reserved - $1 WHERE warehouse_id=$2 AND sku_id=$3", r.quantity, r.warehouseID, r.skuID)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Exec("UPDATE skus SET reserved = reserved - $1 WHERE id=$2", r.quantity, r.skuID)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Exec("UPDATE books SET reserved = reserved - $1 WHERE id=$2", r.quantity, r.bookID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec("DELETE FROM stock_reservations WHERE order_id=$1", orderID)
+	return err
+}
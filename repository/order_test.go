@@ -0,0 +1,455 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var orderRowColumns = []string{"id", "customer_email", "status", "shipping_latitude", "shipping_longitude", "shipping_country", "shipping_region", "prices_include_tax", "preorder", "shipping_method_id", "shipping_cost", "refunded_total", "expires_at", "created_at", "updated_at"}
+
+var orderLineRowColumns = []string{"id", "order_id", "book_id", "sku_id", "bundle_id", "quantity", "unit_price", "discount", "tax"}
+
+var allocationRowColumns = []string{"order_line_id", "warehouse_id", "quantity"}
+
+var redemptionRowColumns = []string{"order_id", "promotion_id", "name", "coupon_code", "description", "discount"}
+
+var taxLineRowColumns = []string{"order_id", "name", "rate", "taxable", "amount"}
+
+func TestGetOrders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM orders ORDER BY id DESC").WillReturnRows(sqlmock.NewRows(orderRowColumns).
+		AddRow(2, "jane@example.com", entity.OrderPending, -6.2, 106.8, "ID", "JK", true, false, 0, 0, 0, time.Now(), time.Now(), time.Now()).
+		AddRow(1, "john@example.com", entity.OrderConfirmed, nil, nil, "", "", true, false, 0, 0, 0, time.Now(), time.Now(), time.Now()))
+	mock.ExpectQuery("SELECT (.+) FROM order_lines WHERE order_id (.+)").WillReturnRows(sqlmock.NewRows(orderLineRowColumns).
+		AddRow(1, 1, 3, 0, 0, 1, 50000, 0, 0).
+		AddRow(2, 2, 3, 5, 7, 2, 45000, 9000, 0).
+		AddRow(3, 2, 4, 0, 7, 1, 30000, 0, 0))
+	mock.ExpectQuery("SELECT (.+) FROM order_allocations (.+)").WillReturnRows(sqlmock.NewRows(allocationRowColumns).
+		AddRow(1, 1, 1).
+		AddRow(2, 1, 1).
+		AddRow(2, 2, 1).
+		AddRow(3, 2, 1))
+	mock.ExpectQuery("SELECT (.+) FROM promotion_redemptions (.+)").WillReturnRows(sqlmock.NewRows(redemptionRowColumns).
+		AddRow(2, 1, "Autumn Sale", "AUTUMN10", "10% off", 9000))
+	mock.ExpectQuery("SELECT (.+) FROM order_tax_lines (.+)").WillReturnRows(sqlmock.NewRows(taxLineRowColumns).
+		AddRow(2, "PPN", 0, 111000, 0))
+
+	mysqlOrder := repository.NewMysqlOrder(db)
+	ret, err := mysqlOrder.GetOrders(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, ret, 2)
+	assert.Len(t, ret[0].Lines, 2)
+	assert.Len(t, ret[0].Lines[0].Allocations, 2)
+	assert.Equal(t, int64(7), ret[0].Lines[0].BundleID)
+	assert.Equal(t, -6.2, *ret[0].ShippingLatitude)
+	assert.Len(t, ret[1].Lines, 1)
+	assert.Nil(t, ret[1].ShippingLatitude)
+	assert.Equal(t, 9000, ret[0].Promotions[0].Discount)
+	assert.Empty(t, ret[1].Promotions)
+	assert.Equal(t, "ID", ret[0].ShippingCountry)
+	assert.Equal(t, []entity.TaxLine{{Name: "PPN", Rate: 0, Taxable: 111000, Amount: 0}}, ret[0].TaxLines)
+}
+
+func TestGetOrder(t *testing.T) {
+	testCases := []struct {
+		name    string
+		found   bool
+		isError bool
+		err     error
+	}{
+		{name: "success", found: true},
+		{name: "not found", found: false},
+		{name: "failed", isError: true, err: errors.New("Dummy Error")},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			query := mock.ExpectQuery("SELECT (.+) FROM orders WHERE id(.+)").WithArgs(1)
+			switch {
+			case test.isError:
+				query.WillReturnError(test.err)
+			case test.found:
+				query.WillReturnRows(sqlmock.NewRows(orderRowColumns).AddRow(1, "jane@example.com", entity.OrderPending, nil, nil, "", "", true, false, 0, 0, 0, time.Now(), time.Now(), time.Now()))
+				mock.ExpectQuery("SELECT (.+) FROM order_lines (.+)").WillReturnRows(sqlmock.NewRows(orderLineRowColumns).AddRow(1, 1, 3, 0, 0, 2, 50000, 0, 0))
+				mock.ExpectQuery("SELECT (.+) FROM order_allocations (.+)").WillReturnRows(sqlmock.NewRows(allocationRowColumns).AddRow(1, 1, 2))
+				mock.ExpectQuery("SELECT (.+) FROM promotion_redemptions (.+)").WillReturnRows(sqlmock.NewRows(redemptionRowColumns))
+				mock.ExpectQuery("SELECT (.+) FROM order_tax_lines (.+)").WillReturnRows(sqlmock.NewRows(taxLineRowColumns))
+			default:
+				query.WillReturnRows(sqlmock.NewRows(orderRowColumns))
+			}
+
+			mysqlOrder := repository.NewMysqlOrder(db)
+			ret, err := mysqlOrder.GetOrder(context.Background(), 1)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.found, ret.ID == 1)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCreateOrder(t *testing.T) {
+	testCases := []struct {
+		name     string
+		reserved int64
+		isError  bool
+	}{
+		{name: "success", reserved: 1, isError: false},
+		{name: "insufficient stock", reserved: 0, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			order := entity.Order{CustomerEmail: "jane@example.com", Status: entity.OrderPending, ShippingCountry: "SG", ExpiresAt: time.Now().Add(time.Minute), Lines: []entity.OrderLine{
+				{BookID: 3, Quantity: 2, UnitPrice: 50000, Tax: 9000, Allocations: []entity.Allocation{{WarehouseID: 1, Quantity: 1}, {WarehouseID: 2, Quantity: 1}}},
+			}, TaxLines: []entity.TaxLine{{Name: "GST", Rate: 900, Taxable: 100000, Amount: 9000}}}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("INSERT INTO orders (.+) RETURNING id").WithArgs(order.CustomerEmail, entity.OrderPending, nil, nil, "SG", "", false, false, int64(0), 0, order.ExpiresAt, sqlmock.AnyArg(), sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			mock.ExpectQuery("INSERT INTO order_lines (.+)").WithArgs(1, 3, 0, 0, 2, 50000, 0, 9000).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+			mock.ExpectExec("UPDATE warehouse_stocks SET reserved = reserved (.+) AND stock - reserved >= (.+)").WithArgs(1, 1, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec("UPDATE books SET reserved = reserved (.+) o.status = (.+)").WithArgs(1, 3, entity.OrderPreordered, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec("INSERT INTO order_allocations (.+)").WithArgs(5, 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec("INSERT INTO stock_reservations (.+)").WithArgs(1, 3, 0, 1, 1, order.ExpiresAt, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec("UPDATE warehouse_stocks SET reserved = reserved (.+) AND stock - reserved >= (.+)").WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, test.reserved))
+			if !test.isError {
+				mock.ExpectExec("UPDATE books SET reserved = reserved (.+) o.status = (.+)").WithArgs(1, 3, entity.OrderPreordered, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO order_allocations (.+)").WithArgs(5, 2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO stock_reservations (.+)").WithArgs(1, 3, 0, 2, 1, order.ExpiresAt, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO order_tax_lines (.+)").WithArgs(1, "GST", 900, 100000, 9000).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			mysqlOrder := repository.NewMysqlOrder(db)
+			err = mysqlOrder.CreateOrder(context.Background(), &order)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.isError, errors.Is(err, repository.ErrInsufficientStock))
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if !test.isError {
+				assert.Equal(t, int64(1), order.ID)
+				assert.Equal(t, int64(5), order.Lines[0].ID)
+			}
+		})
+	}
+}
+
+func TestCreateOrderReservesSKU(t *testing.T) {
+	testCases := []struct {
+		name              string
+		warehouseReserved int64
+		reserved          int64
+		isError           bool
+	}{
+		{name: "success", warehouseReserved: 1, reserved: 1, isError: false},
+		{name: "edition out of stock in the warehouse", warehouseReserved: 0, isError: true},
+		{name: "edition out of stock", warehouseReserved: 1, reserved: 0, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			order := entity.Order{CustomerEmail: "jane@example.com", Status: entity.OrderPending, ExpiresAt: time.Now().Add(time.Minute), Lines: []entity.OrderLine{
+				{BookID: 3, SKUID: 8, Quantity: 2, UnitPrice: 120000, Allocations: []entity.Allocation{{WarehouseID: 1, Quantity: 2}}},
+			}}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("INSERT INTO orders (.+) RETURNING id").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			mock.ExpectQuery("INSERT INTO order_lines (.+)").WithArgs(1, 3, 8, 0, 2, 120000, 0, 0).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+			mock.ExpectExec("UPDATE warehouse_stocks SET reserved = reserved (.+)").WithArgs(2, 1, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec("UPDATE warehouse_sku_stocks SET reserved = reserved (.+) AND stock - reserved >= (.+)").WithArgs(2, 1, 8).WillReturnResult(sqlmock.NewResult(0, test.warehouseReserved))
+			if test.warehouseReserved == 1 {
+				mock.ExpectExec("UPDATE skus SET reserved = reserved (.+) AND stock - reserved - (.+) o.status = (.+)").WithArgs(2, 8, entity.OrderPreordered).WillReturnResult(sqlmock.NewResult(0, test.reserved))
+			}
+			if !test.isError {
+				mock.ExpectExec("UPDATE books SET reserved = reserved (.+) o.status = (.+)").WithArgs(2, 3, entity.OrderPreordered, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO order_allocations (.+)").WithArgs(5, 1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO stock_reservations (.+)").WithArgs(1, 3, 8, 1, 2, order.ExpiresAt, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			mysqlOrder := repository.NewMysqlOrder(db)
+			err = mysqlOrder.CreateOrder(context.Background(), &order)
+
+			assert.Equal(t, test.isError, errors.Is(err, repository.ErrInsufficientStock))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCreateOrderLeavesPreOrderedStock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	order := entity.Order{CustomerEmail: "jane@example.com", Status: entity.OrderPending, ExpiresAt: time.Now().Add(time.Minute), Lines: []entity.OrderLine{
+		{BookID: 3, Quantity: 2, UnitPrice: 50000, Allocations: []entity.Allocation{{WarehouseID: 1, Quantity: 2}}},
+	}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO orders (.+) RETURNING id").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("INSERT INTO order_lines (.+)").WithArgs(1, 3, 0, 0, 2, 50000, 0, 0).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectExec("UPDATE warehouse_stocks SET reserved = reserved (.+)").WithArgs(2, 1, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE books SET reserved = reserved (.+) o.status = (.+)").WithArgs(2, 3, entity.OrderPreordered, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	mysqlOrder := repository.NewMysqlOrder(db)
+	err = mysqlOrder.CreateOrder(context.Background(), &order)
+
+	assert.True(t, errors.Is(err, repository.ErrInsufficientStock))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateOrderRedeemsPromotions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		limit    int
+		redeemed int
+		isError  bool
+	}{
+		{name: "within the usage limit", limit: 100, redeemed: 99, isError: false},
+		{name: "usage limit reached", limit: 100, redeemed: 100, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			order := entity.Order{CustomerEmail: "jane@example.com", Status: entity.OrderPending, ExpiresAt: time.Now().Add(time.Minute),
+				Lines:      []entity.OrderLine{{BookID: 3, Quantity: 1, UnitPrice: 50000, Discount: 5000}},
+				Promotions: []entity.AppliedPromotion{{PromotionID: 7, Name: "Autumn Sale", CouponCode: "AUTUMN10", Description: "10% off", Discount: 5000}},
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("INSERT INTO orders (.+) RETURNING id").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			mock.ExpectQuery("INSERT INTO order_lines (.+)").WithArgs(1, 3, 0, 0, 1, 50000, 5000, 0).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+			mock.ExpectQuery("SELECT usage_limit, per_customer_limit FROM promotions WHERE id(.+) FOR UPDATE").WithArgs(7).
+				WillReturnRows(sqlmock.NewRows([]string{"usage_limit", "per_customer_limit"}).AddRow(test.limit, 0))
+			mock.ExpectQuery("SELECT COUNT(.+)customer_email = LOWER(.+) FROM promotion_redemptions (.+)").WithArgs(7, "jane@example.com", entity.OrderCancelled, entity.OrderExpired).
+				WillReturnRows(sqlmock.NewRows([]string{"total", "customer"}).AddRow(test.redeemed, 0))
+			if !test.isError {
+				mock.ExpectExec("INSERT INTO promotion_redemptions (.+) VALUES(.+) LOWER(.+)").WithArgs(7, 1, "jane@example.com", "Autumn Sale", "AUTUMN10", "10% off", 5000, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			mysqlOrder := repository.NewMysqlOrder(db)
+			err = mysqlOrder.CreateOrder(context.Background(), &order)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.isError, err == repository.ErrPromotionLimitReached)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestConfirmOrder(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pending  bool
+		preorder bool
+		skuID    int64
+		wantErr  error
+	}{
+		{name: "success", pending: true, wantErr: nil},
+		{name: "edition", pending: true, skuID: 8, wantErr: nil},
+		{name: "pre-order", pending: true, preorder: true, wantErr: nil},
+		{name: "order no longer pending", pending: false, wantErr: repository.ErrOrderNotPending},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			confirm := mock.ExpectQuery("UPDATE orders SET status = CASE WHEN preorder (.+) RETURNING preorder").WithArgs(entity.OrderPreordered, entity.OrderConfirmed, sqlmock.AnyArg(), 1, entity.OrderPending)
+			switch {
+			case !test.pending:
+				confirm.WillReturnRows(sqlmock.NewRows([]string{"preorder"}))
+				mock.ExpectRollback()
+			case test.preorder:
+				confirm.WillReturnRows(sqlmock.NewRows([]string{"preorder"}).AddRow(true))
+				mock.ExpectCommit()
+			default:
+				confirm.WillReturnRows(sqlmock.NewRows([]string{"preorder"}).AddRow(false))
+				mock.ExpectQuery("SELECT book_id, COALESCE\\(sku_id, 0\\), warehouse_id, quantity FROM stock_reservations (.+)").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"book_id", "sku_id", "warehouse_id", "quantity"}).AddRow(3, test.skuID, 2, 2))
+				mock.ExpectExec("UPDATE warehouse_stocks SET stock = stock (.+)").WithArgs(2, 2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				if test.skuID != 0 {
+					mock.ExpectExec("UPDATE warehouse_sku_stocks SET stock = stock (.+)").WithArgs(2, 2, test.skuID).WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec("UPDATE skus SET stock = stock (.+)").WithArgs(2, sqlmock.AnyArg(), test.skuID).WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				mock.ExpectQuery("UPDATE books SET stock = stock (.+) RETURNING stock").WithArgs(2, sqlmock.AnyArg(), 3).WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(8))
+				mock.ExpectQuery("INSERT INTO stock_movements (.+)").WithArgs(3, test.skuID, 2, entity.MovementSale, -2, "order #1", "bookstorebe", 8, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				mock.ExpectExec("DELETE FROM stock_reservations (.+)").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			mysqlOrder := repository.NewMysqlOrder(db)
+			err = mysqlOrder.ConfirmOrder(context.Background(), 1, "bookstorebe")
+
+			assert.Equal(t, test.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFulfillPreOrder(t *testing.T) {
+	testCases := []struct {
+		name     string
+		preorder bool
+		inStock  bool
+		wantErr  error
+	}{
+		{name: "success", preorder: true, inStock: true},
+		{name: "stock taken meanwhile", preorder: true, inStock: false, wantErr: repository.ErrInsufficientStock},
+		{name: "fulfilled meanwhile", preorder: false, wantErr: repository.ErrOrderNotPreOrdered},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			if !test.preorder {
+				mock.ExpectExec("UPDATE orders SET status(.+) WHERE id(.+) AND status(.+)").WithArgs(entity.OrderConfirmed, sqlmock.AnyArg(), 1, entity.OrderPreordered).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectExec("UPDATE orders SET status(.+) WHERE id(.+) AND status(.+)").WithArgs(entity.OrderConfirmed, sqlmock.AnyArg(), 1, entity.OrderPreordered).WillReturnResult(sqlmock.NewResult(0, 1))
+				if !test.inStock {
+					mock.ExpectExec("UPDATE warehouse_stocks SET stock = stock (.+) AND stock - reserved >= (.+)").WithArgs(1, 1, 3).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectRollback()
+				} else {
+					// stock is taken by book and warehouse, whatever the order of the lines
+					mock.ExpectExec("UPDATE warehouse_stocks SET stock = stock (.+) AND stock - reserved >= (.+)").WithArgs(1, 1, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectQuery("UPDATE books SET stock = stock (.+) AND stock - reserved >= (.+) RETURNING stock").WithArgs(1, sqlmock.AnyArg(), 3).WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(19))
+					mock.ExpectQuery("INSERT INTO stock_movements (.+)").WithArgs(3, 0, 1, entity.MovementSale, -1, "order #1", "preorder", 19, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+					mock.ExpectExec("INSERT INTO order_allocations (.+)").WithArgs(5, 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec("UPDATE warehouse_stocks SET stock = stock (.+) AND stock - reserved >= (.+)").WithArgs(1, 2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectQuery("UPDATE books SET stock = stock (.+) AND stock - reserved >= (.+) RETURNING stock").WithArgs(1, sqlmock.AnyArg(), 3).WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(18))
+					mock.ExpectQuery("INSERT INTO stock_movements (.+)").WithArgs(3, 0, 2, entity.MovementSale, -1, "order #1", "preorder", 18, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+					mock.ExpectExec("INSERT INTO order_allocations (.+)").WithArgs(5, 2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec("UPDATE warehouse_stocks SET stock = stock (.+) AND stock - reserved >= (.+)").WithArgs(1, 1, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectQuery("UPDATE books SET stock = stock (.+) AND stock - reserved >= (.+) RETURNING stock").WithArgs(1, sqlmock.AnyArg(), 4).WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(7))
+					mock.ExpectQuery("INSERT INTO stock_movements (.+)").WithArgs(4, 0, 1, entity.MovementSale, -1, "order #1", "preorder", 7, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+					mock.ExpectExec("INSERT INTO order_allocations (.+)").WithArgs(6, 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectCommit()
+				}
+			}
+
+			order := entity.Order{ID: 1, Status: entity.OrderPreordered, PreOrder: true, Lines: []entity.OrderLine{
+				{ID: 6, OrderID: 1, BookID: 4, Quantity: 1, Allocations: []entity.Allocation{{WarehouseID: 1, Quantity: 1}}},
+				{ID: 5, OrderID: 1, BookID: 3, Quantity: 2, Allocations: []entity.Allocation{{WarehouseID: 2, Quantity: 1}, {WarehouseID: 1, Quantity: 1}}},
+			}}
+			mysqlOrder := repository.NewMysqlOrder(db)
+			err = mysqlOrder.FulfillPreOrder(context.Background(), &order, "preorder")
+
+			assert.True(t, errors.Is(err, test.wantErr))
+			if test.wantErr == nil {
+				assert.Equal(t, entity.OrderConfirmed, order.Status)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE orders SET status(.+)").WithArgs(entity.OrderCancelled, sqlmock.AnyArg(), 1, entity.OrderPending).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT book_id, COALESCE\\(sku_id, 0\\), warehouse_id, quantity FROM stock_reservations (.+)").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"book_id", "sku_id", "warehouse_id", "quantity"}).AddRow(3, 0, 1, 2))
+	mock.ExpectExec("UPDATE warehouse_stocks SET reserved = reserved - (.+)").WithArgs(2, 1, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE books SET reserved = reserved - (.+)").WithArgs(2, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM stock_reservations (.+)").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mysqlOrder := repository.NewMysqlOrder(db)
+	err = mysqlOrder.CancelOrder(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExpireOrders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM orders (.+) FOR UPDATE SKIP LOCKED").WithArgs(entity.OrderPending, now).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	for _, id := range []int64{1, 2} {
+		mock.ExpectExec("UPDATE orders SET status(.+)").WithArgs(entity.OrderExpired, now, id).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("SELECT book_id, COALESCE\\(sku_id, 0\\), warehouse_id, quantity FROM stock_reservations (.+)").WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"book_id", "sku_id", "warehouse_id", "quantity"}).AddRow(3, 0, 1, 1))
+		mock.ExpectExec("UPDATE warehouse_stocks SET reserved = reserved - (.+)").WithArgs(1, 1, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE books SET reserved = reserved - (.+)").WithArgs(1, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM stock_reservations (.+)").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	mysqlOrder := repository.NewMysqlOrder(db)
+	expired, err := mysqlOrder.ExpireOrders(context.Background(), now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), expired)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
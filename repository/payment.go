@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	"winartodev/book-store-be/entity"
+)
+
+const paymentColumns = "id, order_id, idempotency_key, gateway, reference, status, amount, currency, captured_amount, refunded_amount, failure_reason, created_at, updated_at"
+
+// ErrDuplicatePayment is returned when a payment is created with an idempotency key that was already used
+var ErrDuplicatePayment = errors.New("a payment with this idempotency key already exists")
+
+// ErrPaymentChanged is returned when a payment is updated after it left the status it was read in
+var ErrPaymentChanged = errors.New("payment was changed by another request")
+
+type PaymentRepository interface {
+	GetPayment(ctx context.Context, id int64) (entity.Payment, error)
+	GetPaymentByKey(ctx context.Context, idempotencyKey string) (entity.Payment, error)
+	GetPaymentByReference(ctx context.Context, gateway string, reference string) (entity.Payment, error)
+	GetOrderPayments(ctx context.Context, orderID int64) ([]entity.Payment, error)
+	CreatePayment(ctx context.Context, payment *entity.Payment) error
+	UpdatePayment(ctx context.Context, payment *entity.Payment, status string) error
+	CreateRefund(ctx context.Context, refund *entity.PaymentRefund) error
+	FinishRefund(ctx context.Context, refund *entity.PaymentRefund, status string) error
+}
+
+type mysqlPayment struct {
+	DB *sql.DB
+}
+
+func NewMysqlPayment(db *sql.DB) PaymentRepository {
+	return &mysqlPayment{DB: db}
+}
+
+func (mp *mysqlPayment) GetPayment(ctx context.Context, id int64) (entity.Payment, error) {
+	return mp.getPayment("SELECT "+paymentColumns+" FROM payments WHERE id=$1", id)
+}
+
+func (mp *mysqlPayment) GetPaymentByKey(ctx context.Context, idempotencyKey string) (entity.Payment, error) {
+	return mp.getPayment("SELECT "+paymentColumns+" FROM payments WHERE idempotency_key=$1", idempotencyKey)
+}
+
+func (mp *mysqlPayment) GetPaymentByReference(ctx context.Context, gateway string, reference string) (entity.Payment, error) {
+	return mp.getPayment("SELECT "+paymentColumns+" FROM payments WHERE gateway=$1 AND reference=$2", gateway, reference)
+}
+
+func (mp *mysqlPayment) GetOrderPayments(ctx context.Context, orderID int64) ([]entity.Payment, error) {
+	var payments []entity.Payment
+
+	rows, err := mp.DB.Query("SELECT "+paymentColumns+" FROM payments WHERE order_id=$1 ORDER BY id", orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var payment entity.Payment
+
+		err := rows.Scan(&payment.ID, &payment.OrderID, &payment.IdempotencyKey, &payment.Gateway, &payment.Reference, &payment.Status, &payment.Amount, &payment.Currency, &payment.CapturedAmount, &payment.RefundedAmount, &payment.FailureReason, &payment.CreatedAt, &payment.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		payments = append(payments, payment)
+	}
+
+	return payments, nil
+}
+
+// CreatePayment records a payment attempt before the gateway is asked for it, so concurrent
+// attempts with the same idempotency key fail with ErrDuplicatePayment
+func (mp *mysqlPayment) CreatePayment(ctx context.Context, payment *entity.Payment) error {
+	stmt, err := mp.DB.Prepare("INSERT INTO payments (order_id, idempotency_key, gateway, reference, status, amount, currency, captured_amount, refunded_amount, failure_reason, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) ON CONFLICT (idempotency_key) DO NOTHING RETURNING id")
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	payment.CreatedAt = startTime
+	payment.UpdatedAt = startTime
+
+	err = stmt.QueryRow(payment.OrderID, payment.IdempotencyKey, payment.Gateway, payment.Reference, payment.Status, payment.Amount, payment.Currency, payment.CapturedAmount, payment.RefundedAmount, payment.FailureReason, payment.CreatedAt, payment.UpdatedAt).Scan(&payment.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrDuplicatePayment
+		}
+		return err
+	}
+
+	return nil
+}
+
+// UpdatePayment saves the payment if it is still in status, otherwise it fails with
+// ErrPaymentChanged and the caller reads the payment again. The refunded amount is left alone,
+// refunds only add to it through CreateRefund and RefundReturn.
+func (mp *mysqlPayment) UpdatePayment(ctx context.Context, payment *entity.Payment, status string) error {
+	stmt, err := mp.DB.Prepare("UPDATE payments SET reference=$1, status=$2, captured_amount=$3, failure_reason=$4, updated_at=$5 WHERE id=$6 AND status=$7")
+	if err != nil {
+		return err
+	}
+
+	payment.UpdatedAt = time.Now()
+	res, err := stmt.Exec(payment.Reference, payment.Status, payment.CapturedAmount, payment.FailureReason, payment.UpdatedAt, payment.ID, status)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrPaymentChanged
+	}
+
+	return nil
+}
+
+// CreateRefund holds the amount of a refund on a captured payment and records the refund as
+// pending in one transaction. A payment that is no longer captured or has less left than the
+// refund fails with ErrPaymentChanged, so concurrent refunds never pay back more than was
+// captured.
+func (mp *mysqlPayment) CreateRefund(ctx context.Context, refund *entity.PaymentRefund) error {
+	tx, err := mp.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	res, err := tx.Exec("UPDATE payments SET refunded_amount = refunded_amount + $1, status = CASE WHEN refunded_amount + $1 = captured_amount THEN $2 ELSE status END, updated_at=$3 WHERE id=$4 AND status=$5 AND refunded_amount + $1 <= captured_amount",
+		refund.Amount, entity.PaymentRefunded, now, refund.PaymentID, entity.PaymentCaptured)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := res.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrPaymentChanged
+	}
+
+	refund.Status, refund.CreatedAt, refund.UpdatedAt = entity.RefundPending, now, now
+	err = tx.QueryRow("INSERT INTO payment_refunds (payment_id, amount, status, created_at, updated_at) VALUES($1, $2, $3, $4, $5) RETURNING id",
+		refund.PaymentID, refund.Amount, refund.Status, refund.CreatedAt, refund.UpdatedAt).Scan(&refund.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FinishRefund records how the gateway answered a pending refund. A failed refund gives its
+// amount back to the payment, which is captured again if the refund had taken all of it.
+func (mp *mysqlPayment) FinishRefund(ctx context.Context, refund *entity.PaymentRefund, status string) error {
+	tx, err := mp.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	res, err := tx.Exec("UPDATE payment_refunds SET status=$1, updated_at=$2 WHERE id=$3 AND status=$4", status, now, refund.ID, entity.RefundPending)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := res.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrPaymentChanged
+	}
+
+	if status == entity.RefundFailed {
+		_, err = tx.Exec("UPDATE payments SET refunded_amount = refunded_amount - $1, status=$2, updated_at=$3 WHERE id=$4", refund.Amount, entity.PaymentCaptured, now, refund.PaymentID)
+		if err != nil {
+			return err
+		}
+	}
+
+	refund.Status, refund.UpdatedAt = status, now
+	return tx.Commit()
+}
+
+func (mp *mysqlPayment) getPayment(query string, args ...interface{}) (entity.Payment, error) {
+	var payment entity.Payment
+
+	err := mp.DB.QueryRow(query, args...).Scan(&payment.ID, &payment.OrderID, &payment.IdempotencyKey, &payment.Gateway, &payment.Reference, &payment.Status, &payment.Amount, &payment.Currency, &payment.CapturedAmount, &payment.RefundedAmount, &payment.FailureReason, &payment.CreatedAt, &payment.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.Payment{}, nil
+		}
+		return entity.Payment{}, err
+	}
+
+	return payment, nil
+}
@@ -0,0 +1,186 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var paymentRowColumns = []string{"id", "order_id", "idempotency_key", "gateway", "reference", "status", "amount", "currency", "captured_amount", "refunded_amount", "failure_reason", "created_at", "updated_at"}
+
+func TestGetPaymentByKey(t *testing.T) {
+	testCases := []struct {
+		name   string
+		rows   *sqlmock.Rows
+		wantID int64
+	}{
+		{name: "found", rows: sqlmock.NewRows(paymentRowColumns).AddRow(1, 1, "key-1", "fake", "fake_1", entity.PaymentAuthorized, 150000, "IDR", 0, 0, "", time.Now(), time.Now()), wantID: 1},
+		{name: "not found", rows: sqlmock.NewRows(paymentRowColumns), wantID: 0},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectQuery("SELECT (.+) FROM payments WHERE idempotency_key(.+)").WithArgs("key-1").WillReturnRows(test.rows)
+
+			mysqlPayment := repository.NewMysqlPayment(db)
+			ret, err := mysqlPayment.GetPaymentByKey(context.Background(), "key-1")
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantID, ret.ID)
+		})
+	}
+}
+
+func TestCreatePayment(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantErr error
+	}{
+		{name: "created", rows: sqlmock.NewRows([]string{"id"}).AddRow(1)},
+		{name: "idempotency key already used", rows: sqlmock.NewRows([]string{"id"}), wantErr: repository.ErrDuplicatePayment},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectPrepare("INSERT INTO payments (.+) ON CONFLICT").ExpectQuery().
+				WithArgs(1, "key-1", "fake", "", entity.PaymentPending, 150000, "IDR", 0, 0, "", sqlmock.AnyArg(), sqlmock.AnyArg()).
+				WillReturnRows(test.rows)
+
+			payment := entity.Payment{OrderID: 1, IdempotencyKey: "key-1", Gateway: "fake", Status: entity.PaymentPending, Amount: 150000, Currency: "IDR"}
+			mysqlPayment := repository.NewMysqlPayment(db)
+			err = mysqlPayment.CreatePayment(context.Background(), &payment)
+
+			assert.Equal(t, test.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdatePayment(t *testing.T) {
+	testCases := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "updated", affected: 1},
+		{name: "status changed", affected: 0, wantErr: repository.ErrPaymentChanged},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectPrepare("UPDATE payments (.+) WHERE id=(.+) AND status=(.+)").ExpectExec().
+				WithArgs("fake_1", entity.PaymentCaptured, 150000, "", sqlmock.AnyArg(), 1, entity.PaymentAuthorized).
+				WillReturnResult(sqlmock.NewResult(0, test.affected))
+
+			payment := entity.Payment{ID: 1, Reference: "fake_1", Status: entity.PaymentCaptured, Amount: 150000, CapturedAmount: 150000}
+			mysqlPayment := repository.NewMysqlPayment(db)
+			err = mysqlPayment.UpdatePayment(context.Background(), &payment, entity.PaymentAuthorized)
+
+			assert.Equal(t, test.wantErr, err)
+		})
+	}
+}
+
+func TestCreateRefund(t *testing.T) {
+	testCases := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "held", affected: 1},
+		{name: "less left than the refund", affected: 0, wantErr: repository.ErrPaymentChanged},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE payments SET refunded_amount = refunded_amount \\+ (.+) AND refunded_amount \\+ (.+) <= captured_amount").
+				WithArgs(40000, entity.PaymentRefunded, sqlmock.AnyArg(), 1, entity.PaymentCaptured).
+				WillReturnResult(sqlmock.NewResult(0, test.affected))
+			if test.wantErr == nil {
+				mock.ExpectQuery("INSERT INTO payment_refunds (.+)").WithArgs(1, 40000, entity.RefundPending, sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			refund := entity.PaymentRefund{PaymentID: 1, Amount: 40000}
+			mysqlPayment := repository.NewMysqlPayment(db)
+			err = mysqlPayment.CreateRefund(context.Background(), &refund)
+
+			assert.Equal(t, test.wantErr, err)
+			if test.wantErr == nil {
+				assert.Equal(t, int64(3), refund.ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFinishRefund(t *testing.T) {
+	testCases := []struct {
+		name         string
+		status       string
+		wantGiveBack bool
+	}{
+		{name: "succeeded", status: entity.RefundSucceeded},
+		{name: "failed gives the amount back", status: entity.RefundFailed, wantGiveBack: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE payment_refunds SET status(.+)").WithArgs(test.status, sqlmock.AnyArg(), 3, entity.RefundPending).WillReturnResult(sqlmock.NewResult(0, 1))
+			if test.wantGiveBack {
+				mock.ExpectExec("UPDATE payments SET refunded_amount = refunded_amount - (.+)").WithArgs(40000, entity.PaymentCaptured, sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			mock.ExpectCommit()
+
+			refund := entity.PaymentRefund{ID: 3, PaymentID: 1, Amount: 40000, Status: entity.RefundPending}
+			mysqlPayment := repository.NewMysqlPayment(db)
+			err = mysqlPayment.FinishRefund(context.Background(), &refund, test.status)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.status, refund.Status)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
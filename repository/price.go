@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	"winartodev/book-store-be/entity"
+)
+
+const bookPriceColumns = "id, book_id, price, effective_from, effective_to, created_at"
+
+const insertBookPriceQuery = "INSERT INTO book_prices (book_id, price, effective_from, effective_to, created_at) VALUES($1, $2, $3, $4, $5) RETURNING id"
+
+// currentPriceColumn resolves the price of a book from its active price entry and falls back
+// on the price stored with the book when it has none
+const currentPriceColumn = "COALESCE((SELECT bp.price FROM book_prices bp WHERE bp.book_id = books.id AND bp.effective_from <= NOW() AND (bp.effective_to IS NULL OR bp.effective_to > NOW()) ORDER BY bp.effective_from DESC, bp.id DESC LIMIT 1), books.price) AS price"
+
+// ErrPriceInEffect is returned when a price that already took effect is cancelled
+var ErrPriceInEffect = errors.New("price already took effect")
+
+type PriceRepository interface {
+	GetBookPrices(ctx context.Context, bookID int64) ([]entity.BookPrice, error)
+	GetBookPrice(ctx context.Context, bookID int64, id int64) (entity.BookPrice, error)
+	GetPriceAt(ctx context.Context, bookID int64, at time.Time) (entity.BookPrice, error)
+	CreateBookPrice(ctx context.Context, price *entity.BookPrice) error
+	DeleteScheduledPrice(ctx context.Context, bookID int64, id int64, now time.Time) error
+}
+
+type mysqlPrice struct {
+	DB *sql.DB
+}
+
+func NewMysqlPrice(db *sql.DB) PriceRepository {
+	return &mysqlPrice{DB: db}
+}
+
+// GetBookPrices returns the price history of a book, scheduled prices first
+func (mp *mysqlPrice) GetBookPrices(ctx context.Context, bookID int64) ([]entity.BookPrice, error) {
+	var prices []entity.BookPrice
+
+	rows, err := mp.DB.Query("SELECT "+bookPriceColumns+" FROM book_prices WHERE book_id=$1 ORDER BY effective_from DESC, id DESC", bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var price entity.BookPrice
+
+		err := rows.Scan(&price.ID, &price.BookID, &price.Price, &price.EffectiveFrom, &price.EffectiveTo, &price.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		prices = append(prices, price)
+	}
+
+	return prices, nil
+}
+
+func (mp *mysqlPrice) GetBookPrice(ctx context.Context, bookID int64, id int64) (entity.BookPrice, error) {
+	var price entity.BookPrice
+
+	err := mp.DB.QueryRow("SELECT "+bookPriceColumns+" FROM book_prices WHERE id=$1 AND book_id=$2", id, bookID).
+		Scan(&price.ID, &price.BookID, &price.Price, &price.EffectiveFrom, &price.EffectiveTo, &price.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.BookPrice{}, nil
+		}
+		return entity.BookPrice{}, err
+	}
+
+	return price, nil
+}
+
+// GetPriceAt returns the price entry of a book active at the given time, or an empty entry
+// when the book had no price then
+func (mp *mysqlPrice) GetPriceAt(ctx context.Context, bookID int64, at time.Time) (entity.BookPrice, error) {
+	var price entity.BookPrice
+
+	err := mp.DB.QueryRow("SELECT "+bookPriceColumns+" FROM book_prices WHERE book_id=$1 AND effective_from <= $2 AND (effective_to IS NULL OR effective_to > $2) ORDER BY effective_from DESC, id DESC LIMIT 1", bookID, at).
+		Scan(&price.ID, &price.BookID, &price.Price, &price.EffectiveFrom, &price.EffectiveTo, &price.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.BookPrice{}, nil
+		}
+		return entity.BookPrice{}, err
+	}
+
+	return price, nil
+}
+
+func (mp *mysqlPrice) CreateBookPrice(ctx context.Context, price *entity.BookPrice) error {
+	stmt, err := mp.DB.Prepare(insertBookPriceQuery)
+	if err != nil {
+		return err
+	}
+
+	price.CreatedAt = time.Now()
+
+	err = stmt.QueryRow(price.BookID, price.Price, price.EffectiveFrom, price.EffectiveTo, price.CreatedAt).Scan(&price.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteScheduledPrice cancels a price that has not taken effect yet, prices that did are
+// history and stay
+func (mp *mysqlPrice) DeleteScheduledPrice(ctx context.Context, bookID int64, id int64, now time.Time) error {
+	res, err := mp.DB.Exec("DELETE FROM book_prices WHERE id=$1 AND book_id=$2 AND effective_from > $3", id, bookID, now)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrPriceInEffect
+	}
+
+	return nil
+}
+
+// insertBookPrice adds an entry to the price history of a book as part of a larger write
+func insertBookPrice(tx *sql.Tx, price *entity.BookPrice) error {
+	price.CreatedAt = time.Now()
+	return tx.QueryRow(insertBookPriceQuery, price.BookID, price.Price, price.EffectiveFrom, price.EffectiveTo, price.CreatedAt).Scan(&price.ID)
+}
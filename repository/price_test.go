@@ -0,0 +1,131 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var bookPriceRowColumns = []string{"id", "book_id", "price", "effective_from", "effective_to", "created_at"}
+
+func TestGetBookPrices(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT (.+) FROM book_prices WHERE book_id(.+) ORDER BY effective_from DESC").WithArgs(1).WillReturnRows(sqlmock.NewRows(bookPriceRowColumns).
+		AddRow(3, 1, 30000, now.Add(24*time.Hour), now.Add(48*time.Hour), now).
+		AddRow(2, 1, 50000, now.Add(-24*time.Hour), nil, now))
+
+	mysqlPrice := repository.NewMysqlPrice(db)
+	ret, err := mysqlPrice.GetBookPrices(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, ret, 2)
+	assert.NotNil(t, ret[0].EffectiveTo)
+	assert.Nil(t, ret[1].EffectiveTo)
+}
+
+func TestGetPriceAt(t *testing.T) {
+	at := time.Date(2026, 9, 19, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		found   bool
+		isError bool
+		err     error
+	}{
+		{name: "success", found: true},
+		{name: "no price", found: false},
+		{name: "failed", isError: true, err: errors.New("Dummy Error")},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			query := mock.ExpectQuery("SELECT (.+) FROM book_prices WHERE book_id=(.+) AND effective_from <= (.+) ORDER BY effective_from DESC, id DESC LIMIT 1").WithArgs(1, at)
+			switch {
+			case test.isError:
+				query.WillReturnError(test.err)
+			case test.found:
+				query.WillReturnRows(sqlmock.NewRows(bookPriceRowColumns).AddRow(2, 1, 50000, at.Add(-time.Hour), nil, at))
+			default:
+				query.WillReturnRows(sqlmock.NewRows(bookPriceRowColumns))
+			}
+
+			mysqlPrice := repository.NewMysqlPrice(db)
+			ret, err := mysqlPrice.GetPriceAt(context.Background(), 1, at)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.found, ret.Price == 50000)
+		})
+	}
+}
+
+func TestCreateBookPrice(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	from := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+	price := entity.BookPrice{BookID: 1, Price: 30000, EffectiveFrom: from, EffectiveTo: &to}
+
+	mock.ExpectPrepare("INSERT INTO book_prices (.+)").ExpectQuery().WithArgs(1, 30000, from, to, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+
+	mysqlPrice := repository.NewMysqlPrice(db)
+	err = mysqlPrice.CreateBookPrice(context.Background(), &price)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), price.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteScheduledPrice(t *testing.T) {
+	testCases := []struct {
+		name    string
+		deleted int64
+		wantErr error
+	}{
+		{name: "success", deleted: 1},
+		{name: "already in effect", deleted: 0, wantErr: repository.ErrPriceInEffect},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			now := time.Now()
+			mock.ExpectExec("DELETE FROM book_prices WHERE id=(.+) AND book_id=(.+) AND effective_from > (.+)").WithArgs(4, 1, now).WillReturnResult(sqlmock.NewResult(0, test.deleted))
+
+			mysqlPrice := repository.NewMysqlPrice(db)
+			err = mysqlPrice.DeleteScheduledPrice(context.Background(), 1, 4, now)
+
+			assert.Equal(t, test.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+const promotionColumns = "id, name, promotion_type, value, scope, scope_ids, buy_quantity, get_quantity, min_spend, coupon_code, usage_limit, per_customer_limit, stackable, priority, starts_at, ends_at, created_at, updated_at"
+
+// ErrPromotionLimitReached is returned when an order redeems a promotion that was used up
+var ErrPromotionLimitReached = errors.New("promotion usage limit reached")
+
+type PromotionRepository interface {
+	GetPromotions(ctx context.Context) ([]entity.Promotion, error)
+	GetPromotion(ctx context.Context, id int64) (entity.Promotion, error)
+	CreatePromotion(ctx context.Context, promotion *entity.Promotion) error
+	UpdatePromotion(ctx context.Context, id int64, promotion *entity.Promotion) error
+	DeletePromotion(ctx context.Context, id int64) error
+	GetApplicablePromotions(ctx context.Context, couponCodes []string) ([]entity.Promotion, error)
+	GetRedemptions(ctx context.Context, promotionIDs []int64, customerEmail string) (map[int64]entity.Redemptions, error)
+}
+
+type mysqlPromotion struct {
+	DB *sql.DB
+}
+
+func NewMysqlPromotion(db *sql.DB) PromotionRepository {
+	return &mysqlPromotion{DB: db}
+}
+
+func (mp *mysqlPromotion) GetPromotions(ctx context.Context) ([]entity.Promotion, error) {
+	rows, err := mp.DB.Query("SELECT " + promotionColumns + " FROM promotions ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPromotions(rows)
+}
+
+func (mp *mysqlPromotion) GetPromotion(ctx context.Context, id int64) (entity.Promotion, error) {
+	var promotion entity.Promotion
+
+	err := scanPromotion(mp.DB.QueryRow("SELECT "+promotionColumns+" FROM promotions WHERE id=$1", id), &promotion)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.Promotion{}, nil
+		}
+		return entity.Promotion{}, err
+	}
+
+	return promotion, nil
+}
+
+func (mp *mysqlPromotion) CreatePromotion(ctx context.Context, promotion *entity.Promotion) error {
+	stmt, err := mp.DB.Prepare("INSERT INTO promotions (name, promotion_type, value, scope, scope_ids, buy_quantity, get_quantity, min_spend, coupon_code, usage_limit, per_customer_limit, stackable, priority, starts_at, ends_at, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, $11, $12, $13, $14, $15, $16, $17) RETURNING id")
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	promotion.CreatedAt = startTime
+	promotion.UpdatedAt = startTime
+
+	err = stmt.QueryRow(promotion.Name, promotion.Type, promotion.Value, promotion.Scope, pq.Array(promotion.ScopeIDs), promotion.BuyQuantity, promotion.GetQuantity, promotion.MinSpend, promotion.CouponCode,
+		promotion.UsageLimit, promotion.PerCustomerLimit, promotion.Stackable, promotion.Priority, promotion.StartsAt, promotion.EndsAt, promotion.CreatedAt, promotion.UpdatedAt).Scan(&promotion.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mp *mysqlPromotion) UpdatePromotion(ctx context.Context, id int64, promotion *entity.Promotion) error {
+	stmt, err := mp.DB.Prepare("UPDATE promotions SET name=$1, promotion_type=$2, value=$3, scope=$4, scope_ids=$5, buy_quantity=$6, get_quantity=$7, min_spend=$8, coupon_code=NULLIF($9, ''), usage_limit=$10, per_customer_limit=$11, stackable=$12, priority=$13, starts_at=$14, ends_at=$15, updated_at=$16 WHERE id=$17")
+	if err != nil {
+		return err
+	}
+
+	promotion.UpdatedAt = time.Now()
+	_, err = stmt.Exec(promotion.Name, promotion.Type, promotion.Value, promotion.Scope, pq.Array(promotion.ScopeIDs), promotion.BuyQuantity, promotion.GetQuantity, promotion.MinSpend, promotion.CouponCode,
+		promotion.UsageLimit, promotion.PerCustomerLimit, promotion.Stackable, promotion.Priority, promotion.StartsAt, promotion.EndsAt, promotion.UpdatedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mp *mysqlPromotion) DeletePromotion(ctx context.Context, id int64) error {
+	stmt, err := mp.DB.Prepare("DELETE FROM promotions WHERE id=$1")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetApplicablePromotions returns the automatic promotions and those redeemed by one of the
+// coupon codes. Whether they are running and what they apply to is left to the caller.
+func (mp *mysqlPromotion) GetApplicablePromotions(ctx context.Context, couponCodes []string) ([]entity.Promotion, error) {
+	rows, err := mp.DB.Query("SELECT "+promotionColumns+" FROM promotions WHERE coupon_code IS NULL OR coupon_code = ANY($1) ORDER BY priority DESC, id", pq.Array(couponCodes))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPromotions(rows)
+}
+
+// GetRedemptions counts the orders that used the promotions, cancelled and expired orders
+// give their redemptions back. Customers are told apart by their email in lower case.
+func (mp *mysqlPromotion) GetRedemptions(ctx context.Context, promotionIDs []int64, customerEmail string) (map[int64]entity.Redemptions, error) {
+	redemptions := make(map[int64]entity.Redemptions)
+	if len(promotionIDs) == 0 {
+		return redemptions, nil
+	}
+
+	rows, err := mp.DB.Query("SELECT r.promotion_id, COUNT(*), COUNT(*) FILTER (WHERE r.customer_email = LOWER($2)) FROM promotion_redemptions r JOIN orders o ON o.id = r.order_id WHERE r.promotion_id = ANY($1) AND o.status NOT IN ($3, $4) GROUP BY r.promotion_id",
+		pq.Array(promotionIDs), customerEmail, entity.OrderCancelled, entity.OrderExpired)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var promotionID int64
+		var redemption entity.Redemptions
+
+		err := rows.Scan(&promotionID, &redemption.Total, &redemption.Customer)
+		if err != nil {
+			return nil, err
+		}
+
+		redemptions[promotionID] = redemption
+	}
+
+	return redemptions, nil
+}
+
+// redeemPromotion records that an order used a promotion. The promotion row is locked while
+// its redemptions are counted so concurrent orders can never go over its limits.
+func redeemPromotion(tx *sql.Tx, order *entity.Order, applied entity.AppliedPromotion) error {
+	var usageLimit, perCustomerLimit int
+	err := tx.QueryRow("SELECT usage_limit, per_customer_limit FROM promotions WHERE id=$1 FOR UPDATE", applied.PromotionID).Scan(&usageLimit, &perCustomerLimit)
+	if err != nil {
+		return err
+	}
+
+	if usageLimit > 0 || perCustomerLimit > 0 {
+		var total, customer int
+		err = tx.QueryRow("SELECT COUNT(*), COUNT(*) FILTER (WHERE r.customer_email = LOWER($2)) FROM promotion_redemptions r JOIN orders o ON o.id = r.order_id WHERE r.promotion_id = $1 AND o.status NOT IN ($3, $4)",
+			applied.PromotionID, order.CustomerEmail, entity.OrderCancelled, entity.OrderExpired).Scan(&total, &customer)
+		if err != nil {
+			return err
+		}
+
+		if (usageLimit > 0 && total >= usageLimit) || (perCustomerLimit > 0 && customer >= perCustomerLimit) {
+			return ErrPromotionLimitReached
+		}
+	}
+
+	_, err = tx.Exec("INSERT INTO promotion_redemptions (promotion_id, order_id, customer_email, name, coupon_code, description, discount, created_at) VALUES($1, $2, LOWER($3), $4, $5, $6, $7, $8)",
+		applied.PromotionID, order.ID, order.CustomerEmail, applied.Name, applied.CouponCode, applied.Description, applied.Discount, order.CreatedAt)
+	return err
+}
+
+func scanPromotions(rows *sql.Rows) ([]entity.Promotion, error) {
+	var promotions []entity.Promotion
+
+	for rows.Next() {
+		var promotion entity.Promotion
+
+		err := scanPromotion(rows, &promotion)
+		if err != nil {
+			return nil, err
+		}
+
+		promotions = append(promotions, promotion)
+	}
+
+	return promotions, nil
+}
+
+func scanPromotion(row interface{ Scan(...interface{}) error }, promotion *entity.Promotion) error {
+	var couponCode sql.NullString
+	var scopeIDs pq.Int64Array
+
+	err := row.Scan(&promotion.ID, &promotion.Name, &promotion.Type, &promotion.Value, &promotion.Scope, &scopeIDs, &promotion.BuyQuantity, &promotion.GetQuantity, &promotion.MinSpend, &couponCode,
+		&promotion.UsageLimit, &promotion.PerCustomerLimit, &promotion.Stackable, &promotion.Priority, &promotion.StartsAt, &promotion.EndsAt, &promotion.CreatedAt, &promotion.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	promotion.CouponCode = couponCode.String
+	promotion.ScopeIDs = []int64(scopeIDs)
+	return nil
+}
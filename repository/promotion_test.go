@@ -0,0 +1,106 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var promotionRowColumns = []string{"id", "name", "promotion_type", "value", "scope", "scope_ids", "buy_quantity", "get_quantity", "min_spend", "coupon_code", "usage_limit", "per_customer_limit", "stackable", "priority", "starts_at", "ends_at", "created_at", "updated_at"}
+
+func TestGetApplicablePromotions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT (.+) FROM promotions WHERE coupon_code IS NULL OR coupon_code = ANY(.+) ORDER BY priority DESC, id").WithArgs(pq.Array([]string{"WELCOME"})).WillReturnRows(sqlmock.NewRows(promotionRowColumns).
+		AddRow(1, "Fiction Week", entity.PromotionPercentage, 20, entity.ScopeCategory, "{3,4}", 0, 0, 0, nil, 0, 0, true, 10, now, nil, now, now).
+		AddRow(2, "Welcome", entity.PromotionFixed, 10000, entity.ScopeAll, "{}", 0, 0, 100000, "WELCOME", 0, 1, true, 0, nil, nil, now, now))
+
+	mysqlPromotion := repository.NewMysqlPromotion(db)
+	ret, err := mysqlPromotion.GetApplicablePromotions(context.Background(), []string{"WELCOME"})
+
+	assert.NoError(t, err)
+	assert.Len(t, ret, 2)
+	assert.Equal(t, []int64{3, 4}, ret[0].ScopeIDs)
+	assert.Empty(t, ret[0].CouponCode)
+	assert.NotNil(t, ret[0].StartsAt)
+	assert.Equal(t, "WELCOME", ret[1].CouponCode)
+	assert.Nil(t, ret[1].StartsAt)
+}
+
+func TestGetRedemptions(t *testing.T) {
+	testCases := []struct {
+		name    string
+		ids     []int64
+		isError bool
+		err     error
+	}{
+		{name: "success", ids: []int64{1, 2}},
+		{name: "no promotions", ids: nil},
+		{name: "failed", ids: []int64{1}, isError: true, err: errors.New("Dummy Error")},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			if len(test.ids) > 0 {
+				query := mock.ExpectQuery("SELECT (.+)customer_email = LOWER(.+) FROM promotion_redemptions r JOIN orders o (.+) GROUP BY r.promotion_id").
+					WithArgs(pq.Array(test.ids), "Jane@Example.com", entity.OrderCancelled, entity.OrderExpired)
+				if test.isError {
+					query.WillReturnError(test.err)
+				} else {
+					query.WillReturnRows(sqlmock.NewRows([]string{"promotion_id", "total", "customer"}).AddRow(1, 40, 1))
+				}
+			}
+
+			mysqlPromotion := repository.NewMysqlPromotion(db)
+			ret, err := mysqlPromotion.GetRedemptions(context.Background(), test.ids, "Jane@Example.com")
+
+			assert.Equal(t, test.isError, err != nil)
+			if len(test.ids) > 1 {
+				assert.Equal(t, entity.Redemptions{Total: 40, Customer: 1}, ret[1])
+				assert.Equal(t, entity.Redemptions{}, ret[2])
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCreatePromotion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	promotion := entity.Promotion{Name: "Welcome", Type: entity.PromotionFixed, Value: 10000, Scope: entity.ScopeAll, CouponCode: "WELCOME", PerCustomerLimit: 1}
+
+	mock.ExpectPrepare("INSERT INTO promotions").ExpectQuery().
+		WithArgs(promotion.Name, promotion.Type, promotion.Value, promotion.Scope, sqlmock.AnyArg(), 0, 0, 0, "WELCOME", 0, 1, false, 0, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+	mysqlPromotion := repository.NewMysqlPromotion(db)
+	err = mysqlPromotion.CreatePromotion(context.Background(), &promotion)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), promotion.ID)
+	assert.False(t, promotion.CreatedAt.IsZero())
+}
@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+const purchaseOrderColumns = "id, supplier_id, warehouse_id, status, expected_at, notes, created_at, updated_at"
+
+var (
+	// ErrPurchaseOrderStatus is returned when a purchase order is changed in a status that does not allow it
+	ErrPurchaseOrderStatus = errors.New("purchase order cannot be changed in its current status")
+	// ErrOverReceipt is returned when more copies are received than were ordered
+	ErrOverReceipt = errors.New("received quantity exceeds the ordered quantity")
+)
+
+type PurchaseOrderRepository interface {
+	GetPurchaseOrders(ctx context.Context) ([]entity.PurchaseOrder, error)
+	GetPurchaseOrder(ctx context.Context, id int64) (entity.PurchaseOrder, error)
+	CreatePurchaseOrder(ctx context.Context, order *entity.PurchaseOrder) error
+	UpdatePurchaseOrder(ctx context.Context, id int64, order *entity.PurchaseOrder) error
+	SetPurchaseOrderStatus(ctx context.Context, id int64, from []string, to string) error
+	ReceivePurchaseOrder(ctx context.Context, receipt *entity.PurchaseOrderReceipt) error
+}
+
+type mysqlPurchaseOrder struct {
+	DB *sql.DB
+}
+
+func NewMysqlPurchaseOrder(db *sql.DB) PurchaseOrderRepository {
+	return &mysqlPurchaseOrder{DB: db}
+}
+
+func (mp *mysqlPurchaseOrder) GetPurchaseOrders(ctx context.Context) ([]entity.PurchaseOrder, error) {
+	var orders []entity.PurchaseOrder
+
+	rows, err := mp.DB.Query("SELECT " + purchaseOrderColumns + " FROM purchase_orders ORDER BY id DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var order entity.PurchaseOrder
+
+		err := rows.Scan(&order.ID, &order.SupplierID, &order.WarehouseID, &order.Status, &order.ExpectedAt, &order.Notes, &order.CreatedAt, &order.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, order)
+	}
+
+	err = mp.attachPurchaseOrderLines(orders)
+	if err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (mp *mysqlPurchaseOrder) GetPurchaseOrder(ctx context.Context, id int64) (entity.PurchaseOrder, error) {
+	var order entity.PurchaseOrder
+
+	err := mp.DB.QueryRow("SELECT "+purchaseOrderColumns+" FROM purchase_orders WHERE id=$1", id).Scan(&order.ID, &order.SupplierID, &order.WarehouseID, &order.Status, &order.ExpectedAt, &order.Notes, &order.CreatedAt, &order.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.PurchaseOrder{}, nil
+		}
+		return entity.PurchaseOrder{}, err
+	}
+
+	orders := []entity.PurchaseOrder{order}
+	err = mp.attachPurchaseOrderLines(orders)
+	if err != nil {
+		return entity.PurchaseOrder{}, err
+	}
+
+	return orders[0], nil
+}
+
+func (mp *mysqlPurchaseOrder) CreatePurchaseOrder(ctx context.Context, order *entity.PurchaseOrder) error {
+	tx, err := mp.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	startTime := time.Now()
+	order.CreatedAt = startTime
+	order.UpdatedAt = startTime
+
+	err = tx.QueryRow("INSERT INTO purchase_orders (supplier_id, warehouse_id, status, expected_at, notes, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id",
+		order.SupplierID, order.WarehouseID, order.Status, order.ExpectedAt, order.Notes, order.CreatedAt, order.UpdatedAt).Scan(&order.ID)
+	if err != nil {
+		return err
+	}
+
+	err = insertPurchaseOrderLines(tx, order)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdatePurchaseOrder replaces a draft purchase order and its lines
+func (mp *mysqlPurchaseOrder) UpdatePurchaseOrder(ctx context.Context, id int64, order *entity.PurchaseOrder) error {
+	tx, err := mp.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	order.ID = id
+	order.UpdatedAt = time.Now()
+
+	res, err := tx.Exec("UPDATE purchase_orders SET supplier_id=$1, warehouse_id=$2, expected_at=$3, notes=$4, updated_at=$5 WHERE id=$6 AND status=$7",
+		order.SupplierID, order.WarehouseID, order.ExpectedAt, order.Notes, order.UpdatedAt, id, entity.PurchaseOrderDraft)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := res.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrPurchaseOrderStatus
+	}
+
+	_, err = tx.Exec("DELETE FROM purchase_order_lines WHERE purchase_order_id=$1", id)
+	if err != nil {
+		return err
+	}
+
+	err = insertPurchaseOrderLines(tx, order)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetPurchaseOrderStatus moves a purchase order to status to when it is in one of the from statuses
+func (mp *mysqlPurchaseOrder) SetPurchaseOrderStatus(ctx context.Context, id int64, from []string, to string) error {
+	res, err := mp.DB.Exec("UPDATE purchase_orders SET status=$1, updated_at=$2 WHERE id=$3 AND status = ANY($4)", to, time.Now(), id, pq.Array(from))
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrPurchaseOrderStatus
+	}
+
+	return nil
+}
+
+// ReceivePurchaseOrder adds the received quantities to the lines of the purchase order and
+// records them as receipts in its warehouse. The purchase order row is locked so concurrent
+// deliveries are applied one after the other and can never exceed the ordered quantities.
+func (mp *mysqlPurchaseOrder) ReceivePurchaseOrder(ctx context.Context, receipt *entity.PurchaseOrderReceipt) error {
+	tx, err := mp.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	var warehouseID int64
+	err = tx.QueryRow("SELECT status, warehouse_id FROM purchase_orders WHERE id=$1 FOR UPDATE", receipt.PurchaseOrderID).Scan(&status, &warehouseID)
+	if err != nil {
+		return err
+	}
+
+	if status != entity.PurchaseOrderOrdered && status != entity.PurchaseOrderPartiallyReceived {
+		return ErrPurchaseOrderStatus
+	}
+
+	now := time.Now()
+	receipt.Movements = nil
+	for _, line := range receipt.Lines {
+		res, err := tx.Exec("UPDATE purchase_order_lines SET received_quantity = received_quantity + $1 WHERE purchase_order_id=$2 AND book_id=$3 AND received_quantity + $1 <= quantity", line.Quantity, receipt.PurchaseOrderID, line.BookID)
+		if err != nil {
+			return err
+		}
+
+		if affected, err := res.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return fmt.Errorf("book ID %d: %w", line.BookID, ErrOverReceipt)
+		}
+
+		movement := entity.StockMovement{BookID: line.BookID, WarehouseID: warehouseID, Type: entity.MovementReceipt, Quantity: line.Quantity, Reason: fmt.Sprintf("purchase order #%d", receipt.PurchaseOrderID), Actor: receipt.Actor, CreatedAt: now}
+		err = applyStockMovement(tx, &movement)
+		if err != nil {
+			return err
+		}
+
+		receipt.Movements = append(receipt.Movements, movement)
+	}
+
+	var complete bool
+	err = tx.QueryRow("SELECT bool_and(received_quantity >= quantity) FROM purchase_order_lines WHERE purchase_order_id=$1", receipt.PurchaseOrderID).Scan(&complete)
+	if err != nil {
+		return err
+	}
+
+	receipt.Status = entity.PurchaseOrderPartiallyReceived
+	if complete {
+		receipt.Status = entity.PurchaseOrderReceived
+	}
+
+	_, err = tx.Exec("UPDATE purchase_orders SET status=$1, updated_at=$2 WHERE id=$3", receipt.Status, now, receipt.PurchaseOrderID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (mp *mysqlPurchaseOrder) attachPurchaseOrderLines(orders []entity.PurchaseOrder) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int)
+	ids := make([]int64, len(orders))
+	for i, order := range orders {
+		index[order.ID] = i
+		ids[i] = order.ID
+	}
+
+	rows, err := mp.DB.Query("SELECT id, purchase_order_id, book_id, quantity, received_quantity, unit_cost FROM purchase_order_lines WHERE purchase_order_id = ANY($1) ORDER BY purchase_order_id, id", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line entity.PurchaseOrderLine
+
+		err := rows.Scan(&line.ID, &line.PurchaseOrderID, &line.BookID, &line.Quantity, &line.ReceivedQuantity, &line.UnitCost)
+		if err != nil {
+			return err
+		}
+
+		i := index[line.PurchaseOrderID]
+		orders[i].Lines = append(orders[i].Lines, line)
+	}
+
+	return nil
+}
+
+func insertPurchaseOrderLines(tx *sql.Tx, order *entity.PurchaseOrder) error {
+	for i := range order.Lines {
+		line := &order.Lines[i]
+		line.PurchaseOrderID = order.ID
+		line.ReceivedQuantity = 0
+
+		err := tx.QueryRow("INSERT INTO purchase_order_lines (purchase_order_id, book_id, quantity, received_quantity, unit_cost) VALUES($1, $2, $3, 0, $4) RETURNING id",
+			order.ID, line.BookID, line.Quantity, line.UnitCost).Scan(&line.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
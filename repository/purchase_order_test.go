@@ -0,0 +1,243 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	purchaseOrderRowColumns     = []string{"id", "supplier_id", "warehouse_id", "status", "expected_at", "notes", "created_at", "updated_at"}
+	purchaseOrderLineRowColumns = []string{"id", "purchase_order_id", "book_id", "quantity", "received_quantity", "unit_cost"}
+)
+
+func TestGetPurchaseOrders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM purchase_orders ORDER BY id DESC").WillReturnRows(sqlmock.NewRows(purchaseOrderRowColumns).
+		AddRow(2, 1, 1, entity.PurchaseOrderDraft, nil, "", time.Now(), time.Now()).
+		AddRow(1, 1, 2, entity.PurchaseOrderReceived, time.Now(), "urgent", time.Now(), time.Now()))
+	mock.ExpectQuery("SELECT (.+) FROM purchase_order_lines WHERE purchase_order_id = ANY(.+)").WillReturnRows(sqlmock.NewRows(purchaseOrderLineRowColumns).
+		AddRow(1, 1, 1, 10, 10, 5000).
+		AddRow(2, 2, 1, 5, 0, 5000).
+		AddRow(3, 2, 2, 3, 0, 7000))
+
+	mysqlPurchaseOrder := repository.NewMysqlPurchaseOrder(db)
+	ret, err := mysqlPurchaseOrder.GetPurchaseOrders(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, ret, 2)
+	assert.Len(t, ret[0].Lines, 2)
+	assert.Len(t, ret[1].Lines, 1)
+	assert.Nil(t, ret[0].ExpectedAt)
+}
+
+func TestGetPurchaseOrder(t *testing.T) {
+	testCases := []struct {
+		name    string
+		found   bool
+		isError bool
+		err     error
+	}{
+		{name: "success", found: true},
+		{name: "not found", found: false},
+		{name: "failed", isError: true, err: errors.New("Dummy Error")},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			query := mock.ExpectQuery("SELECT (.+) FROM purchase_orders WHERE id(.+)").WithArgs(1)
+			switch {
+			case test.isError:
+				query.WillReturnError(test.err)
+			case test.found:
+				query.WillReturnRows(sqlmock.NewRows(purchaseOrderRowColumns).AddRow(1, 1, 1, entity.PurchaseOrderOrdered, nil, "", time.Now(), time.Now()))
+				mock.ExpectQuery("SELECT (.+) FROM purchase_order_lines (.+)").WillReturnRows(sqlmock.NewRows(purchaseOrderLineRowColumns).AddRow(1, 1, 1, 10, 4, 5000))
+			default:
+				query.WillReturnRows(sqlmock.NewRows(purchaseOrderRowColumns))
+			}
+
+			mysqlPurchaseOrder := repository.NewMysqlPurchaseOrder(db)
+			ret, err := mysqlPurchaseOrder.GetPurchaseOrder(context.Background(), 1)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.found, ret.ID == 1)
+			if test.found {
+				assert.Equal(t, 4, ret.Lines[0].ReceivedQuantity)
+			}
+		})
+	}
+}
+
+func TestCreatePurchaseOrder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	order := entity.PurchaseOrder{SupplierID: 1, WarehouseID: 2, Status: entity.PurchaseOrderDraft, Lines: []entity.PurchaseOrderLine{{BookID: 1, Quantity: 10, UnitCost: 5000}, {BookID: 2, Quantity: 3, UnitCost: 7000}}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO purchase_orders (.+)").WithArgs(1, 2, entity.PurchaseOrderDraft, nil, "", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectQuery("INSERT INTO purchase_order_lines (.+)").WithArgs(5, 1, 10, 5000).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+	mock.ExpectQuery("INSERT INTO purchase_order_lines (.+)").WithArgs(5, 2, 3, 7000).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	mock.ExpectCommit()
+
+	mysqlPurchaseOrder := repository.NewMysqlPurchaseOrder(db)
+	err = mysqlPurchaseOrder.CreatePurchaseOrder(context.Background(), &order)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), order.ID)
+	assert.Equal(t, int64(9), order.Lines[1].ID)
+	assert.Equal(t, int64(5), order.Lines[1].PurchaseOrderID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdatePurchaseOrder(t *testing.T) {
+	testCases := []struct {
+		name    string
+		updated int64
+		wantErr error
+	}{
+		{name: "success", updated: 1},
+		{name: "not a draft", updated: 0, wantErr: repository.ErrPurchaseOrderStatus},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			order := entity.PurchaseOrder{SupplierID: 1, WarehouseID: 2, Lines: []entity.PurchaseOrderLine{{BookID: 1, Quantity: 12, UnitCost: 5000}}}
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE purchase_orders SET (.+) WHERE id=(.+) AND status=(.+)").WithArgs(1, 2, nil, "", sqlmock.AnyArg(), 5, entity.PurchaseOrderDraft).
+				WillReturnResult(sqlmock.NewResult(0, test.updated))
+			if test.wantErr == nil {
+				mock.ExpectExec("DELETE FROM purchase_order_lines WHERE purchase_order_id(.+)").WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectQuery("INSERT INTO purchase_order_lines (.+)").WithArgs(5, 1, 12, 5000).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			mysqlPurchaseOrder := repository.NewMysqlPurchaseOrder(db)
+			err = mysqlPurchaseOrder.UpdatePurchaseOrder(context.Background(), 5, &order)
+
+			assert.Equal(t, test.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSetPurchaseOrderStatus(t *testing.T) {
+	testCases := []struct {
+		name    string
+		updated int64
+		wantErr error
+	}{
+		{name: "success", updated: 1},
+		{name: "wrong status", updated: 0, wantErr: repository.ErrPurchaseOrderStatus},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectExec("UPDATE purchase_orders SET status(.+) WHERE id=(.+) AND status = ANY(.+)").WithArgs(entity.PurchaseOrderOrdered, sqlmock.AnyArg(), 5, sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(0, test.updated))
+
+			mysqlPurchaseOrder := repository.NewMysqlPurchaseOrder(db)
+			err = mysqlPurchaseOrder.SetPurchaseOrderStatus(context.Background(), 5, []string{entity.PurchaseOrderDraft}, entity.PurchaseOrderOrdered)
+
+			assert.Equal(t, test.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReceivePurchaseOrder(t *testing.T) {
+	testCases := []struct {
+		name       string
+		status     string
+		updated    int64
+		complete   bool
+		wantStatus string
+		wantErr    error
+	}{
+		{name: "partial", status: entity.PurchaseOrderOrdered, updated: 1, complete: false, wantStatus: entity.PurchaseOrderPartiallyReceived},
+		{name: "complete", status: entity.PurchaseOrderPartiallyReceived, updated: 1, complete: true, wantStatus: entity.PurchaseOrderReceived},
+		{name: "over receipt", status: entity.PurchaseOrderOrdered, updated: 0, wantErr: repository.ErrOverReceipt},
+		{name: "cancelled", status: entity.PurchaseOrderCancelled, wantErr: repository.ErrPurchaseOrderStatus},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			receipt := entity.PurchaseOrderReceipt{PurchaseOrderID: 5, Lines: []entity.ReceiptLine{{BookID: 1, Quantity: 4}}, Actor: "admin"}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT status, warehouse_id FROM purchase_orders WHERE id(.+) FOR UPDATE").WithArgs(5).
+				WillReturnRows(sqlmock.NewRows([]string{"status", "warehouse_id"}).AddRow(test.status, 2))
+			if test.status != entity.PurchaseOrderCancelled {
+				mock.ExpectExec("UPDATE purchase_order_lines SET received_quantity (.+)").WithArgs(4, 5, 1).WillReturnResult(sqlmock.NewResult(0, test.updated))
+			}
+			if test.wantErr == nil {
+				mock.ExpectExec("INSERT INTO warehouse_stocks (.+)").WithArgs(2, 1, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("UPDATE books SET stock (.+) RETURNING stock").WithArgs(4, sqlmock.AnyArg(), 1).WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(14))
+				mock.ExpectQuery("INSERT INTO stock_movements (.+)").WithArgs(1, 0, 2, entity.MovementReceipt, 4, "purchase order #5", "admin", 14, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(30))
+				mock.ExpectQuery("SELECT bool_and(.+) FROM purchase_order_lines (.+)").WithArgs(5).WillReturnRows(sqlmock.NewRows([]string{"complete"}).AddRow(test.complete))
+				mock.ExpectExec("UPDATE purchase_orders SET status(.+)").WithArgs(test.wantStatus, sqlmock.AnyArg(), 5).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			mysqlPurchaseOrder := repository.NewMysqlPurchaseOrder(db)
+			err = mysqlPurchaseOrder.ReceivePurchaseOrder(context.Background(), &receipt)
+
+			if test.wantErr != nil {
+				assert.True(t, errors.Is(err, test.wantErr))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantStatus, receipt.Status)
+				assert.Len(t, receipt.Movements, 1)
+				assert.Equal(t, 14, receipt.Movements[0].StockAfter)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
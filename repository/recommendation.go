@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+// boughtTogetherQuery ranks, for every book, the books that were in the same confirmed orders
+// by how many orders had both and keeps the first ones
+const boughtTogetherQuery = `INSERT INTO book_recommendations (book_id, recommended_book_id, reason, score, computed_at)
+	SELECT book_id, recommended_book_id, $1, score, $2 FROM (
+		SELECT a.book_id, b.book_id AS recommended_book_id, COUNT(DISTINCT a.order_id) AS score,
+			ROW_NUMBER() OVER (PARTITION BY a.book_id ORDER BY COUNT(DISTINCT a.order_id) DESC, b.book_id) AS position
+		FROM order_lines a
+		JOIN order_lines b ON b.order_id = a.order_id AND b.book_id <> a.book_id
+		JOIN orders o ON o.id = a.order_id
+		WHERE o.status <> ALL($3)
+		GROUP BY a.book_id, b.book_id
+	) bought WHERE position <= $4`
+
+// similarQuery ranks, for every book nobody bought together with another one yet, the books
+// sharing an author (3 points), a category (2 points) or the publisher (1 point) with it
+const similarQuery = `INSERT INTO book_recommendations (book_id, recommended_book_id, reason, score, computed_at)
+	SELECT book_id, recommended_book_id, $1, score, $2 FROM (
+		SELECT book_id, recommended_book_id, SUM(weight) AS score,
+			ROW_NUMBER() OVER (PARTITION BY book_id ORDER BY SUM(weight) DESC, recommended_book_id) AS position
+		FROM (
+			SELECT x.book_id, y.book_id AS recommended_book_id, 3 AS weight FROM book_authors x JOIN book_authors y ON y.author_id = x.author_id AND y.book_id <> x.book_id
+			UNION ALL
+			SELECT x.book_id, y.book_id, 2 FROM book_categories x JOIN book_categories y ON y.category_id = x.category_id AND y.book_id <> x.book_id
+			UNION ALL
+			SELECT x.id, y.id, 1 FROM books x JOIN books y ON y.publisher_id = x.publisher_id AND y.id <> x.id
+		) related
+		WHERE book_id NOT IN (SELECT book_id FROM book_recommendations)
+		GROUP BY book_id, recommended_book_id
+	) similar WHERE position <= $3`
+
+type RecommendationRepository interface {
+	GetRecommendations(ctx context.Context, bookID int64) ([]entity.Recommendation, error)
+	RefreshRecommendations(ctx context.Context, limit int, now time.Time) (int64, error)
+}
+
+type mysqlRecommendation struct {
+	DB *sql.DB
+}
+
+func NewMysqlRecommendation(db *sql.DB) RecommendationRepository {
+	return &mysqlRecommendation{DB: db}
+}
+
+// GetRecommendations reads the recommendations of a book as the last refresh computed them
+func (mr *mysqlRecommendation) GetRecommendations(ctx context.Context, bookID int64) ([]entity.Recommendation, error) {
+	var recommendations []entity.Recommendation
+
+	rows, err := mr.DB.Query("SELECT books.id, books.title, "+currentPriceColumn+", books.stock - books.reserved, COALESCE(ROUND(books.rating_total::numeric / NULLIF(books.rating_count, 0), 2), 0), r.reason, r.score, r.computed_at FROM book_recommendations r JOIN books ON books.id = r.recommended_book_id WHERE r.book_id=$1 ORDER BY r.score DESC, r.recommended_book_id", bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var recommendation entity.Recommendation
+
+		err := rows.Scan(&recommendation.BookID, &recommendation.Title, &recommendation.Price, &recommendation.Available, &recommendation.Rating, &recommendation.Reason, &recommendation.Score, &recommendation.ComputedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		recommendations = append(recommendations, recommendation)
+	}
+
+	return recommendations, nil
+}
+
+// RefreshRecommendations recomputes the recommendations of every book, at most limit each, and
+// returns how many were stored. The table is replaced in one transaction so readers keep the
+// previous recommendations until the new ones are complete, and a second refresh running at
+// the same time waits for the first one.
+func (mr *mysqlRecommendation) RefreshRecommendations(ctx context.Context, limit int, now time.Time) (int64, error) {
+	tx, err := mr.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("LOCK TABLE book_recommendations IN EXCLUSIVE MODE")
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec("DELETE FROM book_recommendations")
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := tx.Exec(boughtTogetherQuery, entity.RecommendationBoughtTogether, now, pq.Array([]string{entity.OrderPending, entity.OrderCancelled, entity.OrderExpired}), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	boughtTogether, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	res, err = tx.Exec(similarQuery, entity.RecommendationSimilar, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	similar, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return boughtTogether + similar, nil
+}
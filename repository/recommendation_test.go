@@ -0,0 +1,83 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshRecommendations(t *testing.T) {
+	testCases := []struct {
+		name       string
+		similarErr error
+		wantStored int64
+		isError    bool
+	}{
+		{name: "success", wantStored: 5},
+		{name: "similar books failed", similarErr: errors.New("canceling statement due to statement timeout"), isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			now := time.Now()
+			mock.ExpectBegin()
+			mock.ExpectExec("LOCK TABLE book_recommendations IN EXCLUSIVE MODE").WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec("DELETE FROM book_recommendations").WillReturnResult(sqlmock.NewResult(0, 7))
+			mock.ExpectExec("INSERT INTO book_recommendations (.+) FROM order_lines a JOIN order_lines b (.+) WHERE position <= \\$4").
+				WithArgs(entity.RecommendationBoughtTogether, now, sqlmock.AnyArg(), 10).
+				WillReturnResult(sqlmock.NewResult(0, 3))
+			similar := mock.ExpectExec("INSERT INTO book_recommendations (.+) WHERE book_id NOT IN \\(SELECT book_id FROM book_recommendations\\) (.+) WHERE position <= \\$3").
+				WithArgs(entity.RecommendationSimilar, now, 10)
+			if test.similarErr != nil {
+				similar.WillReturnError(test.similarErr)
+				mock.ExpectRollback()
+			} else {
+				similar.WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			}
+
+			mysqlRecommendation := repository.NewMysqlRecommendation(db)
+			stored, err := mysqlRecommendation.RefreshRecommendations(context.Background(), 10, now)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.wantStored, stored)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetRecommendations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "title", "price", "available", "rating", "reason", "score", "computed_at"}).
+		AddRow(6, "Children of Dune", 90000, 4, 4.5, entity.RecommendationBoughtTogether, 12, now)
+	mock.ExpectQuery("SELECT (.+) FROM book_recommendations r JOIN books ON books.id = r.recommended_book_id WHERE r.book_id=\\$1 ORDER BY r.score DESC").
+		WithArgs(5).
+		WillReturnRows(rows)
+
+	mysqlRecommendation := repository.NewMysqlRecommendation(db)
+	recommendations, err := mysqlRecommendation.GetRecommendations(context.Background(), 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Recommendation{{BookID: 6, Title: "Children of Dune", Price: 90000, Available: 4, Rating: 4.5, Reason: entity.RecommendationBoughtTogether, Score: 12, ComputedAt: now}}, recommendations)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
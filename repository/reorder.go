@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+type ReorderRepository interface {
+	GetReorderPoint(ctx context.Context, bookID int64) (entity.ReorderPoint, error)
+	SetReorderPoint(ctx context.Context, point *entity.ReorderPoint) error
+	DeleteReorderPoint(ctx context.Context, bookID int64) error
+	GetLowStock(ctx context.Context) ([]entity.LowStock, error)
+	MarkAlerted(ctx context.Context, bookIDs []int64, now time.Time) error
+	ResetRestockedAlerts(ctx context.Context) (int64, error)
+}
+
+type mysqlReorder struct {
+	DB *sql.DB
+}
+
+func NewMysqlReorder(db *sql.DB) ReorderRepository {
+	return &mysqlReorder{DB: db}
+}
+
+func (mr *mysqlReorder) GetReorderPoint(ctx context.Context, bookID int64) (entity.ReorderPoint, error) {
+	var point entity.ReorderPoint
+
+	err := mr.DB.QueryRow("SELECT book_id, threshold, reorder_quantity, alerted_at, created_at, updated_at FROM reorder_points WHERE book_id=$1", bookID).
+		Scan(&point.BookID, &point.Threshold, &point.ReorderQuantity, &point.AlertedAt, &point.CreatedAt, &point.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.ReorderPoint{}, nil
+		}
+		return entity.ReorderPoint{}, err
+	}
+
+	return point, nil
+}
+
+// SetReorderPoint creates or replaces the reorder point of a book. A changed threshold may
+// already be crossed, the alert is cleared so the next scan reports it.
+func (mr *mysqlReorder) SetReorderPoint(ctx context.Context, point *entity.ReorderPoint) error {
+	stmt, err := mr.DB.Prepare("INSERT INTO reorder_points (book_id, threshold, reorder_quantity, alerted_at, created_at, updated_at) VALUES($1, $2, $3, NULL, $4, $4) ON CONFLICT (book_id) DO UPDATE SET threshold = EXCLUDED.threshold, reorder_quantity = EXCLUDED.reorder_quantity, alerted_at = NULL, updated_at = EXCLUDED.updated_at RETURNING created_at")
+	if err != nil {
+		return err
+	}
+
+	point.UpdatedAt = time.Now()
+	point.AlertedAt = nil
+
+	err = stmt.QueryRow(point.BookID, point.Threshold, point.ReorderQuantity, point.UpdatedAt).Scan(&point.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mr *mysqlReorder) DeleteReorderPoint(ctx context.Context, bookID int64) error {
+	stmt, err := mr.DB.Prepare("DELETE FROM reorder_points WHERE book_id=$1")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(bookID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetLowStock returns the books whose available stock is at or below their reorder point,
+// the most depleted first
+func (mr *mysqlReorder) GetLowStock(ctx context.Context) ([]entity.LowStock, error) {
+	var books []entity.LowStock
+
+	rows, err := mr.DB.Query("SELECT b.id, b.title, b.stock, b.reserved, r.threshold, r.reorder_quantity, r.alerted_at FROM reorder_points r JOIN books b ON b.id = r.book_id WHERE b.stock - b.reserved <= r.threshold ORDER BY b.stock - b.reserved, b.id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var book entity.LowStock
+
+		err := rows.Scan(&book.BookID, &book.Title, &book.Stock, &book.Reserved, &book.Threshold, &book.ReorderQuantity, &book.AlertedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		book.Available = book.Stock - book.Reserved
+		books = append(books, book)
+	}
+
+	return books, nil
+}
+
+func (mr *mysqlReorder) MarkAlerted(ctx context.Context, bookIDs []int64, now time.Time) error {
+	_, err := mr.DB.Exec("UPDATE reorder_points SET alerted_at=$1 WHERE book_id = ANY($2)", now, pq.Array(bookIDs))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ResetRestockedAlerts clears the alert of books that were restocked above their reorder point
+func (mr *mysqlReorder) ResetRestockedAlerts(ctx context.Context) (int64, error) {
+	res, err := mr.DB.Exec("UPDATE reorder_points r SET alerted_at = NULL FROM books b WHERE b.id = r.book_id AND r.alerted_at IS NOT NULL AND b.stock - b.reserved > r.threshold")
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,146 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReorderPoint(t *testing.T) {
+	testCases := []struct {
+		name    string
+		found   bool
+		isError bool
+		err     error
+	}{
+		{name: "success", found: true},
+		{name: "not found", found: false},
+		{name: "failed", isError: true, err: errors.New("Dummy Error")},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			columns := []string{"book_id", "threshold", "reorder_quantity", "alerted_at", "created_at", "updated_at"}
+			query := mock.ExpectQuery("SELECT (.+) FROM reorder_points WHERE book_id(.+)").WithArgs(1)
+			switch {
+			case test.isError:
+				query.WillReturnError(test.err)
+			case test.found:
+				query.WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 5, 20, nil, time.Now(), time.Now()))
+			default:
+				query.WillReturnRows(sqlmock.NewRows(columns))
+			}
+
+			mysqlReorder := repository.NewMysqlReorder(db)
+			ret, err := mysqlReorder.GetReorderPoint(context.Background(), 1)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.found, ret.BookID == 1)
+		})
+	}
+}
+
+func TestSetReorderPoint(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	alertedAt := time.Now()
+	point := entity.ReorderPoint{BookID: 1, Threshold: 5, ReorderQuantity: 20, AlertedAt: &alertedAt}
+	mock.ExpectPrepare("INSERT INTO reorder_points (.+) ON CONFLICT (.+)").ExpectQuery().WithArgs(1, 5, 20, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+
+	mysqlReorder := repository.NewMysqlReorder(db)
+	err = mysqlReorder.SetReorderPoint(context.Background(), &point)
+
+	assert.NoError(t, err)
+	assert.Nil(t, point.AlertedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteReorderPoint(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectPrepare("DELETE FROM reorder_points (.+)").ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mysqlReorder := repository.NewMysqlReorder(db)
+	err = mysqlReorder.DeleteReorderPoint(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLowStock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "title", "stock", "reserved", "threshold", "reorder_quantity", "alerted_at"}).
+		AddRow(2, "Dune", 1, 1, 3, 10, nil).
+		AddRow(1, "Emma", 4, 0, 5, 20, time.Now())
+	mock.ExpectQuery("SELECT (.+) FROM reorder_points r JOIN books b (.+) WHERE b.stock - b.reserved <= r.threshold (.+)").WillReturnRows(rows)
+
+	mysqlReorder := repository.NewMysqlReorder(db)
+	ret, err := mysqlReorder.GetLowStock(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, ret, 2)
+	assert.Equal(t, 0, ret[0].Available)
+	assert.Nil(t, ret[0].AlertedAt)
+	assert.NotNil(t, ret[1].AlertedAt)
+}
+
+func TestMarkAlerted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec("UPDATE reorder_points SET alerted_at(.+)").WithArgs(now, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	mysqlReorder := repository.NewMysqlReorder(db)
+	err = mysqlReorder.MarkAlerted(context.Background(), []int64{1, 2}, now)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResetRestockedAlerts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE reorder_points r SET alerted_at = NULL (.+) b.stock - b.reserved > r.threshold").WillReturnResult(sqlmock.NewResult(0, 3))
+
+	mysqlReorder := repository.NewMysqlReorder(db)
+	reset, err := mysqlReorder.ResetRestockedAlerts(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), reset)
+}
@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+const returnColumns = "id, order_id, status, customer_note, staff_note, refund_amount, created_at, updated_at"
+
+var (
+	// ErrReturnStatus is returned when a return is changed in a status that does not allow it
+	ErrReturnStatus = errors.New("return cannot be changed in its current status")
+	// ErrOverReturn is returned when more copies are returned than are left of the order line
+	ErrOverReturn = errors.New("returned quantity exceeds what is left of the order line")
+)
+
+type ReturnRepository interface {
+	GetReturns(ctx context.Context) ([]entity.Return, error)
+	GetReturn(ctx context.Context, id int64) (entity.Return, error)
+	GetOrderReturns(ctx context.Context, orderID int64) ([]entity.Return, error)
+	CreateReturn(ctx context.Context, returned *entity.Return) error
+	SetReturnStatus(ctx context.Context, id int64, from string, to string, staffNote string) error
+	ReceiveReturn(ctx context.Context, returned *entity.Return, actor string) error
+	RefundReturn(ctx context.Context, returned *entity.Return, paymentID int64) error
+}
+
+type mysqlReturn struct {
+	DB *sql.DB
+}
+
+func NewMysqlReturn(db *sql.DB) ReturnRepository {
+	return &mysqlReturn{DB: db}
+}
+
+func (mr *mysqlReturn) GetReturns(ctx context.Context) ([]entity.Return, error) {
+	return mr.getReturns("SELECT " + returnColumns + " FROM returns ORDER BY id DESC")
+}
+
+func (mr *mysqlReturn) GetReturn(ctx context.Context, id int64) (entity.Return, error) {
+	returns, err := mr.getReturns("SELECT "+returnColumns+" FROM returns WHERE id=$1", id)
+	if err != nil {
+		return entity.Return{}, err
+	}
+
+	if len(returns) == 0 {
+		return entity.Return{}, nil
+	}
+
+	return returns[0], nil
+}
+
+func (mr *mysqlReturn) GetOrderReturns(ctx context.Context, orderID int64) ([]entity.Return, error) {
+	return mr.getReturns("SELECT "+returnColumns+" FROM returns WHERE order_id=$1 ORDER BY id", orderID)
+}
+
+// CreateReturn stores a requested return. The order row is locked so concurrent requests can
+// never return more of a line than was ordered, rejected returns do not count.
+func (mr *mysqlReturn) CreateReturn(ctx context.Context, ret *entity.Return) error {
+	tx, err := mr.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("SELECT id FROM orders WHERE id=$1 FOR UPDATE", ret.OrderID)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range ret.Lines {
+		var left int
+		err = tx.QueryRow("SELECT ol.quantity - COALESCE((SELECT SUM(rl.quantity) FROM return_lines rl JOIN returns r ON r.id = rl.return_id WHERE rl.order_line_id = ol.id AND r.status <> $1), 0) FROM order_lines ol WHERE ol.id=$2 AND ol.order_id=$3",
+			entity.ReturnRejected, line.OrderLineID, ret.OrderID).Scan(&left)
+		if err != nil {
+			return err
+		}
+
+		if line.Quantity > left {
+			return fmt.Errorf("order line ID %d has %d left to return: %w", line.OrderLineID, left, ErrOverReturn)
+		}
+	}
+
+	startTime := time.Now()
+	ret.CreatedAt = startTime
+	ret.UpdatedAt = startTime
+
+	err = tx.QueryRow("INSERT INTO returns (order_id, status, customer_note, staff_note, refund_amount, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id",
+		ret.OrderID, ret.Status, ret.CustomerNote, ret.StaffNote, ret.RefundAmount, ret.CreatedAt, ret.UpdatedAt).Scan(&ret.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := range ret.Lines {
+		line := &ret.Lines[i]
+		line.ReturnID = ret.ID
+
+		err = tx.QueryRow("INSERT INTO return_lines (return_id, order_line_id, book_id, quantity, reason) VALUES($1, $2, $3, $4, $5) RETURNING id",
+			line.ReturnID, line.OrderLineID, line.BookID, line.Quantity, line.Reason).Scan(&line.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SetReturnStatus moves a return from status from to status to with the staff's note
+func (mr *mysqlReturn) SetReturnStatus(ctx context.Context, id int64, from string, to string, staffNote string) error {
+	res, err := mr.DB.Exec("UPDATE returns SET status=$1, staff_note=$2, updated_at=$3 WHERE id=$4 AND status=$5", to, staffNote, time.Now(), id, from)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrReturnStatus
+	}
+
+	return nil
+}
+
+// ReceiveReturn records the disposition of the books of an approved return, or of a refunded
+// one that was not received yet, which stays refunded. Every line comes back into its
+// warehouse as a return in the stock ledger, written off books go out again at once as damage.
+func (mr *mysqlReturn) ReceiveReturn(ctx context.Context, ret *entity.Return, actor string) error {
+	tx, err := mr.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = lockReturn(tx, ret.ID, entity.ReturnApproved, entity.ReturnRefunded)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	reason := fmt.Sprintf("return #%d", ret.ID)
+	for _, line := range ret.Lines {
+		// the books come back as the edition they were sold as, digital editions hold no stock
+		var skuID int64
+		var digital bool
+		err = tx.QueryRow("UPDATE return_lines rl SET disposition=$1, warehouse_id=$2 FROM order_lines ol LEFT JOIN skus s ON s.id = ol.sku_id WHERE rl.id=$3 AND rl.return_id=$4 AND rl.disposition = '' AND ol.id = rl.order_line_id RETURNING COALESCE(ol.sku_id, 0), COALESCE(s.format = ANY($5), false)",
+			line.Disposition, line.WarehouseID, line.ID, ret.ID, pq.Array([]string{entity.FormatEbook, entity.FormatAudiobook})).Scan(&skuID, &digital)
+		if err == sql.ErrNoRows {
+			return ErrReturnStatus
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if digital {
+			continue
+		}
+
+		movement := entity.StockMovement{BookID: line.BookID, SKUID: skuID, WarehouseID: line.WarehouseID, Type: entity.MovementReturn, Quantity: line.Quantity, Reason: reason, Actor: actor, CreatedAt: now}
+		err = applyStockMovement(tx, &movement)
+		if err != nil {
+			return err
+		}
+
+		if line.Disposition == entity.DispositionWriteOff {
+			movement = entity.StockMovement{BookID: line.BookID, SKUID: skuID, WarehouseID: line.WarehouseID, Type: entity.MovementDamage, Quantity: -line.Quantity, Reason: reason, Actor: actor, CreatedAt: now}
+			err = applyStockMovement(tx, &movement)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	ret.UpdatedAt = now
+	err = tx.QueryRow("UPDATE returns SET status = CASE WHEN status = $1 THEN $2 ELSE status END, updated_at=$3 WHERE id=$4 RETURNING status",
+		entity.ReturnApproved, entity.ReturnReceived, ret.UpdatedAt, ret.ID).Scan(&ret.Status)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RefundReturn records the refund of an approved or received return, which the gateway already
+// paid back, on the return, the payment and the order together. The order is returned once
+// all of its books are refunded, partially returned until then.
+func (mr *mysqlReturn) RefundReturn(ctx context.Context, ret *entity.Return, paymentID int64) error {
+	tx, err := mr.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = lockReturn(tx, ret.ID, entity.ReturnApproved, entity.ReturnReceived)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	res, err := tx.Exec("UPDATE payments SET refunded_amount = refunded_amount + $1, status = CASE WHEN refunded_amount + $1 = captured_amount THEN $2 ELSE status END, updated_at=$3 WHERE id=$4 AND status=$5 AND refunded_amount + $1 <= captured_amount",
+		ret.RefundAmount, entity.PaymentRefunded, now, paymentID, entity.PaymentCaptured)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := res.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrPaymentChanged
+	}
+
+	ret.Status, ret.UpdatedAt = entity.ReturnRefunded, now
+	_, err = tx.Exec("UPDATE returns SET status=$1, refund_amount=$2, updated_at=$3 WHERE id=$4", ret.Status, ret.RefundAmount, ret.UpdatedAt, ret.ID)
+	if err != nil {
+		return err
+	}
+
+	var complete bool
+	err = tx.QueryRow("SELECT bool_and(ol.quantity <= COALESCE((SELECT SUM(rl.quantity) FROM return_lines rl JOIN returns r ON r.id = rl.return_id WHERE rl.order_line_id = ol.id AND r.status = $1), 0)) FROM order_lines ol WHERE ol.order_id=$2",
+		entity.ReturnRefunded, ret.OrderID).Scan(&complete)
+	if err != nil {
+		return err
+	}
+
+	status := entity.OrderPartiallyReturned
+	if complete {
+		status = entity.OrderReturned
+	}
+
+	_, err = tx.Exec("UPDATE orders SET status=$1, refunded_total = refunded_total + $2, updated_at=$3 WHERE id=$4", status, ret.RefundAmount, now, ret.OrderID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (mr *mysqlReturn) getReturns(query string, args ...interface{}) ([]entity.Return, error) {
+	var returns []entity.Return
+
+	rows, err := mr.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ret entity.Return
+
+		err := rows.Scan(&ret.ID, &ret.OrderID, &ret.Status, &ret.CustomerNote, &ret.StaffNote, &ret.RefundAmount, &ret.CreatedAt, &ret.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		returns = append(returns, ret)
+	}
+
+	err = mr.attachReturnLines(returns)
+	if err != nil {
+		return nil, err
+	}
+
+	return returns, nil
+}
+
+func (mr *mysqlReturn) attachReturnLines(returns []entity.Return) error {
+	if len(returns) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int)
+	ids := make([]int64, len(returns))
+	for i, ret := range returns {
+		index[ret.ID] = i
+		ids[i] = ret.ID
+	}
+
+	rows, err := mr.DB.Query("SELECT id, return_id, order_line_id, book_id, quantity, reason, disposition, COALESCE(warehouse_id, 0) FROM return_lines WHERE return_id = ANY($1) ORDER BY return_id, id", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line entity.ReturnLine
+
+		err := rows.Scan(&line.ID, &line.ReturnID, &line.OrderLineID, &line.BookID, &line.Quantity, &line.Reason, &line.Disposition, &line.WarehouseID)
+		if err != nil {
+			return err
+		}
+
+		i := index[line.ReturnID]
+		returns[i].Lines = append(returns[i].Lines, line)
+	}
+
+	return nil
+}
+
+// lockReturn locks the row of a return for the transaction, which fails with ErrReturnStatus
+// unless the return is in one of the statuses
+func lockReturn(tx *sql.Tx, id int64, statuses ...string) error {
+	var status string
+	err := tx.QueryRow("SELECT status FROM returns WHERE id=$1 FOR UPDATE", id).Scan(&status)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range statuses {
+		if status == s {
+			return nil
+		}
+	}
+
+	return ErrReturnStatus
+}
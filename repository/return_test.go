@@ -0,0 +1,184 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateReturn(t *testing.T) {
+	testCases := []struct {
+		name    string
+		left    int
+		wantErr error
+	}{
+		{name: "success", left: 2},
+		{name: "more than is left", left: 1, wantErr: repository.ErrOverReturn},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("SELECT id FROM orders WHERE id(.+) FOR UPDATE").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery("SELECT ol.quantity - (.+) FROM order_lines ol (.+)").WithArgs(entity.ReturnRejected, 3, 1).WillReturnRows(sqlmock.NewRows([]string{"left"}).AddRow(test.left))
+			if test.wantErr == nil {
+				mock.ExpectQuery("INSERT INTO returns (.+)").WithArgs(1, entity.ReturnRequested, "cover torn", "", 0, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectQuery("INSERT INTO return_lines (.+)").WithArgs(7, 3, 1, 2, entity.ReturnDamaged).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			ret := entity.Return{OrderID: 1, Status: entity.ReturnRequested, CustomerNote: "cover torn", Lines: []entity.ReturnLine{{OrderLineID: 3, BookID: 1, Quantity: 2, Reason: entity.ReturnDamaged}}}
+			mysqlReturn := repository.NewMysqlReturn(db)
+			err = mysqlReturn.CreateReturn(context.Background(), &ret)
+
+			assert.True(t, errors.Is(err, test.wantErr))
+			if test.wantErr == nil {
+				assert.Equal(t, int64(7), ret.ID)
+				assert.Equal(t, int64(9), ret.Lines[0].ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReceiveReturn(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      string
+		disposition string
+		skuID       int64
+		digital     bool
+		received    bool
+		wantStatus  string
+	}{
+		{name: "restock", status: entity.ReturnApproved, disposition: entity.DispositionRestock, wantStatus: entity.ReturnReceived},
+		{name: "write off", status: entity.ReturnApproved, disposition: entity.DispositionWriteOff, wantStatus: entity.ReturnReceived},
+		{name: "restock an edition", status: entity.ReturnApproved, disposition: entity.DispositionRestock, skuID: 4, wantStatus: entity.ReturnReceived},
+		{name: "digital edition holds no stock", status: entity.ReturnApproved, disposition: entity.DispositionRestock, skuID: 5, digital: true, wantStatus: entity.ReturnReceived},
+		{name: "refunded return stays refunded", status: entity.ReturnRefunded, disposition: entity.DispositionRestock, wantStatus: entity.ReturnRefunded},
+		{name: "refunded return received already", status: entity.ReturnRefunded, disposition: entity.DispositionRestock, received: true},
+		{name: "not approved", status: entity.ReturnRequested, disposition: entity.DispositionRestock},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT status FROM returns WHERE id(.+) FOR UPDATE").WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(test.status))
+			if test.status == entity.ReturnRequested {
+				mock.ExpectRollback()
+			} else {
+				lines := mock.ExpectQuery("UPDATE return_lines rl SET disposition(.+) rl.disposition = ''(.+) RETURNING COALESCE\\(ol.sku_id, 0\\)(.+)").WithArgs(test.disposition, 2, 9, 7, sqlmock.AnyArg())
+				if test.received {
+					lines.WillReturnError(sql.ErrNoRows)
+					mock.ExpectRollback()
+				} else {
+					lines.WillReturnRows(sqlmock.NewRows([]string{"sku_id", "digital"}).AddRow(test.skuID, test.digital))
+				}
+			}
+			if test.wantStatus != "" && !test.digital {
+				mock.ExpectExec("INSERT INTO warehouse_stocks (.+)").WithArgs(2, 1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				if test.skuID != 0 {
+					mock.ExpectExec("INSERT INTO warehouse_sku_stocks (.+)").WithArgs(2, test.skuID, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec("UPDATE skus SET stock = stock (.+)").WithArgs(2, sqlmock.AnyArg(), test.skuID, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				mock.ExpectQuery("UPDATE books SET stock (.+) RETURNING stock").WithArgs(2, sqlmock.AnyArg(), 1).WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(12))
+				mock.ExpectQuery("INSERT INTO stock_movements (.+)").WithArgs(1, test.skuID, 2, entity.MovementReturn, 2, "return #7", "admin", 12, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(40))
+			}
+			if test.disposition == entity.DispositionWriteOff {
+				mock.ExpectExec("UPDATE warehouse_stocks SET stock (.+)").WithArgs(-2, 2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("UPDATE books SET stock (.+) RETURNING stock").WithArgs(-2, sqlmock.AnyArg(), 1).WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(10))
+				mock.ExpectQuery("INSERT INTO stock_movements (.+)").WithArgs(1, 0, 2, entity.MovementDamage, -2, "return #7", "admin", 10, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(41))
+			}
+			if test.wantStatus != "" {
+				mock.ExpectQuery("UPDATE returns SET status = CASE (.+) RETURNING status").WithArgs(entity.ReturnApproved, entity.ReturnReceived, sqlmock.AnyArg(), 7).
+					WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(test.wantStatus))
+				mock.ExpectCommit()
+			}
+
+			ret := entity.Return{ID: 7, OrderID: 1, Lines: []entity.ReturnLine{{ID: 9, ReturnID: 7, BookID: 1, Quantity: 2, Disposition: test.disposition, WarehouseID: 2}}}
+			mysqlReturn := repository.NewMysqlReturn(db)
+			err = mysqlReturn.ReceiveReturn(context.Background(), &ret, "admin")
+
+			if test.wantStatus == "" {
+				assert.Equal(t, repository.ErrReturnStatus, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantStatus, ret.Status)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRefundReturn(t *testing.T) {
+	testCases := []struct {
+		name            string
+		status          string
+		paymentUpdated  int64
+		complete        bool
+		wantOrderStatus string
+		wantErr         error
+	}{
+		{name: "some books returned", status: entity.ReturnReceived, paymentUpdated: 1, complete: false, wantOrderStatus: entity.OrderPartiallyReturned},
+		{name: "all books returned", status: entity.ReturnApproved, paymentUpdated: 1, complete: true, wantOrderStatus: entity.OrderReturned},
+		{name: "payment refunded meanwhile", status: entity.ReturnReceived, paymentUpdated: 0, wantErr: repository.ErrPaymentChanged},
+		{name: "not approved", status: entity.ReturnRequested, wantErr: repository.ErrReturnStatus},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT status FROM returns WHERE id(.+) FOR UPDATE").WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(test.status))
+			if test.status != entity.ReturnRequested {
+				mock.ExpectExec("UPDATE payments SET refunded_amount (.+)").WithArgs(45000, entity.PaymentRefunded, sqlmock.AnyArg(), 3, entity.PaymentCaptured).
+					WillReturnResult(sqlmock.NewResult(0, test.paymentUpdated))
+			}
+			if test.wantErr == nil {
+				mock.ExpectExec("UPDATE returns SET status(.+)").WithArgs(entity.ReturnRefunded, 45000, sqlmock.AnyArg(), 7).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT bool_and(.+) FROM order_lines ol (.+)").WithArgs(entity.ReturnRefunded, 1).WillReturnRows(sqlmock.NewRows([]string{"complete"}).AddRow(test.complete))
+				mock.ExpectExec("UPDATE orders SET status(.+)").WithArgs(test.wantOrderStatus, 45000, sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			ret := entity.Return{ID: 7, OrderID: 1, RefundAmount: 45000}
+			mysqlReturn := repository.NewMysqlReturn(db)
+			err = mysqlReturn.RefundReturn(context.Background(), &ret, 3)
+
+			assert.Equal(t, test.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
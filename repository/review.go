@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+const reviewColumns = "id, book_id, customer_email, rating, title, body, verified_purchase, status, moderated_by, moderated_at, created_at, updated_at"
+
+var (
+	// ErrDuplicateReview is returned when a customer reviews a book they already reviewed
+	ErrDuplicateReview = errors.New("the customer already reviewed this book")
+	// ErrReviewChanged is returned when a review left the status it was moderated from
+	ErrReviewChanged = errors.New("review was changed meanwhile")
+)
+
+type ReviewRepository interface {
+	GetReviews(ctx context.Context, filter entity.ReviewFilter) ([]entity.Review, error)
+	GetReview(ctx context.Context, id int64) (entity.Review, error)
+	CreateReview(ctx context.Context, review *entity.Review) error
+	ModerateReview(ctx context.Context, review *entity.Review, from string) error
+	HasPurchased(ctx context.Context, customerEmail string, bookID int64) (bool, error)
+}
+
+type mysqlReview struct {
+	DB *sql.DB
+}
+
+func NewMysqlReview(db *sql.DB) ReviewRepository {
+	return &mysqlReview{DB: db}
+}
+
+func (mr *mysqlReview) GetReviews(ctx context.Context, filter entity.ReviewFilter) ([]entity.Review, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.BookID != 0 {
+		args = append(args, filter.BookID)
+		conditions = append(conditions, fmt.Sprintf("book_id=$%d", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status=$%d", len(args)))
+	}
+
+	query := "SELECT " + reviewColumns + " FROM reviews"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	return mr.getReviews(query+" ORDER BY created_at DESC, id DESC", args...)
+}
+
+func (mr *mysqlReview) GetReview(ctx context.Context, id int64) (entity.Review, error) {
+	reviews, err := mr.getReviews("SELECT "+reviewColumns+" FROM reviews WHERE id=$1", id)
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	if len(reviews) == 0 {
+		return entity.Review{}, nil
+	}
+
+	return reviews[0], nil
+}
+
+// CreateReview stores a pending review, a second review of the same book by the same customer
+// fails with ErrDuplicateReview
+func (mr *mysqlReview) CreateReview(ctx context.Context, review *entity.Review) error {
+	stmt, err := mr.DB.Prepare("INSERT INTO reviews (book_id, customer_email, rating, title, body, verified_purchase, status, moderated_by, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $6, $7, '', $8, $9) ON CONFLICT (book_id, customer_email) DO NOTHING RETURNING id")
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	review.CreatedAt = startTime
+	review.UpdatedAt = startTime
+
+	err = stmt.QueryRow(review.BookID, review.CustomerEmail, review.Rating, review.Title, review.Body, review.VerifiedPurchase, review.Status, review.CreatedAt, review.UpdatedAt).Scan(&review.ID)
+	if err == sql.ErrNoRows {
+		return ErrDuplicateReview
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ModerateReview moves a review on from status from and keeps the rating of its book up to
+// date: the review counts toward it while it is approved
+func (mr *mysqlReview) ModerateReview(ctx context.Context, review *entity.Review, from string) error {
+	tx, err := mr.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	review.UpdatedAt = time.Now()
+	res, err := tx.Exec("UPDATE reviews SET status=$1, moderated_by=$2, moderated_at=$3, updated_at=$4 WHERE id=$5 AND status=$6",
+		review.Status, review.ModeratedBy, review.ModeratedAt, review.UpdatedAt, review.ID, from)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := res.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrReviewChanged
+	}
+
+	var count int
+	switch {
+	case from != entity.ReviewApproved && review.Status == entity.ReviewApproved:
+		count = 1
+	case from == entity.ReviewApproved && review.Status != entity.ReviewApproved:
+		count = -1
+	}
+
+	if count != 0 {
+		_, err = tx.Exec("UPDATE books SET rating_total = rating_total + $1, rating_count = rating_count + $2 WHERE id=$3", count*review.Rating, count, review.BookID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// HasPurchased tells whether the customer bought the book and kept at least a copy: a confirmed
+// order, shipped or not, with a line of the book that was not returned in full. Pre-orders
+// waiting for stock are not purchases yet, returns count once their books are back.
+func (mr *mysqlReview) HasPurchased(ctx context.Context, customerEmail string, bookID int64) (bool, error) {
+	var purchased bool
+
+	err := mr.DB.QueryRow("SELECT EXISTS (SELECT 1 FROM orders o JOIN order_lines ol ON ol.order_id = o.id WHERE lower(o.customer_email) = $1 AND ol.book_id = $2 AND o.status = ANY($3) AND ol.quantity > (SELECT COALESCE(SUM(rl.quantity), 0) FROM return_lines rl JOIN returns r ON r.id = rl.return_id WHERE rl.order_line_id = ol.id AND r.status = ANY($4)))",
+		customerEmail, bookID, pq.Array([]string{entity.OrderConfirmed, entity.OrderPartiallyShipped, entity.OrderShipped, entity.OrderDelivered, entity.OrderPartiallyReturned}), pq.Array([]string{entity.ReturnReceived, entity.ReturnRefunded})).Scan(&purchased)
+	if err != nil {
+		return false, err
+	}
+
+	return purchased, nil
+}
+
+func (mr *mysqlReview) getReviews(query string, args ...interface{}) ([]entity.Review, error) {
+	var reviews []entity.Review
+
+	rows, err := mr.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var review entity.Review
+
+		err := rows.Scan(&review.ID, &review.BookID, &review.CustomerEmail, &review.Rating, &review.Title, &review.Body, &review.VerifiedPurchase, &review.Status, &review.ModeratedBy, &review.ModeratedAt, &review.CreatedAt, &review.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		reviews = append(reviews, review)
+	}
+
+	return reviews, nil
+}
@@ -0,0 +1,130 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateReview(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantErr error
+	}{
+		{name: "success", rows: sqlmock.NewRows([]string{"id"}).AddRow(3)},
+		{name: "already reviewed", rows: sqlmock.NewRows([]string{"id"}), wantErr: repository.ErrDuplicateReview},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectPrepare("INSERT INTO reviews (.+) ON CONFLICT \\(book_id, customer_email\\) DO NOTHING RETURNING id").ExpectQuery().
+				WithArgs(5, "buyer@mail.com", 4, "Great", "Loved it", true, entity.ReviewPending, sqlmock.AnyArg(), sqlmock.AnyArg()).
+				WillReturnRows(test.rows)
+
+			review := entity.Review{BookID: 5, CustomerEmail: "buyer@mail.com", Rating: 4, Title: "Great", Body: "Loved it", VerifiedPurchase: true, Status: entity.ReviewPending}
+			mysqlReview := repository.NewMysqlReview(db)
+			err = mysqlReview.CreateReview(context.Background(), &review)
+
+			assert.Equal(t, test.wantErr, err)
+			if test.wantErr == nil {
+				assert.Equal(t, int64(3), review.ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestModerateReview(t *testing.T) {
+	testCases := []struct {
+		name      string
+		from      string
+		to        string
+		updated   int64
+		wantTotal int
+		wantCount int
+		wantErr   error
+	}{
+		{name: "approved", from: entity.ReviewPending, to: entity.ReviewApproved, updated: 1, wantTotal: 4, wantCount: 1},
+		{name: "approval taken back", from: entity.ReviewApproved, to: entity.ReviewRejected, updated: 1, wantTotal: -4, wantCount: -1},
+		{name: "rejected", from: entity.ReviewPending, to: entity.ReviewRejected, updated: 1},
+		{name: "moderated meanwhile", from: entity.ReviewPending, to: entity.ReviewApproved, updated: 0, wantErr: repository.ErrReviewChanged},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			now := time.Now()
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE reviews SET status(.+) WHERE id(.+) AND status(.+)").WithArgs(test.to, "moderator", &now, sqlmock.AnyArg(), 3, test.from).
+				WillReturnResult(sqlmock.NewResult(0, test.updated))
+			if test.wantCount != 0 {
+				mock.ExpectExec("UPDATE books SET rating_total = rating_total (.+), rating_count = rating_count (.+)").WithArgs(test.wantTotal, test.wantCount, 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			if test.wantErr == nil {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			review := entity.Review{ID: 3, BookID: 5, Rating: 4, Status: test.to, ModeratedBy: "moderator", ModeratedAt: &now}
+			mysqlReview := repository.NewMysqlReview(db)
+			err = mysqlReview.ModerateReview(context.Background(), &review, test.from)
+
+			assert.Equal(t, test.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHasPurchased(t *testing.T) {
+	testCases := []struct {
+		name      string
+		purchased bool
+	}{
+		{name: "kept a copy", purchased: true},
+		{name: "no purchase left", purchased: false},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			statuses := pq.Array([]string{entity.OrderConfirmed, entity.OrderPartiallyShipped, entity.OrderShipped, entity.OrderDelivered, entity.OrderPartiallyReturned})
+			returned := pq.Array([]string{entity.ReturnReceived, entity.ReturnRefunded})
+			mock.ExpectQuery("SELECT EXISTS (.+) o.status = ANY(.+) AND ol.quantity > (.+) FROM return_lines (.+) r.status = ANY(.+)").WithArgs("jane@example.com", 3, statuses, returned).
+				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(test.purchased))
+
+			mysqlReview := repository.NewMysqlReview(db)
+			purchased, err := mysqlReview.HasPurchased(context.Background(), "jane@example.com", 3)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.purchased, purchased)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
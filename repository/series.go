@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+type SeriesRepository interface {
+	GetSeriesList(ctx context.Context) ([]entity.Series, error)
+	GetSeries(ctx context.Context, id int64) (entity.Series, error)
+	CreateSeries(ctx context.Context, series *entity.Series) error
+	UpdateSeries(ctx context.Context, id int64, series *entity.Series) error
+	DeleteSeries(ctx context.Context, id int64) error
+	GetBookSeries(ctx context.Context, bookIDs []int64) (map[int64][]entity.BookSeries, error)
+}
+
+type mysqlSeries struct {
+	DB *sql.DB
+}
+
+func NewMysqlSeries(db *sql.DB) SeriesRepository {
+	return &mysqlSeries{DB: db}
+}
+
+func (ms *mysqlSeries) GetSeriesList(ctx context.Context) ([]entity.Series, error) {
+	var list []entity.Series
+
+	rows, err := ms.DB.Query("SELECT id, name, description, created_at, updated_at FROM series ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var series entity.Series
+
+		err := rows.Scan(&series.ID, &series.Name, &series.Description, &series.CreatedAt, &series.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, series)
+	}
+
+	return list, nil
+}
+
+func (ms *mysqlSeries) GetSeries(ctx context.Context, id int64) (entity.Series, error) {
+	var series entity.Series
+
+	err := ms.DB.QueryRow("SELECT id, name, description, created_at, updated_at FROM series WHERE id=$1", id).Scan(&series.ID, &series.Name, &series.Description, &series.CreatedAt, &series.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.Series{}, nil
+		}
+		return entity.Series{}, err
+	}
+
+	return series, nil
+}
+
+func (ms *mysqlSeries) CreateSeries(ctx context.Context, series *entity.Series) error {
+	stmt, err := ms.DB.Prepare("INSERT INTO series (name, description, created_at, updated_at) VALUES($1, $2, $3, $4) RETURNING id")
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	series.CreatedAt = startTime
+	series.UpdatedAt = startTime
+
+	err = stmt.QueryRow(series.Name, series.Description, series.CreatedAt, series.UpdatedAt).Scan(&series.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ms *mysqlSeries) UpdateSeries(ctx context.Context, id int64, series *entity.Series) error {
+	stmt, err := ms.DB.Prepare("UPDATE series SET name=$1, description=$2, updated_at=$3 WHERE id=$4")
+	if err != nil {
+		return err
+	}
+
+	series.UpdatedAt = time.Now()
+	_, err = stmt.Exec(series.Name, series.Description, series.UpdatedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ms *mysqlSeries) DeleteSeries(ctx context.Context, id int64) error {
+	stmt, err := ms.DB.Prepare("DELETE FROM series WHERE id=$1")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetBookSeries returns the series of every given book keyed by book id
+func (ms *mysqlSeries) GetBookSeries(ctx context.Context, bookIDs []int64) (map[int64][]entity.BookSeries, error) {
+	bookSeries := make(map[int64][]entity.BookSeries)
+
+	rows, err := ms.DB.Query("SELECT bs.book_id, bs.series_id, s.name, bs.position FROM book_series bs JOIN series s ON s.id = bs.series_id WHERE bs.book_id = ANY($1) ORDER BY bs.book_id, s.name", pq.Array(bookIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookID int64
+		var series entity.BookSeries
+
+		err := rows.Scan(&bookID, &series.SeriesID, &series.Name, &series.Position)
+		if err != nil {
+			return nil, err
+		}
+
+		bookSeries[bookID] = append(bookSeries[bookID], series)
+	}
+
+	return bookSeries, nil
+}
+
+// setBookSeries replaces the series a book is in and its positions
+func setBookSeries(tx *sql.Tx, bookID int64, series []entity.BookSeries) error {
+	_, err := tx.Exec("DELETE FROM book_series WHERE book_id=$1", bookID)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range series {
+		_, err = tx.Exec("INSERT INTO book_series (book_id, series_id, position) VALUES($1, $2, $3)", bookID, s.SeriesID, s.Position)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
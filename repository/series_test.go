@@ -0,0 +1,34 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBookSeries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"book_id", "series_id", "name", "position"}).
+		AddRow(1, 5, "Discworld", 2.5).
+		AddRow(1, 6, "Rincewind", 1).
+		AddRow(2, 5, "Discworld", 3)
+	mock.ExpectQuery("SELECT (.+) FROM book_series bs JOIN series s (.+)").WillReturnRows(rows)
+
+	mysqlSeries := repository.NewMysqlSeries(db)
+	ret, err := mysqlSeries.GetBookSeries(context.Background(), []int64{1, 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.BookSeries{{SeriesID: 5, Name: "Discworld", Position: 2.5}, {SeriesID: 6, Name: "Rincewind", Position: 1}}, ret[1])
+	assert.Equal(t, 3.0, ret[2][0].Position)
+}
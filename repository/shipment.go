@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+const shipmentColumns = "id, order_id, carrier, tracking_number, status, shipped_at, delivered_at, created_at, updated_at"
+
+var (
+	// ErrOrderNotShippable is returned when books of an order that is not confirmed, or is
+	// shipped already, are shipped
+	ErrOrderNotShippable = errors.New("order cannot be shipped in its current status")
+	// ErrOverShipment is returned when more books of an order line are shipped than are left
+	ErrOverShipment = errors.New("shipped quantity exceeds what is left of the order line")
+	// ErrShipmentChanged is returned when a shipment left the status it was updated from
+	ErrShipmentChanged = errors.New("shipment was changed meanwhile")
+)
+
+type ShipmentRepository interface {
+	GetShipment(ctx context.Context, id int64) (entity.Shipment, error)
+	GetOrderShipments(ctx context.Context, orderID int64) ([]entity.Shipment, error)
+	CreateShipment(ctx context.Context, shipment *entity.Shipment) error
+	UpdateShipmentStatus(ctx context.Context, shipment *entity.Shipment, from string) error
+}
+
+type mysqlShipment struct {
+	DB *sql.DB
+}
+
+func NewMysqlShipment(db *sql.DB) ShipmentRepository {
+	return &mysqlShipment{DB: db}
+}
+
+func (ms *mysqlShipment) GetShipment(ctx context.Context, id int64) (entity.Shipment, error) {
+	shipments, err := ms.getShipments("SELECT "+shipmentColumns+" FROM shipments WHERE id=$1", id)
+	if err != nil {
+		return entity.Shipment{}, err
+	}
+
+	if len(shipments) == 0 {
+		return entity.Shipment{}, nil
+	}
+
+	return shipments[0], nil
+}
+
+func (ms *mysqlShipment) GetOrderShipments(ctx context.Context, orderID int64) ([]entity.Shipment, error) {
+	return ms.getShipments("SELECT "+shipmentColumns+" FROM shipments WHERE order_id=$1 ORDER BY id", orderID)
+}
+
+// CreateShipment stores a shipment of some books of a confirmed order. The order row is locked
+// so concurrent shipments can never ship more of a line than was ordered, the order is shipped
+// once all its books are and partially shipped until then.
+func (ms *mysqlShipment) CreateShipment(ctx context.Context, shipment *entity.Shipment) error {
+	tx, err := ms.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRow("SELECT status FROM orders WHERE id=$1 FOR UPDATE", shipment.OrderID).Scan(&status)
+	if err != nil {
+		return err
+	}
+
+	if status != entity.OrderConfirmed && status != entity.OrderPartiallyShipped {
+		return ErrOrderNotShippable
+	}
+
+	for _, line := range shipment.Lines {
+		var left int
+		err = tx.QueryRow("SELECT ol.quantity - COALESCE((SELECT SUM(sl.quantity) FROM shipment_lines sl WHERE sl.order_line_id = ol.id), 0) FROM order_lines ol WHERE ol.id=$1 AND ol.order_id=$2",
+			line.OrderLineID, shipment.OrderID).Scan(&left)
+		if err != nil {
+			return err
+		}
+
+		if line.Quantity > left {
+			return fmt.Errorf("order line ID %d has %d left to ship: %w", line.OrderLineID, left, ErrOverShipment)
+		}
+	}
+
+	startTime := time.Now()
+	shipment.ShippedAt = startTime
+	shipment.CreatedAt = startTime
+	shipment.UpdatedAt = startTime
+
+	err = tx.QueryRow("INSERT INTO shipments (order_id, carrier, tracking_number, status, shipped_at, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id",
+		shipment.OrderID, shipment.Carrier, shipment.TrackingNumber, shipment.Status, shipment.ShippedAt, shipment.CreatedAt, shipment.UpdatedAt).Scan(&shipment.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := range shipment.Lines {
+		line := &shipment.Lines[i]
+		line.ShipmentID = shipment.ID
+
+		err = tx.QueryRow("INSERT INTO shipment_lines (shipment_id, order_line_id, book_id, quantity) VALUES($1, $2, $3, $4) RETURNING id",
+			line.ShipmentID, line.OrderLineID, line.BookID, line.Quantity).Scan(&line.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	var complete bool
+	err = tx.QueryRow("SELECT bool_and(ol.quantity <= COALESCE((SELECT SUM(sl.quantity) FROM shipment_lines sl WHERE sl.order_line_id = ol.id), 0)) FROM order_lines ol WHERE ol.order_id=$1",
+		shipment.OrderID).Scan(&complete)
+	if err != nil {
+		return err
+	}
+
+	status = entity.OrderPartiallyShipped
+	if complete {
+		status = entity.OrderShipped
+	}
+
+	_, err = tx.Exec("UPDATE orders SET status=$1, updated_at=$2 WHERE id=$3", status, startTime, shipment.OrderID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateShipmentStatus moves a shipment on from status from as the carrier tracks it. The order
+// is delivered with the last of its shipments once all its books are shipped.
+func (ms *mysqlShipment) UpdateShipmentStatus(ctx context.Context, shipment *entity.Shipment, from string) error {
+	tx, err := ms.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	shipment.UpdatedAt = time.Now()
+	res, err := tx.Exec("UPDATE shipments SET status=$1, delivered_at=$2, updated_at=$3 WHERE id=$4 AND status=$5",
+		shipment.Status, shipment.DeliveredAt, shipment.UpdatedAt, shipment.ID, from)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := res.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrShipmentChanged
+	}
+
+	if shipment.Status == entity.ShipmentDelivered {
+		_, err = tx.Exec("UPDATE orders SET status=$1, updated_at=$2 WHERE id=$3 AND status=$4 AND NOT EXISTS (SELECT 1 FROM shipments WHERE order_id=$3 AND status<>$5)",
+			entity.OrderDelivered, shipment.UpdatedAt, shipment.OrderID, entity.OrderShipped, entity.ShipmentDelivered)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (ms *mysqlShipment) getShipments(query string, args ...interface{}) ([]entity.Shipment, error) {
+	var shipments []entity.Shipment
+
+	rows, err := ms.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var shipment entity.Shipment
+
+		err := rows.Scan(&shipment.ID, &shipment.OrderID, &shipment.Carrier, &shipment.TrackingNumber, &shipment.Status, &shipment.ShippedAt, &shipment.DeliveredAt, &shipment.CreatedAt, &shipment.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		shipments = append(shipments, shipment)
+	}
+
+	err = ms.attachShipmentLines(shipments)
+	if err != nil {
+		return nil, err
+	}
+
+	return shipments, nil
+}
+
+func (ms *mysqlShipment) attachShipmentLines(shipments []entity.Shipment) error {
+	if len(shipments) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int)
+	ids := make([]int64, len(shipments))
+	for i, shipment := range shipments {
+		index[shipment.ID] = i
+		ids[i] = shipment.ID
+	}
+
+	rows, err := ms.DB.Query("SELECT id, shipment_id, order_line_id, book_id, quantity FROM shipment_lines WHERE shipment_id = ANY($1) ORDER BY shipment_id, id", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line entity.ShipmentLine
+
+		err := rows.Scan(&line.ID, &line.ShipmentID, &line.OrderLineID, &line.BookID, &line.Quantity)
+		if err != nil {
+			return err
+		}
+
+		i := index[line.ShipmentID]
+		shipments[i].Lines = append(shipments[i].Lines, line)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateShipment(t *testing.T) {
+	testCases := []struct {
+		name            string
+		orderStatus     string
+		left            int
+		complete        bool
+		wantOrderStatus string
+		wantErr         error
+	}{
+		{name: "some books shipped", orderStatus: entity.OrderConfirmed, left: 3, complete: false, wantOrderStatus: entity.OrderPartiallyShipped},
+		{name: "last books shipped", orderStatus: entity.OrderPartiallyShipped, left: 2, complete: true, wantOrderStatus: entity.OrderShipped},
+		{name: "more than is left", orderStatus: entity.OrderPartiallyShipped, left: 1, wantErr: repository.ErrOverShipment},
+		{name: "order not confirmed", orderStatus: entity.OrderPending, wantErr: repository.ErrOrderNotShippable},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT status FROM orders WHERE id(.+) FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(test.orderStatus))
+			if test.orderStatus != entity.OrderPending {
+				mock.ExpectQuery("SELECT ol.quantity - (.+) FROM order_lines ol (.+)").WithArgs(3, 1).WillReturnRows(sqlmock.NewRows([]string{"left"}).AddRow(test.left))
+			}
+			if test.wantErr == nil {
+				mock.ExpectQuery("INSERT INTO shipments (.+)").WithArgs(1, "JNE", "JNE123", entity.ShipmentShipped, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectQuery("INSERT INTO shipment_lines (.+)").WithArgs(7, 3, 5, 2).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				mock.ExpectQuery("SELECT bool_and(.+) FROM order_lines ol (.+)").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"complete"}).AddRow(test.complete))
+				mock.ExpectExec("UPDATE orders SET status(.+)").WithArgs(test.wantOrderStatus, sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			shipment := entity.Shipment{OrderID: 1, Carrier: "JNE", TrackingNumber: "JNE123", Status: entity.ShipmentShipped, Lines: []entity.ShipmentLine{{OrderLineID: 3, BookID: 5, Quantity: 2}}}
+			mysqlShipment := repository.NewMysqlShipment(db)
+			err = mysqlShipment.CreateShipment(context.Background(), &shipment)
+
+			assert.True(t, errors.Is(err, test.wantErr))
+			if test.wantErr == nil {
+				assert.Equal(t, int64(7), shipment.ID)
+				assert.Equal(t, int64(9), shipment.Lines[0].ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateShipmentStatus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   string
+		updated  int64
+		wantErr  error
+		delivers bool
+	}{
+		{name: "in transit", status: entity.ShipmentInTransit, updated: 1},
+		{name: "delivered", status: entity.ShipmentDelivered, updated: 1, delivers: true},
+		{name: "changed meanwhile", status: entity.ShipmentDelivered, updated: 0, wantErr: repository.ErrShipmentChanged},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE shipments SET status(.+)").WithArgs(test.status, sqlmock.AnyArg(), sqlmock.AnyArg(), 7, entity.ShipmentShipped).WillReturnResult(sqlmock.NewResult(0, test.updated))
+			if test.delivers {
+				mock.ExpectExec("UPDATE orders SET status(.+) NOT EXISTS (.+)").WithArgs(entity.OrderDelivered, sqlmock.AnyArg(), 1, entity.OrderShipped, entity.ShipmentDelivered).WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			if test.wantErr == nil {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			now := time.Now()
+			shipment := entity.Shipment{ID: 7, OrderID: 1, Status: test.status}
+			if test.status == entity.ShipmentDelivered {
+				shipment.DeliveredAt = &now
+			}
+			mysqlShipment := repository.NewMysqlShipment(db)
+			err = mysqlShipment.UpdateShipmentStatus(context.Background(), &shipment, entity.ShipmentShipped)
+
+			assert.Equal(t, test.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
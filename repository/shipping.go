@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+const (
+	shippingMethodColumns = "id, code, name, carrier, active, created_at, updated_at"
+	shippingRateColumns   = "id, shipping_method_id, country, region, max_weight, price, created_at, updated_at"
+)
+
+type ShippingRepository interface {
+	GetShippingMethods(ctx context.Context) ([]entity.ShippingMethod, error)
+	GetShippingMethod(ctx context.Context, id int64) (entity.ShippingMethod, error)
+	CreateShippingMethod(ctx context.Context, method *entity.ShippingMethod) error
+	UpdateShippingMethod(ctx context.Context, id int64, method *entity.ShippingMethod) error
+	DeleteShippingMethod(ctx context.Context, id int64) error
+	GetShippingRate(ctx context.Context, id int64) (entity.ShippingRate, error)
+	CreateShippingRate(ctx context.Context, rate *entity.ShippingRate) error
+	UpdateShippingRate(ctx context.Context, id int64, rate *entity.ShippingRate) error
+	DeleteShippingRate(ctx context.Context, id int64) error
+	GetZoneRates(ctx context.Context, methodID int64, country string, region string) ([]entity.ShippingRate, error)
+}
+
+type mysqlShipping struct {
+	DB *sql.DB
+}
+
+func NewMysqlShipping(db *sql.DB) ShippingRepository {
+	return &mysqlShipping{DB: db}
+}
+
+func (ms *mysqlShipping) GetShippingMethods(ctx context.Context) ([]entity.ShippingMethod, error) {
+	var methods []entity.ShippingMethod
+
+	rows, err := ms.DB.Query("SELECT " + shippingMethodColumns + " FROM shipping_methods ORDER BY code")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var method entity.ShippingMethod
+
+		err := rows.Scan(&method.ID, &method.Code, &method.Name, &method.Carrier, &method.Active, &method.CreatedAt, &method.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		methods = append(methods, method)
+	}
+
+	err = ms.attachShippingRates(methods)
+	if err != nil {
+		return nil, err
+	}
+
+	return methods, nil
+}
+
+func (ms *mysqlShipping) GetShippingMethod(ctx context.Context, id int64) (entity.ShippingMethod, error) {
+	var method entity.ShippingMethod
+
+	err := ms.DB.QueryRow("SELECT "+shippingMethodColumns+" FROM shipping_methods WHERE id=$1", id).
+		Scan(&method.ID, &method.Code, &method.Name, &method.Carrier, &method.Active, &method.CreatedAt, &method.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.ShippingMethod{}, nil
+		}
+		return entity.ShippingMethod{}, err
+	}
+
+	methods := []entity.ShippingMethod{method}
+	err = ms.attachShippingRates(methods)
+	if err != nil {
+		return entity.ShippingMethod{}, err
+	}
+
+	return methods[0], nil
+}
+
+func (ms *mysqlShipping) CreateShippingMethod(ctx context.Context, method *entity.ShippingMethod) error {
+	stmt, err := ms.DB.Prepare("INSERT INTO shipping_methods (code, name, carrier, active, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $6) RETURNING id")
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	method.CreatedAt = startTime
+	method.UpdatedAt = startTime
+
+	err = stmt.QueryRow(method.Code, method.Name, method.Carrier, method.Active, method.CreatedAt, method.UpdatedAt).Scan(&method.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ms *mysqlShipping) UpdateShippingMethod(ctx context.Context, id int64, method *entity.ShippingMethod) error {
+	stmt, err := ms.DB.Prepare("UPDATE shipping_methods SET code=$1, name=$2, carrier=$3, active=$4, updated_at=$5 WHERE id=$6")
+	if err != nil {
+		return err
+	}
+
+	method.UpdatedAt = time.Now()
+	_, err = stmt.Exec(method.Code, method.Name, method.Carrier, method.Active, method.UpdatedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteShippingMethod removes the method with its rates, the orders shipped by it keep what
+// they were charged
+func (ms *mysqlShipping) DeleteShippingMethod(ctx context.Context, id int64) error {
+	stmt, err := ms.DB.Prepare("DELETE FROM shipping_methods WHERE id=$1")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ms *mysqlShipping) GetShippingRate(ctx context.Context, id int64) (entity.ShippingRate, error) {
+	var rate entity.ShippingRate
+
+	err := ms.DB.QueryRow("SELECT "+shippingRateColumns+" FROM shipping_rates WHERE id=$1", id).
+		Scan(&rate.ID, &rate.ShippingMethodID, &rate.Country, &rate.Region, &rate.MaxWeight, &rate.Price, &rate.CreatedAt, &rate.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.ShippingRate{}, nil
+		}
+		return entity.ShippingRate{}, err
+	}
+
+	return rate, nil
+}
+
+func (ms *mysqlShipping) CreateShippingRate(ctx context.Context, rate *entity.ShippingRate) error {
+	stmt, err := ms.DB.Prepare("INSERT INTO shipping_rates (shipping_method_id, country, region, max_weight, price, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id")
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	rate.CreatedAt = startTime
+	rate.UpdatedAt = startTime
+
+	err = stmt.QueryRow(rate.ShippingMethodID, rate.Country, rate.Region, rate.MaxWeight, rate.Price, rate.CreatedAt, rate.UpdatedAt).Scan(&rate.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ms *mysqlShipping) UpdateShippingRate(ctx context.Context, id int64, rate *entity.ShippingRate) error {
+	stmt, err := ms.DB.Prepare("UPDATE shipping_rates SET shipping_method_id=$1, country=$2, region=$3, max_weight=$4, price=$5, updated_at=$6 WHERE id=$7")
+	if err != nil {
+		return err
+	}
+
+	rate.UpdatedAt = time.Now()
+	_, err = stmt.Exec(rate.ShippingMethodID, rate.Country, rate.Region, rate.MaxWeight, rate.Price, rate.UpdatedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ms *mysqlShipping) DeleteShippingRate(ctx context.Context, id int64) error {
+	stmt, err := ms.DB.Prepare("DELETE FROM shipping_rates WHERE id=$1")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetZoneRates returns the rates of a method whose zone covers the destination: those of its
+// region, of its whole country and of anywhere. Which of them applies is left to the caller.
+func (ms *mysqlShipping) GetZoneRates(ctx context.Context, methodID int64, country string, region string) ([]entity.ShippingRate, error) {
+	rows, err := ms.DB.Query("SELECT "+shippingRateColumns+" FROM shipping_rates WHERE shipping_method_id=$1 AND (country='' OR (country=$2 AND (region='' OR region=$3))) ORDER BY id", methodID, country, region)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanShippingRates(rows)
+}
+
+func (ms *mysqlShipping) attachShippingRates(methods []entity.ShippingMethod) error {
+	if len(methods) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int)
+	ids := make([]int64, len(methods))
+	for i, method := range methods {
+		index[method.ID] = i
+		ids[i] = method.ID
+	}
+
+	rows, err := ms.DB.Query("SELECT "+shippingRateColumns+" FROM shipping_rates WHERE shipping_method_id = ANY($1) ORDER BY shipping_method_id, country, region, max_weight", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	rates, err := scanShippingRates(rows)
+	if err != nil {
+		return err
+	}
+
+	for _, rate := range rates {
+		i := index[rate.ShippingMethodID]
+		methods[i].Rates = append(methods[i].Rates, rate)
+	}
+
+	return nil
+}
+
+func scanShippingRates(rows *sql.Rows) ([]entity.ShippingRate, error) {
+	var rates []entity.ShippingRate
+
+	for rows.Next() {
+		var rate entity.ShippingRate
+
+		err := rows.Scan(&rate.ID, &rate.ShippingMethodID, &rate.Country, &rate.Region, &rate.MaxWeight, &rate.Price, &rate.CreatedAt, &rate.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
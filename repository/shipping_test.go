@@ -0,0 +1,94 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var shippingRateRowColumns = []string{"id", "shipping_method_id", "country", "region", "max_weight", "price", "created_at", "updated_at"}
+
+func TestGetShippingMethod(t *testing.T) {
+	testCases := []struct {
+		name      string
+		found     bool
+		wantRates int
+	}{
+		{name: "with its rates", found: true, wantRates: 2},
+		{name: "not found", found: false},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"id", "code", "name", "carrier", "active", "created_at", "updated_at"})
+			if test.found {
+				rows.AddRow(1, "standard", "Standard", "JNE", true, time.Now(), time.Now())
+			}
+			mock.ExpectQuery("SELECT (.+) FROM shipping_methods WHERE id(.+)").WithArgs(1).WillReturnRows(rows)
+			if test.found {
+				mock.ExpectQuery("SELECT (.+) FROM shipping_rates WHERE shipping_method_id = ANY(.+)").WillReturnRows(sqlmock.NewRows(shippingRateRowColumns).
+					AddRow(1, 1, "ID", "", 1000, 10000, time.Now(), time.Now()).
+					AddRow(2, 1, "ID", "", 0, 25000, time.Now(), time.Now()))
+			}
+
+			mysqlShipping := repository.NewMysqlShipping(db)
+			method, err := mysqlShipping.GetShippingMethod(context.Background(), 1)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.found, method.ID != 0)
+			assert.Len(t, method.Rates, test.wantRates)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetZoneRates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM shipping_rates WHERE shipping_method_id(.+)").WithArgs(1, "ID", "JK").WillReturnRows(sqlmock.NewRows(shippingRateRowColumns).
+		AddRow(1, 1, "ID", "", 1000, 10000, time.Now(), time.Now()).
+		AddRow(3, 1, "", "", 0, 90000, time.Now(), time.Now()))
+
+	mysqlShipping := repository.NewMysqlShipping(db)
+	rates, err := mysqlShipping.GetZoneRates(context.Background(), 1, "ID", "JK")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{10000, 90000}, []int{rates[0].Price, rates[1].Price})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateShippingRate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectPrepare("INSERT INTO shipping_rates (.+)").ExpectQuery().WithArgs(1, "ID", "JK", 1000, 9000, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+
+	rate := entity.ShippingRate{ShippingMethodID: 1, Country: "ID", Region: "JK", MaxWeight: 1000, Price: 9000}
+	mysqlShipping := repository.NewMysqlShipping(db)
+	err = mysqlShipping.CreateShippingRate(context.Background(), &rate)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), rate.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+const skuColumns = "id, book_id, format, isbn, price, COALESCE(tax_category_id, 0), stock, reserved, weight_grams, width_mm, height_mm, depth_mm, created_at, updated_at"
+
+type SKURepository interface {
+	GetSKUs(ctx context.Context, bookIDs []int64) (map[int64][]entity.SKU, error)
+	GetSKU(ctx context.Context, id int64) (entity.SKU, error)
+	CreateSKU(ctx context.Context, sku *entity.SKU, receipt *entity.StockMovement) error
+	UpdateSKU(ctx context.Context, id int64, sku *entity.SKU) error
+	DeleteSKU(ctx context.Context, id int64) error
+}
+
+type mysqlSKU struct {
+	DB *sql.DB
+}
+
+func NewMysqlSKU(db *sql.DB) SKURepository {
+	return &mysqlSKU{DB: db}
+}
+
+// GetSKUs returns the editions of every given book keyed by book id
+func (ms *mysqlSKU) GetSKUs(ctx context.Context, bookIDs []int64) (map[int64][]entity.SKU, error) {
+	skus := make(map[int64][]entity.SKU)
+
+	rows, err := ms.DB.Query("SELECT "+skuColumns+" FROM skus WHERE book_id = ANY($1) ORDER BY book_id, id", pq.Array(bookIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sku entity.SKU
+
+		err := rows.Scan(&sku.ID, &sku.BookID, &sku.Format, &sku.ISBN, &sku.Price, &sku.TaxCategoryID, &sku.Stock, &sku.Reserved, &sku.WeightGrams, &sku.WidthMM, &sku.HeightMM, &sku.DepthMM, &sku.CreatedAt, &sku.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		skus[sku.BookID] = append(skus[sku.BookID], sku)
+	}
+
+	return skus, nil
+}
+
+func (ms *mysqlSKU) GetSKU(ctx context.Context, id int64) (entity.SKU, error) {
+	var sku entity.SKU
+
+	err := ms.DB.QueryRow("SELECT "+skuColumns+" FROM skus WHERE id=$1", id).Scan(&sku.ID, &sku.BookID, &sku.Format, &sku.ISBN, &sku.Price, &sku.TaxCategoryID, &sku.Stock, &sku.Reserved, &sku.WeightGrams, &sku.WidthMM, &sku.HeightMM, &sku.DepthMM, &sku.CreatedAt, &sku.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.SKU{}, nil
+		}
+		return entity.SKU{}, err
+	}
+
+	return sku, nil
+}
+
+// CreateSKU stores an edition without stock and, when given, the receipt of its opening stock
+// in a single transaction
+func (ms *mysqlSKU) CreateSKU(ctx context.Context, sku *entity.SKU, receipt *entity.StockMovement) error {
+	tx, err := ms.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	startTime := time.Now()
+	sku.CreatedAt = startTime
+	sku.UpdatedAt = startTime
+	sku.Stock, sku.Reserved = 0, 0
+
+	err = tx.QueryRow("INSERT INTO skus (book_id, format, isbn, price, tax_category_id, weight_grams, width_mm, height_mm, depth_mm, created_at, updated_at) VALUES($1, $2, $3, $4, NULLIF($5, 0), $6, $7, $8, $9, $10, $11) RETURNING id", &sku.BookID, &sku.Format, &sku.ISBN, &sku.Price, &sku.TaxCategoryID, &sku.WeightGrams, &sku.WidthMM, &sku.HeightMM, &sku.DepthMM, &sku.CreatedAt, &sku.UpdatedAt).Scan(&sku.ID)
+	if err != nil {
+		return err
+	}
+
+	if receipt != nil {
+		receipt.BookID = sku.BookID
+		receipt.SKUID = sku.ID
+		receipt.CreatedAt = startTime
+
+		err = recordStockMovement(tx, receipt)
+		if err != nil {
+			return err
+		}
+
+		sku.Stock = receipt.Quantity
+	}
+
+	return tx.Commit()
+}
+
+// UpdateSKU leaves stock untouched, stock only changes through recorded stock movements
+func (ms *mysqlSKU) UpdateSKU(ctx context.Context, id int64, sku *entity.SKU) error {
+	stmt, err := ms.DB.Prepare("UPDATE skus SET format=$1, isbn=$2, price=$3, tax_category_id=NULLIF($4, 0), weight_grams=$5, width_mm=$6, height_mm=$7, depth_mm=$8, updated_at=$9 WHERE id=$10")
+	if err != nil {
+		return err
+	}
+
+	sku.UpdatedAt = time.Now()
+	_, err = stmt.Exec(&sku.Format, &sku.ISBN, &sku.Price, &sku.TaxCategoryID, &sku.WeightGrams, &sku.WidthMM, &sku.HeightMM, &sku.DepthMM, &sku.UpdatedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ms *mysqlSKU) DeleteSKU(ctx context.Context, id int64) error {
+	stmt, err := ms.DB.Prepare("DELETE FROM skus WHERE id=$1")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
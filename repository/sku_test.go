@@ -0,0 +1,180 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var skuRowColumns = []string{"id", "book_id", "format", "isbn", "price", "tax_category_id", "stock", "reserved", "weight_grams", "width_mm", "height_mm", "depth_mm", "created_at", "updated_at"}
+
+func TestGetSKUs(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rows    []entity.SKU
+		isError bool
+		err     error
+	}{
+		{
+			name: "success",
+			rows: []entity.SKU{
+				{ID: 1, BookID: 1, Format: entity.FormatHardcover, ISBN: "9780306406157", Price: 250000, Stock: 3, WeightGrams: 600, WidthMM: 160, HeightMM: 240, DepthMM: 30},
+				{ID: 2, BookID: 1, Format: entity.FormatEbook, Price: 90000},
+			},
+			isError: false,
+			err:     nil,
+		},
+		{
+			name:    "failed",
+			rows:    nil,
+			isError: true,
+			err:     errors.New("Dummy Error"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			if !test.isError {
+				rows := sqlmock.NewRows(skuRowColumns)
+				for _, row := range test.rows {
+					rows.AddRow(row.ID, row.BookID, row.Format, row.ISBN, row.Price, row.TaxCategoryID, row.Stock, row.Reserved, row.WeightGrams, row.WidthMM, row.HeightMM, row.DepthMM, time.Now(), time.Now())
+				}
+				mock.ExpectQuery("SELECT (.+) FROM skus WHERE book_id (.+)").WillReturnRows(rows)
+			} else {
+				mock.ExpectQuery("SELECT (.+) FROM skus WHERE book_id (.+)").WillReturnError(test.err)
+			}
+
+			mysqlSKU := repository.NewMysqlSKU(db)
+			ret, err := mysqlSKU.GetSKUs(context.Background(), []int64{1})
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Len(t, ret[1], len(test.rows))
+		})
+	}
+}
+
+func TestGetSKU(t *testing.T) {
+	testCases := []struct {
+		name    string
+		row     entity.SKU
+		err     error
+		isError bool
+	}{
+		{
+			name:    "success",
+			row:     entity.SKU{ID: 1, BookID: 1, Format: entity.FormatPaperback},
+			isError: false,
+		},
+		{
+			name:    "success but data is empty",
+			row:     entity.SKU{},
+			err:     sql.ErrNoRows,
+			isError: false,
+		},
+		{
+			name:    "failed",
+			row:     entity.SKU{},
+			err:     errors.New("Dummy Error"),
+			isError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			if test.err == nil {
+				row := sqlmock.NewRows(skuRowColumns).
+					AddRow(test.row.ID, test.row.BookID, test.row.Format, test.row.ISBN, test.row.Price, test.row.TaxCategoryID, test.row.Stock, 0, 0, 0, 0, 0, time.Now(), time.Now())
+				mock.ExpectQuery("SELECT (.+) FROM skus WHERE id").WithArgs(1).WillReturnRows(row)
+			} else {
+				mock.ExpectQuery("SELECT (.+) FROM skus WHERE id").WithArgs(1).WillReturnError(test.err)
+			}
+
+			mysqlSKU := repository.NewMysqlSKU(db)
+			ret, err := mysqlSKU.GetSKU(context.Background(), 1)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.row.ID, ret.ID)
+		})
+	}
+}
+
+func TestCreateSKU(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO skus (.+) RETURNING id").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectQuery("SELECT id FROM warehouses (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec("INSERT INTO warehouse_stocks (.+)").WithArgs(1, 1, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO warehouse_sku_stocks (.+)").WithArgs(1, 5, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE skus SET stock = stock (.+)").WithArgs(10, sqlmock.AnyArg(), 5, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("UPDATE books SET stock = stock (.+)").WithArgs(10, sqlmock.AnyArg(), 1).WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(14))
+	mock.ExpectQuery("INSERT INTO stock_movements (.+)").WithArgs(1, 5, 1, entity.MovementReceipt, 10, "initial stock", "system", 14, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	sku := entity.SKU{BookID: 1, Format: entity.FormatPaperback, ISBN: "9780306406157", Price: 120000}
+	receipt := entity.StockMovement{Type: entity.MovementReceipt, Quantity: 10, Reason: "initial stock", Actor: "system"}
+	mysqlSKU := repository.NewMysqlSKU(db)
+	err = mysqlSKU.CreateSKU(context.Background(), &sku, &receipt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), sku.ID)
+	assert.Equal(t, 10, sku.Stock)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateSKU(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectPrepare("UPDATE skus (.+)").ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mysqlSKU := repository.NewMysqlSKU(db)
+	err = mysqlSKU.UpdateSKU(context.Background(), 1, &entity.SKU{Format: entity.FormatPaperback})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteSKU(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectPrepare("DELETE FROM skus (.+)").ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mysqlSKU := repository.NewMysqlSKU(db)
+	err = mysqlSKU.DeleteSKU(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrInsufficientStock is returned when a movement takes more than the available stock
+	ErrInsufficientStock = errors.New("insufficient stock")
+	// ErrNoWarehouse is returned when stock is recorded before any warehouse exists
+	ErrNoWarehouse = errors.New("no warehouse to keep the stock in")
+)
+
+type StockRepository interface {
+	GetStockMovements(ctx context.Context, bookID int64) ([]entity.StockMovement, error)
+	RecordMovement(ctx context.Context, movement *entity.StockMovement) error
+	TransferStock(ctx context.Context, transfer *entity.StockTransfer) error
+}
+
+type mysqlStock struct {
+	DB *sql.DB
+}
+
+func NewMysqlStock(db *sql.DB) StockRepository {
+	return &mysqlStock{DB: db}
+}
+
+func (ms *mysqlStock) GetStockMovements(ctx context.Context, bookID int64) ([]entity.StockMovement, error) {
+	var movements []entity.StockMovement
+
+	rows, err := ms.DB.Query("SELECT id, book_id, COALESCE(sku_id, 0), warehouse_id, movement_type, quantity, reason, actor, stock_after, created_at FROM stock_movements WHERE book_id=$1 ORDER BY id", bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var movement entity.StockMovement
+
+		err := rows.Scan(&movement.ID, &movement.BookID, &movement.SKUID, &movement.WarehouseID, &movement.Type, &movement.Quantity, &movement.Reason, &movement.Actor, &movement.StockAfter, &movement.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		movements = append(movements, movement)
+	}
+
+	return movements, nil
+}
+
+// RecordMovement applies the movement to the stock of its warehouse and of the book and
+// appends it to the ledger in one transaction. Movements without a warehouse go to the
+// default warehouse, the one with the lowest id.
+func (ms *mysqlStock) RecordMovement(ctx context.Context, movement *entity.StockMovement) error {
+	tx, err := ms.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	movement.CreatedAt = time.Now()
+
+	err = recordStockMovement(tx, movement)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// TransferStock moves stock between two warehouses, the total stock of the book and of the
+// edition is unchanged. The stock rows of both warehouses are locked by warehouse first, like
+// order reservations lock them, so transfers in opposite directions cannot deadlock.
+func (ms *mysqlStock) TransferStock(ctx context.Context, transfer *entity.StockTransfer) error {
+	tx, err := ms.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	warehouseIDs := pq.Array([]int64{transfer.FromWarehouseID, transfer.ToWarehouseID})
+	_, err = tx.Exec("SELECT id FROM warehouse_stocks WHERE book_id=$1 AND warehouse_id = ANY($2) ORDER BY warehouse_id FOR UPDATE", transfer.BookID, warehouseIDs)
+	if err != nil {
+		return err
+	}
+
+	if transfer.SKUID != 0 {
+		_, err = tx.Exec("SELECT id FROM warehouse_sku_stocks WHERE sku_id=$1 AND warehouse_id = ANY($2) ORDER BY warehouse_id FOR UPDATE", transfer.SKUID, warehouseIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	movements := []entity.StockMovement{
+		{BookID: transfer.BookID, SKUID: transfer.SKUID, WarehouseID: transfer.FromWarehouseID, Type: entity.MovementTransfer, Quantity: -transfer.Quantity, Reason: transfer.Reason, Actor: transfer.Actor, CreatedAt: now},
+		{BookID: transfer.BookID, SKUID: transfer.SKUID, WarehouseID: transfer.ToWarehouseID, Type: entity.MovementTransfer, Quantity: transfer.Quantity, Reason: transfer.Reason, Actor: transfer.Actor, CreatedAt: now},
+	}
+
+	for i := range movements {
+		err := changeWarehouseStock(tx, movements[i].WarehouseID, movements[i].BookID, movements[i].Quantity)
+		if err != nil {
+			return err
+		}
+
+		if movements[i].SKUID != 0 {
+			err = changeWarehouseSKUStock(tx, movements[i].WarehouseID, movements[i].SKUID, movements[i].Quantity)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = tx.QueryRow("SELECT stock FROM books WHERE id=$1", transfer.BookID).Scan(&movements[i].StockAfter)
+		if err != nil {
+			return err
+		}
+
+		err = insertStockMovement(tx, &movements[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	transfer.Movements = movements
+	return tx.Commit()
+}
+
+// recordStockMovement applies a movement to its warehouse, or to the default warehouse when
+// it has none
+func recordStockMovement(tx *sql.Tx, movement *entity.StockMovement) error {
+	if movement.WarehouseID == 0 {
+		err := tx.QueryRow("SELECT id FROM warehouses ORDER BY id LIMIT 1").Scan(&movement.WarehouseID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrNoWarehouse
+			}
+			return err
+		}
+	}
+
+	return applyStockMovement(tx, movement)
+}
+
+// applyStockMovement changes the stock of the warehouse, of the edition and of the book by the
+// movement and appends it to the ledger
+func applyStockMovement(tx *sql.Tx, movement *entity.StockMovement) error {
+	err := changeWarehouseStock(tx, movement.WarehouseID, movement.BookID, movement.Quantity)
+	if err != nil {
+		return err
+	}
+
+	if movement.SKUID != 0 {
+		err = changeWarehouseSKUStock(tx, movement.WarehouseID, movement.SKUID, movement.Quantity)
+		if err != nil {
+			return err
+		}
+
+		err = changeSKUStock(tx, movement.SKUID, movement.BookID, movement.Quantity, movement.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.QueryRow("UPDATE books SET stock = stock + $1, updated_at=$2 WHERE id=$3 AND stock - reserved + $1 >= 0 RETURNING stock", movement.Quantity, movement.CreatedAt, movement.BookID).Scan(&movement.StockAfter)
+	if err == sql.ErrNoRows {
+		return ErrInsufficientStock
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return insertStockMovement(tx, movement)
+}
+
+// changeSKUStock adds quantity to the stock of an edition of a book, only taking out what is
+// not reserved
+func changeSKUStock(tx *sql.Tx, skuID, bookID int64, quantity int, now time.Time) error {
+	res, err := tx.Exec("UPDATE skus SET stock = stock + $1, updated_at=$2 WHERE id=$3 AND book_id=$4 AND stock - reserved + $1 >= 0", quantity, now, skuID, bookID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrInsufficientStock
+	}
+
+	return nil
+}
+
+// changeWarehouseStock adds quantity to the stock a warehouse keeps of a book. Stock is only
+// taken out of what is not reserved, the update is conditional so concurrent movements can
+// never take more than that.
+func changeWarehouseStock(tx *sql.Tx, warehouseID, bookID int64, quantity int) error {
+	if quantity > 0 {
+		_, err := tx.Exec("INSERT INTO warehouse_stocks (warehouse_id, book_id, stock, reserved) VALUES($1, $2, $3, 0) ON CONFLICT (warehouse_id, book_id) DO UPDATE SET stock = warehouse_stocks.stock + EXCLUDED.stock", warehouseID, bookID, quantity)
+		return err
+	}
+
+	res, err := tx.Exec("UPDATE warehouse_stocks SET stock = stock + $1 WHERE warehouse_id=$2 AND book_id=$3 AND stock - reserved + $1 >= 0", quantity, warehouseID, bookID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrInsufficientStock
+	}
+
+	return nil
+}
+
+// changeWarehouseSKUStock adds quantity to the stock a warehouse keeps of an edition, like
+// changeWarehouseStock does for its book
+func changeWarehouseSKUStock(tx *sql.Tx, warehouseID, skuID int64, quantity int) error {
+	if quantity > 0 {
+		_, err := tx.Exec("INSERT INTO warehouse_sku_stocks (warehouse_id, sku_id, stock, reserved) VALUES($1, $2, $3, 0) ON CONFLICT (warehouse_id, sku_id) DO UPDATE SET stock = warehouse_sku_stocks.stock + EXCLUDED.stock", warehouseID, skuID, quantity)
+		return err
+	}
+
+	res, err := tx.Exec("UPDATE warehouse_sku_stocks SET stock = stock + $1 WHERE warehouse_id=$2 AND sku_id=$3 AND stock - reserved + $1 >= 0", quantity, warehouseID, skuID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrInsufficientStock
+	}
+
+	return nil
+}
+
+func insertStockMovement(tx *sql.Tx, movement *entity.StockMovement) error {
+	return tx.QueryRow("INSERT INTO stock_movements (book_id, sku_id, warehouse_id, movement_type, quantity, reason, actor, stock_after, created_at) VALUES($1, NULLIF($2, 0), $3, $4, $5, $6, $7, $8, $9) RETURNING id",
+		movement.BookID, movement.SKUID, movement.WarehouseID, movement.Type, movement.Quantity, movement.Reason, movement.Actor, movement.StockAfter, movement.CreatedAt).Scan(&movement.ID)
+}
@@ -0,0 +1,261 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStockMovements(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rows    []entity.StockMovement
+		isError bool
+		err     error
+	}{
+		{
+			name: "success",
+			rows: []entity.StockMovement{
+				{ID: 1, BookID: 1, WarehouseID: 1, Type: entity.MovementReceipt, Quantity: 10, Reason: "initial stock", Actor: "system", StockAfter: 10},
+				{ID: 2, BookID: 1, WarehouseID: 1, Type: entity.MovementSale, Quantity: -1, Actor: "bookstorebe", StockAfter: 9},
+			},
+			isError: false,
+			err:     nil,
+		},
+		{
+			name:    "failed",
+			rows:    nil,
+			isError: true,
+			err:     errors.New("Dummy Error"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			if !test.isError {
+				rows := sqlmock.NewRows([]string{"id", "book_id", "sku_id", "warehouse_id", "movement_type", "quantity", "reason", "actor", "stock_after", "created_at"})
+				for _, row := range test.rows {
+					rows.AddRow(row.ID, row.BookID, row.SKUID, row.WarehouseID, row.Type, row.Quantity, row.Reason, row.Actor, row.StockAfter, time.Now())
+				}
+				mock.ExpectQuery("SELECT (.+) FROM stock_movements WHERE book_id(.+)").WithArgs(1).WillReturnRows(rows)
+			} else {
+				mock.ExpectQuery("SELECT (.+) FROM stock_movements WHERE book_id(.+)").WillReturnError(test.err)
+			}
+
+			mysqlStock := repository.NewMysqlStock(db)
+			ret, err := mysqlStock.GetStockMovements(context.Background(), 1)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Len(t, ret, len(test.rows))
+		})
+	}
+}
+
+func TestRecordMovement(t *testing.T) {
+	testCases := []struct {
+		name        string
+		warehouseID int64
+		noWarehouse bool
+		updated     int64
+		bookShort   bool
+		insertErr   error
+		wantErr     error
+		isError     bool
+	}{
+		{
+			name:        "success",
+			warehouseID: 2,
+			updated:     1,
+			isError:     false,
+		},
+		{
+			name:    "default warehouse",
+			updated: 1,
+			isError: false,
+		},
+		{
+			name:        "no warehouse",
+			noWarehouse: true,
+			wantErr:     repository.ErrNoWarehouse,
+			isError:     true,
+		},
+		{
+			name:        "insufficient stock",
+			warehouseID: 2,
+			updated:     0,
+			wantErr:     repository.ErrInsufficientStock,
+			isError:     true,
+		},
+		{
+			name:        "book stock is reserved",
+			warehouseID: 2,
+			updated:     1,
+			bookShort:   true,
+			wantErr:     repository.ErrInsufficientStock,
+			isError:     true,
+		},
+		{
+			name:        "failed to insert movement",
+			warehouseID: 2,
+			updated:     1,
+			insertErr:   errors.New("Dummy Error"),
+			wantErr:     errors.New("Dummy Error"),
+			isError:     true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			warehouseID := test.warehouseID
+			mock.ExpectBegin()
+			if warehouseID == 0 {
+				query := mock.ExpectQuery("SELECT id FROM warehouses ORDER BY id LIMIT 1")
+				if test.noWarehouse {
+					query.WillReturnError(sql.ErrNoRows)
+				} else {
+					warehouseID = 1
+					query.WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(warehouseID))
+				}
+			}
+
+			if test.noWarehouse {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectExec("UPDATE warehouse_stocks SET stock = stock (.+) AND stock - reserved (.+)").WithArgs(-2, warehouseID, 1).WillReturnResult(sqlmock.NewResult(0, test.updated))
+				if test.updated == 0 {
+					mock.ExpectRollback()
+				} else {
+					books := mock.ExpectQuery("UPDATE books SET stock = stock (.+) AND stock - reserved (.+) RETURNING stock").WithArgs(-2, sqlmock.AnyArg(), 1)
+					if test.bookShort {
+						books.WillReturnError(sql.ErrNoRows)
+						mock.ExpectRollback()
+					} else {
+						books.WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(3))
+						insert := mock.ExpectQuery("INSERT INTO stock_movements (.+)").WithArgs(1, 0, warehouseID, entity.MovementSale, -2, "", "bookstorebe", 3, sqlmock.AnyArg())
+						if test.insertErr != nil {
+							insert.WillReturnError(test.insertErr)
+							mock.ExpectRollback()
+						} else {
+							insert.WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+							mock.ExpectCommit()
+						}
+					}
+				}
+			}
+
+			movement := entity.StockMovement{BookID: 1, WarehouseID: test.warehouseID, Type: entity.MovementSale, Quantity: -2, Actor: "bookstorebe"}
+			mysqlStock := repository.NewMysqlStock(db)
+			err = mysqlStock.RecordMovement(context.Background(), &movement)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if !test.isError {
+				assert.Equal(t, int64(7), movement.ID)
+				assert.Equal(t, warehouseID, movement.WarehouseID)
+				assert.Equal(t, 3, movement.StockAfter)
+			}
+		})
+	}
+}
+
+func TestReceiveStockIntoWarehouse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO warehouse_stocks (.+) ON CONFLICT (.+)").WithArgs(1, 1, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("UPDATE books SET stock = stock (.+) RETURNING stock").WithArgs(5, sqlmock.AnyArg(), 1).WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(5))
+	mock.ExpectQuery("INSERT INTO stock_movements (.+)").WithArgs(1, 0, 1, entity.MovementReceipt, 5, "", "bookstorebe", 5, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	movement := entity.StockMovement{BookID: 1, WarehouseID: 1, Type: entity.MovementReceipt, Quantity: 5, Actor: "bookstorebe"}
+	mysqlStock := repository.NewMysqlStock(db)
+	err = mysqlStock.RecordMovement(context.Background(), &movement)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransferStock(t *testing.T) {
+	testCases := []struct {
+		name    string
+		skuID   int64
+		updated int64
+		isError bool
+	}{
+		{name: "success", updated: 1, isError: false},
+		{name: "edition", skuID: 4, updated: 1, isError: false},
+		{name: "insufficient stock", updated: 0, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("SELECT id FROM warehouse_stocks WHERE book_id(.+) ORDER BY warehouse_id FOR UPDATE").WithArgs(7, pq.Array([]int64{1, 2})).WillReturnResult(sqlmock.NewResult(0, 2))
+			if test.skuID != 0 {
+				mock.ExpectExec("SELECT id FROM warehouse_sku_stocks WHERE sku_id(.+) ORDER BY warehouse_id FOR UPDATE").WithArgs(test.skuID, pq.Array([]int64{1, 2})).WillReturnResult(sqlmock.NewResult(0, 2))
+			}
+			mock.ExpectExec("UPDATE warehouse_stocks SET stock = stock (.+)").WithArgs(-3, 1, 7).WillReturnResult(sqlmock.NewResult(0, test.updated))
+			if test.isError {
+				mock.ExpectRollback()
+			} else {
+				if test.skuID != 0 {
+					mock.ExpectExec("UPDATE warehouse_sku_stocks SET stock = stock (.+)").WithArgs(-3, 1, test.skuID).WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				mock.ExpectQuery("SELECT stock FROM books (.+)").WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(10))
+				mock.ExpectQuery("INSERT INTO stock_movements (.+)").WithArgs(7, test.skuID, 1, entity.MovementTransfer, -3, "rebalance", "bookstorebe", 10, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO warehouse_stocks (.+)").WithArgs(2, 7, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				if test.skuID != 0 {
+					mock.ExpectExec("INSERT INTO warehouse_sku_stocks (.+)").WithArgs(2, test.skuID, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				mock.ExpectQuery("SELECT stock FROM books (.+)").WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(10))
+				mock.ExpectQuery("INSERT INTO stock_movements (.+)").WithArgs(7, test.skuID, 2, entity.MovementTransfer, 3, "rebalance", "bookstorebe", 10, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectCommit()
+			}
+
+			transfer := entity.StockTransfer{BookID: 7, SKUID: test.skuID, FromWarehouseID: 1, ToWarehouseID: 2, Quantity: 3, Reason: "rebalance", Actor: "bookstorebe"}
+			mysqlStock := repository.NewMysqlStock(db)
+			err = mysqlStock.TransferStock(context.Background(), &transfer)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.isError, err == repository.ErrInsufficientStock)
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if !test.isError {
+				assert.Len(t, transfer.Movements, 2)
+				assert.Equal(t, int64(2), transfer.Movements[1].ID)
+			}
+		})
+	}
+}
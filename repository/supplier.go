@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"winartodev/book-store-be/entity"
+)
+
+const supplierColumns = "id, name, publisher_id, email, phone_number, address, created_at, updated_at"
+
+type SupplierRepository interface {
+	GetSuppliers(ctx context.Context) ([]entity.Supplier, error)
+	GetSupplier(ctx context.Context, id int64) (entity.Supplier, error)
+	CreateSupplier(ctx context.Context, supplier *entity.Supplier) error
+	UpdateSupplier(ctx context.Context, id int64, supplier *entity.Supplier) error
+	DeleteSupplier(ctx context.Context, id int64) error
+}
+
+type mysqlSupplier struct {
+	DB *sql.DB
+}
+
+func NewMysqlSupplier(db *sql.DB) SupplierRepository {
+	return &mysqlSupplier{DB: db}
+}
+
+func (ms *mysqlSupplier) GetSuppliers(ctx context.Context) ([]entity.Supplier, error) {
+	var suppliers []entity.Supplier
+
+	rows, err := ms.DB.Query("SELECT " + supplierColumns + " FROM suppliers ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var supplier entity.Supplier
+
+		err := rows.Scan(&supplier.ID, &supplier.Name, &supplier.PublisherID, &supplier.Email, &supplier.PhoneNumber, &supplier.Address, &supplier.CreatedAt, &supplier.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		suppliers = append(suppliers, supplier)
+	}
+
+	return suppliers, nil
+}
+
+func (ms *mysqlSupplier) GetSupplier(ctx context.Context, id int64) (entity.Supplier, error) {
+	var supplier entity.Supplier
+
+	err := ms.DB.QueryRow("SELECT "+supplierColumns+" FROM suppliers WHERE id=$1", id).Scan(&supplier.ID, &supplier.Name, &supplier.PublisherID, &supplier.Email, &supplier.PhoneNumber, &supplier.Address, &supplier.CreatedAt, &supplier.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.Supplier{}, nil
+		}
+		return entity.Supplier{}, err
+	}
+
+	return supplier, nil
+}
+
+func (ms *mysqlSupplier) CreateSupplier(ctx context.Context, supplier *entity.Supplier) error {
+	stmt, err := ms.DB.Prepare("INSERT INTO suppliers (name, publisher_id, email, phone_number, address, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id")
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	supplier.CreatedAt = startTime
+	supplier.UpdatedAt = startTime
+
+	err = stmt.QueryRow(supplier.Name, supplier.PublisherID, supplier.Email, supplier.PhoneNumber, supplier.Address, supplier.CreatedAt, supplier.UpdatedAt).Scan(&supplier.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ms *mysqlSupplier) UpdateSupplier(ctx context.Context, id int64, supplier *entity.Supplier) error {
+	stmt, err := ms.DB.Prepare("UPDATE suppliers SET name=$1, publisher_id=$2, email=$3, phone_number=$4, address=$5, updated_at=$6 WHERE id=$7")
+	if err != nil {
+		return err
+	}
+
+	supplier.UpdatedAt = time.Now()
+	_, err = stmt.Exec(supplier.Name, supplier.PublisherID, supplier.Email, supplier.PhoneNumber, supplier.Address, supplier.UpdatedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ms *mysqlSupplier) DeleteSupplier(ctx context.Context, id int64) error {
+	stmt, err := ms.DB.Prepare("DELETE FROM suppliers WHERE id=$1")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var supplierRowColumns = []string{"id", "name", "publisher_id", "email", "phone_number", "address", "created_at", "updated_at"}
+
+func TestGetSuppliers(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rows    int
+		isError bool
+		err     error
+	}{
+		{name: "success", rows: 2},
+		{name: "failed", isError: true, err: errors.New("Dummy Error")},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			if !test.isError {
+				rows := sqlmock.NewRows(supplierRowColumns).
+					AddRow(1, "Gramedia Distribution", 1, "order@gramedia.test", "021555", "Jakarta", time.Now(), time.Now()).
+					AddRow(2, "Local Wholesaler", nil, "", "", "", time.Now(), time.Now())
+				mock.ExpectQuery("SELECT (.+) FROM suppliers ORDER BY name").WillReturnRows(rows)
+			} else {
+				mock.ExpectQuery("SELECT (.+) FROM suppliers ORDER BY name").WillReturnError(test.err)
+			}
+
+			mysqlSupplier := repository.NewMysqlSupplier(db)
+			ret, err := mysqlSupplier.GetSuppliers(context.Background())
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.rows, len(ret))
+			if test.rows > 0 {
+				assert.Equal(t, int64(1), *ret[0].PublisherID)
+				assert.Nil(t, ret[1].PublisherID)
+			}
+		})
+	}
+}
+
+func TestGetSupplier(t *testing.T) {
+	testCases := []struct {
+		name    string
+		found   bool
+		isError bool
+		err     error
+	}{
+		{name: "success", found: true},
+		{name: "not found", found: false},
+		{name: "failed", isError: true, err: errors.New("Dummy Error")},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			query := mock.ExpectQuery("SELECT (.+) FROM suppliers WHERE id(.+)").WithArgs(1)
+			switch {
+			case test.isError:
+				query.WillReturnError(test.err)
+			case test.found:
+				query.WillReturnRows(sqlmock.NewRows(supplierRowColumns).AddRow(1, "Gramedia Distribution", nil, "", "", "", time.Now(), time.Now()))
+			default:
+				query.WillReturnRows(sqlmock.NewRows(supplierRowColumns))
+			}
+
+			mysqlSupplier := repository.NewMysqlSupplier(db)
+			ret, err := mysqlSupplier.GetSupplier(context.Background(), 1)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.found, ret.ID == 1)
+		})
+	}
+}
+
+func TestCreateSupplier(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	publisherID := int64(1)
+	supplier := entity.Supplier{Name: "Gramedia Distribution", PublisherID: &publisherID, Email: "order@gramedia.test"}
+
+	mock.ExpectPrepare("INSERT INTO suppliers (.+)").ExpectQuery().WithArgs("Gramedia Distribution", 1, "order@gramedia.test", "", "", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	mysqlSupplier := repository.NewMysqlSupplier(db)
+	err = mysqlSupplier.CreateSupplier(context.Background(), &supplier)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), supplier.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateSupplier(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	supplier := entity.Supplier{Name: "Local Wholesaler", Address: "Bandung"}
+
+	mock.ExpectPrepare("UPDATE suppliers (.+)").ExpectExec().WithArgs("Local Wholesaler", nil, "", "", "Bandung", sqlmock.AnyArg(), 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mysqlSupplier := repository.NewMysqlSupplier(db)
+	err = mysqlSupplier.UpdateSupplier(context.Background(), 2, &supplier)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteSupplier(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectPrepare("DELETE FROM suppliers (.+)").ExpectExec().WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mysqlSupplier := repository.NewMysqlSupplier(db)
+	err = mysqlSupplier.DeleteSupplier(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
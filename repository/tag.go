@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+type TagRepository interface {
+	GetTags(ctx context.Context, prefix string, limit int) ([]entity.Tag, error)
+	GetBookTags(ctx context.Context, bookIDs []int64) (map[int64][]string, error)
+}
+
+type mysqlTag struct {
+	DB *sql.DB
+}
+
+func NewMysqlTag(db *sql.DB) TagRepository {
+	return &mysqlTag{DB: db}
+}
+
+// GetTags returns tags whose slug starts with prefix, most used first
+func (mt *mysqlTag) GetTags(ctx context.Context, prefix string, limit int) ([]entity.Tag, error) {
+	var tags []entity.Tag
+
+	rows, err := mt.DB.Query("SELECT t.id, t.name, t.slug, t.created_at FROM tags t LEFT JOIN book_tags bt ON bt.tag_id = t.id WHERE t.slug LIKE $1 GROUP BY t.id ORDER BY COUNT(bt.book_id) DESC, t.slug LIMIT $2", prefix+"%", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tag entity.Tag
+
+		err := rows.Scan(&tag.ID, &tag.Name, &tag.Slug, &tag.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// GetBookTags returns the tag names of every given book keyed by book id
+func (mt *mysqlTag) GetBookTags(ctx context.Context, bookIDs []int64) (map[int64][]string, error) {
+	bookTags := make(map[int64][]string)
+
+	rows, err := mt.DB.Query("SELECT bt.book_id, t.name FROM book_tags bt JOIN tags t ON t.id = bt.tag_id WHERE bt.book_id = ANY($1) ORDER BY bt.book_id, t.slug", pq.Array(bookIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookID int64
+		var name string
+
+		err := rows.Scan(&bookID, &name)
+		if err != nil {
+			return nil, err
+		}
+
+		bookTags[bookID] = append(bookTags[bookID], name)
+	}
+
+	return bookTags, nil
+}
+
+// setBookTags creates missing tags and replaces the tags of a book
+func setBookTags(tx *sql.Tx, bookID int64, tags []entity.Tag) error {
+	_, err := tx.Exec("DELETE FROM book_tags WHERE book_id=$1", bookID)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		var tagID int64
+		err = tx.QueryRow("INSERT INTO tags (name, slug, created_at) VALUES($1, $2, $3) ON CONFLICT (slug) DO UPDATE SET slug=EXCLUDED.slug RETURNING id", tag.Name, tag.Slug, time.Now()).Scan(&tagID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec("INSERT INTO book_tags (book_id, tag_id) VALUES($1, $2)", bookID, tagID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTags(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rows    []entity.Tag
+		isError bool
+		err     error
+	}{
+		{
+			name:    "success",
+			rows:    []entity.Tag{{ID: 1, Name: "Travel Guide", Slug: "travel-guide", CreatedAt: time.Now()}},
+			isError: false,
+			err:     nil,
+		},
+		{
+			name:    "failed",
+			rows:    []entity.Tag{},
+			isError: true,
+			err:     errors.New("Dummy Error"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			if !test.isError {
+				rows := sqlmock.NewRows([]string{"id", "name", "slug", "created_at"})
+				for _, row := range test.rows {
+					rows.AddRow(row.ID, row.Name, row.Slug, row.CreatedAt)
+				}
+				mock.ExpectQuery("SELECT (.+) FROM tags (.+) WHERE t.slug LIKE (.+)").WithArgs("tra%", 10).WillReturnRows(rows)
+			} else {
+				mock.ExpectQuery("SELECT (.+) FROM tags (.+) WHERE t.slug LIKE (.+)").WithArgs("tra%", 10).WillReturnError(test.err)
+			}
+
+			mysqlTag := repository.NewMysqlTag(db)
+			ret, err := mysqlTag.GetTags(context.Background(), "tra", 10)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, len(test.rows), len(ret))
+		})
+	}
+}
+
+func TestGetBookTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"book_id", "name"}).
+		AddRow(1, "Cookbook").
+		AddRow(1, "Travel Guide").
+		AddRow(2, "Cookbook")
+	mock.ExpectQuery("SELECT (.+) FROM book_tags (.+)").WillReturnRows(rows)
+
+	mysqlTag := repository.NewMysqlTag(db)
+	ret, err := mysqlTag.GetBookTags(context.Background(), []int64{1, 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Cookbook", "Travel Guide"}, ret[1])
+	assert.Equal(t, []string{"Cookbook"}, ret[2])
+}
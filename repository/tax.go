@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"winartodev/book-store-be/entity"
+)
+
+const taxRuleColumns = "id, country, region, COALESCE(tax_category_id, 0), name, rate, created_at, updated_at"
+
+type TaxRepository interface {
+	GetTaxCategories(ctx context.Context) ([]entity.TaxCategory, error)
+	GetTaxCategory(ctx context.Context, id int64) (entity.TaxCategory, error)
+	CreateTaxCategory(ctx context.Context, category *entity.TaxCategory) error
+	UpdateTaxCategory(ctx context.Context, id int64, category *entity.TaxCategory) error
+	DeleteTaxCategory(ctx context.Context, id int64) error
+	GetTaxRules(ctx context.Context) ([]entity.TaxRule, error)
+	GetTaxRule(ctx context.Context, id int64) (entity.TaxRule, error)
+	CreateTaxRule(ctx context.Context, rule *entity.TaxRule) error
+	UpdateTaxRule(ctx context.Context, id int64, rule *entity.TaxRule) error
+	DeleteTaxRule(ctx context.Context, id int64) error
+	GetJurisdictionRules(ctx context.Context, country string, region string) ([]entity.TaxRule, error)
+}
+
+type mysqlTax struct {
+	DB *sql.DB
+}
+
+func NewMysqlTax(db *sql.DB) TaxRepository {
+	return &mysqlTax{DB: db}
+}
+
+func (mt *mysqlTax) GetTaxCategories(ctx context.Context) ([]entity.TaxCategory, error) {
+	var categories []entity.TaxCategory
+
+	rows, err := mt.DB.Query("SELECT id, code, name, created_at, updated_at FROM tax_categories ORDER BY code")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var category entity.TaxCategory
+
+		err := rows.Scan(&category.ID, &category.Code, &category.Name, &category.CreatedAt, &category.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
+func (mt *mysqlTax) GetTaxCategory(ctx context.Context, id int64) (entity.TaxCategory, error) {
+	var category entity.TaxCategory
+
+	err := mt.DB.QueryRow("SELECT id, code, name, created_at, updated_at FROM tax_categories WHERE id=$1", id).Scan(&category.ID, &category.Code, &category.Name, &category.CreatedAt, &category.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.TaxCategory{}, nil
+		}
+		return entity.TaxCategory{}, err
+	}
+
+	return category, nil
+}
+
+func (mt *mysqlTax) CreateTaxCategory(ctx context.Context, category *entity.TaxCategory) error {
+	stmt, err := mt.DB.Prepare("INSERT INTO tax_categories (code, name, created_at, updated_at) VALUES($1, $2, $3, $4) RETURNING id")
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	category.CreatedAt = startTime
+	category.UpdatedAt = startTime
+
+	err = stmt.QueryRow(category.Code, category.Name, category.CreatedAt, category.UpdatedAt).Scan(&category.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mt *mysqlTax) UpdateTaxCategory(ctx context.Context, id int64, category *entity.TaxCategory) error {
+	stmt, err := mt.DB.Prepare("UPDATE tax_categories SET code=$1, name=$2, updated_at=$3 WHERE id=$4")
+	if err != nil {
+		return err
+	}
+
+	category.UpdatedAt = time.Now()
+	_, err = stmt.Exec(category.Code, category.Name, category.UpdatedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteTaxCategory removes the category with its rules, its books fall back to the rules of
+// no category
+func (mt *mysqlTax) DeleteTaxCategory(ctx context.Context, id int64) error {
+	stmt, err := mt.DB.Prepare("DELETE FROM tax_categories WHERE id=$1")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mt *mysqlTax) GetTaxRules(ctx context.Context) ([]entity.TaxRule, error) {
+	rows, err := mt.DB.Query("SELECT " + taxRuleColumns + " FROM tax_rules ORDER BY country, region, tax_category_id NULLS FIRST")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTaxRules(rows)
+}
+
+func (mt *mysqlTax) GetTaxRule(ctx context.Context, id int64) (entity.TaxRule, error) {
+	var rule entity.TaxRule
+
+	err := mt.DB.QueryRow("SELECT "+taxRuleColumns+" FROM tax_rules WHERE id=$1", id).
+		Scan(&rule.ID, &rule.Country, &rule.Region, &rule.TaxCategoryID, &rule.Name, &rule.Rate, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.TaxRule{}, nil
+		}
+		return entity.TaxRule{}, err
+	}
+
+	return rule, nil
+}
+
+func (mt *mysqlTax) CreateTaxRule(ctx context.Context, rule *entity.TaxRule) error {
+	stmt, err := mt.DB.Prepare("INSERT INTO tax_rules (country, region, tax_category_id, name, rate, created_at, updated_at) VALUES($1, $2, NULLIF($3, 0), $4, $5, $6, $7) RETURNING id")
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	rule.CreatedAt = startTime
+	rule.UpdatedAt = startTime
+
+	err = stmt.QueryRow(rule.Country, rule.Region, rule.TaxCategoryID, rule.Name, rule.Rate, rule.CreatedAt, rule.UpdatedAt).Scan(&rule.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mt *mysqlTax) UpdateTaxRule(ctx context.Context, id int64, rule *entity.TaxRule) error {
+	stmt, err := mt.DB.Prepare("UPDATE tax_rules SET country=$1, region=$2, tax_category_id=NULLIF($3, 0), name=$4, rate=$5, updated_at=$6 WHERE id=$7")
+	if err != nil {
+		return err
+	}
+
+	rule.UpdatedAt = time.Now()
+	_, err = stmt.Exec(rule.Country, rule.Region, rule.TaxCategoryID, rule.Name, rule.Rate, rule.UpdatedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mt *mysqlTax) DeleteTaxRule(ctx context.Context, id int64) error {
+	stmt, err := mt.DB.Prepare("DELETE FROM tax_rules WHERE id=$1")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetJurisdictionRules returns the rules of a country that cover the region, those of the whole
+// country included. Which of them applies to a book is left to the caller.
+func (mt *mysqlTax) GetJurisdictionRules(ctx context.Context, country string, region string) ([]entity.TaxRule, error) {
+	rows, err := mt.DB.Query("SELECT "+taxRuleColumns+" FROM tax_rules WHERE country=$1 AND (region='' OR region=$2) ORDER BY id", country, region)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTaxRules(rows)
+}
+
+func scanTaxRules(rows *sql.Rows) ([]entity.TaxRule, error) {
+	var rules []entity.TaxRule
+
+	for rows.Next() {
+		var rule entity.TaxRule
+
+		err := rows.Scan(&rule.ID, &rule.Country, &rule.Region, &rule.TaxCategoryID, &rule.Name, &rule.Rate, &rule.CreatedAt, &rule.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,79 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var taxRuleRowColumns = []string{"id", "country", "region", "tax_category_id", "name", "rate", "created_at", "updated_at"}
+
+func TestGetJurisdictionRules(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rows    int
+		isError bool
+		err     error
+	}{
+		{name: "success", rows: 2},
+		{name: "failed", isError: true, err: errors.New("Dummy Error")},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			query := mock.ExpectQuery("SELECT (.+) FROM tax_rules WHERE country(.+)").WithArgs("US", "NY")
+			if !test.isError {
+				rows := sqlmock.NewRows(taxRuleRowColumns).
+					AddRow(4, "US", "", 0, "Sales Tax", 400, time.Now(), time.Now()).
+					AddRow(5, "US", "NY", 1, "Sales Tax", 800, time.Now(), time.Now())
+				query.WillReturnRows(rows)
+			} else {
+				query.WillReturnError(test.err)
+			}
+
+			mysqlTax := repository.NewMysqlTax(db)
+			ret, err := mysqlTax.GetJurisdictionRules(context.Background(), "US", "NY")
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.rows, len(ret))
+			if test.rows > 0 {
+				assert.Equal(t, int64(0), ret[0].TaxCategoryID)
+				assert.Equal(t, int64(1), ret[1].TaxCategoryID)
+				assert.Equal(t, 800, ret[1].Rate)
+			}
+		})
+	}
+}
+
+func TestCreateTaxRule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectPrepare("INSERT INTO tax_rules (.+)").ExpectQuery().WithArgs("GB", "", 0, "VAT", 2000, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	rule := entity.TaxRule{Country: "GB", Name: "VAT", Rate: 2000}
+	mysqlTax := repository.NewMysqlTax(db)
+	err = mysqlTax.CreateTaxRule(context.Background(), &rule)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rule.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+// TranslationRepository keeps the catalog's text in other locales. The getters return the
+// translations keyed by what they translate, in the given locales or in every locale when
+// locales is nil.
+type TranslationRepository interface {
+	GetBookTranslations(ctx context.Context, bookIDs []int64, locales []string) (map[int64][]entity.BookTranslation, error)
+	SetBookTranslation(ctx context.Context, translation *entity.BookTranslation) error
+	DeleteBookTranslation(ctx context.Context, bookID int64, locale string) error
+	GetCategoryTranslations(ctx context.Context, categoryIDs []int64, locales []string) (map[int64][]entity.CategoryTranslation, error)
+	SetCategoryTranslation(ctx context.Context, translation *entity.CategoryTranslation) error
+	DeleteCategoryTranslation(ctx context.Context, categoryID int64, locale string) error
+	GetPublisherTranslations(ctx context.Context, publisherIDs []int64, locales []string) (map[int64][]entity.PublisherTranslation, error)
+	SetPublisherTranslation(ctx context.Context, translation *entity.PublisherTranslation) error
+	DeletePublisherTranslation(ctx context.Context, publisherID int64, locale string) error
+}
+
+type mysqlTranslation struct {
+	DB *sql.DB
+}
+
+func NewMysqlTranslation(db *sql.DB) TranslationRepository {
+	return &mysqlTranslation{DB: db}
+}
+
+func (mt *mysqlTranslation) GetBookTranslations(ctx context.Context, bookIDs []int64, locales []string) (map[int64][]entity.BookTranslation, error) {
+	translations := make(map[int64][]entity.BookTranslation)
+	if len(bookIDs) == 0 {
+		return translations, nil
+	}
+
+	rows, err := mt.DB.Query("SELECT book_id, locale, title, description, created_at, updated_at FROM book_translations WHERE book_id = ANY($1) AND ($2::varchar[] IS NULL OR locale = ANY($2)) ORDER BY book_id, locale", pq.Array(bookIDs), pq.Array(locales))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var translation entity.BookTranslation
+
+		err := rows.Scan(&translation.BookID, &translation.Locale, &translation.Title, &translation.Description, &translation.CreatedAt, &translation.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		translations[translation.BookID] = append(translations[translation.BookID], translation)
+	}
+
+	return translations, nil
+}
+
+// SetBookTranslation adds the book's translation into the locale or replaces it
+func (mt *mysqlTranslation) SetBookTranslation(ctx context.Context, translation *entity.BookTranslation) error {
+	stmt, err := mt.DB.Prepare("INSERT INTO book_translations (book_id, locale, title, description, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $5) ON CONFLICT (book_id, locale) DO UPDATE SET title=EXCLUDED.title, description=EXCLUDED.description, updated_at=EXCLUDED.updated_at RETURNING created_at, updated_at")
+	if err != nil {
+		return err
+	}
+
+	err = stmt.QueryRow(translation.BookID, translation.Locale, translation.Title, translation.Description, time.Now()).Scan(&translation.CreatedAt, &translation.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mt *mysqlTranslation) DeleteBookTranslation(ctx context.Context, bookID int64, locale string) error {
+	stmt, err := mt.DB.Prepare("DELETE FROM book_translations WHERE book_id=$1 AND locale=$2")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(bookID, locale)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mt *mysqlTranslation) GetCategoryTranslations(ctx context.Context, categoryIDs []int64, locales []string) (map[int64][]entity.CategoryTranslation, error) {
+	translations := make(map[int64][]entity.CategoryTranslation)
+	if len(categoryIDs) == 0 {
+		return translations, nil
+	}
+
+	rows, err := mt.DB.Query("SELECT category_id, locale, name, created_at, updated_at FROM category_translations WHERE category_id = ANY($1) AND ($2::varchar[] IS NULL OR locale = ANY($2)) ORDER BY category_id, locale", pq.Array(categoryIDs), pq.Array(locales))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var translation entity.CategoryTranslation
+
+		err := rows.Scan(&translation.CategoryID, &translation.Locale, &translation.Name, &translation.CreatedAt, &translation.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		translations[translation.CategoryID] = append(translations[translation.CategoryID], translation)
+	}
+
+	return translations, nil
+}
+
+// SetCategoryTranslation adds the category's translation into the locale or replaces it
+func (mt *mysqlTranslation) SetCategoryTranslation(ctx context.Context, translation *entity.CategoryTranslation) error {
+	stmt, err := mt.DB.Prepare("INSERT INTO category_translations (category_id, locale, name, created_at, updated_at) VALUES($1, $2, $3, $4, $4) ON CONFLICT (category_id, locale) DO UPDATE SET name=EXCLUDED.name, updated_at=EXCLUDED.updated_at RETURNING created_at, updated_at")
+	if err != nil {
+		return err
+	}
+
+	err = stmt.QueryRow(translation.CategoryID, translation.Locale, translation.Name, time.Now()).Scan(&translation.CreatedAt, &translation.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mt *mysqlTranslation) DeleteCategoryTranslation(ctx context.Context, categoryID int64, locale string) error {
+	stmt, err := mt.DB.Prepare("DELETE FROM category_translations WHERE category_id=$1 AND locale=$2")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(categoryID, locale)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mt *mysqlTranslation) GetPublisherTranslations(ctx context.Context, publisherIDs []int64, locales []string) (map[int64][]entity.PublisherTranslation, error) {
+	translations := make(map[int64][]entity.PublisherTranslation)
+	if len(publisherIDs) == 0 {
+		return translations, nil
+	}
+
+	rows, err := mt.DB.Query("SELECT publisher_id, locale, name, created_at, updated_at FROM publisher_translations WHERE publisher_id = ANY($1) AND ($2::varchar[] IS NULL OR locale = ANY($2)) ORDER BY publisher_id, locale", pq.Array(publisherIDs), pq.Array(locales))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var translation entity.PublisherTranslation
+
+		err := rows.Scan(&translation.PublisherID, &translation.Locale, &translation.Name, &translation.CreatedAt, &translation.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		translations[translation.PublisherID] = append(translations[translation.PublisherID], translation)
+	}
+
+	return translations, nil
+}
+
+// SetPublisherTranslation adds the publisher's translation into the locale or replaces it
+func (mt *mysqlTranslation) SetPublisherTranslation(ctx context.Context, translation *entity.PublisherTranslation) error {
+	stmt, err := mt.DB.Prepare("INSERT INTO publisher_translations (publisher_id, locale, name, created_at, updated_at) VALUES($1, $2, $3, $4, $4) ON CONFLICT (publisher_id, locale) DO UPDATE SET name=EXCLUDED.name, updated_at=EXCLUDED.updated_at RETURNING created_at, updated_at")
+	if err != nil {
+		return err
+	}
+
+	err = stmt.QueryRow(translation.PublisherID, translation.Locale, translation.Name, time.Now()).Scan(&translation.CreatedAt, &translation.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mt *mysqlTranslation) DeletePublisherTranslation(ctx context.Context, publisherID int64, locale string) error {
+	stmt, err := mt.DB.Prepare("DELETE FROM publisher_translations WHERE publisher_id=$1 AND locale=$2")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(publisherID, locale)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBookTranslations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"book_id", "locale", "title", "description", "created_at", "updated_at"}).
+		AddRow(1, "fr", "La Couleur de la magie", "", time.Now(), time.Now()).
+		AddRow(1, "fr-CA", "", "Un roman du Disque-monde", time.Now(), time.Now()).
+		AddRow(2, "fr", "Le Huitième Sortilège", "", time.Now(), time.Now())
+	mock.ExpectQuery("SELECT (.+) FROM book_translations WHERE book_id = ANY\\(\\$1\\) AND (.+) ORDER BY book_id, locale").WillReturnRows(rows)
+
+	mysqlTranslation := repository.NewMysqlTranslation(db)
+	ret, err := mysqlTranslation.GetBookTranslations(context.Background(), []int64{1, 2}, []string{"fr-CA", "fr"})
+
+	assert.NoError(t, err)
+	assert.Len(t, ret[1], 2)
+	assert.Equal(t, "Le Huitième Sortilège", ret[2][0].Title)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetCategoryTranslation(t *testing.T) {
+	testCases := []struct {
+		name    string
+		isError bool
+	}{
+		{name: "success", isError: false},
+		{name: "failed", isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			query := mock.ExpectPrepare("INSERT INTO category_translations (.+) ON CONFLICT \\(category_id, locale\\) DO UPDATE (.+)").
+				ExpectQuery().WithArgs(3, "fr", "Fantaisie", sqlmock.AnyArg())
+			if !test.isError {
+				query.WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+			} else {
+				query.WillReturnError(errors.New("Dummy Error"))
+			}
+
+			mysqlTranslation := repository.NewMysqlTranslation(db)
+			translation := entity.CategoryTranslation{CategoryID: 3, Locale: "fr", Name: "Fantaisie"}
+			err = mysqlTranslation.SetCategoryTranslation(context.Background(), &translation)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, !test.isError, !translation.UpdatedAt.IsZero())
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
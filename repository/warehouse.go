@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+const warehouseColumns = "id, code, name, latitude, longitude, created_at, updated_at"
+
+type WarehouseRepository interface {
+	GetWarehouses(ctx context.Context) ([]entity.Warehouse, error)
+	GetWarehouse(ctx context.Context, id int64) (entity.Warehouse, error)
+	CreateWarehouse(ctx context.Context, warehouse *entity.Warehouse) error
+	UpdateWarehouse(ctx context.Context, id int64, warehouse *entity.Warehouse) error
+	DeleteWarehouse(ctx context.Context, id int64) error
+	GetWarehouseStocks(ctx context.Context, bookIDs []int64) (map[int64][]entity.WarehouseStock, error)
+	GetWarehouseSKUStocks(ctx context.Context, skuIDs []int64) (map[int64][]entity.WarehouseStock, error)
+	GetWarehouseStockTotal(ctx context.Context, id int64) (int, error)
+}
+
+type mysqlWarehouse struct {
+	DB *sql.DB
+}
+
+func NewMysqlWarehouse(db *sql.DB) WarehouseRepository {
+	return &mysqlWarehouse{DB: db}
+}
+
+func (mw *mysqlWarehouse) GetWarehouses(ctx context.Context) ([]entity.Warehouse, error) {
+	var warehouses []entity.Warehouse
+
+	rows, err := mw.DB.Query("SELECT " + warehouseColumns + " FROM warehouses ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var warehouse entity.Warehouse
+
+		err := rows.Scan(&warehouse.ID, &warehouse.Code, &warehouse.Name, &warehouse.Latitude, &warehouse.Longitude, &warehouse.CreatedAt, &warehouse.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		warehouses = append(warehouses, warehouse)
+	}
+
+	return warehouses, nil
+}
+
+func (mw *mysqlWarehouse) GetWarehouse(ctx context.Context, id int64) (entity.Warehouse, error) {
+	var warehouse entity.Warehouse
+
+	err := mw.DB.QueryRow("SELECT "+warehouseColumns+" FROM warehouses WHERE id=$1", id).Scan(&warehouse.ID, &warehouse.Code, &warehouse.Name, &warehouse.Latitude, &warehouse.Longitude, &warehouse.CreatedAt, &warehouse.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.Warehouse{}, nil
+		}
+		return entity.Warehouse{}, err
+	}
+
+	return warehouse, nil
+}
+
+func (mw *mysqlWarehouse) CreateWarehouse(ctx context.Context, warehouse *entity.Warehouse) error {
+	stmt, err := mw.DB.Prepare("INSERT INTO warehouses (code, name, latitude, longitude, created_at, updated_at) VALUES($1, $2, $3, $4, $5, $6) RETURNING id")
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	warehouse.CreatedAt = startTime
+	warehouse.UpdatedAt = startTime
+
+	err = stmt.QueryRow(&warehouse.Code, &warehouse.Name, &warehouse.Latitude, &warehouse.Longitude, &warehouse.CreatedAt, &warehouse.UpdatedAt).Scan(&warehouse.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mw *mysqlWarehouse) UpdateWarehouse(ctx context.Context, id int64, warehouse *entity.Warehouse) error {
+	stmt, err := mw.DB.Prepare("UPDATE warehouses SET code=$1, name=$2, latitude=$3, longitude=$4, updated_at=$5 WHERE id=$6")
+	if err != nil {
+		return err
+	}
+
+	warehouse.UpdatedAt = time.Now()
+	_, err = stmt.Exec(&warehouse.Code, &warehouse.Name, &warehouse.Latitude, &warehouse.Longitude, &warehouse.UpdatedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mw *mysqlWarehouse) DeleteWarehouse(ctx context.Context, id int64) error {
+	stmt, err := mw.DB.Prepare("DELETE FROM warehouses WHERE id=$1")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetWarehouseStocks returns the stock every warehouse keeps of the given books keyed by book id
+func (mw *mysqlWarehouse) GetWarehouseStocks(ctx context.Context, bookIDs []int64) (map[int64][]entity.WarehouseStock, error) {
+	stocks := make(map[int64][]entity.WarehouseStock)
+
+	rows, err := mw.DB.Query("SELECT ws.book_id, ws.warehouse_id, w.code, ws.stock, ws.reserved FROM warehouse_stocks ws JOIN warehouses w ON w.id = ws.warehouse_id WHERE ws.book_id = ANY($1) ORDER BY ws.book_id, ws.warehouse_id", pq.Array(bookIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var stock entity.WarehouseStock
+
+		err := rows.Scan(&stock.BookID, &stock.WarehouseID, &stock.WarehouseCode, &stock.Stock, &stock.Reserved)
+		if err != nil {
+			return nil, err
+		}
+
+		stock.Available = stock.Stock - stock.Reserved
+		stocks[stock.BookID] = append(stocks[stock.BookID], stock)
+	}
+
+	return stocks, nil
+}
+
+// GetWarehouseSKUStocks returns the stock every warehouse keeps of the given editions keyed by
+// SKU id
+func (mw *mysqlWarehouse) GetWarehouseSKUStocks(ctx context.Context, skuIDs []int64) (map[int64][]entity.WarehouseStock, error) {
+	stocks := make(map[int64][]entity.WarehouseStock)
+
+	rows, err := mw.DB.Query("SELECT s.book_id, ws.sku_id, ws.warehouse_id, w.code, ws.stock, ws.reserved FROM warehouse_sku_stocks ws JOIN warehouses w ON w.id = ws.warehouse_id JOIN skus s ON s.id = ws.sku_id WHERE ws.sku_id = ANY($1) ORDER BY ws.sku_id, ws.warehouse_id", pq.Array(skuIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var stock entity.WarehouseStock
+
+		err := rows.Scan(&stock.BookID, &stock.SKUID, &stock.WarehouseID, &stock.WarehouseCode, &stock.Stock, &stock.Reserved)
+		if err != nil {
+			return nil, err
+		}
+
+		stock.Available = stock.Stock - stock.Reserved
+		stocks[stock.SKUID] = append(stocks[stock.SKUID], stock)
+	}
+
+	return stocks, nil
+}
+
+// GetWarehouseStockTotal returns the number of copies of all books kept in a warehouse
+func (mw *mysqlWarehouse) GetWarehouseStockTotal(ctx context.Context, id int64) (int, error) {
+	var total int
+
+	err := mw.DB.QueryRow("SELECT COALESCE(SUM(stock), 0) FROM warehouse_stocks WHERE warehouse_id=$1", id).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
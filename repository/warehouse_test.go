@@ -0,0 +1,221 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var warehouseRowColumns = []string{"id", "code", "name", "latitude", "longitude", "created_at", "updated_at"}
+
+func TestGetWarehouses(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rows    []entity.Warehouse
+		isError bool
+		err     error
+	}{
+		{
+			name:    "success",
+			rows:    []entity.Warehouse{{ID: 1, Code: "MAIN", Name: "Main Warehouse"}, {ID: 2, Code: "SBY", Name: "Surabaya", Latitude: -7.25, Longitude: 112.75}},
+			isError: false,
+			err:     nil,
+		},
+		{
+			name:    "failed",
+			rows:    []entity.Warehouse{},
+			isError: true,
+			err:     errors.New("Dummy Error"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			if !test.isError {
+				rows := sqlmock.NewRows(warehouseRowColumns)
+				for _, row := range test.rows {
+					rows.AddRow(row.ID, row.Code, row.Name, row.Latitude, row.Longitude, time.Now(), time.Now())
+				}
+				mock.ExpectQuery("SELECT (.+) FROM warehouses ORDER BY id").WillReturnRows(rows)
+			} else {
+				mock.ExpectQuery("SELECT (.+) FROM warehouses ORDER BY id").WillReturnError(test.err)
+			}
+
+			mysqlWarehouse := repository.NewMysqlWarehouse(db)
+			ret, err := mysqlWarehouse.GetWarehouses(context.Background())
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, len(test.rows), len(ret))
+		})
+	}
+}
+
+func TestGetWarehouse(t *testing.T) {
+	testCases := []struct {
+		name    string
+		found   bool
+		isError bool
+		err     error
+	}{
+		{name: "success", found: true},
+		{name: "not found", found: false},
+		{name: "failed", isError: true, err: errors.New("Dummy Error")},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			query := mock.ExpectQuery("SELECT (.+) FROM warehouses WHERE id(.+)").WithArgs(1)
+			switch {
+			case test.isError:
+				query.WillReturnError(test.err)
+			case test.found:
+				query.WillReturnRows(sqlmock.NewRows(warehouseRowColumns).AddRow(1, "MAIN", "Main Warehouse", 0, 0, time.Now(), time.Now()))
+			default:
+				query.WillReturnRows(sqlmock.NewRows(warehouseRowColumns))
+			}
+
+			mysqlWarehouse := repository.NewMysqlWarehouse(db)
+			ret, err := mysqlWarehouse.GetWarehouse(context.Background(), 1)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.found, ret.ID == 1)
+		})
+	}
+}
+
+func TestCreateWarehouse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	warehouse := entity.Warehouse{Code: "SBY", Name: "Surabaya", Latitude: -7.25, Longitude: 112.75}
+
+	mock.ExpectPrepare("INSERT INTO warehouses (.+)").ExpectQuery().WithArgs("SBY", "Surabaya", -7.25, 112.75, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+	mysqlWarehouse := repository.NewMysqlWarehouse(db)
+	err = mysqlWarehouse.CreateWarehouse(context.Background(), &warehouse)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), warehouse.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateWarehouse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	warehouse := entity.Warehouse{Code: "SBY", Name: "Surabaya", Latitude: -7.25, Longitude: 112.75}
+
+	mock.ExpectPrepare("UPDATE warehouses (.+)").ExpectExec().WithArgs("SBY", "Surabaya", -7.25, 112.75, sqlmock.AnyArg(), 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mysqlWarehouse := repository.NewMysqlWarehouse(db)
+	err = mysqlWarehouse.UpdateWarehouse(context.Background(), 2, &warehouse)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteWarehouse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectPrepare("DELETE FROM warehouses (.+)").ExpectExec().WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mysqlWarehouse := repository.NewMysqlWarehouse(db)
+	err = mysqlWarehouse.DeleteWarehouse(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetWarehouseStocks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"book_id", "warehouse_id", "code", "stock", "reserved"}).
+		AddRow(1, 1, "MAIN", 10, 2).
+		AddRow(1, 2, "SBY", 4, 0).
+		AddRow(2, 1, "MAIN", 3, 3)
+	mock.ExpectQuery("SELECT (.+) FROM warehouse_stocks (.+)").WillReturnRows(rows)
+
+	mysqlWarehouse := repository.NewMysqlWarehouse(db)
+	ret, err := mysqlWarehouse.GetWarehouseStocks(context.Background(), []int64{1, 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, ret[1], 2)
+	assert.Equal(t, 8, ret[1][0].Available)
+	assert.Equal(t, "SBY", ret[1][1].WarehouseCode)
+	assert.Equal(t, 0, ret[2][0].Available)
+}
+
+func TestGetWarehouseSKUStocks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"book_id", "sku_id", "warehouse_id", "code", "stock", "reserved"}).
+		AddRow(1, 10, 1, "MAIN", 4, 1).
+		AddRow(1, 10, 2, "SBY", 2, 0).
+		AddRow(1, 11, 2, "SBY", 3, 0)
+	mock.ExpectQuery("SELECT (.+) FROM warehouse_sku_stocks (.+)").WillReturnRows(rows)
+
+	mysqlWarehouse := repository.NewMysqlWarehouse(db)
+	ret, err := mysqlWarehouse.GetWarehouseSKUStocks(context.Background(), []int64{10, 11})
+
+	assert.NoError(t, err)
+	assert.Len(t, ret[10], 2)
+	assert.Equal(t, 3, ret[10][0].Available)
+	assert.Equal(t, int64(1), ret[10][0].BookID)
+	assert.Equal(t, "SBY", ret[11][0].WarehouseCode)
+}
+
+func TestGetWarehouseStockTotal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT COALESCE(.+) FROM warehouse_stocks WHERE warehouse_id(.+)").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(14))
+
+	mysqlWarehouse := repository.NewMysqlWarehouse(db)
+	total, err := mysqlWarehouse.GetWarehouseStockTotal(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 14, total)
+}
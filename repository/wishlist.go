@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	"winartodev/book-store-be/entity"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrDuplicateWishlistItem is returned when a customer saves a book already on their wishlist
+	ErrDuplicateWishlistItem = errors.New("the book is already on the wishlist")
+	// ErrDuplicateStockSubscription is returned when a customer already waits for the book
+	ErrDuplicateStockSubscription = errors.New("the customer is already subscribed to the book")
+)
+
+// WishlistRepository stores the books customers want: saved for later on their wishlist, or
+// subscribed to until they are back in stock
+type WishlistRepository interface {
+	GetWishlist(ctx context.Context, customerEmail string) ([]entity.WishlistItem, error)
+	AddWishlistItem(ctx context.Context, item *entity.WishlistItem) error
+	RemoveWishlistItem(ctx context.Context, customerEmail string, bookID int64) error
+	CreateStockSubscription(ctx context.Context, subscription *entity.StockSubscription) error
+	DeleteStockSubscription(ctx context.Context, bookID int64, customerEmail string) error
+	GetBackInStock(ctx context.Context) ([]entity.BackInStock, error)
+	MarkNotified(ctx context.Context, subscriptionIDs []int64, now time.Time) error
+}
+
+type mysqlWishlist struct {
+	DB *sql.DB
+}
+
+func NewMysqlWishlist(db *sql.DB) WishlistRepository {
+	return &mysqlWishlist{DB: db}
+}
+
+func (mw *mysqlWishlist) GetWishlist(ctx context.Context, customerEmail string) ([]entity.WishlistItem, error) {
+	var items []entity.WishlistItem
+
+	rows, err := mw.DB.Query("SELECT w.id, w.customer_email, w.book_id, b.title, b.stock - b.reserved, w.created_at FROM wishlist_items w JOIN books b ON b.id = w.book_id WHERE w.customer_email=$1 ORDER BY w.created_at DESC, w.id DESC", customerEmail)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.WishlistItem
+
+		err := rows.Scan(&item.ID, &item.CustomerEmail, &item.BookID, &item.Title, &item.Available, &item.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// AddWishlistItem saves a book for a customer, saving it twice fails with ErrDuplicateWishlistItem
+func (mw *mysqlWishlist) AddWishlistItem(ctx context.Context, item *entity.WishlistItem) error {
+	stmt, err := mw.DB.Prepare("INSERT INTO wishlist_items (customer_email, book_id, created_at) VALUES($1, $2, $3) ON CONFLICT (customer_email, book_id) DO NOTHING RETURNING id")
+	if err != nil {
+		return err
+	}
+
+	item.CreatedAt = time.Now()
+
+	err = stmt.QueryRow(item.CustomerEmail, item.BookID, item.CreatedAt).Scan(&item.ID)
+	if err == sql.ErrNoRows {
+		return ErrDuplicateWishlistItem
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (mw *mysqlWishlist) RemoveWishlistItem(ctx context.Context, customerEmail string, bookID int64) error {
+	stmt, err := mw.DB.Prepare("DELETE FROM wishlist_items WHERE customer_email=$1 AND book_id=$2")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(customerEmail, bookID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateStockSubscription queues a back-in-stock notice for a customer. Only one subscription
+// per book and customer waits at a time, a second one fails with ErrDuplicateStockSubscription.
+func (mw *mysqlWishlist) CreateStockSubscription(ctx context.Context, subscription *entity.StockSubscription) error {
+	stmt, err := mw.DB.Prepare("INSERT INTO stock_subscriptions (book_id, customer_email, created_at) VALUES($1, $2, $3) ON CONFLICT (book_id, customer_email) WHERE notified_at IS NULL DO NOTHING RETURNING id")
+	if err != nil {
+		return err
+	}
+
+	subscription.CreatedAt = time.Now()
+
+	err = stmt.QueryRow(subscription.BookID, subscription.CustomerEmail, subscription.CreatedAt).Scan(&subscription.ID)
+	if err == sql.ErrNoRows {
+		return ErrDuplicateStockSubscription
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteStockSubscription cancels the waiting subscription, those already notified are kept
+func (mw *mysqlWishlist) DeleteStockSubscription(ctx context.Context, bookID int64, customerEmail string) error {
+	stmt, err := mw.DB.Prepare("DELETE FROM stock_subscriptions WHERE book_id=$1 AND customer_email=$2 AND notified_at IS NULL")
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(bookID, customerEmail)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetBackInStock lists the waiting subscriptions whose book can be ordered again. Subscribing is
+// only possible while a book is out of stock, so these are the books restocked since, however
+// the stock came back. They are ordered by customer so each customer can be told at once.
+func (mw *mysqlWishlist) GetBackInStock(ctx context.Context) ([]entity.BackInStock, error) {
+	var books []entity.BackInStock
+
+	rows, err := mw.DB.Query("SELECT s.id, s.customer_email, b.id, b.title, b.stock - b.reserved FROM stock_subscriptions s JOIN books b ON b.id = s.book_id WHERE s.notified_at IS NULL AND b.stock - b.reserved > 0 ORDER BY s.customer_email, s.id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var book entity.BackInStock
+
+		err := rows.Scan(&book.SubscriptionID, &book.CustomerEmail, &book.BookID, &book.Title, &book.Available)
+		if err != nil {
+			return nil, err
+		}
+
+		books = append(books, book)
+	}
+
+	return books, nil
+}
+
+func (mw *mysqlWishlist) MarkNotified(ctx context.Context, subscriptionIDs []int64, now time.Time) error {
+	_, err := mw.DB.Exec("UPDATE stock_subscriptions SET notified_at=$1 WHERE id = ANY($2) AND notified_at IS NULL", now, pq.Array(subscriptionIDs))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddWishlistItem(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantErr error
+	}{
+		{name: "success", rows: sqlmock.NewRows([]string{"id"}).AddRow(2)},
+		{name: "already saved", rows: sqlmock.NewRows([]string{"id"}), wantErr: repository.ErrDuplicateWishlistItem},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectPrepare("INSERT INTO wishlist_items (.+) ON CONFLICT \\(customer_email, book_id\\) DO NOTHING RETURNING id").ExpectQuery().
+				WithArgs("reader@mail.com", 5, sqlmock.AnyArg()).
+				WillReturnRows(test.rows)
+
+			item := entity.WishlistItem{CustomerEmail: "reader@mail.com", BookID: 5}
+			mysqlWishlist := repository.NewMysqlWishlist(db)
+			err = mysqlWishlist.AddWishlistItem(context.Background(), &item)
+
+			assert.Equal(t, test.wantErr, err)
+			if test.wantErr == nil {
+				assert.Equal(t, int64(2), item.ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCreateStockSubscription(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantErr error
+	}{
+		{name: "success", rows: sqlmock.NewRows([]string{"id"}).AddRow(7)},
+		{name: "already waiting", rows: sqlmock.NewRows([]string{"id"}), wantErr: repository.ErrDuplicateStockSubscription},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				panic(fmt.Sprintf("Database Not Connect %s", err))
+			}
+			defer db.Close()
+
+			mock.ExpectPrepare("INSERT INTO stock_subscriptions (.+) ON CONFLICT \\(book_id, customer_email\\) WHERE notified_at IS NULL DO NOTHING RETURNING id").ExpectQuery().
+				WithArgs(5, "reader@mail.com", sqlmock.AnyArg()).
+				WillReturnRows(test.rows)
+
+			subscription := entity.StockSubscription{BookID: 5, CustomerEmail: "reader@mail.com"}
+			mysqlWishlist := repository.NewMysqlWishlist(db)
+			err = mysqlWishlist.CreateStockSubscription(context.Background(), &subscription)
+
+			assert.Equal(t, test.wantErr, err)
+			if test.wantErr == nil {
+				assert.Equal(t, int64(7), subscription.ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetBackInStock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		panic(fmt.Sprintf("Database Not Connect %s", err))
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "customer_email", "book_id", "title", "available"}).
+		AddRow(7, "reader@mail.com", 5, "Dune", 4).
+		AddRow(9, "reader@mail.com", 6, "Emma", 1)
+	mock.ExpectQuery("SELECT (.+) FROM stock_subscriptions s JOIN books b (.+) WHERE s.notified_at IS NULL AND b.stock - b.reserved > 0 ORDER BY s.customer_email, s.id").
+		WillReturnRows(rows)
+
+	mysqlWishlist := repository.NewMysqlWishlist(db)
+	books, err := mysqlWishlist.GetBackInStock(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.BackInStock{
+		{SubscriptionID: 7, CustomerEmail: "reader@mail.com", BookID: 5, Title: "Dune", Available: 4},
+		{SubscriptionID: 9, CustomerEmail: "reader@mail.com", BookID: 6, Title: "Emma", Available: 1},
+	}, books)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
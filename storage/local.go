@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type local struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocal stores files under dir and serves them from baseURL
+func NewLocal(dir, baseURL string) Storage {
+	return &local{dir: dir, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+func (l *local) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	path := l.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// write to a temporary file first so readers never see a partial upload
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (l *local) Delete(ctx context.Context, key string) error {
+	err := os.Remove(l.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (l *local) URL(key string) string {
+	return l.baseURL + "/" + strings.TrimLeft(key, "/")
+}
+
+// path maps key inside dir, cleaning it so keys cannot escape the directory
+func (l *local) path(key string) string {
+	return filepath.Join(l.dir, filepath.FromSlash(filepath.Clean("/"+key)))
+}
@@ -0,0 +1,56 @@
+package storage_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"winartodev/book-store-be/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalPut(t *testing.T) {
+	dir := t.TempDir()
+	s := storage.NewLocal(dir, "http://localhost:8080/covers/")
+
+	testCases := []struct {
+		name string
+		key  string
+		path string
+	}{
+		{name: "nested key", key: "covers/1/large.jpg", path: "covers/1/large.jpg"},
+		{name: "key cannot escape the directory", key: "../../etc/passwd", path: "etc/passwd"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := s.Put(context.TODO(), test.key, strings.NewReader("content"), "image/jpeg")
+			assert.NoError(t, err)
+
+			b, err := os.ReadFile(filepath.Join(dir, test.path))
+			assert.NoError(t, err)
+			assert.Equal(t, "content", string(b))
+		})
+	}
+}
+
+func TestLocalDelete(t *testing.T) {
+	dir := t.TempDir()
+	s := storage.NewLocal(dir, "http://localhost:8080/covers")
+
+	assert.NoError(t, s.Put(context.TODO(), "a/b.jpg", strings.NewReader("content"), "image/jpeg"))
+	assert.NoError(t, s.Delete(context.TODO(), "a/b.jpg"))
+
+	_, err := os.Stat(filepath.Join(dir, "a/b.jpg"))
+	assert.True(t, os.IsNotExist(err))
+
+	// deleting a missing file is not an error
+	assert.NoError(t, s.Delete(context.TODO(), "a/b.jpg"))
+}
+
+func TestLocalURL(t *testing.T) {
+	s := storage.NewLocal(t.TempDir(), "http://localhost:8080/covers/")
+	assert.Equal(t, "http://localhost:8080/covers/a/b.jpg", s.URL("a/b.jpg"))
+}
@@ -0,0 +1,190 @@
+package storage_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"winartodev/book-store-be/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	dummyAccessKey = "AKIDEXAMPLE"
+	dummySecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	dummyRegion    = "us-east-1"
+	dummyBucket    = "covers"
+)
+
+// fakeS3 is a minimal stand-in for an S3 compatible server that verifies
+// Signature Version 4 and keeps objects in memory
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]string
+	types   map[string]string
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: map[string]string{}, types: map[string]string{}}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if !f.verify(r, body) {
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[r.URL.Path] = string(body)
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeS3) verify(r *http.Request, body []byte) bool {
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	if r.Header.Get("X-Amz-Content-Sha256") != payloadHash {
+		return false
+	}
+
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 ") {
+		return false
+	}
+
+	fields := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(auth, "AWS4-HMAC-SHA256 "), ", ") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	if len(amzDate) < 8 {
+		return false
+	}
+	scope := amzDate[:8] + "/" + dummyRegion + "/s3/aws4_request"
+	if fields["Credential"] != dummyAccessKey+"/"+scope {
+		return false
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range strings.Split(fields["SignedHeaders"], ";") {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + value + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{r.Method, r.URL.EscapedPath(), r.URL.RawQuery, canonicalHeaders.String(), fields["SignedHeaders"], payloadHash}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + dummySecretKey)
+	for _, data := range []string{amzDate[:8], dummyRegion, "s3", "aws4_request", stringToSign} {
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(data))
+		key = h.Sum(nil)
+	}
+
+	return hex.EncodeToString(key) == fields["Signature"]
+}
+
+func TestS3Put(t *testing.T) {
+	fake := newFakeS3()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	testCases := []struct {
+		name      string
+		secretKey string
+		wantError bool
+	}{
+		{name: "success", secretKey: dummySecretKey},
+		{name: "wrong secret key", secretKey: "wrong", wantError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			s := storage.NewS3(storage.S3Config{
+				Endpoint:  server.URL,
+				Region:    dummyRegion,
+				Bucket:    dummyBucket,
+				AccessKey: dummyAccessKey,
+				SecretKey: test.secretKey,
+			}, server.Client())
+
+			err := s.Put(context.TODO(), "covers/1/cover large.jpg", strings.NewReader("content"), "image/jpeg")
+			if test.wantError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "content", fake.objects["/covers/covers/1/cover large.jpg"])
+			assert.Equal(t, "image/jpeg", fake.types["/covers/covers/1/cover large.jpg"])
+		})
+	}
+}
+
+func TestS3Delete(t *testing.T) {
+	fake := newFakeS3()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	s := storage.NewS3(storage.S3Config{
+		Endpoint:  server.URL,
+		Region:    dummyRegion,
+		Bucket:    dummyBucket,
+		AccessKey: dummyAccessKey,
+		SecretKey: dummySecretKey,
+	}, server.Client())
+
+	assert.NoError(t, s.Put(context.TODO(), "a.jpg", strings.NewReader("content"), "image/jpeg"))
+	assert.NoError(t, s.Delete(context.TODO(), "a.jpg"))
+	assert.NotContains(t, fake.objects, "/covers/a.jpg")
+}
+
+func TestS3URL(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  storage.S3Config
+		want string
+	}{
+		{
+			name: "default public url",
+			cfg:  storage.S3Config{Endpoint: "http://localhost:9000/", Bucket: "covers"},
+			want: "http://localhost:9000/covers/1/cover%20large.jpg",
+		},
+		{
+			name: "custom public url",
+			cfg:  storage.S3Config{Endpoint: "http://localhost:9000", Bucket: "covers", PublicURL: "https://cdn.example.com/"},
+			want: "https://cdn.example.com/1/cover%20large.jpg",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, storage.NewS3(test.cfg, nil).URL("1/cover large.jpg"))
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"winartodev/book-store-be/entity"
+)
+
+func isAllocationStrategy(strategy string) bool {
+	switch strategy {
+	case entity.AllocationNearest, entity.AllocationMostStock, entity.AllocationSplit:
+		return true
+	}
+
+	return false
+}
+
+// stockKey is what a warehouse keeps stock of, a book or one of its editions
+type stockKey struct {
+	bookID int64
+	skuID  int64
+}
+
+// lineStockKeys returns the stock a line takes: its book and, for an edition, the edition too
+func lineStockKeys(line entity.OrderLine) []stockKey {
+	if line.SKUID == 0 {
+		return []stockKey{{bookID: line.BookID}}
+	}
+
+	return []stockKey{{bookID: line.BookID}, {skuID: line.SKUID}}
+}
+
+// allocateOrder decides which warehouses ship the lines of an order. The nearest and most_stock
+// strategies ship the whole order from a single warehouse, ranked by distance to the destination
+// or by how much of the order it has. When no warehouse has everything, or with the split
+// strategy, every line is taken from as many warehouses as needed in the same ranking. Lines of
+// the same book, bought alone and in a bundle, share its stock. A line of an edition is only
+// taken from warehouses that keep that edition, the stocks of editions are keyed by SKU id.
+func allocateOrder(strategy string, order *entity.Order, warehouses []entity.Warehouse, stocks map[int64][]entity.WarehouseStock, skuStocks map[int64][]entity.WarehouseStock) error {
+	available := make(map[int64]map[stockKey]int)
+	addStock := func(key stockKey, stock entity.WarehouseStock) {
+		if available[stock.WarehouseID] == nil {
+			available[stock.WarehouseID] = make(map[stockKey]int)
+		}
+		available[stock.WarehouseID][key] = stock.Available
+	}
+	for bookID, bookStocks := range stocks {
+		for _, stock := range bookStocks {
+			addStock(stockKey{bookID: bookID}, stock)
+		}
+	}
+	for skuID, editionStocks := range skuStocks {
+		for _, stock := range editionStocks {
+			addStock(stockKey{skuID: skuID}, stock)
+		}
+	}
+
+	ranked := rankWarehouses(strategy, order, warehouses, available)
+
+	if strategy != entity.AllocationSplit {
+		for _, warehouse := range ranked {
+			if canShipOrder(order, available[warehouse.ID]) {
+				for i := range order.Lines {
+					order.Lines[i].Allocations = []entity.Allocation{{WarehouseID: warehouse.ID, Quantity: order.Lines[i].Quantity}}
+				}
+				return nil
+			}
+		}
+	}
+
+	for i := range order.Lines {
+		line := &order.Lines[i]
+		line.Allocations = nil
+
+		keys := lineStockKeys(*line)
+		remaining := line.Quantity
+		for _, warehouse := range ranked {
+			quantity := remaining
+			for _, key := range keys {
+				if available[warehouse.ID][key] < quantity {
+					quantity = available[warehouse.ID][key]
+				}
+			}
+
+			if quantity <= 0 {
+				continue
+			}
+
+			line.Allocations = append(line.Allocations, entity.Allocation{WarehouseID: warehouse.ID, Quantity: quantity})
+			for _, key := range keys {
+				available[warehouse.ID][key] -= quantity
+			}
+			remaining -= quantity
+			if remaining == 0 {
+				break
+			}
+		}
+
+		if remaining > 0 && line.SKUID != 0 {
+			return fmt.Errorf("not enough stock of SKU ID %d of book ID %d in the warehouses, %d more copies are needed", line.SKUID, line.BookID, remaining)
+		}
+
+		if remaining > 0 {
+			return fmt.Errorf("not enough stock of book ID %d, %d more copies are needed", line.BookID, remaining)
+		}
+	}
+
+	return nil
+}
+
+// allocateShippedLines allocates the lines of an order that are shipped, lines of digital
+// editions are left without allocations
+func allocateShippedLines(strategy string, order *entity.Order, skus map[int64]entity.SKU, warehouses []entity.Warehouse, stocks map[int64][]entity.WarehouseStock, skuStocks map[int64][]entity.WarehouseStock) error {
+	shipped := *order
+	shipped.Lines = nil
+
+	var index []int
+	for i, line := range order.Lines {
+		if skus[line.SKUID].Digital() {
+			order.Lines[i].Allocations = nil
+			continue
+		}
+
+		index = append(index, i)
+		shipped.Lines = append(shipped.Lines, line)
+	}
+
+	if len(shipped.Lines) == 0 {
+		return nil
+	}
+
+	err := allocateOrder(strategy, &shipped, warehouses, stocks, skuStocks)
+	if err != nil {
+		return err
+	}
+
+	for i, line := range shipped.Lines {
+		order.Lines[index[i]].Allocations = line.Allocations
+	}
+
+	return nil
+}
+
+// shippedSKUIDs returns the editions of the lines that are shipped from a warehouse
+func shippedSKUIDs(lines []entity.OrderLine, skus map[int64]entity.SKU) []int64 {
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, line := range lines {
+		if line.SKUID == 0 || skus[line.SKUID].Digital() || seen[line.SKUID] {
+			continue
+		}
+
+		seen[line.SKUID] = true
+		ids = append(ids, line.SKUID)
+	}
+
+	return ids
+}
+
+// rankWarehouses orders the warehouses by preference. Without a destination the nearest
+// strategy cannot measure distances and ranks like most_stock.
+func rankWarehouses(strategy string, order *entity.Order, warehouses []entity.Warehouse, available map[int64]map[stockKey]int) []entity.Warehouse {
+	ranked := make([]entity.Warehouse, len(warehouses))
+	copy(ranked, warehouses)
+
+	hasDestination := order.ShippingLatitude != nil && order.ShippingLongitude != nil
+	if strategy != entity.AllocationMostStock && hasDestination {
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return distanceKM(*order.ShippingLatitude, *order.ShippingLongitude, ranked[i].Latitude, ranked[i].Longitude) <
+				distanceKM(*order.ShippingLatitude, *order.ShippingLongitude, ranked[j].Latitude, ranked[j].Longitude)
+		})
+		return ranked
+	}
+
+	needed := orderQuantities(order)
+	covered := func(warehouseID int64) int {
+		total := 0
+		for key, want := range needed {
+			quantity := available[warehouseID][key]
+			if quantity > want {
+				quantity = want
+			}
+			if quantity > 0 {
+				total += quantity
+			}
+		}
+		return total
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return covered(ranked[i].ID) > covered(ranked[j].ID)
+	})
+	return ranked
+}
+
+func canShipOrder(order *entity.Order, available map[stockKey]int) bool {
+	for key, quantity := range orderQuantities(order) {
+		if available[key] < quantity {
+			return false
+		}
+	}
+
+	return true
+}
+
+// orderQuantities totals the copies of every book in an order, and of every edition, copies of
+// an edition also count as copies of its book
+func orderQuantities(order *entity.Order) map[stockKey]int {
+	quantities := make(map[stockKey]int)
+	for _, line := range order.Lines {
+		for _, key := range lineStockKeys(line) {
+			quantities[key] += line.Quantity
+		}
+	}
+
+	return quantities
+}
+
+// distanceKM returns the great-circle distance between two coordinates
+func distanceKM(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadiusKM = 6371
+
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKM * math.Asin(math.Sqrt(a))
+}
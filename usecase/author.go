@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+type AuthorUsecase interface {
+	GetAuthors(ctx context.Context) ([]entity.Author, error)
+	GetAuthor(ctx context.Context, id int64) (entity.Author, error)
+	CreateAuthor(ctx context.Context, author *entity.Author) error
+	UpdateAuthor(ctx context.Context, id int64, author *entity.Author) error
+	DeleteAuthor(ctx context.Context, id int64) error
+}
+
+type AuthorRepository struct {
+	AuthorRepo repository.AuthorRepository
+}
+
+func NewAuthorUsecase(repo *AuthorRepository) AuthorUsecase {
+	return &AuthorRepository{
+		AuthorRepo: repo.AuthorRepo,
+	}
+}
+
+func (r *AuthorRepository) GetAuthors(ctx context.Context) ([]entity.Author, error) {
+	res, err := r.AuthorRepo.GetAuthors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *AuthorRepository) GetAuthor(ctx context.Context, id int64) (entity.Author, error) {
+	res, err := r.AuthorRepo.GetAuthor(ctx, id)
+	if err != nil {
+		return entity.Author{}, err
+	}
+
+	return res, nil
+}
+
+func (r *AuthorRepository) CreateAuthor(ctx context.Context, author *entity.Author) error {
+	author.Name = strings.Join(strings.Fields(author.Name), " ")
+	author.NormalizedName = normalizeAuthorName(author.Name)
+	if author.NormalizedName == "" {
+		return errors.New("author name cannot be empty")
+	}
+
+	existing, err := r.AuthorRepo.GetAuthorByNormalizedName(ctx, author.NormalizedName)
+	if err != nil {
+		return err
+	}
+
+	if existing.ID != 0 {
+		return errors.New("author already exists")
+	}
+
+	err = r.AuthorRepo.CreateAuthor(ctx, author)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *AuthorRepository) UpdateAuthor(ctx context.Context, id int64, author *entity.Author) error {
+	author.Name = strings.Join(strings.Fields(author.Name), " ")
+	author.NormalizedName = normalizeAuthorName(author.Name)
+	if author.NormalizedName == "" {
+		return errors.New("author name cannot be empty")
+	}
+
+	existing, err := r.AuthorRepo.GetAuthorByNormalizedName(ctx, author.NormalizedName)
+	if err != nil {
+		return err
+	}
+
+	if existing.ID != 0 && existing.ID != id {
+		return errors.New("author already exists")
+	}
+
+	err = r.AuthorRepo.UpdateAuthor(ctx, id, author)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *AuthorRepository) DeleteAuthor(ctx context.Context, id int64) error {
+	err := r.AuthorRepo.DeleteAuthor(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// normalizeAuthorName folds case and whitespace so spelling variants share one author
+func normalizeAuthorName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
@@ -0,0 +1,227 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockAuthorProvider struct {
+	authorRepo *mocks.AuthorRepository
+}
+
+func authorProvider() mockAuthorProvider {
+	return mockAuthorProvider{
+		authorRepo: new(mocks.AuthorRepository),
+	}
+}
+
+func newAuthorUsecase(repo *usecase.AuthorRepository) usecase.AuthorUsecase {
+	return usecase.NewAuthorUsecase(repo)
+}
+
+func TestGetAuthors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		authors []entity.Author
+		isError bool
+		wantErr error
+	}{
+		{
+			name:    "success",
+			authors: []entity.Author{{ID: 1, Name: "Terry Pratchett"}, {ID: 2, Name: "Neil Gaiman"}},
+			isError: false,
+			wantErr: nil,
+		},
+		{
+			name:    "failed",
+			authors: []entity.Author{},
+			isError: true,
+			wantErr: errors.New("Dummy Error"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := authorProvider()
+			prov.authorRepo.On("GetAuthors", mock.Anything).Return(test.authors, test.wantErr)
+
+			authorUsecase := newAuthorUsecase(&usecase.AuthorRepository{AuthorRepo: prov.authorRepo})
+			res, err := authorUsecase.GetAuthors(context.Background())
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.NotNil(t, res)
+			} else {
+				assert.Nil(t, res)
+			}
+		})
+	}
+}
+
+func TestGetAuthor(t *testing.T) {
+	testCases := []struct {
+		name    string
+		id      int64
+		author  entity.Author
+		isError bool
+		wantErr error
+	}{
+		{
+			name:    "success",
+			id:      1,
+			author:  entity.Author{ID: 1, Name: "Terry Pratchett"},
+			isError: false,
+			wantErr: nil,
+		},
+		{
+			name:    "failed",
+			id:      1,
+			author:  entity.Author{},
+			isError: true,
+			wantErr: errors.New("Dummy Error"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := authorProvider()
+			prov.authorRepo.On("GetAuthor", mock.Anything, test.id).Return(test.author, test.wantErr)
+
+			authorUsecase := newAuthorUsecase(&usecase.AuthorRepository{AuthorRepo: prov.authorRepo})
+			res, err := authorUsecase.GetAuthor(context.Background(), test.id)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.author, res)
+		})
+	}
+}
+
+func TestCreateAuthor(t *testing.T) {
+	testCases := []struct {
+		name      string
+		author    entity.Author
+		existing  entity.Author
+		createErr error
+		expName   string
+		isError   bool
+	}{
+		{
+			name:    "success",
+			author:  entity.Author{Name: "  Terry   Pratchett "},
+			expName: "Terry Pratchett",
+			isError: false,
+		},
+		{
+			name:     "failed with duplicate normalized name",
+			author:   entity.Author{Name: "TERRY PRATCHETT"},
+			existing: entity.Author{ID: 1, Name: "Terry Pratchett", NormalizedName: "terry pratchett"},
+			expName:  "TERRY PRATCHETT",
+			isError:  true,
+		},
+		{
+			name:    "failed with empty name",
+			author:  entity.Author{Name: "   "},
+			isError: true,
+		},
+		{
+			name:      "failed to create author",
+			author:    entity.Author{Name: "Terry Pratchett"},
+			createErr: errors.New("Dummy Error"),
+			expName:   "Terry Pratchett",
+			isError:   true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := authorProvider()
+			prov.authorRepo.On("GetAuthorByNormalizedName", mock.Anything, "terry pratchett").Return(test.existing, nil)
+			prov.authorRepo.On("CreateAuthor", mock.Anything, mock.Anything).Return(test.createErr)
+
+			authorUsecase := newAuthorUsecase(&usecase.AuthorRepository{AuthorRepo: prov.authorRepo})
+			err := authorUsecase.CreateAuthor(context.Background(), &test.author)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.expName, test.author.Name)
+		})
+	}
+}
+
+func TestUpdateAuthor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		id       int64
+		author   entity.Author
+		existing entity.Author
+		isError  bool
+	}{
+		{
+			name:     "success renaming the same author",
+			id:       1,
+			author:   entity.Author{Name: "Terry Pratchett"},
+			existing: entity.Author{ID: 1, NormalizedName: "terry pratchett"},
+			isError:  false,
+		},
+		{
+			name:     "failed with name taken by another author",
+			id:       2,
+			author:   entity.Author{Name: "Terry Pratchett"},
+			existing: entity.Author{ID: 1, NormalizedName: "terry pratchett"},
+			isError:  true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := authorProvider()
+			prov.authorRepo.On("GetAuthorByNormalizedName", mock.Anything, "terry pratchett").Return(test.existing, nil)
+			prov.authorRepo.On("UpdateAuthor", mock.Anything, test.id, mock.Anything).Return(nil)
+
+			authorUsecase := newAuthorUsecase(&usecase.AuthorRepository{AuthorRepo: prov.authorRepo})
+			err := authorUsecase.UpdateAuthor(context.Background(), test.id, &test.author)
+
+			assert.Equal(t, test.isError, err != nil)
+		})
+	}
+}
+
+func TestDeleteAuthor(t *testing.T) {
+	testCases := []struct {
+		name    string
+		id      int64
+		isError bool
+		wantErr error
+	}{
+		{
+			name:    "success",
+			id:      1,
+			isError: false,
+			wantErr: nil,
+		},
+		{
+			name:    "failed",
+			id:      1,
+			isError: true,
+			wantErr: errors.New("Dummy Error"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := authorProvider()
+			prov.authorRepo.On("DeleteAuthor", mock.Anything, test.id).Return(test.wantErr)
+
+			authorUsecase := newAuthorUsecase(&usecase.AuthorRepository{AuthorRepo: prov.authorRepo})
+			err := authorUsecase.DeleteAuthor(context.Background(), test.id)
+
+			assert.Equal(t, test.isError, err != nil)
+		})
+	}
+}
@@ -2,13 +2,19 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"winartodev/book-store-be/entity"
 	"winartodev/book-store-be/repository"
+	"winartodev/book-store-be/storage"
 )
 
 type BookUsecase interface {
-	GetBooks(ctx context.Context) ([]entity.Book, error)
+	GetBooks(ctx context.Context, filter entity.BookFilter) ([]entity.Book, error)
 	GetBook(ctx context.Context, id int64) (entity.Book, error)
 	CreateBook(ctx context.Context, book *entity.Book) error
 	UpdateBook(ctx context.Context, id int64, book *entity.Book) error
@@ -16,16 +22,47 @@ type BookUsecase interface {
 }
 
 type BookRepository struct {
-	BookRepo repository.BookRepository
+	BookRepo      repository.BookRepository
+	AuthorRepo    repository.AuthorRepository
+	CategoryRepo  repository.CategoryRepository
+	TagRepo       repository.TagRepository
+	SeriesRepo    repository.SeriesRepository
+	SKURepo       repository.SKURepository
+	CoverRepo     repository.CoverRepository
+	WarehouseRepo repository.WarehouseRepository
+	PriceRepo     repository.PriceRepository
+	TaxRepo       repository.TaxRepository
+	Storage       storage.Storage
 }
 
 func NewBookUsecase(repo *BookRepository) BookUsecase {
-	return &BookRepository{BookRepo: repo.BookRepo}
+	return &BookRepository{
+		BookRepo:      repo.BookRepo,
+		AuthorRepo:    repo.AuthorRepo,
+		CategoryRepo:  repo.CategoryRepo,
+		TagRepo:       repo.TagRepo,
+		SeriesRepo:    repo.SeriesRepo,
+		SKURepo:       repo.SKURepo,
+		CoverRepo:     repo.CoverRepo,
+		WarehouseRepo: repo.WarehouseRepo,
+		PriceRepo:     repo.PriceRepo,
+		TaxRepo:       repo.TaxRepo,
+		Storage:       repo.Storage,
+	}
 }
 
-func (repo *BookRepository) GetBooks(ctx context.Context) ([]entity.Book, error) {
-	res, err := repo.BookRepo.GetBooks(ctx)
+func (repo *BookRepository) GetBooks(ctx context.Context, filter entity.BookFilter) ([]entity.Book, error) {
+	filter.Tags = tagSlugs(filter.Tags)
+	if filter.Sort != "" && filter.Sort != entity.BookSortRating {
+		return nil, fmt.Errorf("books cannot be sorted by %q", filter.Sort)
+	}
+
+	res, err := repo.BookRepo.GetBooks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
 
+	err = repo.attachBookDetails(ctx, res)
 	if err != nil {
 		return nil, err
 	}
@@ -39,32 +76,332 @@ func (repo *BookRepository) GetBook(ctx context.Context, id int64) (entity.Book,
 		return entity.Book{}, err
 	}
 
-	return res, nil
+	if res.ID == 0 {
+		return res, nil
+	}
+
+	books := []entity.Book{res}
+	err = repo.attachBookDetails(ctx, books)
+	if err != nil {
+		return entity.Book{}, err
+	}
+
+	return books[0], nil
 }
 
+// CreateBook stores the book with no stock and records its initial stock as a receipt so the
+// ledger accounts for every copy
 func (repo *BookRepository) CreateBook(ctx context.Context, book *entity.Book) error {
-	err := repo.BookRepo.CreateBook(ctx, book)
+	err := prepareBook(book)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	if book.Stock < 0 {
+		return errors.New("stock cannot be negative")
+	}
+
+	err = checkTaxCategory(ctx, repo.TaxRepo, book.TaxCategoryID)
+	if err != nil {
+		return err
+	}
+
+	// the opening stock is recorded as a receipt so the stock ledger adds up from the start
+	var receipt *entity.StockMovement
+	if book.Stock > 0 {
+		receipt = &entity.StockMovement{Type: entity.MovementReceipt, Quantity: book.Stock, Reason: "initial stock", Actor: systemActor}
+	}
+	book.Stock = 0
+
+	return repo.BookRepo.CreateBook(ctx, book, normalizeTags(book.Tags), receipt)
 }
 
+// UpdateBook stores the book and sets its stock to book.Stock through an adjustment movement
 func (repo *BookRepository) UpdateBook(ctx context.Context, id int64, book *entity.Book) error {
-	err := repo.BookRepo.UpdateBook(ctx, id, book)
+	err := prepareBook(book)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	if book.Stock < 0 {
+		return errors.New("stock cannot be negative")
+	}
+
+	err = checkTaxCategory(ctx, repo.TaxRepo, book.TaxCategoryID)
+	if err != nil {
+		return err
+	}
+
+	current, err := repo.PriceRepo.GetPriceAt(ctx, id, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// a changed price takes effect right away and is kept in the price history
+	var price *entity.BookPrice
+	if current.ID == 0 || current.Price != book.Price {
+		price = &entity.BookPrice{Price: book.Price}
+	}
+
+	// a changed stock is recorded as an adjustment, so a book coming back in stock is noticed
+	// like any other movement
+	adjustment := &entity.StockMovement{Type: entity.MovementAdjustment, Reason: "book update", Actor: systemActor}
+
+	return repo.BookRepo.UpdateBook(ctx, id, book, normalizeTags(book.Tags), price, adjustment)
 }
 
+// DeleteBook only removes books without stock movements, the stock ledger is kept for good
 func (repo *BookRepository) DeleteBook(ctx context.Context, id int64) error {
-	err := repo.BookRepo.DeleteBook(ctx, id)
+	count, err := repo.BookRepo.GetBookMovementCount(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return fmt.Errorf("book ID %d has %d stock movements and cannot be deleted", id, count)
+	}
+
+	covers, err := repo.CoverRepo.GetBookCovers(ctx, []int64{id})
+	if err != nil {
+		return err
+	}
+
+	err = repo.BookRepo.DeleteBook(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// the cover rows go with the book, the stored files are removed on a best effort basis
+	for _, cover := range covers[id] {
+		repo.Storage.Delete(ctx, cover.Key)
+	}
+
+	return nil
+}
+
+// attachBookDetails loads the contributors, categories, tags, series, editions, covers and warehouse stock of books in one query each
+func (repo *BookRepository) attachBookDetails(ctx context.Context, books []entity.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(books))
+	for i, book := range books {
+		ids[i] = book.ID
+	}
+
+	authors, err := repo.AuthorRepo.GetBookAuthors(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	categories, err := repo.CategoryRepo.GetBookCategories(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	tags, err := repo.TagRepo.GetBookTags(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	series, err := repo.SeriesRepo.GetBookSeries(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	skus, err := repo.SKURepo.GetSKUs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	covers, err := repo.CoverRepo.GetBookCovers(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	warehouses, err := repo.WarehouseRepo.GetWarehouseStocks(ctx, ids)
 	if err != nil {
 		return err
 	}
 
+	for i := range books {
+		books[i].Available = books[i].Stock - books[i].Reserved
+		books[i].Authors = authors[books[i].ID]
+		books[i].Categories = categories[books[i].ID]
+		books[i].Tags = tags[books[i].ID]
+		books[i].Series = series[books[i].ID]
+		books[i].SKUs = skus[books[i].ID]
+		books[i].Covers = covers[books[i].ID]
+		books[i].Warehouses = warehouses[books[i].ID]
+		for j := range books[i].Covers {
+			books[i].Covers[j].URL = repo.Storage.URL(books[i].Covers[j].Key)
+		}
+	}
+
+	return nil
+}
+
+// prepareBook validates the book relations and fills in their defaults. A release date only
+// keeps its day and sets the year of publication.
+func prepareBook(book *entity.Book) error {
+	if book.WeightGrams < 0 {
+		return errors.New("weight_grams cannot be negative")
+	}
+
+	book.PreOrder = false
+	if book.ReleaseDate != nil {
+		year, month, day := book.ReleaseDate.Date()
+		releaseDate := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		book.ReleaseDate = &releaseDate
+		book.Publication = year
+		book.PreOrder = releaseDate.After(time.Now())
+	}
+
+	book.Description = strings.TrimSpace(book.Description)
+	err := normalizeBookLanguages(book)
+	if err != nil {
+		return err
+	}
+
+	err = validateBookAuthors(book.Authors)
+	if err != nil {
+		return err
+	}
+
+	err = validateBookCategories(book)
+	if err != nil {
+		return err
+	}
+
+	err = validateBookSeries(book.Series)
+	if err != nil {
+		return err
+	}
+
+	tags := normalizeTags(book.Tags)
+	book.Tags = make([]string, len(tags))
+	for i, tag := range tags {
+		book.Tags[i] = tag.Name
+	}
+
+	return nil
+}
+
+// validateBookCategories makes sure a book has exactly one primary category and keeps
+// CategoryID pointing at it
+func validateBookCategories(book *entity.Book) error {
+	if len(book.Categories) == 0 {
+		if book.CategoryID != 0 {
+			book.Categories = []entity.BookCategory{{CategoryID: book.CategoryID, Primary: true}}
+		}
+		return nil
+	}
+
+	primary := -1
+	seen := make(map[int64]bool)
+	for i, category := range book.Categories {
+		if seen[category.CategoryID] {
+			return fmt.Errorf("category ID %d is listed more than once", category.CategoryID)
+		}
+		seen[category.CategoryID] = true
+
+		if !category.Primary {
+			continue
+		}
+
+		if primary != -1 {
+			return errors.New("book can only have one primary category")
+		}
+		primary = i
+	}
+
+	if primary == -1 {
+		primary = 0
+		for i, category := range book.Categories {
+			if category.CategoryID == book.CategoryID {
+				primary = i
+			}
+		}
+		book.Categories[primary].Primary = true
+	}
+
+	book.CategoryID = book.Categories[primary].CategoryID
+	return nil
+}
+
+func tagSlugs(names []string) []string {
+	var slugs []string
+	for _, tag := range normalizeTags(names) {
+		slugs = append(slugs, tag.Slug)
+	}
+
+	return slugs
+}
+
+// normalizeBookLanguages writes the languages of a book as locales, like pt-BR
+func normalizeBookLanguages(book *entity.Book) error {
+	for _, language := range []*string{&book.Language, &book.OriginalLanguage} {
+		if *language == "" {
+			continue
+		}
+
+		locale := entity.NormalizeLocale(*language)
+		if locale == "" {
+			return fmt.Errorf("%q is not a language", *language)
+		}
+		*language = locale
+	}
+
+	return nil
+}
+
+// validateBookSeries checks a book is in every series once, at a position in its reading order
+func validateBookSeries(series []entity.BookSeries) error {
+	seen := make(map[int64]bool)
+	for _, s := range series {
+		if s.SeriesID == 0 {
+			return errors.New("book series has no series_id")
+		}
+
+		if seen[s.SeriesID] {
+			return fmt.Errorf("series ID %d is listed more than once", s.SeriesID)
+		}
+		seen[s.SeriesID] = true
+
+		if s.Position < 0 {
+			return fmt.Errorf("position in series ID %d cannot be negative", s.SeriesID)
+		}
+
+		// positions are stored with at most 3 decimals below 100000
+		scaled := s.Position * 1000
+		if s.Position >= 100000 || math.Abs(scaled-math.Round(scaled)) > 1e-6 {
+			return fmt.Errorf("position in series ID %d must be below 100000 with at most 3 decimals", s.SeriesID)
+		}
+	}
+
+	return nil
+}
+
+// validateBookAuthors checks contributor roles and fills in defaults for role and position
+func validateBookAuthors(authors []entity.BookAuthor) error {
+	for i := range authors {
+		if authors[i].AuthorID == 0 {
+			return fmt.Errorf("author at position %d has no author_id", i+1)
+		}
+
+		switch authors[i].Role {
+		case "":
+			authors[i].Role = entity.AuthorRoleAuthor
+		case entity.AuthorRoleAuthor, entity.AuthorRoleEditor, entity.AuthorRoleTranslator, entity.AuthorRoleIllustrator:
+		default:
+			return fmt.Errorf("invalid author role %q", authors[i].Role)
+		}
+
+		if authors[i].Position == 0 {
+			authors[i].Position = i + 1
+		}
+	}
+
 	return nil
 }
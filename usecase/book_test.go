@@ -14,15 +14,64 @@ import (
 )
 
 type mockBookProvider struct {
-	BookRepo *mocks.BookRepository
+	BookRepo      *mocks.BookRepository
+	AuthorRepo    *mocks.AuthorRepository
+	CategoryRepo  *mocks.CategoryRepository
+	TagRepo       *mocks.TagRepository
+	SeriesRepo    *mocks.SeriesRepository
+	SKURepo       *mocks.SKURepository
+	CoverRepo     *mocks.CoverRepository
+	WarehouseRepo *mocks.WarehouseRepository
+	PriceRepo     *mocks.PriceRepository
+	Storage       *mocks.Storage
 }
 
 func bookProvider() mockBookProvider {
 	return mockBookProvider{
-		BookRepo: new(mocks.BookRepository),
+		BookRepo:      new(mocks.BookRepository),
+		AuthorRepo:    new(mocks.AuthorRepository),
+		CategoryRepo:  new(mocks.CategoryRepository),
+		TagRepo:       new(mocks.TagRepository),
+		SeriesRepo:    new(mocks.SeriesRepository),
+		SKURepo:       new(mocks.SKURepository),
+		CoverRepo:     new(mocks.CoverRepository),
+		WarehouseRepo: new(mocks.WarehouseRepository),
+		PriceRepo:     new(mocks.PriceRepository),
+		Storage:       new(mocks.Storage),
 	}
 }
 
+func (prov mockBookProvider) repository() *usecase.BookRepository {
+	return &usecase.BookRepository{
+		BookRepo:      prov.BookRepo,
+		AuthorRepo:    prov.AuthorRepo,
+		CategoryRepo:  prov.CategoryRepo,
+		TagRepo:       prov.TagRepo,
+		SeriesRepo:    prov.SeriesRepo,
+		SKURepo:       prov.SKURepo,
+		CoverRepo:     prov.CoverRepo,
+		WarehouseRepo: prov.WarehouseRepo,
+		PriceRepo:     prov.PriceRepo,
+		Storage:       prov.Storage,
+	}
+}
+
+// expectGetBookDetails stubs the lookups used to fill the relations of a loaded book
+func (prov mockBookProvider) expectGetBookDetails() {
+	prov.AuthorRepo.On("GetBookAuthors", mock.Anything, mock.Anything).Return(map[int64][]entity.BookAuthor{}, nil)
+	prov.CategoryRepo.On("GetBookCategories", mock.Anything, mock.Anything).Return(map[int64][]entity.BookCategory{}, nil)
+	prov.TagRepo.On("GetBookTags", mock.Anything, mock.Anything).Return(map[int64][]string{}, nil)
+	prov.SeriesRepo.On("GetBookSeries", mock.Anything, mock.Anything).Return(map[int64][]entity.BookSeries{}, nil)
+	prov.SKURepo.On("GetSKUs", mock.Anything, mock.Anything).Return(map[int64][]entity.SKU{}, nil)
+	prov.CoverRepo.On("GetBookCovers", mock.Anything, mock.Anything).Return(map[int64][]entity.CoverImage{}, nil)
+	prov.WarehouseRepo.On("GetWarehouseStocks", mock.Anything, mock.Anything).Return(map[int64][]entity.WarehouseStock{}, nil)
+}
+
+// expectSaveBookDetails stubs the lookups used before a book is saved
+func (prov mockBookProvider) expectSaveBookDetails() {
+	prov.PriceRepo.On("GetPriceAt", mock.Anything, mock.Anything, mock.Anything).Return(entity.BookPrice{}, nil)
+}
+
 func newBookUseCaseMock(repo *usecase.BookRepository) usecase.BookUsecase {
 	return usecase.NewBookUsecase(repo)
 }
@@ -37,14 +86,14 @@ func TestGetBooks(t *testing.T) {
 	}{
 		{
 			name:    "success",
-			books:   []entity.Book{{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Author: "Book Author", Publication: 2021, Stock: 4}},
-			expRes:  []entity.Book{{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Author: "Book Author", Publication: 2021, Stock: 4}},
+			books:   []entity.Book{{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Authors: []entity.BookAuthor{{AuthorID: 1, Name: "Book Author", Role: entity.AuthorRoleAuthor, Position: 1}}, Publication: 2021, Stock: 4}},
+			expRes:  []entity.Book{{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Authors: []entity.BookAuthor{{AuthorID: 1, Name: "Book Author", Role: entity.AuthorRoleAuthor, Position: 1}}, Publication: 2021, Stock: 4}},
 			isError: false,
 			wantErr: nil,
 		},
 		{
 			name:    "failed",
-			books:   []entity.Book{{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Author: "Book Author", Publication: 2021, Stock: 4}},
+			books:   []entity.Book{{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Authors: []entity.BookAuthor{{AuthorID: 1, Name: "Book Author", Role: entity.AuthorRoleAuthor, Position: 1}}, Publication: 2021, Stock: 4}},
 			expRes:  nil,
 			isError: true,
 			wantErr: errors.New("Dummy Error"),
@@ -54,12 +103,13 @@ func TestGetBooks(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			prov := bookProvider()
-			prov.BookRepo.On("GetBooks", mock.Anything).Return(test.books, test.wantErr)
+			prov.BookRepo.On("GetBooks", mock.Anything, mock.Anything).Return(test.books, test.wantErr)
+			prov.expectGetBookDetails()
 
-			bookUsecase := newBookUseCaseMock(&usecase.BookRepository{prov.BookRepo})
+			bookUsecase := newBookUseCaseMock(prov.repository())
 
 			ctx := context.Background()
-			res, err := bookUsecase.GetBooks(ctx)
+			res, err := bookUsecase.GetBooks(ctx, entity.BookFilter{})
 
 			assert.Equal(t, test.isError, err != nil)
 			if !test.isError {
@@ -85,8 +135,8 @@ func TestGetBook(t *testing.T) {
 		{
 			name:    "success",
 			ID:      1,
-			book:    entity.Book{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Author: "Book Author", Publication: 2021, Stock: 4},
-			expBook: entity.Book{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Author: "Book Author", Publication: 2021, Stock: 4},
+			book:    entity.Book{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Authors: []entity.BookAuthor{{AuthorID: 1, Name: "Book Author", Role: entity.AuthorRoleAuthor, Position: 1}}, Publication: 2021, Stock: 4},
+			expBook: entity.Book{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Authors: []entity.BookAuthor{{AuthorID: 1, Name: "Book Author", Role: entity.AuthorRoleAuthor, Position: 1}}, Publication: 2021, Stock: 4},
 			isError: false,
 			wantErr: nil,
 		},
@@ -104,8 +154,9 @@ func TestGetBook(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			prov := bookProvider()
 			prov.BookRepo.On("GetBook", mock.Anything, mock.AnythingOfType("int64")).Return(test.book, test.wantErr)
+			prov.expectGetBookDetails()
 
-			bookUsecase := newBookUseCaseMock(&usecase.BookRepository{prov.BookRepo})
+			bookUsecase := newBookUseCaseMock(prov.repository())
 			ctx := context.Background()
 			res, err := bookUsecase.GetBook(ctx, test.ID)
 
@@ -129,7 +180,7 @@ func TestCreateBook(t *testing.T) {
 	}{
 		{
 			name:    "success",
-			book:    entity.Book{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Author: "Book Author", Publication: 2021, Stock: 4},
+			book:    entity.Book{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Authors: []entity.BookAuthor{{AuthorID: 1, Name: "Book Author", Role: entity.AuthorRoleAuthor, Position: 1}}, Publication: 2021, Stock: 4},
 			isError: false,
 			wantErr: nil,
 		},
@@ -144,9 +195,10 @@ func TestCreateBook(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			prov := bookProvider()
-			prov.BookRepo.On("CreateBook", mock.Anything, mock.Anything).Return(test.wantErr)
+			prov.BookRepo.On("CreateBook", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(test.wantErr)
+			prov.expectSaveBookDetails()
 
-			bookUsecase := newBookUseCaseMock(&usecase.BookRepository{prov.BookRepo})
+			bookUsecase := newBookUseCaseMock(prov.repository())
 			ctx := context.Background()
 			err := bookUsecase.CreateBook(ctx, &test.book)
 
@@ -166,7 +218,7 @@ func TestUpdateBook(t *testing.T) {
 		{
 			name:    "success",
 			ID:      1,
-			book:    entity.Book{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Author: "Book Author", Publication: 2021, Stock: 4},
+			book:    entity.Book{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Authors: []entity.BookAuthor{{AuthorID: 1, Name: "Book Author", Role: entity.AuthorRoleAuthor, Position: 1}}, Publication: 2021, Stock: 4},
 			isError: false,
 			wantErr: nil,
 		},
@@ -182,9 +234,10 @@ func TestUpdateBook(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			prov := bookProvider()
-			prov.BookRepo.On("UpdateBook", mock.Anything, mock.AnythingOfType("int64"), mock.Anything).Return(test.wantErr)
+			prov.BookRepo.On("UpdateBook", mock.Anything, mock.AnythingOfType("int64"), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(test.wantErr)
+			prov.expectSaveBookDetails()
 
-			bookUsecase := newBookUseCaseMock(&usecase.BookRepository{prov.BookRepo})
+			bookUsecase := newBookUseCaseMock(prov.repository())
 			ctx := context.Background()
 			err := bookUsecase.UpdateBook(ctx, test.ID, &test.book)
 
@@ -204,7 +257,7 @@ func TestDeleteBook(t *testing.T) {
 		{
 			name:    "success",
 			ID:      1,
-			book:    entity.Book{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Author: "Book Author", Publication: 2021, Stock: 4},
+			book:    entity.Book{ID: 1, PublisherID: 1, CategoryID: 1, Title: "Book Title", Authors: []entity.BookAuthor{{AuthorID: 1, Name: "Book Author", Role: entity.AuthorRoleAuthor, Position: 1}}, Publication: 2021, Stock: 4},
 			isError: false,
 			wantErr: nil,
 		},
@@ -220,9 +273,11 @@ func TestDeleteBook(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			prov := bookProvider()
+			prov.BookRepo.On("GetBookMovementCount", mock.Anything, test.ID).Return(0, nil)
+			prov.CoverRepo.On("GetBookCovers", mock.Anything, []int64{test.ID}).Return(map[int64][]entity.CoverImage{}, nil)
 			prov.BookRepo.On("DeleteBook", mock.Anything, mock.AnythingOfType("int64")).Return(test.wantErr)
 
-			bookUsecase := newBookUseCaseMock(&usecase.BookRepository{prov.BookRepo})
+			bookUsecase := newBookUseCaseMock(prov.repository())
 			ctx := context.Background()
 			err := bookUsecase.DeleteBook(ctx, test.ID)
 
@@ -230,3 +285,334 @@ func TestDeleteBook(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteBookWithStockMovements(t *testing.T) {
+	prov := bookProvider()
+	prov.BookRepo.On("GetBookMovementCount", mock.Anything, int64(1)).Return(2, nil)
+
+	bookUsecase := newBookUseCaseMock(prov.repository())
+	err := bookUsecase.DeleteBook(context.Background(), 1)
+
+	assert.Error(t, err)
+	prov.BookRepo.AssertNotCalled(t, "DeleteBook", mock.Anything, mock.Anything)
+}
+
+func TestCreateBookInvalidAuthorRole(t *testing.T) {
+	prov := bookProvider()
+	bookUsecase := newBookUseCaseMock(prov.repository())
+
+	book := entity.Book{Title: "Book Title", Authors: []entity.BookAuthor{{AuthorID: 1, Role: "ghostwriter"}}}
+	err := bookUsecase.CreateBook(context.Background(), &book)
+
+	assert.Error(t, err)
+	prov.BookRepo.AssertNotCalled(t, "CreateBook", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateBookSeries(t *testing.T) {
+	testCases := []struct {
+		name    string
+		series  []entity.BookSeries
+		isError bool
+	}{
+		{name: "fractional position", series: []entity.BookSeries{{SeriesID: 5, Position: 2.5}, {SeriesID: 6, Position: 1}}, isError: false},
+		{name: "no series", series: []entity.BookSeries{{Position: 1}}, isError: true},
+		{name: "same series twice", series: []entity.BookSeries{{SeriesID: 5, Position: 1}, {SeriesID: 5, Position: 2}}, isError: true},
+		{name: "negative position", series: []entity.BookSeries{{SeriesID: 5, Position: -1}}, isError: true},
+		{name: "three decimals", series: []entity.BookSeries{{SeriesID: 5, Position: 2.125}}, isError: false},
+		{name: "more than three decimals", series: []entity.BookSeries{{SeriesID: 5, Position: 2.1255}}, isError: true},
+		{name: "position too large", series: []entity.BookSeries{{SeriesID: 5, Position: 100000}}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := bookProvider()
+			prov.BookRepo.On("CreateBook", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			prov.expectSaveBookDetails()
+			bookUsecase := newBookUseCaseMock(prov.repository())
+
+			book := entity.Book{Title: "Small Gods", Series: test.series}
+			err := bookUsecase.CreateBook(context.Background(), &book)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				prov.BookRepo.AssertNotCalled(t, "CreateBook", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				prov.BookRepo.AssertCalled(t, "CreateBook", mock.Anything, mock.MatchedBy(func(book *entity.Book) bool {
+					return assert.ObjectsAreEqual(test.series, book.Series)
+				}), mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestCreateBookLanguages(t *testing.T) {
+	testCases := []struct {
+		name         string
+		book         entity.Book
+		wantLanguage string
+		wantOriginal string
+		isError      bool
+	}{
+		{name: "translated book", book: entity.Book{Title: "Cantik Itu Luka", Language: "EN", OriginalLanguage: "id"}, wantLanguage: "en", wantOriginal: "id", isError: false},
+		{name: "regional language", book: entity.Book{Title: "A Cor da Magia", Language: "pt_br"}, wantLanguage: "pt-BR", isError: false},
+		{name: "not a language", book: entity.Book{Title: "Book Title", Language: "english!"}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := bookProvider()
+			prov.BookRepo.On("CreateBook", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			prov.expectSaveBookDetails()
+			bookUsecase := newBookUseCaseMock(prov.repository())
+
+			err := bookUsecase.CreateBook(context.Background(), &test.book)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, test.wantLanguage, test.book.Language)
+				assert.Equal(t, test.wantOriginal, test.book.OriginalLanguage)
+			}
+		})
+	}
+}
+
+func TestCreateBookDefaultAuthorRole(t *testing.T) {
+	prov := bookProvider()
+	prov.BookRepo.On("CreateBook", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	prov.expectSaveBookDetails()
+	bookUsecase := newBookUseCaseMock(prov.repository())
+
+	book := entity.Book{Title: "Book Title", Authors: []entity.BookAuthor{{AuthorID: 1}, {AuthorID: 2, Role: entity.AuthorRoleTranslator}}}
+	err := bookUsecase.CreateBook(context.Background(), &book)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.AuthorRoleAuthor, book.Authors[0].Role)
+	assert.Equal(t, 1, book.Authors[0].Position)
+	assert.Equal(t, 2, book.Authors[1].Position)
+}
+
+func TestCreateBookCategories(t *testing.T) {
+	testCases := []struct {
+		name       string
+		book       entity.Book
+		expPrimary int64
+		expCount   int
+		isError    bool
+	}{
+		{
+			name:       "legacy category id becomes the primary category",
+			book:       entity.Book{Title: "Book Title", CategoryID: 4},
+			expPrimary: 4,
+			expCount:   1,
+			isError:    false,
+		},
+		{
+			name:       "flagged primary category wins",
+			book:       entity.Book{Title: "Book Title", CategoryID: 4, Categories: []entity.BookCategory{{CategoryID: 4}, {CategoryID: 7, Primary: true}}},
+			expPrimary: 7,
+			expCount:   2,
+			isError:    false,
+		},
+		{
+			name:       "first category is primary when none is flagged",
+			book:       entity.Book{Title: "Book Title", Categories: []entity.BookCategory{{CategoryID: 5}, {CategoryID: 7}}},
+			expPrimary: 5,
+			expCount:   2,
+			isError:    false,
+		},
+		{
+			name:    "failed with two primary categories",
+			book:    entity.Book{Title: "Book Title", Categories: []entity.BookCategory{{CategoryID: 5, Primary: true}, {CategoryID: 7, Primary: true}}},
+			isError: true,
+		},
+		{
+			name:    "failed with duplicate category",
+			book:    entity.Book{Title: "Book Title", Categories: []entity.BookCategory{{CategoryID: 5}, {CategoryID: 5}}},
+			isError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := bookProvider()
+			prov.BookRepo.On("CreateBook", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			prov.expectSaveBookDetails()
+
+			bookUsecase := newBookUseCaseMock(prov.repository())
+			err := bookUsecase.CreateBook(context.Background(), &test.book)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, test.expPrimary, test.book.CategoryID)
+				assert.Len(t, test.book.Categories, test.expCount)
+			}
+		})
+	}
+}
+
+func TestCreateBookTags(t *testing.T) {
+	prov := bookProvider()
+	prov.BookRepo.On("CreateBook", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	prov.expectSaveBookDetails()
+
+	bookUsecase := newBookUseCaseMock(prov.repository())
+	book := entity.Book{Title: "Book Title", Tags: []string{" Travel  Guide", "travel guide", "Cookbook", "  "}}
+	err := bookUsecase.CreateBook(context.Background(), &book)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Travel Guide", "Cookbook"}, book.Tags)
+	prov.BookRepo.AssertCalled(t, "CreateBook", mock.Anything, mock.Anything, []entity.Tag{{Name: "Travel Guide", Slug: "travel-guide"}, {Name: "Cookbook", Slug: "cookbook"}}, mock.Anything)
+}
+
+func TestGetBooksAttachesDetails(t *testing.T) {
+	prov := bookProvider()
+	prov.BookRepo.On("GetBooks", mock.Anything, entity.BookFilter{Tags: []string{"travel-guide"}}).Return([]entity.Book{{ID: 1}, {ID: 2}}, nil)
+	prov.AuthorRepo.On("GetBookAuthors", mock.Anything, []int64{1, 2}).Return(map[int64][]entity.BookAuthor{1: {{AuthorID: 1, Name: "Rick Steves"}}}, nil)
+	prov.CategoryRepo.On("GetBookCategories", mock.Anything, []int64{1, 2}).Return(map[int64][]entity.BookCategory{2: {{CategoryID: 3, Primary: true}}}, nil)
+	prov.TagRepo.On("GetBookTags", mock.Anything, []int64{1, 2}).Return(map[int64][]string{1: {"Travel Guide"}}, nil)
+	prov.SeriesRepo.On("GetBookSeries", mock.Anything, []int64{1, 2}).Return(map[int64][]entity.BookSeries{2: {{SeriesID: 5, Name: "Discworld", Position: 2.5}}}, nil)
+	prov.SKURepo.On("GetSKUs", mock.Anything, []int64{1, 2}).Return(map[int64][]entity.SKU{1: {{ID: 4, BookID: 1, Format: entity.FormatEbook}}}, nil)
+	prov.CoverRepo.On("GetBookCovers", mock.Anything, []int64{1, 2}).Return(map[int64][]entity.CoverImage{2: {{Rendition: entity.RenditionThumbnail, Key: "covers/2/a/thumbnail.jpg"}}}, nil)
+	prov.WarehouseRepo.On("GetWarehouseStocks", mock.Anything, []int64{1, 2}).Return(map[int64][]entity.WarehouseStock{1: {{WarehouseID: 1, WarehouseCode: "MAIN", Stock: 3, Available: 3}}}, nil)
+	prov.Storage.On("URL", "covers/2/a/thumbnail.jpg").Return("http://localhost:8080/covers/covers/2/a/thumbnail.jpg")
+
+	bookUsecase := newBookUseCaseMock(prov.repository())
+	res, err := bookUsecase.GetBooks(context.Background(), entity.BookFilter{Tags: []string{"Travel Guide"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Rick Steves", res[0].Authors[0].Name)
+	assert.Equal(t, []string{"Travel Guide"}, res[0].Tags)
+	assert.Equal(t, int64(3), res[1].Categories[0].CategoryID)
+	assert.Equal(t, 2.5, res[1].Series[0].Position)
+	assert.Equal(t, entity.FormatEbook, res[0].SKUs[0].Format)
+	assert.Equal(t, "http://localhost:8080/covers/covers/2/a/thumbnail.jpg", res[1].Covers[0].URL)
+	assert.Equal(t, "MAIN", res[0].Warehouses[0].WarehouseCode)
+	assert.Empty(t, res[1].Warehouses)
+}
+
+func TestDeleteBookRemovesCoverFiles(t *testing.T) {
+	prov := bookProvider()
+	prov.BookRepo.On("GetBookMovementCount", mock.Anything, int64(1)).Return(0, nil)
+	prov.CoverRepo.On("GetBookCovers", mock.Anything, []int64{1}).Return(map[int64][]entity.CoverImage{1: {{Key: "covers/1/a/original.png"}, {Key: "covers/1/a/thumbnail.jpg"}}}, nil)
+	prov.BookRepo.On("DeleteBook", mock.Anything, int64(1)).Return(nil)
+	prov.Storage.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	bookUsecase := newBookUseCaseMock(prov.repository())
+	err := bookUsecase.DeleteBook(context.Background(), 1)
+
+	assert.NoError(t, err)
+	prov.Storage.AssertCalled(t, "Delete", mock.Anything, "covers/1/a/original.png")
+	prov.Storage.AssertCalled(t, "Delete", mock.Anything, "covers/1/a/thumbnail.jpg")
+}
+
+func TestCreateBookRecordsInitialStock(t *testing.T) {
+	testCases := []struct {
+		name         string
+		stock        int
+		wantMovement bool
+		isError      bool
+	}{
+		{name: "initial stock is recorded as receipt", stock: 4, wantMovement: true},
+		{name: "no stock records nothing", stock: 0, wantMovement: false},
+		{name: "negative stock", stock: -1, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := bookProvider()
+			prov.BookRepo.On("CreateBook", mock.Anything, mock.MatchedBy(func(book *entity.Book) bool { return book.Stock == 0 }), mock.Anything, mock.Anything).Return(nil)
+			prov.expectSaveBookDetails()
+
+			book := entity.Book{ID: 1, Title: "Book Title", Stock: test.stock}
+			bookUsecase := newBookUseCaseMock(prov.repository())
+			err := bookUsecase.CreateBook(context.Background(), &book)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.wantMovement {
+				prov.BookRepo.AssertCalled(t, "CreateBook", mock.Anything, mock.Anything, mock.Anything, &entity.StockMovement{Type: entity.MovementReceipt, Quantity: test.stock, Reason: "initial stock", Actor: "system"})
+			} else if !test.isError {
+				prov.BookRepo.AssertCalled(t, "CreateBook", mock.Anything, mock.Anything, mock.Anything, (*entity.StockMovement)(nil))
+			}
+		})
+	}
+}
+
+func TestGetBookAvailability(t *testing.T) {
+	prov := bookProvider()
+	prov.BookRepo.On("GetBook", mock.Anything, int64(1)).Return(entity.Book{ID: 1, Stock: 5, Reserved: 2}, nil)
+	prov.expectGetBookDetails()
+
+	bookUsecase := newBookUseCaseMock(prov.repository())
+	res, err := bookUsecase.GetBook(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res.Reserved)
+	assert.Equal(t, 3, res.Available)
+}
+
+func TestUpdateBookPriceHistory(t *testing.T) {
+	testCases := []struct {
+		name      string
+		current   entity.BookPrice
+		price     int
+		wantEntry bool
+	}{
+		{name: "unchanged price", current: entity.BookPrice{ID: 3, BookID: 1, Price: 50000}, price: 50000, wantEntry: false},
+		{name: "changed price", current: entity.BookPrice{ID: 3, BookID: 1, Price: 50000}, price: 45000, wantEntry: true},
+		{name: "book without price history", current: entity.BookPrice{}, price: 50000, wantEntry: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := bookProvider()
+			prov.BookRepo.On("UpdateBook", mock.Anything, int64(1), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			prov.PriceRepo.On("GetPriceAt", mock.Anything, int64(1), mock.Anything).Return(test.current, nil)
+
+			bookUsecase := newBookUseCaseMock(prov.repository())
+			book := entity.Book{Title: "Book Title", CategoryID: 1, Price: test.price}
+			err := bookUsecase.UpdateBook(context.Background(), 1, &book)
+
+			assert.NoError(t, err)
+			if test.wantEntry {
+				prov.BookRepo.AssertCalled(t, "UpdateBook", mock.Anything, int64(1), mock.Anything, mock.Anything, &entity.BookPrice{Price: test.price}, mock.Anything)
+			} else {
+				prov.BookRepo.AssertCalled(t, "UpdateBook", mock.Anything, int64(1), mock.Anything, mock.Anything, (*entity.BookPrice)(nil), mock.Anything)
+			}
+		})
+	}
+}
+
+func TestUpdateBookAdjustsStock(t *testing.T) {
+	prov := bookProvider()
+	prov.BookRepo.On("UpdateBook", mock.Anything, int64(1), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	prov.PriceRepo.On("GetPriceAt", mock.Anything, int64(1), mock.Anything).Return(entity.BookPrice{ID: 3, BookID: 1, Price: 50000}, nil)
+
+	bookUsecase := newBookUseCaseMock(prov.repository())
+	book := entity.Book{Title: "Book Title", CategoryID: 1, Price: 50000, Stock: 5}
+	err := bookUsecase.UpdateBook(context.Background(), 1, &book)
+
+	assert.NoError(t, err)
+	adjustment := &entity.StockMovement{Type: entity.MovementAdjustment, Reason: "book update", Actor: "system"}
+	prov.BookRepo.AssertCalled(t, "UpdateBook", mock.Anything, int64(1), &book, mock.Anything, (*entity.BookPrice)(nil), adjustment)
+}
+
+func TestUpdateBookNegativeStock(t *testing.T) {
+	prov := bookProvider()
+
+	bookUsecase := newBookUseCaseMock(prov.repository())
+	book := entity.Book{Title: "Book Title", CategoryID: 1, Price: 50000, Stock: -1}
+	err := bookUsecase.UpdateBook(context.Background(), 1, &book)
+
+	assert.Error(t, err)
+	prov.BookRepo.AssertNotCalled(t, "UpdateBook", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetBooksUnknownSort(t *testing.T) {
+	prov := bookProvider()
+
+	bookUsecase := newBookUseCaseMock(prov.repository())
+	_, err := bookUsecase.GetBooks(context.Background(), entity.BookFilter{Sort: "price"})
+
+	assert.Error(t, err)
+	prov.BookRepo.AssertNotCalled(t, "GetBooks", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+type BundleUsecase interface {
+	GetBundles(ctx context.Context) ([]entity.Bundle, error)
+	GetBundle(ctx context.Context, id int64) (entity.Bundle, error)
+	CreateBundle(ctx context.Context, bundle *entity.Bundle) error
+	UpdateBundle(ctx context.Context, id int64, bundle *entity.Bundle) error
+	DeleteBundle(ctx context.Context, id int64) error
+}
+
+type BundleRepository struct {
+	BundleRepo repository.BundleRepository
+	BookRepo   repository.BookRepository
+}
+
+func NewBundleUsecase(repo *BundleRepository) BundleUsecase {
+	return &BundleRepository{
+		BundleRepo: repo.BundleRepo,
+		BookRepo:   repo.BookRepo,
+	}
+}
+
+func (r *BundleRepository) GetBundles(ctx context.Context) ([]entity.Bundle, error) {
+	res, err := r.BundleRepo.GetBundles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *BundleRepository) GetBundle(ctx context.Context, id int64) (entity.Bundle, error) {
+	res, err := r.BundleRepo.GetBundle(ctx, id)
+	if err != nil {
+		return entity.Bundle{}, err
+	}
+
+	return res, nil
+}
+
+func (r *BundleRepository) CreateBundle(ctx context.Context, bundle *entity.Bundle) error {
+	err := r.validateBundle(ctx, bundle)
+	if err != nil {
+		return err
+	}
+
+	err = r.BundleRepo.CreateBundle(ctx, bundle)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateBundle replaces the title, price and books of a bundle, orders placed before keep
+// the prices they were placed at
+func (r *BundleRepository) UpdateBundle(ctx context.Context, id int64, bundle *entity.Bundle) error {
+	existing, err := r.BundleRepo.GetBundle(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if existing.ID == 0 {
+		return fmt.Errorf("bundle ID %d was not found", id)
+	}
+
+	err = r.validateBundle(ctx, bundle)
+	if err != nil {
+		return err
+	}
+
+	err = r.BundleRepo.UpdateBundle(ctx, id, bundle)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteBundle only removes bundles that were never sold, orders keep the bundles of their lines
+func (r *BundleRepository) DeleteBundle(ctx context.Context, id int64) error {
+	count, err := r.BundleRepo.GetBundleOrderCount(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return fmt.Errorf("bundle ID %d was sold in %d orders and cannot be deleted", id, count)
+	}
+
+	err = r.BundleRepo.DeleteBundle(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateBundle requires a title, a price and at least two copies of books that exist. The
+// copies of the same book are merged.
+func (r *BundleRepository) validateBundle(ctx context.Context, bundle *entity.Bundle) error {
+	bundle.Title = strings.TrimSpace(bundle.Title)
+	if bundle.Title == "" {
+		return errors.New("bundle needs a title")
+	}
+
+	if bundle.Price <= 0 {
+		return errors.New("bundle price must be greater than zero")
+	}
+
+	quantities := make(map[int64]int)
+	copies := 0
+	for _, item := range bundle.Items {
+		if item.Quantity <= 0 {
+			return fmt.Errorf("quantity of book ID %d must be greater than zero", item.BookID)
+		}
+
+		quantities[item.BookID] += item.Quantity
+		copies += item.Quantity
+	}
+
+	if copies < 2 {
+		return errors.New("bundle needs at least two books")
+	}
+
+	items := make([]entity.BundleItem, 0, len(quantities))
+	for bookID, quantity := range quantities {
+		book, err := r.BookRepo.GetBook(ctx, bookID)
+		if err != nil {
+			return err
+		}
+
+		if book.ID == 0 {
+			return fmt.Errorf("book ID %d was not found", bookID)
+		}
+
+		items = append(items, entity.BundleItem{BookID: bookID, Quantity: quantity})
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].BookID < items[j].BookID })
+	bundle.Items = items
+	return nil
+}
+
+// expandBundles replaces the lines ordering a bundle with a line for every book in it and
+// returns the bundles that were ordered
+func expandBundles(ctx context.Context, bundleRepo repository.BundleRepository, lines []entity.OrderLine) ([]entity.OrderLine, map[int64]entity.Bundle, error) {
+	bundles := make(map[int64]entity.Bundle)
+	expanded := make([]entity.OrderLine, 0, len(lines))
+	for _, line := range lines {
+		if line.BundleID == 0 {
+			expanded = append(expanded, line)
+			continue
+		}
+
+		if line.BookID != 0 || line.SKUID != 0 {
+			return nil, nil, errors.New("order line cannot have both a book_id or sku_id and a bundle_id")
+		}
+
+		if line.Quantity <= 0 {
+			return nil, nil, fmt.Errorf("quantity of bundle ID %d must be greater than zero", line.BundleID)
+		}
+
+		bundle, ok := bundles[line.BundleID]
+		if !ok {
+			var err error
+			bundle, err = bundleRepo.GetBundle(ctx, line.BundleID)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if bundle.ID == 0 {
+				return nil, nil, fmt.Errorf("bundle ID %d was not found", line.BundleID)
+			}
+
+			bundles[bundle.ID] = bundle
+		}
+
+		for _, item := range bundle.Items {
+			expanded = append(expanded, entity.OrderLine{BookID: item.BookID, BundleID: bundle.ID, Quantity: item.Quantity * line.Quantity})
+		}
+	}
+
+	return expanded, bundles, nil
+}
@@ -0,0 +1,84 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateBundle(t *testing.T) {
+	testCases := []struct {
+		name      string
+		bundle    entity.Bundle
+		wantItems []entity.BundleItem
+		isError   bool
+	}{
+		{
+			name:      "copies of a book merged",
+			bundle:    entity.Bundle{Title: " Trilogy ", Price: 99000, Items: []entity.BundleItem{{BookID: 2, Quantity: 1}, {BookID: 1, Quantity: 1}, {BookID: 2, Quantity: 1}}},
+			wantItems: []entity.BundleItem{{BookID: 1, Quantity: 1}, {BookID: 2, Quantity: 2}},
+			isError:   false,
+		},
+		{name: "no title", bundle: entity.Bundle{Price: 99000, Items: []entity.BundleItem{{BookID: 1, Quantity: 2}}}, isError: true},
+		{name: "no price", bundle: entity.Bundle{Title: "Trilogy", Items: []entity.BundleItem{{BookID: 1, Quantity: 2}}}, isError: true},
+		{name: "single book", bundle: entity.Bundle{Title: "Trilogy", Price: 99000, Items: []entity.BundleItem{{BookID: 1, Quantity: 1}}}, isError: true},
+		{name: "book not found", bundle: entity.Bundle{Title: "Trilogy", Price: 99000, Items: []entity.BundleItem{{BookID: 1, Quantity: 1}, {BookID: 9, Quantity: 1}}}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			bookRepo := new(mocks.BookRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(1)).Return(entity.Book{ID: 1}, nil)
+			bookRepo.On("GetBook", mock.Anything, int64(2)).Return(entity.Book{ID: 2}, nil)
+			bookRepo.On("GetBook", mock.Anything, int64(9)).Return(entity.Book{}, nil)
+
+			bundleRepo := new(mocks.BundleRepository)
+			bundleRepo.On("CreateBundle", mock.Anything, mock.Anything).Return(nil)
+
+			bundleUsecase := usecase.NewBundleUsecase(&usecase.BundleRepository{BundleRepo: bundleRepo, BookRepo: bookRepo})
+			bundle := test.bundle
+			err := bundleUsecase.CreateBundle(context.Background(), &bundle)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				bundleRepo.AssertNotCalled(t, "CreateBundle", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Equal(t, "Trilogy", bundle.Title)
+			assert.Equal(t, test.wantItems, bundle.Items)
+		})
+	}
+}
+
+func TestDeleteBundle(t *testing.T) {
+	testCases := []struct {
+		name    string
+		orders  int
+		isError bool
+	}{
+		{name: "never sold", orders: 0, isError: false},
+		{name: "sold in orders", orders: 3, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			bundleRepo := new(mocks.BundleRepository)
+			bundleRepo.On("GetBundleOrderCount", mock.Anything, int64(7)).Return(test.orders, nil)
+			bundleRepo.On("DeleteBundle", mock.Anything, int64(7)).Return(nil)
+
+			bundleUsecase := usecase.NewBundleUsecase(&usecase.BundleRepository{BundleRepo: bundleRepo})
+			err := bundleUsecase.DeleteBundle(context.Background(), 7)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				bundleRepo.AssertNotCalled(t, "DeleteBundle", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
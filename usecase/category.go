@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"winartodev/book-store-be/entity"
 	"winartodev/book-store-be/repository"
 )
@@ -9,8 +11,11 @@ import (
 type CategoryUsecase interface {
 	GetCategories(ctx context.Context) ([]entity.Category, error)
 	GetCategory(ctx context.Context, id int64) (entity.Category, error)
+	GetCategoryTree(ctx context.Context) ([]entity.Category, error)
+	GetCategoryBreadcrumbs(ctx context.Context, id int64) ([]entity.Category, error)
 	CreateCategory(ctx context.Context, category *entity.Category) error
 	UpdateCategory(ctx context.Context, id int64, category *entity.Category) error
+	MoveCategory(ctx context.Context, id int64, parentID *int64) error
 	DeleteCategory(ctx context.Context, id int64) error
 }
 
@@ -42,7 +47,47 @@ func (r *CategoryRepository) GetCategory(ctx context.Context, id int64) (entity.
 	return res, nil
 }
 
+// GetCategoryTree returns the root categories with their descendants nested under Children
+func (r *CategoryRepository) GetCategoryTree(ctx context.Context) ([]entity.Category, error) {
+	res, err := r.CategoryRepo.GetCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[int64][]entity.Category)
+	var roots []entity.Category
+	for _, category := range res {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+			continue
+		}
+		children[*category.ParentID] = append(children[*category.ParentID], category)
+	}
+
+	return buildCategoryTree(roots, children), nil
+}
+
+func (r *CategoryRepository) GetCategoryBreadcrumbs(ctx context.Context, id int64) ([]entity.Category, error) {
+	res, err := r.CategoryRepo.GetCategoryPath(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (r *CategoryRepository) CreateCategory(ctx context.Context, category *entity.Category) error {
+	if category.ParentID != nil {
+		parent, err := r.CategoryRepo.GetCategory(ctx, *category.ParentID)
+		if err != nil {
+			return err
+		}
+
+		if parent.ID == 0 {
+			return fmt.Errorf("parent category ID %d was not found", *category.ParentID)
+		}
+	}
+
 	err := r.CategoryRepo.CreateCategory(ctx, category)
 	if err != nil {
 		return err
@@ -60,6 +105,29 @@ func (r *CategoryRepository) UpdateCategory(ctx context.Context, id int64, categ
 	return nil
 }
 
+// MoveCategory moves a category and its whole subtree under parentID, or to the root when parentID is nil
+func (r *CategoryRepository) MoveCategory(ctx context.Context, id int64, parentID *int64) error {
+	if parentID != nil && *parentID == id {
+		return errors.New("category cannot be its own parent")
+	}
+
+	category, err := r.CategoryRepo.GetCategory(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if category.ID == 0 {
+		return fmt.Errorf("category ID %d was not found", id)
+	}
+
+	err = r.CategoryRepo.MoveCategory(ctx, id, parentID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *CategoryRepository) DeleteCategory(ctx context.Context, id int64) error {
 	err := r.CategoryRepo.DeleteCategory(ctx, id)
 	if err != nil {
@@ -68,3 +136,31 @@ func (r *CategoryRepository) DeleteCategory(ctx context.Context, id int64) error
 
 	return nil
 }
+
+// addBookCategories fills in every category of the books
+func addBookCategories(ctx context.Context, categoryRepo repository.CategoryRepository, books map[int64]entity.Book) error {
+	ids := make([]int64, 0, len(books))
+	for id := range books {
+		ids = append(ids, id)
+	}
+
+	categories, err := categoryRepo.GetBookCategories(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for id, book := range books {
+		book.Categories = categories[id]
+		books[id] = book
+	}
+
+	return nil
+}
+
+func buildCategoryTree(nodes []entity.Category, children map[int64][]entity.Category) []entity.Category {
+	for i := range nodes {
+		nodes[i].Children = buildCategoryTree(children[nodes[i].ID], children)
+	}
+
+	return nodes
+}
@@ -225,3 +225,129 @@ func TestDeleteCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCategoryTree(t *testing.T) {
+	fiction, fantasy := int64(1), int64(2)
+
+	prov := categoryProvider()
+	prov.categoryRepo.On("GetCategories", mock.Anything).Return([]entity.Category{
+		{ID: 1, Name: "Fiction"},
+		{ID: 2, ParentID: &fiction, Name: "Fantasy"},
+		{ID: 3, ParentID: &fantasy, Name: "Epic Fantasy"},
+		{ID: 4, Name: "Non-Fiction"},
+	}, nil)
+
+	categoryUsecase := newCategoryUsecase(&usecase.CategoryRepository{CategoryRepo: prov.categoryRepo})
+	res, err := categoryUsecase.GetCategoryTree(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "Fantasy", res[0].Children[0].Name)
+	assert.Equal(t, "Epic Fantasy", res[0].Children[0].Children[0].Name)
+	assert.Empty(t, res[1].Children)
+}
+
+func TestGetCategoryBreadcrumbs(t *testing.T) {
+	testCases := []struct {
+		name    string
+		path    []entity.Category
+		isError bool
+		wantErr error
+	}{
+		{
+			name:    "success",
+			path:    []entity.Category{{ID: 1, Name: "Fiction"}, {ID: 2, Name: "Fantasy"}},
+			isError: false,
+			wantErr: nil,
+		},
+		{
+			name:    "failed",
+			path:    nil,
+			isError: true,
+			wantErr: errors.New("Dummy Error"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := categoryProvider()
+			prov.categoryRepo.On("GetCategoryPath", mock.Anything, int64(2)).Return(test.path, test.wantErr)
+
+			categoryUsecase := newCategoryUsecase(&usecase.CategoryRepository{CategoryRepo: prov.categoryRepo})
+			res, err := categoryUsecase.GetCategoryBreadcrumbs(context.Background(), 2)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, len(test.path), len(res))
+		})
+	}
+}
+
+func TestCreateCategoryWithMissingParent(t *testing.T) {
+	parentID := int64(9)
+
+	prov := categoryProvider()
+	prov.categoryRepo.On("GetCategory", mock.Anything, parentID).Return(entity.Category{}, nil)
+
+	categoryUsecase := newCategoryUsecase(&usecase.CategoryRepository{CategoryRepo: prov.categoryRepo})
+	err := categoryUsecase.CreateCategory(context.Background(), &entity.Category{Name: "Fantasy", ParentID: &parentID})
+
+	assert.Error(t, err)
+	prov.categoryRepo.AssertNotCalled(t, "CreateCategory", mock.Anything, mock.Anything)
+}
+
+func TestMoveCategory(t *testing.T) {
+	self, other := int64(3), int64(1)
+
+	testCases := []struct {
+		name     string
+		parentID *int64
+		category entity.Category
+		moveErr  error
+		isError  bool
+	}{
+		{
+			name:     "success",
+			parentID: &other,
+			category: entity.Category{ID: 3, Name: "Epic Fantasy"},
+			isError:  false,
+		},
+		{
+			name:     "success move to root",
+			parentID: nil,
+			category: entity.Category{ID: 3, Name: "Epic Fantasy"},
+			isError:  false,
+		},
+		{
+			name:     "failed with self as parent",
+			parentID: &self,
+			category: entity.Category{ID: 3, Name: "Epic Fantasy"},
+			isError:  true,
+		},
+		{
+			name:     "failed with category not found",
+			parentID: &other,
+			category: entity.Category{},
+			isError:  true,
+		},
+		{
+			name:     "failed with descendant as parent",
+			parentID: &other,
+			category: entity.Category{ID: 3, Name: "Epic Fantasy"},
+			moveErr:  errors.New("category cannot be moved under itself or one of its descendants"),
+			isError:  true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := categoryProvider()
+			prov.categoryRepo.On("GetCategory", mock.Anything, self).Return(test.category, nil)
+			prov.categoryRepo.On("MoveCategory", mock.Anything, self, test.parentID).Return(test.moveErr)
+
+			categoryUsecase := newCategoryUsecase(&usecase.CategoryRepository{CategoryRepo: prov.categoryRepo})
+			err := categoryUsecase.MoveCategory(context.Background(), self, test.parentID)
+
+			assert.Equal(t, test.isError, err != nil)
+		})
+	}
+}
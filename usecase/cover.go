@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"time"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+	"winartodev/book-store-be/storage"
+)
+
+// maxCoverPixels guards against images that are small on disk but huge once decoded, the
+// decoded cover takes up to 8 bytes a pixel
+const maxCoverPixels = 40 * 1000 * 1000
+
+// coverTypes maps the accepted upload content types to the extension of the stored original
+var coverTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// coverRenditions are generated from every upload, sized by width
+var coverRenditions = []struct {
+	name  string
+	width int
+}{
+	{name: entity.RenditionThumbnail, width: 150},
+	{name: entity.RenditionMedium, width: 400},
+	{name: entity.RenditionLarge, width: 800},
+}
+
+type CoverUsecase interface {
+	UploadCover(ctx context.Context, bookID int64, data []byte, contentType string) ([]entity.CoverImage, error)
+	DeleteCover(ctx context.Context, bookID int64) error
+}
+
+type CoverRepository struct {
+	CoverRepo repository.CoverRepository
+	BookRepo  repository.BookRepository
+	Storage   storage.Storage
+}
+
+func NewCoverUsecase(repo *CoverRepository) CoverUsecase {
+	return &CoverRepository{
+		CoverRepo: repo.CoverRepo,
+		BookRepo:  repo.BookRepo,
+		Storage:   repo.Storage,
+	}
+}
+
+// UploadCover stores the original image with its renditions and replaces the previous cover of the book
+func (r *CoverRepository) UploadCover(ctx context.Context, bookID int64, data []byte, contentType string) ([]entity.CoverImage, error) {
+	ext, ok := coverTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported cover type %q", contentType)
+	}
+
+	book, err := r.BookRepo.GetBook(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if book.ID == 0 {
+		return nil, fmt.Errorf("book ID %d was not found", bookID)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("cover is not a valid image")
+	}
+
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, errors.New("cover is not a valid image")
+	}
+
+	if int64(cfg.Width)*int64(cfg.Height) > maxCoverPixels {
+		return nil, fmt.Errorf("cover cannot be larger than %d megapixels", maxCoverPixels/1000/1000)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("cover is not a valid image")
+	}
+
+	// every upload gets its own prefix so cached URLs of an older cover never serve the new one
+	prefix := fmt.Sprintf("covers/%d/%x/", bookID, time.Now().UnixNano())
+	covers := []entity.CoverImage{{Rendition: entity.RenditionOriginal, Key: prefix + entity.RenditionOriginal + ext, ContentType: contentType, Width: cfg.Width, Height: cfg.Height}}
+	bodies := [][]byte{data}
+
+	for _, rendition := range coverRenditions {
+		resized := resizeImage(img, rendition.width)
+
+		var buf bytes.Buffer
+		err := jpeg.Encode(&buf, resized, &jpeg.Options{Quality: 85})
+		if err != nil {
+			return nil, err
+		}
+
+		size := resized.Bounds().Size()
+		covers = append(covers, entity.CoverImage{Rendition: rendition.name, Key: prefix + rendition.name + ".jpg", ContentType: "image/jpeg", Width: size.X, Height: size.Y})
+		bodies = append(bodies, buf.Bytes())
+	}
+
+	for i, cover := range covers {
+		err := r.Storage.Put(ctx, cover.Key, bytes.NewReader(bodies[i]), cover.ContentType)
+		if err != nil {
+			r.removeCoverFiles(ctx, covers[:i])
+			return nil, err
+		}
+	}
+
+	previous, err := r.CoverRepo.GetBookCovers(ctx, []int64{bookID})
+	if err != nil {
+		r.removeCoverFiles(ctx, covers)
+		return nil, err
+	}
+
+	err = r.CoverRepo.SetBookCovers(ctx, bookID, covers)
+	if err != nil {
+		r.removeCoverFiles(ctx, covers)
+		return nil, err
+	}
+
+	r.removeCoverFiles(ctx, previous[bookID])
+
+	for i := range covers {
+		covers[i].URL = r.Storage.URL(covers[i].Key)
+	}
+
+	return covers, nil
+}
+
+func (r *CoverRepository) DeleteCover(ctx context.Context, bookID int64) error {
+	covers, err := r.CoverRepo.GetBookCovers(ctx, []int64{bookID})
+	if err != nil {
+		return err
+	}
+
+	err = r.CoverRepo.DeleteBookCovers(ctx, bookID)
+	if err != nil {
+		return err
+	}
+
+	r.removeCoverFiles(ctx, covers[bookID])
+	return nil
+}
+
+// removeCoverFiles deletes stored cover files on a best effort basis, a leftover file
+// is harmless since nothing references it anymore
+func (r *CoverRepository) removeCoverFiles(ctx context.Context, covers []entity.CoverImage) {
+	for _, cover := range covers {
+		r.Storage.Delete(ctx, cover.Key)
+	}
+}
+
+// resizeImage scales img down to width keeping its aspect ratio, averaging every source
+// pixel that falls inside a destination pixel. Transparent areas are flattened onto white
+// since renditions are stored as JPEG. Images narrower than width are not enlarged. Pixels
+// are read from img itself, only the rendition is allocated.
+func resizeImage(img image.Image, width int) *image.RGBA {
+	bounds := img.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+
+	if width > srcWidth {
+		width = srcWidth
+	}
+
+	height := srcHeight * width / srcWidth
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcHeight/height, (y+1)*srcHeight/height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcWidth/width, (x+1)*srcWidth/width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var sum [3]int
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					// colors are alpha-premultiplied, what is transparent shows the white below
+					r, g, b, a := img.At(bounds.Min.X+sx, bounds.Min.Y+sy).RGBA()
+					sum[0] += int(r + 0xffff - a)
+					sum[1] += int(g + 0xffff - a)
+					sum[2] += int(b + 0xffff - a)
+				}
+			}
+
+			count := (y1 - y0) * (x1 - x0)
+			offset := dst.PixOffset(x, y)
+			for c := 0; c < 3; c++ {
+				dst.Pix[offset+c] = uint8(sum[c] / count >> 8)
+			}
+			dst.Pix[offset+3] = 0xff
+		}
+	}
+
+	return dst
+}
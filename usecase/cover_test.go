@@ -0,0 +1,235 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func dummyCover(width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+// hugeCoverHeader returns a PNG that claims the size in its header, only the header is
+// read before a cover that large is refused
+func hugeCoverHeader(width, height int) []byte {
+	data := dummyCover(1, 1)
+	binary.BigEndian.PutUint32(data[16:], uint32(width))
+	binary.BigEndian.PutUint32(data[20:], uint32(height))
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestUploadCover(t *testing.T) {
+	testCases := []struct {
+		name        string
+		data        []byte
+		contentType string
+		book        entity.Book
+		putErr      error
+		setErr      error
+		sizes       map[string][2]int
+		isError     bool
+	}{
+		{
+			name:        "success",
+			data:        dummyCover(1000, 1500),
+			contentType: "image/png",
+			book:        entity.Book{ID: 1},
+			sizes: map[string][2]int{
+				entity.RenditionOriginal:  {1000, 1500},
+				entity.RenditionThumbnail: {150, 225},
+				entity.RenditionMedium:    {400, 600},
+				entity.RenditionLarge:     {800, 1200},
+			},
+			isError: false,
+		},
+		{
+			name:        "small image is not enlarged",
+			data:        dummyCover(300, 200),
+			contentType: "image/png",
+			book:        entity.Book{ID: 1},
+			sizes: map[string][2]int{
+				entity.RenditionOriginal:  {300, 200},
+				entity.RenditionThumbnail: {150, 100},
+				entity.RenditionMedium:    {300, 200},
+				entity.RenditionLarge:     {300, 200},
+			},
+			isError: false,
+		},
+		{
+			name:        "long cover within the pixel budget",
+			data:        dummyCover(12000, 10),
+			contentType: "image/png",
+			book:        entity.Book{ID: 1},
+			sizes: map[string][2]int{
+				entity.RenditionOriginal:  {12000, 10},
+				entity.RenditionThumbnail: {150, 1},
+				entity.RenditionMedium:    {400, 1},
+				entity.RenditionLarge:     {800, 1},
+			},
+			isError: false,
+		},
+		{
+			name:        "too many pixels",
+			data:        hugeCoverHeader(8000, 6000),
+			contentType: "image/png",
+			book:        entity.Book{ID: 1},
+			isError:     true,
+		},
+		{
+			name:        "unsupported content type",
+			data:        []byte("%PDF-1.4"),
+			contentType: "application/pdf",
+			book:        entity.Book{ID: 1},
+			isError:     true,
+		},
+		{
+			name:        "book not found",
+			data:        dummyCover(10, 10),
+			contentType: "image/png",
+			book:        entity.Book{},
+			isError:     true,
+		},
+		{
+			name:        "corrupt image",
+			data:        dummyCover(10, 10)[:40],
+			contentType: "image/png",
+			book:        entity.Book{ID: 1},
+			isError:     true,
+		},
+		{
+			name:        "storage failure",
+			data:        dummyCover(10, 10),
+			contentType: "image/png",
+			book:        entity.Book{ID: 1},
+			putErr:      errors.New("Dummy Error"),
+			isError:     true,
+		},
+		{
+			name:        "database failure removes uploaded files",
+			data:        dummyCover(10, 10),
+			contentType: "image/png",
+			book:        entity.Book{ID: 1},
+			setErr:      errors.New("Dummy Error"),
+			isError:     true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			coverRepo := new(mocks.CoverRepository)
+			bookRepo := new(mocks.BookRepository)
+			store := new(mocks.Storage)
+
+			bookRepo.On("GetBook", mock.Anything, int64(1)).Return(test.book, nil)
+			store.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(test.putErr)
+			store.On("Delete", mock.Anything, mock.Anything).Return(nil)
+			store.On("URL", mock.Anything).Return("http://localhost:8080/covers/dummy.jpg")
+			coverRepo.On("GetBookCovers", mock.Anything, []int64{1}).Return(map[int64][]entity.CoverImage{}, nil)
+			coverRepo.On("SetBookCovers", mock.Anything, int64(1), mock.Anything).Return(test.setErr)
+
+			coverUsecase := usecase.NewCoverUsecase(&usecase.CoverRepository{CoverRepo: coverRepo, BookRepo: bookRepo, Storage: store})
+			covers, err := coverUsecase.UploadCover(context.Background(), 1, test.data, test.contentType)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				if test.setErr != nil {
+					store.AssertNumberOfCalls(t, "Delete", 4)
+				}
+				return
+			}
+
+			assert.Len(t, covers, len(test.sizes))
+			for _, cover := range covers {
+				assert.Equal(t, test.sizes[cover.Rendition], [2]int{cover.Width, cover.Height}, cover.Rendition)
+				assert.True(t, strings.HasPrefix(cover.Key, "covers/1/"))
+				assert.NotEmpty(t, cover.URL)
+			}
+			store.AssertNumberOfCalls(t, "Put", 4)
+		})
+	}
+}
+
+func TestUploadCoverReplacesPrevious(t *testing.T) {
+	coverRepo := new(mocks.CoverRepository)
+	bookRepo := new(mocks.BookRepository)
+	store := new(mocks.Storage)
+
+	bookRepo.On("GetBook", mock.Anything, int64(1)).Return(entity.Book{ID: 1}, nil)
+	store.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	store.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	store.On("URL", mock.Anything).Return("")
+	coverRepo.On("GetBookCovers", mock.Anything, []int64{1}).Return(map[int64][]entity.CoverImage{1: {{Key: "covers/1/old/original.jpg"}}}, nil)
+	coverRepo.On("SetBookCovers", mock.Anything, int64(1), mock.Anything).Return(nil)
+
+	coverUsecase := usecase.NewCoverUsecase(&usecase.CoverRepository{CoverRepo: coverRepo, BookRepo: bookRepo, Storage: store})
+	_, err := coverUsecase.UploadCover(context.Background(), 1, dummyCover(20, 30), "image/png")
+
+	assert.NoError(t, err)
+	store.AssertCalled(t, "Delete", mock.Anything, "covers/1/old/original.jpg")
+	store.AssertNumberOfCalls(t, "Delete", 1)
+}
+
+func TestDeleteCover(t *testing.T) {
+	testCases := []struct {
+		name    string
+		wantErr error
+		isError bool
+	}{
+		{
+			name:    "success",
+			wantErr: nil,
+			isError: false,
+		},
+		{
+			name:    "failed",
+			wantErr: errors.New("Dummy Error"),
+			isError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			coverRepo := new(mocks.CoverRepository)
+			store := new(mocks.Storage)
+
+			coverRepo.On("GetBookCovers", mock.Anything, []int64{1}).Return(map[int64][]entity.CoverImage{1: {{Key: "covers/1/a/original.png"}}}, nil)
+			coverRepo.On("DeleteBookCovers", mock.Anything, int64(1)).Return(test.wantErr)
+			store.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+			coverUsecase := usecase.NewCoverUsecase(&usecase.CoverRepository{CoverRepo: coverRepo, Storage: store})
+			err := coverUsecase.DeleteCover(context.Background(), 1)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				store.AssertCalled(t, "Delete", mock.Anything, "covers/1/a/original.png")
+			} else {
+				store.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
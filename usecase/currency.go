@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/exchange"
+	"winartodev/book-store-be/repository"
+)
+
+type CurrencyUsecase interface {
+	GetPriceList(ctx context.Context, currency string) ([]entity.CurrencyPrice, error)
+	SetCurrencyPrice(ctx context.Context, price *entity.CurrencyPrice) error
+	DeleteCurrencyPrice(ctx context.Context, bookID int64, currency string) error
+	LocalizeBooks(ctx context.Context, books []entity.Book, currency string) error
+	LocalizeSKUs(ctx context.Context, skus []entity.SKU, currency string) error
+	LocalizeBundles(ctx context.Context, bundles []entity.Bundle, currency string) error
+	CheckQuoteCurrency(currency string) error
+}
+
+type CurrencyRepository struct {
+	CurrencyPriceRepo repository.CurrencyPriceRepository
+	BookRepo          repository.BookRepository
+	Rates             *exchange.Rates
+}
+
+func NewCurrencyUsecase(repo *CurrencyRepository) CurrencyUsecase {
+	return &CurrencyRepository{
+		CurrencyPriceRepo: repo.CurrencyPriceRepo,
+		BookRepo:          repo.BookRepo,
+		Rates:             repo.Rates,
+	}
+}
+
+func (r *CurrencyRepository) GetPriceList(ctx context.Context, currency string) ([]entity.CurrencyPrice, error) {
+	currency, err := r.priceListCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.CurrencyPriceRepo.GetPriceList(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetCurrencyPrice puts a book on the price list of a currency, its price there no longer
+// follows the exchange rates
+func (r *CurrencyRepository) SetCurrencyPrice(ctx context.Context, price *entity.CurrencyPrice) error {
+	currency, err := r.priceListCurrency(price.Price.Currency)
+	if err != nil {
+		return err
+	}
+	price.Price.Currency = currency
+
+	if price.Price.Amount < 0 {
+		return errors.New("price cannot be negative")
+	}
+
+	book, err := r.BookRepo.GetBook(ctx, price.BookID)
+	if err != nil {
+		return err
+	}
+
+	if book.ID == 0 {
+		return fmt.Errorf("book ID %d was not found", price.BookID)
+	}
+
+	return r.CurrencyPriceRepo.SetCurrencyPrice(ctx, price)
+}
+
+// DeleteCurrencyPrice takes a book off the price list of a currency, its price there follows
+// the exchange rates again
+func (r *CurrencyRepository) DeleteCurrencyPrice(ctx context.Context, bookID int64, currency string) error {
+	currency, err := r.priceListCurrency(currency)
+	if err != nil {
+		return err
+	}
+
+	return r.CurrencyPriceRepo.DeleteCurrencyPrice(ctx, bookID, currency)
+}
+
+// LocalizeBooks sets the display price of books and their editions in the currency, the
+// store's currency when none is given. A book on the currency's price list is sold at that
+// price, the others and every edition at their price converted with the exchange rates.
+func (r *CurrencyRepository) LocalizeBooks(ctx context.Context, books []entity.Book, currency string) error {
+	currency, err := r.displayCurrency(currency)
+	if err != nil {
+		return err
+	}
+
+	listed := make(map[int64]entity.Money)
+	if currency != r.Rates.Base() && len(books) > 0 {
+		ids := make([]int64, len(books))
+		for i, book := range books {
+			ids[i] = book.ID
+		}
+
+		listed, err = r.CurrencyPriceRepo.GetCurrencyPrices(ctx, ids, currency)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range books {
+		price, ok := listed[books[i].ID]
+		if !ok {
+			price, err = r.Rates.Convert(int64(books[i].Price), currency)
+			if err != nil {
+				return err
+			}
+		}
+
+		books[i].DisplayPrice = &price
+
+		err = r.LocalizeSKUs(ctx, books[i].SKUs, currency)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LocalizeSKUs sets the display price of editions in the currency, the store's currency when
+// none is given. Price lists only hold books, an edition's price is always converted with the
+// exchange rates.
+func (r *CurrencyRepository) LocalizeSKUs(ctx context.Context, skus []entity.SKU, currency string) error {
+	currency, err := r.displayCurrency(currency)
+	if err != nil {
+		return err
+	}
+
+	for i := range skus {
+		price, err := r.Rates.Convert(int64(skus[i].Price), currency)
+		if err != nil {
+			return err
+		}
+
+		skus[i].DisplayPrice = &price
+	}
+
+	return nil
+}
+
+// LocalizeBundles sets the display price of bundles in the currency, the store's currency
+// when none is given, converted with the exchange rates
+func (r *CurrencyRepository) LocalizeBundles(ctx context.Context, bundles []entity.Bundle, currency string) error {
+	currency, err := r.displayCurrency(currency)
+	if err != nil {
+		return err
+	}
+
+	for i := range bundles {
+		price, err := r.Rates.Convert(int64(bundles[i].Price), currency)
+		if err != nil {
+			return err
+		}
+
+		bundles[i].DisplayPrice = &price
+	}
+
+	return nil
+}
+
+// CheckQuoteCurrency refuses a quote in any currency but the store's. Carts are quoted and
+// charged in the store's currency, converting their totals would show a price nobody pays.
+func (r *CurrencyRepository) CheckQuoteCurrency(currency string) error {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency != "" && currency != r.Rates.Base() {
+		return fmt.Errorf("%w %s, carts are quoted in %s", exchange.ErrUnsupportedCurrency, currency, r.Rates.Base())
+	}
+
+	return nil
+}
+
+// displayCurrency returns the currency prices are shown in, the store's currency when none
+// is given
+func (r *CurrencyRepository) displayCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return r.Rates.Base(), nil
+	}
+
+	if currency != r.Rates.Base() && !entity.KnownCurrency(currency) {
+		return "", fmt.Errorf("%w %s", exchange.ErrUnsupportedCurrency, currency)
+	}
+
+	return currency, nil
+}
+
+// priceListCurrency checks a currency can have a price list, the store's own currency has
+// none as it is the currency of the books' prices
+func (r *CurrencyRepository) priceListCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if !entity.KnownCurrency(currency) {
+		return "", fmt.Errorf("unknown currency %q", currency)
+	}
+
+	if currency == r.Rates.Base() {
+		return "", fmt.Errorf("prices in %s are the books' own prices", currency)
+	}
+
+	return currency, nil
+}
@@ -0,0 +1,175 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/exchange"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newRates() *exchange.Rates {
+	rates := exchange.NewRates("IDR")
+	err := rates.Read(strings.NewReader(`{"base": "IDR", "rates": {"USD": "0.0000625"}}`))
+	if err != nil {
+		panic(err)
+	}
+
+	return rates
+}
+
+func TestLocalizeBooks(t *testing.T) {
+	testCases := []struct {
+		name     string
+		currency string
+		listed   map[int64]entity.Money
+		want     []entity.Money
+		err      error
+	}{
+		{name: "store currency", currency: "", want: []entity.Money{{Amount: 125000, Currency: "IDR"}, {Amount: 80000, Currency: "IDR"}}},
+		{name: "converted", currency: "usd", want: []entity.Money{{Amount: 781, Currency: "USD"}, {Amount: 500, Currency: "USD"}}},
+		{name: "price list wins", currency: "USD", listed: map[int64]entity.Money{2: {Amount: 499, Currency: "USD"}}, want: []entity.Money{{Amount: 781, Currency: "USD"}, {Amount: 499, Currency: "USD"}}},
+		{name: "priced in a currency with no rate", currency: "EUR", listed: map[int64]entity.Money{1: {Amount: 700, Currency: "EUR"}, 2: {Amount: 450, Currency: "EUR"}}, want: []entity.Money{{Amount: 700, Currency: "EUR"}, {Amount: 450, Currency: "EUR"}}},
+		{name: "no rate", currency: "EUR", err: exchange.ErrUnsupportedCurrency},
+		{name: "unknown currency", currency: "XYZ", err: exchange.ErrUnsupportedCurrency},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			currencyPriceRepo := new(mocks.CurrencyPriceRepository)
+			currencyPriceRepo.On("GetCurrencyPrices", mock.Anything, []int64{1, 2}, mock.Anything).Return(test.listed, nil)
+
+			books := []entity.Book{{ID: 1, Price: 125000}, {ID: 2, Price: 80000}}
+			currencyUsecase := usecase.NewCurrencyUsecase(&usecase.CurrencyRepository{CurrencyPriceRepo: currencyPriceRepo, Rates: newRates()})
+			err := currencyUsecase.LocalizeBooks(context.Background(), books, test.currency)
+
+			assert.True(t, errors.Is(err, test.err))
+			if test.err != nil {
+				return
+			}
+
+			for i, book := range books {
+				assert.Equal(t, test.want[i], *book.DisplayPrice)
+			}
+		})
+	}
+}
+
+func TestLocalizeBooksEditions(t *testing.T) {
+	currencyPriceRepo := new(mocks.CurrencyPriceRepository)
+	currencyPriceRepo.On("GetCurrencyPrices", mock.Anything, []int64{1}, "USD").Return(map[int64]entity.Money{1: {Amount: 799, Currency: "USD"}}, nil)
+
+	books := []entity.Book{{ID: 1, Price: 125000, SKUs: []entity.SKU{{ID: 1, BookID: 1, Price: 160000}, {ID: 2, BookID: 1, Price: 80000}}}}
+	currencyUsecase := usecase.NewCurrencyUsecase(&usecase.CurrencyRepository{CurrencyPriceRepo: currencyPriceRepo, Rates: newRates()})
+	err := currencyUsecase.LocalizeBooks(context.Background(), books, "USD")
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.Money{Amount: 799, Currency: "USD"}, *books[0].DisplayPrice)
+	assert.Equal(t, entity.Money{Amount: 1000, Currency: "USD"}, *books[0].SKUs[0].DisplayPrice)
+	assert.Equal(t, entity.Money{Amount: 500, Currency: "USD"}, *books[0].SKUs[1].DisplayPrice)
+}
+
+func TestLocalizeSKUs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		currency string
+		want     []entity.Money
+		err      error
+	}{
+		{name: "store currency", currency: "", want: []entity.Money{{Amount: 125000, Currency: "IDR"}, {Amount: 80000, Currency: "IDR"}}},
+		{name: "converted", currency: "usd", want: []entity.Money{{Amount: 781, Currency: "USD"}, {Amount: 500, Currency: "USD"}}},
+		{name: "no rate", currency: "EUR", err: exchange.ErrUnsupportedCurrency},
+		{name: "unknown currency", currency: "XYZ", err: exchange.ErrUnsupportedCurrency},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			skus := []entity.SKU{{ID: 1, BookID: 1, Price: 125000}, {ID: 2, BookID: 1, Price: 80000}}
+			currencyUsecase := usecase.NewCurrencyUsecase(&usecase.CurrencyRepository{Rates: newRates()})
+			err := currencyUsecase.LocalizeSKUs(context.Background(), skus, test.currency)
+
+			assert.True(t, errors.Is(err, test.err))
+			if test.err != nil {
+				return
+			}
+
+			for i, sku := range skus {
+				assert.Equal(t, test.want[i], *sku.DisplayPrice)
+			}
+		})
+	}
+}
+
+func TestLocalizeBundles(t *testing.T) {
+	testCases := []struct {
+		name     string
+		currency string
+		want     entity.Money
+		err      error
+	}{
+		{name: "store currency", currency: "", want: entity.Money{Amount: 250000, Currency: "IDR"}},
+		{name: "converted", currency: "USD", want: entity.Money{Amount: 1563, Currency: "USD"}},
+		{name: "no rate", currency: "EUR", err: exchange.ErrUnsupportedCurrency},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			bundles := []entity.Bundle{{ID: 1, Price: 250000}}
+			currencyUsecase := usecase.NewCurrencyUsecase(&usecase.CurrencyRepository{Rates: newRates()})
+			err := currencyUsecase.LocalizeBundles(context.Background(), bundles, test.currency)
+
+			assert.True(t, errors.Is(err, test.err))
+			if test.err == nil {
+				assert.Equal(t, test.want, *bundles[0].DisplayPrice)
+			}
+		})
+	}
+}
+
+func TestCheckQuoteCurrency(t *testing.T) {
+	currencyUsecase := usecase.NewCurrencyUsecase(&usecase.CurrencyRepository{Rates: newRates()})
+
+	assert.NoError(t, currencyUsecase.CheckQuoteCurrency(""))
+	assert.NoError(t, currencyUsecase.CheckQuoteCurrency("idr"))
+	assert.True(t, errors.Is(currencyUsecase.CheckQuoteCurrency("USD"), exchange.ErrUnsupportedCurrency))
+}
+
+func TestSetCurrencyPrice(t *testing.T) {
+	testCases := []struct {
+		name    string
+		price   entity.CurrencyPrice
+		book    entity.Book
+		isError bool
+	}{
+		{name: "success", price: entity.CurrencyPrice{BookID: 1, Price: entity.Money{Amount: 1299, Currency: "usd"}}, book: entity.Book{ID: 1}, isError: false},
+		{name: "store currency", price: entity.CurrencyPrice{BookID: 1, Price: entity.Money{Amount: 125000, Currency: "IDR"}}, book: entity.Book{ID: 1}, isError: true},
+		{name: "unknown currency", price: entity.CurrencyPrice{BookID: 1, Price: entity.Money{Amount: 1299, Currency: "XYZ"}}, book: entity.Book{ID: 1}, isError: true},
+		{name: "negative price", price: entity.CurrencyPrice{BookID: 1, Price: entity.Money{Amount: -1, Currency: "USD"}}, book: entity.Book{ID: 1}, isError: true},
+		{name: "book not found", price: entity.CurrencyPrice{BookID: 1, Price: entity.Money{Amount: 1299, Currency: "USD"}}, book: entity.Book{}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			bookRepo := new(mocks.BookRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(1)).Return(test.book, nil)
+
+			currencyPriceRepo := new(mocks.CurrencyPriceRepository)
+			currencyPriceRepo.On("SetCurrencyPrice", mock.Anything, mock.Anything).Return(nil)
+
+			currencyUsecase := usecase.NewCurrencyUsecase(&usecase.CurrencyRepository{CurrencyPriceRepo: currencyPriceRepo, BookRepo: bookRepo, Rates: newRates()})
+			err := currencyUsecase.SetCurrencyPrice(context.Background(), &test.price)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, "USD", test.price.Price.Currency)
+				currencyPriceRepo.AssertCalled(t, "SetCurrencyPrice", mock.Anything, &test.price)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/pdf"
+	"winartodev/book-store-be/repository"
+)
+
+type InvoiceUsecase interface {
+	GetInvoice(ctx context.Context, id int64) (entity.Invoice, error)
+	GetOrderInvoices(ctx context.Context, orderID int64) ([]entity.Invoice, error)
+	GetInvoicePDF(ctx context.Context, id int64) ([]byte, error)
+	GetOrderInvoicePDF(ctx context.Context, orderID int64) ([]byte, error)
+	IssueInvoices(ctx context.Context) (int, error)
+}
+
+type InvoiceRepository struct {
+	InvoiceRepo repository.InvoiceRepository
+	OrderRepo   repository.OrderRepository
+	PaymentRepo repository.PaymentRepository
+	BookRepo    repository.BookRepository
+	// FiscalYearStart is the month fiscal years start in, a fiscal year is named after the
+	// calendar year it starts in
+	FiscalYearStart time.Month
+	Seller          pdf.Seller
+}
+
+func NewInvoiceUsecase(repo *InvoiceRepository) InvoiceUsecase {
+	return &InvoiceRepository{
+		InvoiceRepo:     repo.InvoiceRepo,
+		OrderRepo:       repo.OrderRepo,
+		PaymentRepo:     repo.PaymentRepo,
+		BookRepo:        repo.BookRepo,
+		FiscalYearStart: repo.FiscalYearStart,
+		Seller:          repo.Seller,
+	}
+}
+
+func (r *InvoiceRepository) GetInvoice(ctx context.Context, id int64) (entity.Invoice, error) {
+	res, err := r.InvoiceRepo.GetInvoice(ctx, id)
+	if err != nil {
+		return entity.Invoice{}, err
+	}
+
+	return res, nil
+}
+
+// GetOrderInvoices returns the invoice of an order followed by its credit notes, issuing
+// those that are due and were not issued yet
+func (r *InvoiceRepository) GetOrderInvoices(ctx context.Context, orderID int64) ([]entity.Invoice, error) {
+	return r.issueOrderInvoices(ctx, orderID)
+}
+
+// GetInvoicePDF renders an invoice or a credit note, it returns nothing when there is no such invoice
+func (r *InvoiceRepository) GetInvoicePDF(ctx context.Context, id int64) ([]byte, error) {
+	invoice, err := r.InvoiceRepo.GetInvoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice.ID == 0 {
+		return nil, nil
+	}
+
+	return pdf.RenderInvoice(invoice, r.Seller), nil
+}
+
+// GetOrderInvoicePDF renders the invoice of an order, it returns nothing when the order was
+// not paid
+func (r *InvoiceRepository) GetOrderInvoicePDF(ctx context.Context, orderID int64) ([]byte, error) {
+	invoices, err := r.issueOrderInvoices(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, invoice := range invoices {
+		if invoice.Kind == entity.InvoiceKindInvoice {
+			return pdf.RenderInvoice(invoice, r.Seller), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// IssueInvoices issues the invoices and credit notes that are due and returns for how many
+// orders. An order that fails stops the run, it is tried again on the next.
+func (r *InvoiceRepository) IssueInvoices(ctx context.Context) (int, error) {
+	ids, err := r.InvoiceRepo.GetUninvoicedOrders(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, id := range ids {
+		_, err = r.issueOrderInvoices(ctx, id)
+		if err != nil {
+			return i, fmt.Errorf("order ID %d could not be invoiced: %w", id, err)
+		}
+	}
+
+	return len(ids), nil
+}
+
+// issueOrderInvoices issues the invoice of an order once a payment of it was captured, and a
+// credit note for whatever was refunded since the last one, then returns all of them
+func (r *InvoiceRepository) issueOrderInvoices(ctx context.Context, orderID int64) ([]entity.Invoice, error) {
+	payments, err := r.PaymentRepo.GetOrderPayments(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var paid bool
+	var refunded int
+	var currency string
+	for _, p := range payments {
+		if p.Status == entity.PaymentCaptured || p.Status == entity.PaymentRefunded {
+			paid, currency = true, p.Currency
+			refunded += p.RefundedAmount
+		}
+	}
+
+	invoices, err := r.InvoiceRepo.GetOrderInvoices(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !paid {
+		return invoices, nil
+	}
+
+	var invoice entity.Invoice
+	var credited int
+	for _, issued := range invoices {
+		switch issued.Kind {
+		case entity.InvoiceKindInvoice:
+			invoice = issued
+		case entity.InvoiceKindCreditNote:
+			credited += issued.Total
+		}
+	}
+
+	if invoice.ID != 0 && refunded <= credited {
+		return invoices, nil
+	}
+
+	if invoice.ID == 0 {
+		invoice, err = r.orderInvoice(ctx, orderID, currency)
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.createInvoice(ctx, &invoice)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// when a concurrent request issued the invoice first, it credits the refunds as well
+	if invoice.ID != 0 && refunded > credited {
+		note := r.creditNote(invoice, refunded-credited)
+		note.Source = fmt.Sprintf("order-%d-refunded-%d", orderID, refunded)
+
+		err = r.createInvoice(ctx, &note)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r.InvoiceRepo.GetOrderInvoices(ctx, orderID)
+}
+
+// orderInvoice copies the buyer, the lines, the taxes and the totals of an order into an invoice
+func (r *InvoiceRepository) orderInvoice(ctx context.Context, orderID int64, currency string) (entity.Invoice, error) {
+	order, err := r.OrderRepo.GetOrder(ctx, orderID)
+	if err != nil {
+		return entity.Invoice{}, err
+	}
+
+	if order.ID == 0 {
+		return entity.Invoice{}, fmt.Errorf("order ID %d was not found", orderID)
+	}
+
+	invoice := entity.Invoice{
+		OrderID:          order.ID,
+		Kind:             entity.InvoiceKindInvoice,
+		CustomerEmail:    order.CustomerEmail,
+		ShippingCountry:  order.ShippingCountry,
+		ShippingRegion:   order.ShippingRegion,
+		Currency:         currency,
+		PricesIncludeTax: order.PricesIncludeTax,
+		TaxLines:         order.TaxLines,
+		Shipping:         order.ShippingCost,
+		Total:            orderTotal(order),
+		Source:           fmt.Sprintf("order-%d", order.ID),
+	}
+
+	for _, line := range order.Lines {
+		book, err := r.BookRepo.GetBook(ctx, line.BookID)
+		if err != nil {
+			return entity.Invoice{}, err
+		}
+
+		description := book.Title
+		if book.ID == 0 {
+			description = fmt.Sprintf("Book ID %d", line.BookID)
+		}
+
+		amount := line.UnitPrice*line.Quantity - line.Discount
+		invoice.Lines = append(invoice.Lines, entity.InvoiceLine{
+			BookID:      line.BookID,
+			Description: description,
+			Quantity:    line.Quantity,
+			UnitPrice:   line.UnitPrice,
+			Discount:    line.Discount,
+			Tax:         line.Tax,
+			Amount:      amount,
+		})
+		invoice.Subtotal += amount
+		invoice.Tax += line.Tax
+	}
+
+	return invoice, nil
+}
+
+// creditNote credits an amount refunded of an invoice. The tax credited is the share of the
+// amount the tax had in the invoice total.
+func (r *InvoiceRepository) creditNote(invoice entity.Invoice, refunded int) entity.Invoice {
+	var tax int
+	if invoice.Total > 0 {
+		tax = int(int64(refunded) * int64(invoice.Tax) / int64(invoice.Total))
+	}
+
+	amount := refunded
+	if !invoice.PricesIncludeTax {
+		amount -= tax
+	}
+
+	return entity.Invoice{
+		OrderID:           invoice.OrderID,
+		Kind:              entity.InvoiceKindCreditNote,
+		CreditedInvoiceID: invoice.ID,
+		CustomerEmail:     invoice.CustomerEmail,
+		ShippingCountry:   invoice.ShippingCountry,
+		ShippingRegion:    invoice.ShippingRegion,
+		Currency:          invoice.Currency,
+		PricesIncludeTax:  invoice.PricesIncludeTax,
+		Lines: []entity.InvoiceLine{{
+			Description: fmt.Sprintf("Refund of invoice %s", invoice.Number),
+			Quantity:    1,
+			UnitPrice:   amount,
+			Tax:         tax,
+			Amount:      amount,
+		}},
+		Subtotal: amount,
+		Tax:      tax,
+		Total:    refunded,
+	}
+}
+
+// createInvoice issues an invoice in the fiscal year of today, one a concurrent request issued
+// first is left as it is
+func (r *InvoiceRepository) createInvoice(ctx context.Context, invoice *entity.Invoice) error {
+	invoice.IssuedAt = time.Now()
+	invoice.FiscalYear = fiscalYear(invoice.IssuedAt, r.FiscalYearStart)
+
+	err := r.InvoiceRepo.CreateInvoice(ctx, invoice)
+	if err == repository.ErrDuplicateInvoice {
+		invoice.ID = 0
+		return nil
+	}
+
+	return err
+}
+
+// fiscalYear names the fiscal year t is in after the calendar year it started in
+func fiscalYear(t time.Time, start time.Month) int {
+	if start > time.January && t.Month() < start {
+		return t.Year() - 1
+	}
+
+	return t.Year()
+}
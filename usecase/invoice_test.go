@@ -0,0 +1,127 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// invoicedOrder is paid 2*50000 - 10000 + 9900 tax + 10000 shipping = 109900
+var invoicedOrder = entity.Order{
+	ID:              1,
+	CustomerEmail:   "buyer@mail.com",
+	Status:          entity.OrderDelivered,
+	ShippingCountry: "ID",
+	ShippingCost:    10000,
+	Lines:           []entity.OrderLine{{ID: 3, BookID: 5, Quantity: 2, UnitPrice: 50000, Discount: 10000, Tax: 9900}},
+	TaxLines:        []entity.TaxLine{{Name: "VAT", Rate: 1100, Taxable: 90000, Amount: 9900}},
+}
+
+var issuedInvoice = entity.Invoice{ID: 4, OrderID: 1, Kind: entity.InvoiceKindInvoice, Number: "INV-2026-000001", Currency: "IDR", Tax: 9900, Total: 109900}
+
+func newInvoiceUsecase(payments []entity.Payment, invoices []entity.Invoice) (usecase.InvoiceUsecase, *[]entity.Invoice) {
+	paymentRepo := new(mocks.PaymentRepository)
+	paymentRepo.On("GetOrderPayments", mock.Anything, int64(1)).Return(payments, nil)
+
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("GetOrder", mock.Anything, int64(1)).Return(invoicedOrder, nil)
+
+	bookRepo := new(mocks.BookRepository)
+	bookRepo.On("GetBook", mock.Anything, int64(5)).Return(entity.Book{ID: 5, Title: "Go Programming"}, nil)
+
+	var created []entity.Invoice
+	invoiceRepo := new(mocks.InvoiceRepository)
+	invoiceRepo.On("GetOrderInvoices", mock.Anything, int64(1)).Return(invoices, nil)
+	invoiceRepo.On("CreateInvoice", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		invoice := args.Get(1).(*entity.Invoice)
+		invoice.ID = issuedInvoice.ID
+		invoice.Number = issuedInvoice.Number
+		created = append(created, *invoice)
+	}).Return(nil)
+
+	invoiceUsecase := usecase.NewInvoiceUsecase(&usecase.InvoiceRepository{InvoiceRepo: invoiceRepo, OrderRepo: orderRepo, PaymentRepo: paymentRepo, BookRepo: bookRepo, FiscalYearStart: time.January})
+	return invoiceUsecase, &created
+}
+
+func TestGetOrderInvoices(t *testing.T) {
+	captured := entity.Payment{ID: 2, OrderID: 1, Status: entity.PaymentCaptured, Currency: "IDR", CapturedAmount: 109900}
+	refunded := captured
+	refunded.RefundedAmount = 54950
+	creditNote := entity.Invoice{ID: 5, OrderID: 1, Kind: entity.InvoiceKindCreditNote, Total: 54950}
+
+	testCases := []struct {
+		name     string
+		payments []entity.Payment
+		invoices []entity.Invoice
+		wantKind []string
+	}{
+		{name: "not paid", payments: []entity.Payment{{ID: 2, OrderID: 1, Status: entity.PaymentAuthorized}}},
+		{name: "paid", payments: []entity.Payment{captured}, wantKind: []string{entity.InvoiceKindInvoice}},
+		{name: "refunded", payments: []entity.Payment{refunded}, invoices: []entity.Invoice{issuedInvoice}, wantKind: []string{entity.InvoiceKindCreditNote}},
+		{name: "refund credited", payments: []entity.Payment{refunded}, invoices: []entity.Invoice{issuedInvoice, creditNote}},
+		{name: "paid and refunded", payments: []entity.Payment{refunded}, wantKind: []string{entity.InvoiceKindInvoice, entity.InvoiceKindCreditNote}},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			invoiceUsecase, created := newInvoiceUsecase(test.payments, test.invoices)
+			_, err := invoiceUsecase.GetOrderInvoices(context.Background(), 1)
+
+			assert.NoError(t, err)
+			assert.Equal(t, len(test.wantKind), len(*created))
+			for i, invoice := range *created {
+				assert.Equal(t, test.wantKind[i], invoice.Kind)
+				assert.Equal(t, time.Now().Year(), invoice.FiscalYear)
+
+				switch invoice.Kind {
+				case entity.InvoiceKindInvoice:
+					assert.Equal(t, "order-1", invoice.Source)
+					assert.Equal(t, "Go Programming", invoice.Lines[0].Description)
+					assert.Equal(t, 90000, invoice.Subtotal)
+					assert.Equal(t, 9900, invoice.Tax)
+					assert.Equal(t, 109900, invoice.Total)
+				case entity.InvoiceKindCreditNote:
+					assert.Equal(t, "order-1-refunded-54950", invoice.Source)
+					assert.Equal(t, issuedInvoice.ID, invoice.CreditedInvoiceID)
+					assert.Equal(t, "Refund of invoice INV-2026-000001", invoice.Lines[0].Description)
+					assert.Equal(t, 4950, invoice.Tax)
+					assert.Equal(t, 50000, invoice.Subtotal)
+					assert.Equal(t, 54950, invoice.Total)
+				}
+			}
+		})
+	}
+}
+
+func TestGetOrderInvoicePDF(t *testing.T) {
+	testCases := []struct {
+		name     string
+		payments []entity.Payment
+		wantPDF  bool
+	}{
+		{name: "paid", payments: []entity.Payment{{ID: 2, OrderID: 1, Status: entity.PaymentCaptured, Currency: "IDR"}}, wantPDF: true},
+		{name: "not paid", payments: nil, wantPDF: false},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			var invoices []entity.Invoice
+			if test.wantPDF {
+				invoices = []entity.Invoice{issuedInvoice}
+			}
+
+			invoiceUsecase, _ := newInvoiceUsecase(test.payments, invoices)
+			data, err := invoiceUsecase.GetOrderInvoicePDF(context.Background(), 1)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantPDF, bytes.HasPrefix(data, []byte("%PDF-")))
+		})
+	}
+}
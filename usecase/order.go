@@ -0,0 +1,460 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+type OrderUsecase interface {
+	GetOrders(ctx context.Context) ([]entity.Order, error)
+	GetOrder(ctx context.Context, id int64) (entity.Order, error)
+	CreateOrder(ctx context.Context, order *entity.Order) error
+	ConfirmOrder(ctx context.Context, id int64, actor string) error
+	CancelOrder(ctx context.Context, id int64) error
+	ExpireOrders(ctx context.Context) (int64, error)
+	FulfillPreOrders(ctx context.Context) (int, error)
+}
+
+// preOrderActor is recorded as who sold the stock of the pre-orders it fulfilled
+const preOrderActor = "preorder"
+
+type OrderRepository struct {
+	OrderRepo     repository.OrderRepository
+	BookRepo      repository.BookRepository
+	SKURepo       repository.SKURepository
+	CategoryRepo  repository.CategoryRepository
+	WarehouseRepo repository.WarehouseRepository
+	PromotionRepo repository.PromotionRepository
+	TaxRepo       repository.TaxRepository
+	ShippingRepo  repository.ShippingRepository
+	BundleRepo    repository.BundleRepository
+	// TaxPolicy tells how orders are taxed
+	TaxPolicy TaxPolicy
+	// ReservationTTL is how long a pending order holds its stock
+	ReservationTTL time.Duration
+	// AllocationStrategy picks the warehouses of orders that do not choose one
+	AllocationStrategy string
+}
+
+func NewOrderUsecase(repo *OrderRepository) OrderUsecase {
+	return &OrderRepository{
+		OrderRepo:          repo.OrderRepo,
+		BookRepo:           repo.BookRepo,
+		SKURepo:            repo.SKURepo,
+		CategoryRepo:       repo.CategoryRepo,
+		WarehouseRepo:      repo.WarehouseRepo,
+		PromotionRepo:      repo.PromotionRepo,
+		TaxRepo:            repo.TaxRepo,
+		ShippingRepo:       repo.ShippingRepo,
+		BundleRepo:         repo.BundleRepo,
+		TaxPolicy:          repo.TaxPolicy,
+		ReservationTTL:     repo.ReservationTTL,
+		AllocationStrategy: repo.AllocationStrategy,
+	}
+}
+
+func (r *OrderRepository) GetOrders(ctx context.Context) ([]entity.Order, error) {
+	res, err := r.OrderRepo.GetOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *OrderRepository) GetOrder(ctx context.Context, id int64) (entity.Order, error) {
+	res, err := r.OrderRepo.GetOrder(ctx, id)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	return res, nil
+}
+
+// CreateOrder places a pending order that reserves its books, in the warehouses chosen by the
+// allocation strategy, until the reservation TTL passes. Its lines are priced at the current
+// book prices less the promotions the order qualifies for, a coupon that cannot be used fails
+// the order, and taxed by the rules of the shipping address. The shipping method charges for
+// the weight of the books to that address. An order of books that are not released yet is a
+// pre-order, it reserves no stock and cannot include books that are out already. Bundles are
+// ordered as their books, at the bundle price. A line ordering an edition is sold at its price
+// and reserves its stock, digital editions are neither allocated nor reserved.
+func (r *OrderRepository) CreateOrder(ctx context.Context, order *entity.Order) error {
+	order.CustomerEmail = strings.TrimSpace(order.CustomerEmail)
+	if order.CustomerEmail == "" {
+		return errors.New("order needs a customer email")
+	}
+
+	if order.AllocationStrategy == "" {
+		order.AllocationStrategy = r.AllocationStrategy
+	}
+
+	if !isAllocationStrategy(order.AllocationStrategy) {
+		return fmt.Errorf("invalid allocation strategy %q", order.AllocationStrategy)
+	}
+
+	if (order.ShippingLatitude == nil) != (order.ShippingLongitude == nil) {
+		return errors.New("order needs both shipping_latitude and shipping_longitude")
+	}
+
+	var err error
+	order.ShippingCountry, order.ShippingRegion, err = normalizeJurisdiction(order.ShippingCountry, order.ShippingRegion)
+	if err != nil {
+		return err
+	}
+
+	lines, bundles, err := expandBundles(ctx, r.BundleRepo, order.Lines)
+	if err != nil {
+		return err
+	}
+
+	lines, err = mergeOrderLines(lines)
+	if err != nil {
+		return err
+	}
+
+	books := make(map[int64]entity.Book)
+	bookIDs := make([]int64, 0, len(lines))
+	for _, line := range lines {
+		if _, ok := books[line.BookID]; ok {
+			continue
+		}
+
+		bookIDs = append(bookIDs, line.BookID)
+		book, err := r.BookRepo.GetBook(ctx, line.BookID)
+		if err != nil {
+			return err
+		}
+
+		if book.ID == 0 {
+			return fmt.Errorf("book ID %d was not found", line.BookID)
+		}
+
+		books[book.ID] = book
+	}
+
+	err = addBookCategories(ctx, r.CategoryRepo, books)
+	if err != nil {
+		return err
+	}
+
+	order.PreOrder = books[lines[0].BookID].PreOrder
+	for _, book := range books {
+		if book.PreOrder != order.PreOrder {
+			return errors.New("books that are not released yet must be pre-ordered separately")
+		}
+	}
+
+	skus, err := lineSKUs(ctx, r.SKURepo, lines)
+	if err != nil {
+		return err
+	}
+
+	if !order.PreOrder {
+		preOrders, err := r.OrderRepo.GetPreOrders(ctx)
+		if err != nil {
+			return err
+		}
+
+		var waiting []entity.OrderLine
+		for _, preOrder := range preOrders {
+			waiting = append(waiting, preOrder.Lines...)
+		}
+
+		waitingSKUs, err := lineSKUs(ctx, r.SKURepo, waiting)
+		if err != nil {
+			return err
+		}
+
+		claimedBooks, claimedSKUs := shippedQuantities(waiting, waitingSKUs)
+		err = checkBookStock(lines, books, skus, claimedBooks)
+		if err != nil {
+			return err
+		}
+
+		err = checkSKUStock(lines, skus, claimedSKUs)
+		if err != nil {
+			return err
+		}
+	}
+
+	cart := entity.Cart{CustomerEmail: order.CustomerEmail, CouponCodes: order.CouponCodes, ShippingCountry: order.ShippingCountry, ShippingRegion: order.ShippingRegion, ShippingMethodID: order.ShippingMethodID, Lines: lines}
+	quote, err := priceCart(ctx, r.PromotionRepo, r.TaxRepo, r.ShippingRepo, r.TaxPolicy, cart, books, skus, bundles)
+	if err != nil {
+		return err
+	}
+
+	if len(quote.RejectedCoupons) > 0 {
+		coupon := quote.RejectedCoupons[0]
+		return fmt.Errorf("coupon %s cannot be used: %s", coupon.Code, coupon.Reason)
+	}
+
+	for i := range lines {
+		lines[i].UnitPrice = quote.Lines[i].UnitPrice
+		lines[i].Discount = quote.Lines[i].Discount
+		lines[i].Tax = quote.Lines[i].Tax
+	}
+	order.Promotions = quote.Promotions
+	order.TaxLines = quote.TaxLines
+	order.PricesIncludeTax = quote.PricesIncludeTax
+	order.ShippingCost = quote.Shipping
+
+	order.Lines = lines
+	if !order.PreOrder {
+		warehouses, err := r.WarehouseRepo.GetWarehouses(ctx)
+		if err != nil {
+			return err
+		}
+
+		stocks, err := r.WarehouseRepo.GetWarehouseStocks(ctx, bookIDs)
+		if err != nil {
+			return err
+		}
+
+		skuStocks, err := r.getWarehouseSKUStocks(ctx, lines, skus)
+		if err != nil {
+			return err
+		}
+
+		err = allocateShippedLines(order.AllocationStrategy, order, skus, warehouses, stocks, skuStocks)
+		if err != nil {
+			return err
+		}
+	}
+
+	order.Status = entity.OrderPending
+	order.ExpiresAt = time.Now().Add(r.ReservationTTL)
+
+	err = r.OrderRepo.CreateOrder(ctx, order)
+	if err != nil {
+		if errors.Is(err, repository.ErrInsufficientStock) {
+			return fmt.Errorf("stock changed while placing the order, please try again: %w", err)
+		}
+		if err == repository.ErrPromotionLimitReached {
+			return fmt.Errorf("a promotion was used up while placing the order, please try again: %w", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *OrderRepository) ConfirmOrder(ctx context.Context, id int64, actor string) error {
+	err := r.OrderRepo.ConfirmOrder(ctx, id, actor)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *OrderRepository) CancelOrder(ctx context.Context, id int64) error {
+	err := r.OrderRepo.CancelOrder(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ExpireOrders releases the stock held by pending orders whose reservation expired
+func (r *OrderRepository) ExpireOrders(ctx context.Context) (int64, error) {
+	return r.OrderRepo.ExpireOrders(ctx, time.Now())
+}
+
+// FulfillPreOrders allocates the stock that arrived to the waiting pre-orders and returns how
+// many were fulfilled. Pre-orders are served in the order they were placed: one that cannot be
+// fulfilled in full keeps the stock of its books for itself, the pre-orders placed after it only
+// get books it does not wait for.
+func (r *OrderRepository) FulfillPreOrders(ctx context.Context) (int, error) {
+	orders, err := r.OrderRepo.GetPreOrders(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(orders) == 0 {
+		return 0, nil
+	}
+
+	var bookIDs []int64
+	var lines []entity.OrderLine
+	seen := make(map[int64]bool)
+	for _, order := range orders {
+		for _, line := range order.Lines {
+			lines = append(lines, line)
+			if !seen[line.BookID] {
+				seen[line.BookID] = true
+				bookIDs = append(bookIDs, line.BookID)
+			}
+		}
+	}
+
+	skus, err := lineSKUs(ctx, r.SKURepo, lines)
+	if err != nil {
+		return 0, err
+	}
+
+	warehouses, err := r.WarehouseRepo.GetWarehouses(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	stocks, err := r.WarehouseRepo.GetWarehouseStocks(ctx, bookIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	skuStocks, err := r.getWarehouseSKUStocks(ctx, lines, skus)
+	if err != nil {
+		return 0, err
+	}
+
+	var fulfilled int
+	waiting := make(map[int64]bool)
+	for i := range orders {
+		order := &orders[i]
+
+		ready := !needsAny(order, waiting) && allocateShippedLines(r.AllocationStrategy, order, skus, warehouses, stocks, skuStocks) == nil
+		if ready {
+			err = r.OrderRepo.FulfillPreOrder(ctx, order, preOrderActor)
+			switch {
+			case err == nil:
+				takeAllocatedStock(order, stocks, skuStocks)
+				fulfilled++
+				continue
+			case err == repository.ErrOrderNotPreOrdered:
+				// fulfilled by a concurrent run
+				continue
+			case !errors.Is(err, repository.ErrInsufficientStock):
+				return fulfilled, err
+			}
+			// an order took the stock meanwhile, the pre-order is retried on the next run
+		}
+
+		for _, line := range order.Lines {
+			waiting[line.BookID] = true
+		}
+	}
+
+	return fulfilled, nil
+}
+
+// shippedQuantities sums the quantities of the lines that take stock by book and by edition,
+// digital editions take none
+func shippedQuantities(lines []entity.OrderLine, skus map[int64]entity.SKU) (map[int64]int, map[int64]int) {
+	books := make(map[int64]int)
+	editions := make(map[int64]int)
+	for _, line := range lines {
+		if skus[line.SKUID].Digital() {
+			continue
+		}
+
+		books[line.BookID] += line.Quantity
+		if line.SKUID != 0 {
+			editions[line.SKUID] += line.Quantity
+		}
+	}
+
+	return books, editions
+}
+
+// checkBookStock makes sure the books have the stock the lines need once the waiting
+// pre-orders, which claimed theirs first, are served
+func checkBookStock(lines []entity.OrderLine, books map[int64]entity.Book, skus map[int64]entity.SKU, claimed map[int64]int) error {
+	quantities, _ := shippedQuantities(lines, skus)
+	for id, quantity := range quantities {
+		book := books[id]
+		if available := book.Stock - book.Reserved - claimed[id]; available < quantity {
+			return fmt.Errorf("not enough stock of book ID %d, %d more copies are needed", id, quantity-available)
+		}
+	}
+
+	return nil
+}
+
+// needsAny tells whether an order has one of the books
+func needsAny(order *entity.Order, books map[int64]bool) bool {
+	for _, line := range order.Lines {
+		if books[line.BookID] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// takeAllocatedStock removes what was allocated to an order from the stock left for the next
+// ones, of the books and of the editions
+func takeAllocatedStock(order *entity.Order, stocks map[int64][]entity.WarehouseStock, skuStocks map[int64][]entity.WarehouseStock) {
+	take := func(stocks []entity.WarehouseStock, allocation entity.Allocation) {
+		for i := range stocks {
+			if stocks[i].WarehouseID == allocation.WarehouseID {
+				stocks[i].Available -= allocation.Quantity
+			}
+		}
+	}
+
+	for _, line := range order.Lines {
+		for _, allocation := range line.Allocations {
+			take(stocks[line.BookID], allocation)
+			if line.SKUID != 0 {
+				take(skuStocks[line.SKUID], allocation)
+			}
+		}
+	}
+}
+
+// getWarehouseSKUStocks returns the stock the warehouses keep of the shipped editions of the
+// lines keyed by SKU id
+func (r *OrderRepository) getWarehouseSKUStocks(ctx context.Context, lines []entity.OrderLine, skus map[int64]entity.SKU) (map[int64][]entity.WarehouseStock, error) {
+	ids := shippedSKUIDs(lines, skus)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	return r.WarehouseRepo.GetWarehouseSKUStocks(ctx, ids)
+}
+
+// mergeOrderLines validates the lines and merges those of the same book, edition and bundle. The
+// lines are sorted by book so concurrent checkouts reserve, and lock, the books in the same order.
+func mergeOrderLines(lines []entity.OrderLine) ([]entity.OrderLine, error) {
+	if len(lines) == 0 {
+		return nil, errors.New("order needs at least one line")
+	}
+
+	type key struct{ bookID, skuID, bundleID int64 }
+	quantities := make(map[key]int)
+	for _, line := range lines {
+		if line.BookID == 0 {
+			return nil, errors.New("order line has no book_id")
+		}
+
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("quantity of book ID %d must be greater than zero", line.BookID)
+		}
+
+		quantities[key{line.BookID, line.SKUID, line.BundleID}] += line.Quantity
+	}
+
+	merged := make([]entity.OrderLine, 0, len(quantities))
+	for k, quantity := range quantities {
+		merged = append(merged, entity.OrderLine{BookID: k.bookID, SKUID: k.skuID, BundleID: k.bundleID, Quantity: quantity})
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].BookID != merged[j].BookID {
+			return merged[i].BookID < merged[j].BookID
+		}
+		if merged[i].SKUID != merged[j].SKUID {
+			return merged[i].SKUID < merged[j].SKUID
+		}
+		return merged[i].BundleID < merged[j].BundleID
+	})
+	return merged, nil
+}
@@ -0,0 +1,613 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/repository"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetOrders(t *testing.T) {
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("GetOrders", mock.Anything).Return([]entity.Order{{ID: 1}}, nil)
+
+	orderUsecase := usecase.NewOrderUsecase(&usecase.OrderRepository{OrderRepo: orderRepo})
+	res, err := orderUsecase.GetOrders(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+}
+
+func TestGetOrder(t *testing.T) {
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("GetOrder", mock.Anything, int64(1)).Return(entity.Order{}, errors.New("Dummy Error"))
+
+	orderUsecase := usecase.NewOrderUsecase(&usecase.OrderRepository{OrderRepo: orderRepo})
+	_, err := orderUsecase.GetOrder(context.Background(), 1)
+
+	assert.Error(t, err)
+}
+
+// noPromotions stubs a promotion repository without any promotion
+func noPromotions() *mocks.PromotionRepository {
+	promotionRepo := new(mocks.PromotionRepository)
+	promotionRepo.On("GetApplicablePromotions", mock.Anything, mock.Anything).Return([]entity.Promotion{}, nil)
+	promotionRepo.On("GetRedemptions", mock.Anything, mock.Anything, mock.Anything).Return(map[int64]entity.Redemptions{}, nil)
+	return promotionRepo
+}
+
+// noCategories stubs a category repository without any book in a category
+func noCategories() *mocks.CategoryRepository {
+	categoryRepo := new(mocks.CategoryRepository)
+	categoryRepo.On("GetBookCategories", mock.Anything, mock.Anything).Return(map[int64][]entity.BookCategory{}, nil)
+	return categoryRepo
+}
+
+func TestCreateOrder(t *testing.T) {
+	testCases := []struct {
+		name         string
+		order        entity.Order
+		wantLines    []entity.OrderLine
+		wantPreOrder bool
+		createErr    error
+		isError      bool
+	}{
+		{
+			name:  "lines are merged and sorted by book",
+			order: entity.Order{CustomerEmail: " jane@example.com ", Lines: []entity.OrderLine{{BookID: 2, Quantity: 1}, {BookID: 1, Quantity: 1}, {BookID: 2, Quantity: 2}}},
+			wantLines: []entity.OrderLine{
+				{BookID: 1, Quantity: 1, UnitPrice: 50000, Allocations: []entity.Allocation{{WarehouseID: 1, Quantity: 1}}},
+				{BookID: 2, Quantity: 3, UnitPrice: 30000, Allocations: []entity.Allocation{{WarehouseID: 1, Quantity: 3}}},
+			},
+			isError: false,
+		},
+		{
+			name:      "unit price is taken from the book",
+			order:     entity.Order{CustomerEmail: "jane@example.com", Lines: []entity.OrderLine{{BookID: 1, Quantity: 1, UnitPrice: 1}}},
+			wantLines: []entity.OrderLine{{BookID: 1, Quantity: 1, UnitPrice: 50000, Allocations: []entity.Allocation{{WarehouseID: 1, Quantity: 1}}}},
+			isError:   false,
+		},
+		{
+			name:    "missing customer email",
+			order:   entity.Order{Lines: []entity.OrderLine{{BookID: 1, Quantity: 1}}},
+			isError: true,
+		},
+		{
+			name:    "no lines",
+			order:   entity.Order{CustomerEmail: "jane@example.com"},
+			isError: true,
+		},
+		{
+			name:    "zero quantity",
+			order:   entity.Order{CustomerEmail: "jane@example.com", Lines: []entity.OrderLine{{BookID: 1}}},
+			isError: true,
+		},
+		{
+			name:    "book not found",
+			order:   entity.Order{CustomerEmail: "jane@example.com", Lines: []entity.OrderLine{{BookID: 9, Quantity: 1}}},
+			isError: true,
+		},
+		{
+			name:    "invalid allocation strategy",
+			order:   entity.Order{CustomerEmail: "jane@example.com", AllocationStrategy: "cheapest", Lines: []entity.OrderLine{{BookID: 1, Quantity: 1}}},
+			isError: true,
+		},
+		{
+			name:    "not enough stock in any warehouse",
+			order:   entity.Order{CustomerEmail: "jane@example.com", Lines: []entity.OrderLine{{BookID: 1, Quantity: 10}}},
+			isError: true,
+		},
+		{
+			name:      "stock reserved concurrently",
+			order:     entity.Order{CustomerEmail: "jane@example.com", Lines: []entity.OrderLine{{BookID: 1, Quantity: 1}}},
+			wantLines: []entity.OrderLine{{BookID: 1, Quantity: 1, UnitPrice: 50000, Allocations: []entity.Allocation{{WarehouseID: 1, Quantity: 1}}}},
+			createErr: fmt.Errorf("book ID 1: %w", repository.ErrInsufficientStock),
+			isError:   true,
+		},
+		{
+			name:         "pre-order reserves nothing",
+			order:        entity.Order{CustomerEmail: "jane@example.com", Lines: []entity.OrderLine{{BookID: 3, Quantity: 2}}},
+			wantLines:    []entity.OrderLine{{BookID: 3, Quantity: 2, UnitPrice: 80000}},
+			wantPreOrder: true,
+			isError:      false,
+		},
+		{
+			name:    "pre-order with released books",
+			order:   entity.Order{CustomerEmail: "jane@example.com", Lines: []entity.OrderLine{{BookID: 1, Quantity: 1}, {BookID: 3, Quantity: 1}}},
+			isError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			orderRepo := new(mocks.OrderRepository)
+			bookRepo := new(mocks.BookRepository)
+			warehouseRepo := new(mocks.WarehouseRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(1)).Return(entity.Book{ID: 1, Price: 50000, Stock: 10}, nil)
+			bookRepo.On("GetBook", mock.Anything, int64(2)).Return(entity.Book{ID: 2, Price: 30000, Stock: 10}, nil)
+			bookRepo.On("GetBook", mock.Anything, int64(3)).Return(entity.Book{ID: 3, Price: 80000, PreOrder: true}, nil)
+			bookRepo.On("GetBook", mock.Anything, int64(9)).Return(entity.Book{}, nil)
+			warehouseRepo.On("GetWarehouses", mock.Anything).Return([]entity.Warehouse{{ID: 1, Code: "MAIN"}}, nil)
+			warehouseRepo.On("GetWarehouseStocks", mock.Anything, mock.Anything).Return(map[int64][]entity.WarehouseStock{
+				1: {{WarehouseID: 1, BookID: 1, Stock: 5, Available: 5}},
+				2: {{WarehouseID: 1, BookID: 2, Stock: 5, Available: 5}},
+			}, nil)
+			orderRepo.On("CreateOrder", mock.Anything, mock.Anything).Return(test.createErr)
+			orderRepo.On("GetPreOrders", mock.Anything).Return([]entity.Order{}, nil)
+
+			orderUsecase := usecase.NewOrderUsecase(&usecase.OrderRepository{OrderRepo: orderRepo, BookRepo: bookRepo, CategoryRepo: noCategories(), WarehouseRepo: warehouseRepo, PromotionRepo: noPromotions(), ReservationTTL: 15 * time.Minute, AllocationStrategy: entity.AllocationMostStock})
+			err := orderUsecase.CreateOrder(context.Background(), &test.order)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.wantLines == nil {
+				orderRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Equal(t, test.wantLines, test.order.Lines)
+			assert.Equal(t, test.wantPreOrder, test.order.PreOrder)
+			assert.Equal(t, "jane@example.com", test.order.CustomerEmail)
+			assert.Equal(t, entity.OrderPending, test.order.Status)
+			assert.Equal(t, entity.AllocationMostStock, test.order.AllocationStrategy)
+			assert.WithinDuration(t, time.Now().Add(15*time.Minute), test.order.ExpiresAt, time.Second)
+		})
+	}
+}
+
+func TestCreateOrderSKULines(t *testing.T) {
+	testCases := []struct {
+		name      string
+		lines     []entity.OrderLine
+		wantLines []entity.OrderLine
+		isError   bool
+	}{
+		{
+			name:      "edition is priced and allocated",
+			lines:     []entity.OrderLine{{BookID: 1, SKUID: 10, Quantity: 2}},
+			wantLines: []entity.OrderLine{{BookID: 1, SKUID: 10, Quantity: 2, UnitPrice: 75000, Allocations: []entity.Allocation{{WarehouseID: 1, Quantity: 2}}}},
+		},
+		{
+			name:      "digital edition is not allocated",
+			lines:     []entity.OrderLine{{BookID: 1, SKUID: 11, Quantity: 1}, {BookID: 1, Quantity: 1}},
+			wantLines: []entity.OrderLine{{BookID: 1, Quantity: 1, UnitPrice: 50000, Allocations: []entity.Allocation{{WarehouseID: 1, Quantity: 1}}}, {BookID: 1, SKUID: 11, Quantity: 1, UnitPrice: 20000}},
+		},
+		{
+			name:    "edition of another book",
+			lines:   []entity.OrderLine{{BookID: 2, SKUID: 10, Quantity: 1}},
+			isError: true,
+		},
+		{
+			name:    "not enough stock of the edition",
+			lines:   []entity.OrderLine{{BookID: 1, SKUID: 10, Quantity: 4}},
+			isError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			orderRepo := new(mocks.OrderRepository)
+			bookRepo := new(mocks.BookRepository)
+			skuRepo := new(mocks.SKURepository)
+			warehouseRepo := new(mocks.WarehouseRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(1)).Return(entity.Book{ID: 1, Price: 50000, Stock: 10}, nil)
+			bookRepo.On("GetBook", mock.Anything, int64(2)).Return(entity.Book{ID: 2, Price: 30000, Stock: 10}, nil)
+			skuRepo.On("GetSKU", mock.Anything, int64(10)).Return(entity.SKU{ID: 10, BookID: 1, Format: entity.FormatHardcover, Price: 75000, Stock: 3}, nil)
+			skuRepo.On("GetSKU", mock.Anything, int64(11)).Return(entity.SKU{ID: 11, BookID: 1, Format: entity.FormatEbook, Price: 20000}, nil)
+			warehouseRepo.On("GetWarehouses", mock.Anything).Return([]entity.Warehouse{{ID: 1, Code: "MAIN"}}, nil)
+			warehouseRepo.On("GetWarehouseStocks", mock.Anything, mock.Anything).Return(map[int64][]entity.WarehouseStock{
+				1: {{WarehouseID: 1, BookID: 1, Stock: 5, Available: 5}},
+			}, nil)
+			warehouseRepo.On("GetWarehouseSKUStocks", mock.Anything, []int64{10}).Return(map[int64][]entity.WarehouseStock{
+				10: {{WarehouseID: 1, BookID: 1, SKUID: 10, Stock: 3, Available: 3}},
+			}, nil)
+			orderRepo.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+			orderRepo.On("GetPreOrders", mock.Anything).Return([]entity.Order{}, nil)
+
+			order := entity.Order{CustomerEmail: "jane@example.com", Lines: test.lines}
+			orderUsecase := usecase.NewOrderUsecase(&usecase.OrderRepository{OrderRepo: orderRepo, BookRepo: bookRepo, CategoryRepo: noCategories(), SKURepo: skuRepo, WarehouseRepo: warehouseRepo, PromotionRepo: noPromotions(), ReservationTTL: 15 * time.Minute, AllocationStrategy: entity.AllocationMostStock})
+			err := orderUsecase.CreateOrder(context.Background(), &order)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				orderRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Equal(t, test.wantLines, order.Lines)
+		})
+	}
+}
+
+func TestCreateOrderLeavesPreOrderedStock(t *testing.T) {
+	// 5 copies of book 1 are in stock, a waiting pre-order needs 4 of them and the ebook of another
+	preOrders := []entity.Order{{ID: 3, Status: entity.OrderPreordered, PreOrder: true, Lines: []entity.OrderLine{
+		{BookID: 1, Quantity: 4},
+		{BookID: 1, SKUID: 11, Quantity: 5},
+	}}}
+
+	testCases := []struct {
+		name     string
+		quantity int
+		isError  bool
+	}{
+		{name: "what the pre-orders leave", quantity: 1},
+		{name: "stock claimed by pre-orders", quantity: 2, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			orderRepo := new(mocks.OrderRepository)
+			bookRepo := new(mocks.BookRepository)
+			skuRepo := new(mocks.SKURepository)
+			warehouseRepo := new(mocks.WarehouseRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(1)).Return(entity.Book{ID: 1, Price: 50000, Stock: 5}, nil)
+			skuRepo.On("GetSKU", mock.Anything, int64(11)).Return(entity.SKU{ID: 11, BookID: 1, Format: entity.FormatEbook, Price: 20000}, nil)
+			warehouseRepo.On("GetWarehouses", mock.Anything).Return([]entity.Warehouse{{ID: 1, Code: "MAIN"}}, nil)
+			warehouseRepo.On("GetWarehouseStocks", mock.Anything, mock.Anything).Return(map[int64][]entity.WarehouseStock{
+				1: {{WarehouseID: 1, BookID: 1, Stock: 5, Available: 5}},
+			}, nil)
+			orderRepo.On("GetPreOrders", mock.Anything).Return(preOrders, nil)
+			orderRepo.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+
+			order := entity.Order{CustomerEmail: "jane@example.com", Lines: []entity.OrderLine{{BookID: 1, Quantity: test.quantity}}}
+			orderUsecase := usecase.NewOrderUsecase(&usecase.OrderRepository{OrderRepo: orderRepo, BookRepo: bookRepo, SKURepo: skuRepo, CategoryRepo: noCategories(), WarehouseRepo: warehouseRepo, PromotionRepo: noPromotions(), ReservationTTL: 15 * time.Minute, AllocationStrategy: entity.AllocationMostStock})
+			err := orderUsecase.CreateOrder(context.Background(), &order)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				orderRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestCreateOrderAllocation(t *testing.T) {
+	jakarta := entity.Warehouse{ID: 1, Code: "JKT", Latitude: -6.2, Longitude: 106.8}
+	surabaya := entity.Warehouse{ID: 2, Code: "SBY", Latitude: -7.25, Longitude: 112.75}
+	lat, lng := -7.3, 112.7
+
+	testCases := []struct {
+		name       string
+		strategy   string
+		noLocation bool
+		stocks     map[int64][]entity.WarehouseStock
+		want       [][]entity.Allocation
+		isError    bool
+	}{
+		{
+			name:     "nearest warehouse with everything",
+			strategy: entity.AllocationNearest,
+			stocks: map[int64][]entity.WarehouseStock{
+				1: {{WarehouseID: 1, Available: 9}, {WarehouseID: 2, Available: 2}},
+				2: {{WarehouseID: 1, Available: 9}, {WarehouseID: 2, Available: 1}},
+			},
+			want: [][]entity.Allocation{{{WarehouseID: 2, Quantity: 2}}, {{WarehouseID: 2, Quantity: 1}}},
+		},
+		{
+			name:     "nearest falls back to another single warehouse",
+			strategy: entity.AllocationNearest,
+			stocks: map[int64][]entity.WarehouseStock{
+				1: {{WarehouseID: 1, Available: 9}, {WarehouseID: 2, Available: 2}},
+				2: {{WarehouseID: 1, Available: 9}},
+			},
+			want: [][]entity.Allocation{{{WarehouseID: 1, Quantity: 2}}, {{WarehouseID: 1, Quantity: 1}}},
+		},
+		{
+			name:       "nearest without destination ranks by stock",
+			strategy:   entity.AllocationNearest,
+			noLocation: true,
+			stocks: map[int64][]entity.WarehouseStock{
+				1: {{WarehouseID: 1, Available: 2}, {WarehouseID: 2, Available: 2}},
+				2: {{WarehouseID: 1, Available: 0}, {WarehouseID: 2, Available: 1}},
+			},
+			want: [][]entity.Allocation{{{WarehouseID: 2, Quantity: 2}}, {{WarehouseID: 2, Quantity: 1}}},
+		},
+		{
+			name:     "most stock",
+			strategy: entity.AllocationMostStock,
+			stocks: map[int64][]entity.WarehouseStock{
+				1: {{WarehouseID: 1, Available: 1}, {WarehouseID: 2, Available: 2}},
+				2: {{WarehouseID: 1, Available: 1}, {WarehouseID: 2, Available: 1}},
+			},
+			want: [][]entity.Allocation{{{WarehouseID: 2, Quantity: 2}}, {{WarehouseID: 2, Quantity: 1}}},
+		},
+		{
+			name:     "most stock splits when no warehouse has everything",
+			strategy: entity.AllocationMostStock,
+			stocks: map[int64][]entity.WarehouseStock{
+				1: {{WarehouseID: 1, Available: 1}, {WarehouseID: 2, Available: 1}},
+				2: {{WarehouseID: 1, Available: 1}},
+			},
+			want: [][]entity.Allocation{{{WarehouseID: 1, Quantity: 1}, {WarehouseID: 2, Quantity: 1}}, {{WarehouseID: 1, Quantity: 1}}},
+		},
+		{
+			name:     "split takes from the nearest first",
+			strategy: entity.AllocationSplit,
+			stocks: map[int64][]entity.WarehouseStock{
+				1: {{WarehouseID: 1, Available: 9}, {WarehouseID: 2, Available: 1}},
+				2: {{WarehouseID: 1, Available: 9}, {WarehouseID: 2, Available: 1}},
+			},
+			want: [][]entity.Allocation{{{WarehouseID: 2, Quantity: 1}, {WarehouseID: 1, Quantity: 1}}, {{WarehouseID: 2, Quantity: 1}}},
+		},
+		{
+			name:     "not enough stock",
+			strategy: entity.AllocationSplit,
+			stocks: map[int64][]entity.WarehouseStock{
+				1: {{WarehouseID: 1, Available: 1}},
+				2: {{WarehouseID: 2, Available: 1}},
+			},
+			isError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			orderRepo := new(mocks.OrderRepository)
+			bookRepo := new(mocks.BookRepository)
+			warehouseRepo := new(mocks.WarehouseRepository)
+			bookRepo.On("GetBook", mock.Anything, mock.Anything).Return(func(_ context.Context, id int64) entity.Book { return entity.Book{ID: id, Stock: 100} }, nil)
+			warehouseRepo.On("GetWarehouses", mock.Anything).Return([]entity.Warehouse{jakarta, surabaya}, nil)
+			warehouseRepo.On("GetWarehouseStocks", mock.Anything, []int64{1, 2}).Return(test.stocks, nil)
+			orderRepo.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+			orderRepo.On("GetPreOrders", mock.Anything).Return([]entity.Order{}, nil)
+
+			order := entity.Order{CustomerEmail: "jane@example.com", AllocationStrategy: test.strategy, Lines: []entity.OrderLine{{BookID: 1, Quantity: 2}, {BookID: 2, Quantity: 1}}}
+			if !test.noLocation {
+				order.ShippingLatitude, order.ShippingLongitude = &lat, &lng
+			}
+
+			orderUsecase := usecase.NewOrderUsecase(&usecase.OrderRepository{OrderRepo: orderRepo, BookRepo: bookRepo, CategoryRepo: noCategories(), WarehouseRepo: warehouseRepo, PromotionRepo: noPromotions(), AllocationStrategy: entity.AllocationMostStock})
+			err := orderUsecase.CreateOrder(context.Background(), &order)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				orderRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+				return
+			}
+
+			for i, line := range order.Lines {
+				assert.Equal(t, test.want[i], line.Allocations)
+			}
+		})
+	}
+}
+
+func TestCreateOrderSKUAllocation(t *testing.T) {
+	jakarta := entity.Warehouse{ID: 1, Code: "JKT", Latitude: -6.2, Longitude: 106.8}
+	surabaya := entity.Warehouse{ID: 2, Code: "SBY", Latitude: -7.25, Longitude: 112.75}
+	lat, lng := -7.3, 112.7
+
+	testCases := []struct {
+		name      string
+		strategy  string
+		skuStocks []entity.WarehouseStock
+		want      [][]entity.Allocation
+		isError   bool
+	}{
+		{
+			name:      "nearest warehouse without the edition is passed over",
+			strategy:  entity.AllocationNearest,
+			skuStocks: []entity.WarehouseStock{{WarehouseID: 1, Available: 2}},
+			want:      [][]entity.Allocation{{{WarehouseID: 1, Quantity: 1}}, {{WarehouseID: 1, Quantity: 2}}},
+		},
+		{
+			name:      "split takes the edition where it is kept",
+			strategy:  entity.AllocationSplit,
+			skuStocks: []entity.WarehouseStock{{WarehouseID: 1, Available: 1}, {WarehouseID: 2, Available: 1}},
+			want:      [][]entity.Allocation{{{WarehouseID: 2, Quantity: 1}}, {{WarehouseID: 2, Quantity: 1}, {WarehouseID: 1, Quantity: 1}}},
+		},
+		{
+			name:      "not enough of the edition in the warehouses",
+			strategy:  entity.AllocationSplit,
+			skuStocks: []entity.WarehouseStock{{WarehouseID: 2, Available: 1}},
+			isError:   true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			orderRepo := new(mocks.OrderRepository)
+			bookRepo := new(mocks.BookRepository)
+			skuRepo := new(mocks.SKURepository)
+			warehouseRepo := new(mocks.WarehouseRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(1)).Return(entity.Book{ID: 1, Stock: 100}, nil)
+			skuRepo.On("GetSKU", mock.Anything, int64(10)).Return(entity.SKU{ID: 10, BookID: 1, Format: entity.FormatHardcover, Stock: 5}, nil)
+			warehouseRepo.On("GetWarehouses", mock.Anything).Return([]entity.Warehouse{jakarta, surabaya}, nil)
+			warehouseRepo.On("GetWarehouseStocks", mock.Anything, []int64{1}).Return(map[int64][]entity.WarehouseStock{
+				1: {{WarehouseID: 1, Available: 9}, {WarehouseID: 2, Available: 9}},
+			}, nil)
+			warehouseRepo.On("GetWarehouseSKUStocks", mock.Anything, []int64{10}).Return(map[int64][]entity.WarehouseStock{10: test.skuStocks}, nil)
+			orderRepo.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+			orderRepo.On("GetPreOrders", mock.Anything).Return([]entity.Order{}, nil)
+
+			order := entity.Order{CustomerEmail: "jane@example.com", AllocationStrategy: test.strategy, ShippingLatitude: &lat, ShippingLongitude: &lng, Lines: []entity.OrderLine{{BookID: 1, Quantity: 1}, {BookID: 1, SKUID: 10, Quantity: 2}}}
+			orderUsecase := usecase.NewOrderUsecase(&usecase.OrderRepository{OrderRepo: orderRepo, BookRepo: bookRepo, CategoryRepo: noCategories(), SKURepo: skuRepo, WarehouseRepo: warehouseRepo, PromotionRepo: noPromotions(), AllocationStrategy: entity.AllocationMostStock})
+			err := orderUsecase.CreateOrder(context.Background(), &order)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				orderRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+				return
+			}
+
+			for i, line := range order.Lines {
+				assert.Equal(t, test.want[i], line.Allocations)
+			}
+		})
+	}
+}
+func TestFulfillPreOrders(t *testing.T) {
+	// pre-orders in the order they were placed, the first one waits for more copies of book 3
+	// than arrived so the third cannot take them, the second one only needs book 4
+	preOrders := []entity.Order{
+		{ID: 1, Status: entity.OrderPreordered, PreOrder: true, Lines: []entity.OrderLine{{ID: 1, BookID: 3, Quantity: 5}}},
+		{ID: 2, Status: entity.OrderPreordered, PreOrder: true, Lines: []entity.OrderLine{{ID: 2, BookID: 4, Quantity: 1}}},
+		{ID: 3, Status: entity.OrderPreordered, PreOrder: true, Lines: []entity.OrderLine{{ID: 3, BookID: 3, Quantity: 1}}},
+		{ID: 4, Status: entity.OrderPreordered, PreOrder: true, Lines: []entity.OrderLine{{ID: 4, BookID: 4, Quantity: 2}}},
+	}
+
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("GetPreOrders", mock.Anything).Return(preOrders, nil)
+	orderRepo.On("FulfillPreOrder", mock.Anything, mock.Anything, "preorder").Return(nil)
+
+	warehouseRepo := new(mocks.WarehouseRepository)
+	warehouseRepo.On("GetWarehouses", mock.Anything).Return([]entity.Warehouse{{ID: 1, Code: "MAIN"}}, nil)
+	warehouseRepo.On("GetWarehouseStocks", mock.Anything, []int64{3, 4}).Return(map[int64][]entity.WarehouseStock{
+		3: {{WarehouseID: 1, BookID: 3, Stock: 2, Available: 2}},
+		4: {{WarehouseID: 1, BookID: 4, Stock: 2, Available: 2}},
+	}, nil)
+
+	orderUsecase := usecase.NewOrderUsecase(&usecase.OrderRepository{OrderRepo: orderRepo, WarehouseRepo: warehouseRepo, AllocationStrategy: entity.AllocationMostStock})
+	fulfilled, err := orderUsecase.FulfillPreOrders(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fulfilled)
+	orderRepo.AssertNumberOfCalls(t, "FulfillPreOrder", 1)
+	orderRepo.AssertCalled(t, "FulfillPreOrder", mock.Anything, mock.MatchedBy(func(order *entity.Order) bool {
+		return order.ID == 2 && order.Lines[0].Allocations[0] == entity.Allocation{WarehouseID: 1, Quantity: 1}
+	}), "preorder")
+}
+
+func TestConfirmOrder(t *testing.T) {
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("ConfirmOrder", mock.Anything, int64(1), "bookstorebe").Return(nil)
+
+	orderUsecase := usecase.NewOrderUsecase(&usecase.OrderRepository{OrderRepo: orderRepo})
+	err := orderUsecase.ConfirmOrder(context.Background(), 1, "bookstorebe")
+
+	assert.NoError(t, err)
+	orderRepo.AssertExpectations(t)
+}
+
+func TestCancelOrder(t *testing.T) {
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("CancelOrder", mock.Anything, int64(1)).Return(errors.New("order is no longer pending"))
+
+	orderUsecase := usecase.NewOrderUsecase(&usecase.OrderRepository{OrderRepo: orderRepo})
+	err := orderUsecase.CancelOrder(context.Background(), 1)
+
+	assert.Error(t, err)
+}
+
+func TestExpireOrders(t *testing.T) {
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("ExpireOrders", mock.Anything, mock.AnythingOfType("time.Time")).Return(int64(2), nil)
+
+	orderUsecase := usecase.NewOrderUsecase(&usecase.OrderRepository{OrderRepo: orderRepo})
+	expired, err := orderUsecase.ExpireOrders(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), expired)
+}
+
+func TestCreateOrderWithBundle(t *testing.T) {
+	// 50000 + 2 x 30000 of books for 99000
+	trilogy := entity.Bundle{ID: 7, Title: "Trilogy", Price: 99000, Items: []entity.BundleItem{{BookID: 1, Quantity: 1}, {BookID: 2, Quantity: 2}}}
+
+	testCases := []struct {
+		name      string
+		stock     int
+		wantLines []entity.OrderLine
+		isError   bool
+	}{
+		{
+			name:  "bundle priced over its books",
+			stock: 3,
+			wantLines: []entity.OrderLine{
+				{BookID: 1, Quantity: 1, UnitPrice: 50000, Allocations: []entity.Allocation{{WarehouseID: 1, Quantity: 1}}},
+				{BookID: 1, BundleID: 7, Quantity: 2, UnitPrice: 50000, Discount: 10000, Allocations: []entity.Allocation{{WarehouseID: 1, Quantity: 2}}},
+				{BookID: 2, BundleID: 7, Quantity: 4, UnitPrice: 30000, Discount: 12000, Allocations: []entity.Allocation{{WarehouseID: 1, Quantity: 4}}},
+			},
+			isError: false,
+		},
+		{name: "bundle and book share the stock", stock: 2, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			orderRepo := new(mocks.OrderRepository)
+			bookRepo := new(mocks.BookRepository)
+			warehouseRepo := new(mocks.WarehouseRepository)
+			bundleRepo := new(mocks.BundleRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(1)).Return(entity.Book{ID: 1, Price: 50000, Stock: 10}, nil)
+			bookRepo.On("GetBook", mock.Anything, int64(2)).Return(entity.Book{ID: 2, Price: 30000, Stock: 10}, nil)
+			bundleRepo.On("GetBundle", mock.Anything, int64(7)).Return(trilogy, nil)
+			warehouseRepo.On("GetWarehouses", mock.Anything).Return([]entity.Warehouse{{ID: 1, Code: "MAIN"}}, nil)
+			warehouseRepo.On("GetWarehouseStocks", mock.Anything, []int64{1, 2}).Return(map[int64][]entity.WarehouseStock{
+				1: {{WarehouseID: 1, BookID: 1, Stock: test.stock, Available: test.stock}},
+				2: {{WarehouseID: 1, BookID: 2, Stock: 4, Available: 4}},
+			}, nil)
+			orderRepo.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+			orderRepo.On("GetPreOrders", mock.Anything).Return([]entity.Order{}, nil)
+
+			order := entity.Order{CustomerEmail: "jane@example.com", Lines: []entity.OrderLine{{BundleID: 7, Quantity: 2}, {BookID: 1, Quantity: 1}}}
+			orderUsecase := usecase.NewOrderUsecase(&usecase.OrderRepository{OrderRepo: orderRepo, BookRepo: bookRepo, CategoryRepo: noCategories(), WarehouseRepo: warehouseRepo, PromotionRepo: noPromotions(), BundleRepo: bundleRepo, AllocationStrategy: entity.AllocationMostStock})
+			err := orderUsecase.CreateOrder(context.Background(), &order)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				orderRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Equal(t, test.wantLines, order.Lines)
+			bundleRepo.AssertNumberOfCalls(t, "GetBundle", 1)
+		})
+	}
+}
+
+func TestCreateOrderWithCoupon(t *testing.T) {
+	autumn := entity.Promotion{ID: 7, Name: "Autumn Sale", Type: entity.PromotionPercentage, Value: 10, Scope: entity.ScopeAll, CouponCode: "AUTUMN10", UsageLimit: 100}
+
+	testCases := []struct {
+		name         string
+		coupons      []string
+		redeemed     int
+		wantDiscount int
+		isError      bool
+	}{
+		{name: "coupon applied", coupons: []string{" autumn10 "}, redeemed: 99, wantDiscount: 10000, isError: false},
+		{name: "coupon used up", coupons: []string{"AUTUMN10"}, redeemed: 100, isError: true},
+		{name: "unknown coupon", coupons: []string{"WINTER"}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			orderRepo := new(mocks.OrderRepository)
+			bookRepo := new(mocks.BookRepository)
+			warehouseRepo := new(mocks.WarehouseRepository)
+			promotionRepo := new(mocks.PromotionRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(1)).Return(entity.Book{ID: 1, Price: 50000, Stock: 10}, nil)
+			warehouseRepo.On("GetWarehouses", mock.Anything).Return([]entity.Warehouse{{ID: 1, Code: "MAIN"}}, nil)
+			warehouseRepo.On("GetWarehouseStocks", mock.Anything, mock.Anything).Return(map[int64][]entity.WarehouseStock{1: {{WarehouseID: 1, BookID: 1, Stock: 5, Available: 5}}}, nil)
+			promotionRepo.On("GetApplicablePromotions", mock.Anything, []string{"AUTUMN10"}).Return([]entity.Promotion{autumn}, nil)
+			promotionRepo.On("GetApplicablePromotions", mock.Anything, mock.Anything).Return([]entity.Promotion{}, nil)
+			promotionRepo.On("GetRedemptions", mock.Anything, mock.Anything, "jane@example.com").Return(map[int64]entity.Redemptions{7: {Total: test.redeemed}}, nil)
+			orderRepo.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+			orderRepo.On("GetPreOrders", mock.Anything).Return([]entity.Order{}, nil)
+
+			order := entity.Order{CustomerEmail: "jane@example.com", CouponCodes: test.coupons, Lines: []entity.OrderLine{{BookID: 1, Quantity: 2}}}
+			orderUsecase := usecase.NewOrderUsecase(&usecase.OrderRepository{OrderRepo: orderRepo, BookRepo: bookRepo, CategoryRepo: noCategories(), WarehouseRepo: warehouseRepo, PromotionRepo: promotionRepo, AllocationStrategy: entity.AllocationMostStock})
+			err := orderUsecase.CreateOrder(context.Background(), &order)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				orderRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Equal(t, test.wantDiscount, order.Lines[0].Discount)
+			assert.Len(t, order.Promotions, 1)
+			assert.Equal(t, "AUTUMN10", order.Promotions[0].CouponCode)
+		})
+	}
+}
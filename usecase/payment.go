@@ -0,0 +1,369 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/payment"
+	"winartodev/book-store-be/repository"
+)
+
+// maxIdempotencyKey is the longest idempotency key a client can send
+const maxIdempotencyKey = 255
+
+// ErrUnknownPayment is returned for webhooks about a payment that was not recorded, the gateway
+// retries them so a webhook that arrives before the payment is saved is not lost
+var ErrUnknownPayment = errors.New("no payment has this reference")
+
+// paymentActor is recorded as who confirmed the orders paid through the gateway
+const paymentActor = "payment"
+
+type PaymentUsecase interface {
+	GetPayment(ctx context.Context, id int64) (entity.Payment, error)
+	GetOrderPayments(ctx context.Context, orderID int64) ([]entity.Payment, error)
+	PayOrder(ctx context.Context, orderID int64, idempotencyKey string, paymentMethod string) (entity.Payment, error)
+	CapturePayment(ctx context.Context, id int64, amount int) (entity.Payment, error)
+	VoidPayment(ctx context.Context, id int64) (entity.Payment, error)
+	RefundPayment(ctx context.Context, id int64, amount int) (entity.Payment, error)
+	HandleWebhook(ctx context.Context, payload []byte, header http.Header) error
+}
+
+type PaymentRepository struct {
+	PaymentRepo repository.PaymentRepository
+	OrderRepo   repository.OrderRepository
+	Gateway     payment.PaymentGateway
+	// Currency is the currency orders are charged in
+	Currency string
+}
+
+func NewPaymentUsecase(repo *PaymentRepository) PaymentUsecase {
+	return &PaymentRepository{
+		PaymentRepo: repo.PaymentRepo,
+		OrderRepo:   repo.OrderRepo,
+		Gateway:     repo.Gateway,
+		Currency:    repo.Currency,
+	}
+}
+
+func (r *PaymentRepository) GetPayment(ctx context.Context, id int64) (entity.Payment, error) {
+	res, err := r.PaymentRepo.GetPayment(ctx, id)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	return res, nil
+}
+
+func (r *PaymentRepository) GetOrderPayments(ctx context.Context, orderID int64) ([]entity.Payment, error) {
+	res, err := r.PaymentRepo.GetOrderPayments(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// PayOrder authorizes the total of a pending order and confirms the order once the gateway
+// authorizes it, which can be later through a webhook. The attempt is recorded before the
+// gateway is asked, a retry with the same idempotency key returns it instead of paying again
+// and asks the gateway again when its first answer was lost.
+func (r *PaymentRepository) PayOrder(ctx context.Context, orderID int64, idempotencyKey string, paymentMethod string) (entity.Payment, error) {
+	idempotencyKey = strings.TrimSpace(idempotencyKey)
+	if idempotencyKey == "" || len(idempotencyKey) > maxIdempotencyKey {
+		return entity.Payment{}, fmt.Errorf("payment needs an idempotency key of at most %d characters", maxIdempotencyKey)
+	}
+
+	p, err := r.PaymentRepo.GetPaymentByKey(ctx, idempotencyKey)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	if p.ID == 0 {
+		p, err = r.createPayment(ctx, orderID, idempotencyKey)
+		if err != nil {
+			return entity.Payment{}, err
+		}
+	}
+
+	if p.OrderID != orderID {
+		return entity.Payment{}, fmt.Errorf("idempotency key was used to pay order ID %d", p.OrderID)
+	}
+
+	// the attempt was already answered by the gateway
+	if p.Status != entity.PaymentPending || p.Reference != "" {
+		return p, nil
+	}
+
+	if strings.TrimSpace(paymentMethod) == "" {
+		return entity.Payment{}, errors.New("payment needs a payment_method")
+	}
+
+	auth, err := r.Gateway.Authorize(ctx, payment.AuthorizeRequest{
+		IdempotencyKey: p.IdempotencyKey,
+		Amount:         p.Amount,
+		Currency:       p.Currency,
+		PaymentMethod:  paymentMethod,
+		Description:    fmt.Sprintf("Order %d", p.OrderID),
+	})
+	if err != nil {
+		return p, fmt.Errorf("payment gateway failed, retry with the same idempotency key: %w", err)
+	}
+
+	p.Reference = auth.Reference
+	switch auth.Status {
+	case entity.PaymentAuthorized:
+		return r.authorized(ctx, p)
+	case entity.PaymentFailed:
+		p.Status, p.FailureReason = entity.PaymentFailed, auth.FailureReason
+	}
+
+	err = r.PaymentRepo.UpdatePayment(ctx, &p, entity.PaymentPending)
+	if err != nil {
+		return p, err
+	}
+
+	return p, nil
+}
+
+// CapturePayment charges an authorized payment once the order is ready to ship. An amount of 0
+// captures all of it, less releases the rest.
+func (r *PaymentRepository) CapturePayment(ctx context.Context, id int64, amount int) (entity.Payment, error) {
+	p, err := r.paymentIn(ctx, id, entity.PaymentAuthorized)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	if amount == 0 {
+		amount = p.Amount
+	}
+
+	if amount < 0 || amount > p.Amount {
+		return p, fmt.Errorf("capture must be between 1 and the authorized %d", p.Amount)
+	}
+
+	err = r.Gateway.Capture(ctx, p.Reference, amount)
+	if err != nil {
+		return p, err
+	}
+
+	p.Status, p.CapturedAmount = entity.PaymentCaptured, amount
+	err = r.PaymentRepo.UpdatePayment(ctx, &p, entity.PaymentAuthorized)
+	if err != nil {
+		return p, err
+	}
+
+	return p, nil
+}
+
+// VoidPayment releases an authorization that will not be captured
+func (r *PaymentRepository) VoidPayment(ctx context.Context, id int64) (entity.Payment, error) {
+	p, err := r.paymentIn(ctx, id, entity.PaymentAuthorized)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	return r.void(ctx, p, "")
+}
+
+// RefundPayment pays back part of what was captured, an amount of 0 refunds all that is left
+func (r *PaymentRepository) RefundPayment(ctx context.Context, id int64, amount int) (entity.Payment, error) {
+	p, err := r.paymentIn(ctx, id, entity.PaymentCaptured)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	left := p.CapturedAmount - p.RefundedAmount
+	if amount == 0 {
+		amount = left
+	}
+
+	if amount < 0 || amount > left {
+		return p, fmt.Errorf("refund must be between 1 and the %d left", left)
+	}
+
+	// the amount is held before the gateway is asked, so concurrent refunds cannot pay back
+	// more than was captured, and each refund is a request of its own to the gateway
+	refund := entity.PaymentRefund{PaymentID: p.ID, Amount: amount}
+	err = r.PaymentRepo.CreateRefund(ctx, &refund)
+	if err != nil {
+		return p, err
+	}
+
+	key := fmt.Sprintf("%s-refund-%d", p.IdempotencyKey, refund.ID)
+	err = r.Gateway.Refund(ctx, p.Reference, amount, key)
+	if err != nil {
+		// the held amount can be refunded again
+		if ferr := r.PaymentRepo.FinishRefund(ctx, &refund, entity.RefundFailed); ferr != nil {
+			return p, ferr
+		}
+		return p, err
+	}
+
+	err = r.PaymentRepo.FinishRefund(ctx, &refund, entity.RefundSucceeded)
+	if err != nil {
+		return p, err
+	}
+
+	return r.PaymentRepo.GetPayment(ctx, id)
+}
+
+// HandleWebhook applies the status the gateway notifies about a payment. Gateways deliver
+// webhooks at least once and in any order, so events that do not move the payment forward
+// from its current status are ignored.
+func (r *PaymentRepository) HandleWebhook(ctx context.Context, payload []byte, header http.Header) error {
+	event, err := r.Gateway.ParseWebhook(payload, header)
+	if err != nil {
+		return err
+	}
+
+	p, err := r.PaymentRepo.GetPaymentByReference(ctx, r.Gateway.Name(), event.Reference)
+	if err != nil {
+		return err
+	}
+
+	if p.ID == 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownPayment, event.Reference)
+	}
+
+	switch {
+	case p.Status == entity.PaymentPending && event.Status == entity.PaymentAuthorized:
+		p, err = r.authorized(ctx, p)
+		if p.Status == entity.PaymentVoided {
+			// the order could not be confirmed and the authorization was released
+			return nil
+		}
+	case p.Status == entity.PaymentPending && event.Status == entity.PaymentFailed:
+		p.Status, p.FailureReason = entity.PaymentFailed, event.FailureReason
+		err = r.PaymentRepo.UpdatePayment(ctx, &p, entity.PaymentPending)
+	case p.Status == entity.PaymentAuthorized && event.Status == entity.PaymentVoided:
+		p.Status, p.FailureReason = entity.PaymentVoided, event.FailureReason
+		err = r.PaymentRepo.UpdatePayment(ctx, &p, entity.PaymentAuthorized)
+	default:
+		return nil
+	}
+
+	if err == repository.ErrPaymentChanged {
+		// another delivery of the event got there first
+		return nil
+	}
+
+	return err
+}
+
+func (r *PaymentRepository) createPayment(ctx context.Context, orderID int64, idempotencyKey string) (entity.Payment, error) {
+	order, err := r.OrderRepo.GetOrder(ctx, orderID)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	if order.ID == 0 {
+		return entity.Payment{}, fmt.Errorf("order ID %d was not found", orderID)
+	}
+
+	if order.Status != entity.OrderPending {
+		return entity.Payment{}, fmt.Errorf("order ID %d is %s, only pending orders can be paid", orderID, order.Status)
+	}
+
+	payments, err := r.PaymentRepo.GetOrderPayments(ctx, orderID)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	for _, p := range payments {
+		if p.Status == entity.PaymentPending || p.Status == entity.PaymentAuthorized {
+			return entity.Payment{}, fmt.Errorf("order ID %d already has payment ID %d %s", orderID, p.ID, p.Status)
+		}
+	}
+
+	p := entity.Payment{
+		OrderID:        orderID,
+		IdempotencyKey: idempotencyKey,
+		Gateway:        r.Gateway.Name(),
+		Status:         entity.PaymentPending,
+		Amount:         orderTotal(order),
+		Currency:       r.Currency,
+	}
+
+	err = r.PaymentRepo.CreatePayment(ctx, &p)
+	if err == repository.ErrDuplicatePayment {
+		// a concurrent retry created it first
+		return r.PaymentRepo.GetPaymentByKey(ctx, idempotencyKey)
+	}
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	return p, nil
+}
+
+// authorized records the authorization of a pending payment and confirms its order. When the
+// order cannot be confirmed anymore, because it expired meanwhile or its stock is gone, the
+// authorization is voided so the customer is not charged for it.
+func (r *PaymentRepository) authorized(ctx context.Context, p entity.Payment) (entity.Payment, error) {
+	p.Status = entity.PaymentAuthorized
+	err := r.PaymentRepo.UpdatePayment(ctx, &p, entity.PaymentPending)
+	if err != nil {
+		return p, err
+	}
+
+	err = r.OrderRepo.ConfirmOrder(ctx, p.OrderID, paymentActor)
+	if err != nil {
+		reason := fmt.Sprintf("order could not be confirmed: %s", err)
+		voided, voidErr := r.void(ctx, p, reason)
+		if voidErr != nil {
+			return voided, fmt.Errorf("%s, and the payment could not be voided: %w", reason, voidErr)
+		}
+		return voided, errors.New(reason)
+	}
+
+	return p, nil
+}
+
+func (r *PaymentRepository) void(ctx context.Context, p entity.Payment, reason string) (entity.Payment, error) {
+	err := r.Gateway.Void(ctx, p.Reference)
+	if err != nil {
+		return p, err
+	}
+
+	p.Status, p.FailureReason = entity.PaymentVoided, reason
+	err = r.PaymentRepo.UpdatePayment(ctx, &p, entity.PaymentAuthorized)
+	if err != nil {
+		return p, err
+	}
+
+	return p, nil
+}
+
+func (r *PaymentRepository) paymentIn(ctx context.Context, id int64, status string) (entity.Payment, error) {
+	p, err := r.PaymentRepo.GetPayment(ctx, id)
+	if err != nil {
+		return entity.Payment{}, err
+	}
+
+	if p.ID == 0 {
+		return entity.Payment{}, fmt.Errorf("payment ID %d was not found", id)
+	}
+
+	if p.Status != status {
+		return entity.Payment{}, fmt.Errorf("payment ID %d is %s, not %s", id, p.Status, status)
+	}
+
+	return p, nil
+}
+
+// orderTotal is what the customer pays for an order: its lines less their discounts, plus the
+// taxes unless the prices include them, plus the shipping
+func orderTotal(order entity.Order) int {
+	total := order.ShippingCost
+	for _, line := range order.Lines {
+		total += line.UnitPrice*line.Quantity - line.Discount
+		if !order.PricesIncludeTax {
+			total += line.Tax
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,197 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/payment"
+	"winartodev/book-store-be/repository"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// payableOrder totals 2*50000 - 10000 + 9900 tax = 99900
+var payableOrder = entity.Order{
+	ID:     1,
+	Status: entity.OrderPending,
+	Lines:  []entity.OrderLine{{BookID: 1, Quantity: 2, UnitPrice: 50000, Discount: 10000, Tax: 9900}},
+}
+
+func TestPayOrder(t *testing.T) {
+	testCases := []struct {
+		name          string
+		orderID       int64
+		existing      entity.Payment
+		order         entity.Order
+		paymentMethod string
+		confirmErr    error
+		wantStatus    string
+		wantConfirm   bool
+		isError       bool
+	}{
+		{name: "authorized", orderID: 1, order: payableOrder, paymentMethod: "tok_visa", wantStatus: entity.PaymentAuthorized, wantConfirm: true},
+		{name: "declined", orderID: 1, order: payableOrder, paymentMethod: payment.FakeDeclined, wantStatus: entity.PaymentFailed},
+		{name: "pending until the webhook", orderID: 1, order: payableOrder, paymentMethod: payment.FakePending, wantStatus: entity.PaymentPending},
+		{name: "order cannot be confirmed", orderID: 1, order: payableOrder, paymentMethod: "tok_visa", confirmErr: repository.ErrOrderNotPending, wantStatus: entity.PaymentVoided, wantConfirm: true, isError: true},
+		{name: "retried attempt", orderID: 1, existing: entity.Payment{ID: 7, OrderID: 1, IdempotencyKey: "key-1", Reference: "fake_9", Status: entity.PaymentAuthorized}, wantStatus: entity.PaymentAuthorized},
+		{name: "key used for another order", orderID: 2, existing: entity.Payment{ID: 7, OrderID: 1, IdempotencyKey: "key-1", Reference: "fake_9", Status: entity.PaymentAuthorized}, isError: true},
+		{name: "order not pending", orderID: 1, order: entity.Order{ID: 1, Status: entity.OrderConfirmed}, paymentMethod: "tok_visa", isError: true},
+		{name: "order not found", orderID: 1, order: entity.Order{}, paymentMethod: "tok_visa", isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			paymentRepo := new(mocks.PaymentRepository)
+			paymentRepo.On("GetPaymentByKey", mock.Anything, "key-1").Return(test.existing, nil)
+			paymentRepo.On("GetOrderPayments", mock.Anything, test.orderID).Return(nil, nil)
+			paymentRepo.On("CreatePayment", mock.Anything, mock.Anything).Return(nil)
+			paymentRepo.On("UpdatePayment", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			orderRepo := new(mocks.OrderRepository)
+			orderRepo.On("GetOrder", mock.Anything, test.orderID).Return(test.order, nil)
+			orderRepo.On("ConfirmOrder", mock.Anything, test.orderID, "payment").Return(test.confirmErr)
+
+			paymentUsecase := usecase.NewPaymentUsecase(&usecase.PaymentRepository{PaymentRepo: paymentRepo, OrderRepo: orderRepo, Gateway: payment.NewFake("whsec"), Currency: "IDR"})
+			p, err := paymentUsecase.PayOrder(context.Background(), test.orderID, "key-1", test.paymentMethod)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.wantStatus, p.Status)
+			if test.existing.ID == 0 && test.wantStatus != "" {
+				assert.Equal(t, 99900, p.Amount)
+				assert.Equal(t, "IDR", p.Currency)
+				assert.NotEmpty(t, p.Reference)
+			}
+			if test.wantConfirm {
+				orderRepo.AssertCalled(t, "ConfirmOrder", mock.Anything, test.orderID, "payment")
+			} else {
+				orderRepo.AssertNotCalled(t, "ConfirmOrder", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRefundPayment(t *testing.T) {
+	ctx := context.Background()
+	gateway := payment.NewFake("whsec")
+	auth, _ := gateway.Authorize(ctx, payment.AuthorizeRequest{IdempotencyKey: "key-1", Amount: 99900, Currency: "IDR", PaymentMethod: "tok_visa"})
+	assert.NoError(t, gateway.Capture(ctx, auth.Reference, 99900))
+
+	captured := entity.Payment{ID: 1, OrderID: 1, IdempotencyKey: "key-1", Reference: auth.Reference, Status: entity.PaymentCaptured, Amount: 99900, CapturedAmount: 99900}
+
+	testCases := []struct {
+		name       string
+		payment    entity.Payment
+		amount     int
+		wantRefund int
+		isError    bool
+	}{
+		{name: "partial refund", payment: captured, amount: 40000, wantRefund: 40000},
+		{name: "more than was captured", payment: captured, amount: 100000, isError: true},
+		{name: "refund all that is left", payment: entity.Payment{ID: 1, IdempotencyKey: "key-1", Reference: auth.Reference, Status: entity.PaymentCaptured, Amount: 99900, CapturedAmount: 99900, RefundedAmount: 40000}, amount: 0, wantRefund: 59900},
+	}
+
+	for i, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			paymentRepo := new(mocks.PaymentRepository)
+			paymentRepo.On("GetPayment", mock.Anything, int64(1)).Return(test.payment, nil)
+			paymentRepo.On("CreateRefund", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				args.Get(1).(*entity.PaymentRefund).ID = int64(i + 1)
+			})
+			paymentRepo.On("FinishRefund", mock.Anything, mock.Anything, entity.RefundSucceeded).Return(nil)
+
+			paymentUsecase := usecase.NewPaymentUsecase(&usecase.PaymentRepository{PaymentRepo: paymentRepo, Gateway: gateway, Currency: "IDR"})
+			_, err := paymentUsecase.RefundPayment(ctx, 1, test.amount)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				paymentRepo.AssertCalled(t, "CreateRefund", mock.Anything, &entity.PaymentRefund{ID: int64(i + 1), PaymentID: 1, Amount: test.wantRefund})
+			} else {
+				paymentRepo.AssertNotCalled(t, "CreateRefund", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+// TestConcurrentRefundPayment refunds twice from the same read of the payment, both refunds
+// reach the gateway under keys of their own
+func TestConcurrentRefundPayment(t *testing.T) {
+	ctx := context.Background()
+	gateway := payment.NewFake("whsec")
+	auth, _ := gateway.Authorize(ctx, payment.AuthorizeRequest{IdempotencyKey: "key-1", Amount: 99900, Currency: "IDR", PaymentMethod: "tok_visa"})
+	assert.NoError(t, gateway.Capture(ctx, auth.Reference, 99900))
+
+	captured := entity.Payment{ID: 1, OrderID: 1, IdempotencyKey: "key-1", Reference: auth.Reference, Status: entity.PaymentCaptured, Amount: 99900, CapturedAmount: 99900}
+
+	var refunds int64
+	paymentRepo := new(mocks.PaymentRepository)
+	paymentRepo.On("GetPayment", mock.Anything, int64(1)).Return(captured, nil)
+	paymentRepo.On("CreateRefund", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		refunds++
+		args.Get(1).(*entity.PaymentRefund).ID = refunds
+	})
+	paymentRepo.On("FinishRefund", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	paymentUsecase := usecase.NewPaymentUsecase(&usecase.PaymentRepository{PaymentRepo: paymentRepo, Gateway: gateway, Currency: "IDR"})
+	_, err := paymentUsecase.RefundPayment(ctx, 1, 40000)
+	assert.NoError(t, err)
+	_, err = paymentUsecase.RefundPayment(ctx, 1, 40000)
+	assert.NoError(t, err)
+
+	// the gateway paid back both refunds, so it has less left than the stale read says and the
+	// held amount of a refund it turns down is given back
+	_, err = paymentUsecase.RefundPayment(ctx, 1, 20000)
+	assert.Error(t, err)
+	paymentRepo.AssertNumberOfCalls(t, "FinishRefund", 3)
+	paymentRepo.AssertCalled(t, "FinishRefund", mock.Anything, &entity.PaymentRefund{ID: 3, PaymentID: 1, Amount: 20000}, entity.RefundFailed)
+}
+
+func TestHandlePaymentWebhook(t *testing.T) {
+	ctx := context.Background()
+	gateway := payment.NewFake("whsec")
+	auth, _ := gateway.Authorize(ctx, payment.AuthorizeRequest{IdempotencyKey: "key-1", Amount: 99900, Currency: "IDR", PaymentMethod: payment.FakePending})
+	payload, header, err := gateway.Resolve(auth.Reference, true)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		header      http.Header
+		payment     entity.Payment
+		wantStatus  string
+		wantConfirm bool
+		wantErr     error
+	}{
+		{name: "authorized", header: header, payment: entity.Payment{ID: 1, OrderID: 1, Reference: auth.Reference, Status: entity.PaymentPending}, wantStatus: entity.PaymentAuthorized, wantConfirm: true},
+		{name: "delivered again", header: header, payment: entity.Payment{ID: 1, OrderID: 1, Reference: auth.Reference, Status: entity.PaymentAuthorized}},
+		{name: "unknown payment", header: header, payment: entity.Payment{}, wantErr: usecase.ErrUnknownPayment},
+		{name: "not signed", header: http.Header{}, wantErr: payment.ErrInvalidSignature},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			paymentRepo := new(mocks.PaymentRepository)
+			paymentRepo.On("GetPaymentByReference", mock.Anything, "fake", auth.Reference).Return(test.payment, nil)
+			paymentRepo.On("UpdatePayment", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			orderRepo := new(mocks.OrderRepository)
+			orderRepo.On("ConfirmOrder", mock.Anything, int64(1), "payment").Return(nil)
+
+			paymentUsecase := usecase.NewPaymentUsecase(&usecase.PaymentRepository{PaymentRepo: paymentRepo, OrderRepo: orderRepo, Gateway: gateway, Currency: "IDR"})
+			err := paymentUsecase.HandleWebhook(ctx, payload, test.header)
+
+			assert.True(t, errors.Is(err, test.wantErr))
+			if test.wantStatus != "" {
+				paymentRepo.AssertCalled(t, "UpdatePayment", mock.Anything, mock.MatchedBy(func(p *entity.Payment) bool { return p.Status == test.wantStatus }), entity.PaymentPending)
+			} else {
+				paymentRepo.AssertNotCalled(t, "UpdatePayment", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if test.wantConfirm {
+				orderRepo.AssertCalled(t, "ConfirmOrder", mock.Anything, int64(1), "payment")
+			}
+		})
+	}
+}
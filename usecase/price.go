@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+type PriceUsecase interface {
+	GetBookPrices(ctx context.Context, bookID int64) ([]entity.BookPrice, error)
+	GetPriceAt(ctx context.Context, bookID int64, at time.Time) (entity.BookPrice, error)
+	SchedulePrice(ctx context.Context, price *entity.BookPrice) error
+	CancelScheduledPrice(ctx context.Context, bookID int64, id int64) error
+}
+
+type PriceRepository struct {
+	PriceRepo repository.PriceRepository
+	BookRepo  repository.BookRepository
+}
+
+func NewPriceUsecase(repo *PriceRepository) PriceUsecase {
+	return &PriceRepository{
+		PriceRepo: repo.PriceRepo,
+		BookRepo:  repo.BookRepo,
+	}
+}
+
+func (r *PriceRepository) GetBookPrices(ctx context.Context, bookID int64) ([]entity.BookPrice, error) {
+	err := r.checkBook(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.PriceRepo.GetBookPrices(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetPriceAt returns the price a book had, has or will have at the given time
+func (r *PriceRepository) GetPriceAt(ctx context.Context, bookID int64, at time.Time) (entity.BookPrice, error) {
+	err := r.checkBook(ctx, bookID)
+	if err != nil {
+		return entity.BookPrice{}, err
+	}
+
+	res, err := r.PriceRepo.GetPriceAt(ctx, bookID, at)
+	if err != nil {
+		return entity.BookPrice{}, err
+	}
+
+	return res, nil
+}
+
+// SchedulePrice adds a price to the history of a book. It takes effect right away when
+// EffectiveFrom is empty, prices cannot be changed in the past.
+func (r *PriceRepository) SchedulePrice(ctx context.Context, price *entity.BookPrice) error {
+	now := time.Now()
+	if price.EffectiveFrom.IsZero() {
+		price.EffectiveFrom = now
+	}
+
+	if price.Price < 0 {
+		return errors.New("price cannot be negative")
+	}
+
+	// a minute of slack for clients whose clock is slightly ahead
+	if price.EffectiveFrom.Before(now.Add(-time.Minute)) {
+		return errors.New("price cannot take effect in the past")
+	}
+
+	if price.EffectiveTo != nil && !price.EffectiveTo.After(price.EffectiveFrom) {
+		return errors.New("effective_to must be after effective_from")
+	}
+
+	err := r.checkBook(ctx, price.BookID)
+	if err != nil {
+		return err
+	}
+
+	err = r.PriceRepo.CreateBookPrice(ctx, price)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PriceRepository) CancelScheduledPrice(ctx context.Context, bookID int64, id int64) error {
+	price, err := r.PriceRepo.GetBookPrice(ctx, bookID, id)
+	if err != nil {
+		return err
+	}
+
+	if price.ID == 0 {
+		return fmt.Errorf("price ID %d of book ID %d was not found", id, bookID)
+	}
+
+	err = r.PriceRepo.DeleteScheduledPrice(ctx, bookID, id, time.Now())
+	if err != nil {
+		if err == repository.ErrPriceInEffect {
+			return fmt.Errorf("price ID %d already took effect and cannot be cancelled", id)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *PriceRepository) checkBook(ctx context.Context, bookID int64) error {
+	book, err := r.BookRepo.GetBook(ctx, bookID)
+	if err != nil {
+		return err
+	}
+
+	if book.ID == 0 {
+		return fmt.Errorf("book ID %d was not found", bookID)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/repository"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSchedulePrice(t *testing.T) {
+	now := time.Now()
+	nextWeek := now.Add(7 * 24 * time.Hour)
+	nextMonth := now.Add(30 * 24 * time.Hour)
+
+	testCases := []struct {
+		name    string
+		price   entity.BookPrice
+		isError bool
+	}{
+		{name: "effective now", price: entity.BookPrice{BookID: 1, Price: 45000}, isError: false},
+		{name: "sale next week", price: entity.BookPrice{BookID: 1, Price: 30000, EffectiveFrom: nextWeek, EffectiveTo: &nextMonth}, isError: false},
+		{name: "negative price", price: entity.BookPrice{BookID: 1, Price: -1}, isError: true},
+		{name: "in the past", price: entity.BookPrice{BookID: 1, Price: 45000, EffectiveFrom: now.Add(-time.Hour)}, isError: true},
+		{name: "ends before it starts", price: entity.BookPrice{BookID: 1, Price: 30000, EffectiveFrom: nextMonth, EffectiveTo: &nextWeek}, isError: true},
+		{name: "book not found", price: entity.BookPrice{BookID: 9, Price: 45000}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			priceRepo := new(mocks.PriceRepository)
+			priceRepo.On("CreateBookPrice", mock.Anything, mock.Anything).Return(nil)
+
+			bookRepo := new(mocks.BookRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(1)).Return(entity.Book{ID: 1}, nil)
+			bookRepo.On("GetBook", mock.Anything, int64(9)).Return(entity.Book{}, nil)
+
+			priceUsecase := usecase.NewPriceUsecase(&usecase.PriceRepository{PriceRepo: priceRepo, BookRepo: bookRepo})
+			err := priceUsecase.SchedulePrice(context.Background(), &test.price)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				priceRepo.AssertNotCalled(t, "CreateBookPrice", mock.Anything, mock.Anything)
+			} else {
+				assert.False(t, test.price.EffectiveFrom.IsZero())
+			}
+		})
+	}
+}
+
+func TestCancelScheduledPrice(t *testing.T) {
+	testCases := []struct {
+		name      string
+		price     entity.BookPrice
+		deleteErr error
+		isError   bool
+	}{
+		{name: "success", price: entity.BookPrice{ID: 4, BookID: 1}, isError: false},
+		{name: "not found", price: entity.BookPrice{}, isError: true},
+		{name: "already in effect", price: entity.BookPrice{ID: 4, BookID: 1}, deleteErr: repository.ErrPriceInEffect, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			priceRepo := new(mocks.PriceRepository)
+			priceRepo.On("GetBookPrice", mock.Anything, int64(1), int64(4)).Return(test.price, nil)
+			priceRepo.On("DeleteScheduledPrice", mock.Anything, int64(1), int64(4), mock.Anything).Return(test.deleteErr)
+
+			priceUsecase := usecase.NewPriceUsecase(&usecase.PriceRepository{PriceRepo: priceRepo})
+			err := priceUsecase.CancelScheduledPrice(context.Background(), 1, 4)
+
+			assert.Equal(t, test.isError, err != nil)
+		})
+	}
+}
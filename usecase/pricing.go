@@ -0,0 +1,389 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+// priceCart quotes the lines of a cart, which have to be merged already, at the prices of the
+// editions they order and of the bundles they were expanded from and with the automatic
+// promotions and those redeemed by its coupon codes, then taxes them and adds the shipping
+func priceCart(ctx context.Context, promotionRepo repository.PromotionRepository, taxRepo repository.TaxRepository, shippingRepo repository.ShippingRepository, policy TaxPolicy, cart entity.Cart, books map[int64]entity.Book, skus map[int64]entity.SKU, bundles map[int64]entity.Bundle) (entity.Quote, error) {
+	codes := normalizeCouponCodes(cart.CouponCodes)
+
+	promotions, err := promotionRepo.GetApplicablePromotions(ctx, codes)
+	if err != nil {
+		return entity.Quote{}, err
+	}
+
+	ids := make([]int64, len(promotions))
+	for i, promotion := range promotions {
+		ids[i] = promotion.ID
+	}
+
+	redemptions, err := promotionRepo.GetRedemptions(ctx, ids, cart.CustomerEmail)
+	if err != nil {
+		return entity.Quote{}, err
+	}
+
+	quote := quoteCart(cart.CustomerEmail, codes, cart.Lines, books, skus, bundles, promotions, redemptions, time.Now())
+
+	err = taxQuote(ctx, taxRepo, policy, &quote, books, skus, cart.ShippingCountry, cart.ShippingRegion)
+	if err != nil {
+		return entity.Quote{}, err
+	}
+
+	err = shippingQuote(ctx, shippingRepo, &quote, books, skus, cart)
+	if err != nil {
+		return entity.Quote{}, err
+	}
+
+	return quote, nil
+}
+
+// quoteCart applies the best combination of promotions to the lines: either all the eligible
+// stackable promotions one after the other, by priority, or the single non-stackable one that
+// takes off the most. Every promotion discounts what the ones before it left of the lines, the
+// books of a bundle start at the bundle price and an edition is sold at its own price.
+func quoteCart(customerEmail string, codes []string, lines []entity.OrderLine, books map[int64]entity.Book, skus map[int64]entity.SKU, bundles map[int64]entity.Bundle, promotions []entity.Promotion, redemptions map[int64]entity.Redemptions, now time.Time) entity.Quote {
+	var quote entity.Quote
+	for _, line := range lines {
+		price := books[line.BookID].Price
+		if sku, ok := skus[line.SKUID]; ok {
+			price = sku.Price
+		}
+
+		subtotal := price * line.Quantity
+		quote.Lines = append(quote.Lines, entity.QuoteLine{BookID: line.BookID, SKUID: line.SKUID, BundleID: line.BundleID, Quantity: line.Quantity, UnitPrice: price, Subtotal: subtotal, Total: subtotal})
+		quote.Subtotal += subtotal
+	}
+
+	bundled := bundleDiscounts(quote.Lines, bundles)
+	priced := make([]entity.QuoteLine, len(quote.Lines))
+	for i, line := range quote.Lines {
+		line.Subtotal -= bundled[i]
+		priced[i] = line
+	}
+
+	rejected := make(map[string]string)
+	for _, code := range codes {
+		rejected[code] = "unknown coupon code"
+	}
+
+	var stackable, exclusive []entity.Promotion
+	for _, promotion := range promotions {
+		if promotion.CouponCode != "" {
+			if _, ok := rejected[promotion.CouponCode]; !ok {
+				continue
+			}
+		}
+
+		reason := promotionIneligibility(promotion, customerEmail, priced, books, redemptions[promotion.ID], now)
+		if reason != "" {
+			if promotion.CouponCode != "" {
+				rejected[promotion.CouponCode] = reason
+			}
+			continue
+		}
+
+		if promotion.CouponCode != "" {
+			rejected[promotion.CouponCode] = "cannot be combined with the promotions applied to the cart"
+		}
+
+		if promotion.Stackable {
+			stackable = append(stackable, promotion)
+		} else {
+			exclusive = append(exclusive, promotion)
+		}
+	}
+
+	best, bestApplied := applyPromotions(stackable, priced, books)
+	for _, promotion := range exclusive {
+		discounts, applied := applyPromotions([]entity.Promotion{promotion}, priced, books)
+		if sum(discounts) > sum(best) {
+			best, bestApplied = discounts, applied
+		}
+	}
+
+	for i := range quote.Lines {
+		quote.Lines[i].Discount = bundled[i] + best[i]
+		quote.Lines[i].Total = quote.Lines[i].Subtotal - quote.Lines[i].Discount
+		quote.Discount += quote.Lines[i].Discount
+	}
+	quote.Total = quote.Subtotal - quote.Discount
+	quote.Promotions = bestApplied
+
+	for _, applied := range bestApplied {
+		delete(rejected, applied.CouponCode)
+	}
+
+	for _, code := range codes {
+		if reason, ok := rejected[code]; ok {
+			quote.RejectedCoupons = append(quote.RejectedCoupons, entity.RejectedCoupon{Code: code, Reason: reason})
+		}
+	}
+
+	return quote
+}
+
+// bundleDiscounts takes what the books of every bundle cost separately over the bundle price
+// off their lines, spread in proportion to their prices. A bundle that costs more than its
+// books is sold at their price.
+func bundleDiscounts(lines []entity.QuoteLine, bundles map[int64]entity.Bundle) []int {
+	discounts := make([]int, len(lines))
+	for _, bundle := range bundles {
+		copies := make(map[int64]int)
+		for _, item := range bundle.Items {
+			copies[item.BookID] = item.Quantity
+		}
+
+		quantity := 0
+		remaining := make([]int, len(lines))
+		for i, line := range lines {
+			if line.BundleID == bundle.ID {
+				remaining[i] = line.Subtotal
+				quantity = line.Quantity / copies[line.BookID]
+			}
+		}
+
+		saving := sum(remaining) - bundle.Price*quantity
+		if saving <= 0 {
+			continue
+		}
+
+		off := promotionDiscounts(entity.Promotion{Type: entity.PromotionFixed, Value: saving}, lines, remaining)
+		for i := range discounts {
+			discounts[i] += off[i]
+		}
+	}
+
+	return discounts
+}
+
+// promotionIneligibility tells why a promotion cannot apply to the lines, or returns nothing when it can
+func promotionIneligibility(promotion entity.Promotion, customerEmail string, lines []entity.QuoteLine, books map[int64]entity.Book, redemptions entity.Redemptions, now time.Time) string {
+	if promotion.StartsAt != nil && now.Before(*promotion.StartsAt) {
+		return "promotion has not started yet"
+	}
+
+	if promotion.EndsAt != nil && !now.Before(*promotion.EndsAt) {
+		return "promotion has ended"
+	}
+
+	if promotion.UsageLimit > 0 && redemptions.Total >= promotion.UsageLimit {
+		return "promotion has been fully redeemed"
+	}
+
+	if promotion.PerCustomerLimit > 0 && customerEmail != "" && redemptions.Customer >= promotion.PerCustomerLimit {
+		return "customer already used this promotion as often as allowed"
+	}
+
+	spend := 0
+	for _, line := range lines {
+		if inPromotionScope(promotion, books[line.BookID]) {
+			spend += line.Subtotal
+		}
+	}
+
+	if spend == 0 {
+		return "no book in the cart qualifies"
+	}
+
+	if spend < promotion.MinSpend {
+		return fmt.Sprintf("spend at least %d on qualifying books", promotion.MinSpend)
+	}
+
+	return ""
+}
+
+// applyPromotions applies the promotions one after the other and returns the discount of
+// every line and the promotions that took something off
+func applyPromotions(promotions []entity.Promotion, lines []entity.QuoteLine, books map[int64]entity.Book) ([]int, []entity.AppliedPromotion) {
+	discounts := make([]int, len(lines))
+	var applied []entity.AppliedPromotion
+
+	for _, promotion := range promotions {
+		remaining := make([]int, len(lines))
+		for i, line := range lines {
+			if inPromotionScope(promotion, books[line.BookID]) {
+				remaining[i] = line.Subtotal - discounts[i]
+			}
+		}
+
+		off := promotionDiscounts(promotion, lines, remaining)
+		if sum(off) == 0 {
+			continue
+		}
+
+		for i := range discounts {
+			discounts[i] += off[i]
+		}
+
+		applied = append(applied, entity.AppliedPromotion{
+			PromotionID: promotion.ID,
+			Name:        promotion.Name,
+			CouponCode:  promotion.CouponCode,
+			Description: describePromotion(promotion),
+			Discount:    sum(off),
+		})
+	}
+
+	return discounts, applied
+}
+
+// promotionDiscounts computes what a promotion takes off every line given what is left of
+// them, lines out of its scope have nothing left
+func promotionDiscounts(promotion entity.Promotion, lines []entity.QuoteLine, remaining []int) []int {
+	off := make([]int, len(lines))
+
+	switch promotion.Type {
+	case entity.PromotionPercentage:
+		for i := range lines {
+			off[i] = remaining[i] * promotion.Value / 100
+		}
+
+	case entity.PromotionFixed:
+		total := sum(remaining)
+		if total == 0 {
+			break
+		}
+
+		amount := promotion.Value
+		if amount > total {
+			amount = total
+		}
+
+		// spread over the lines in proportion to what is left of them, the rounding
+		// leftovers go to the first lines with room for them
+		spread := 0
+		for i := range lines {
+			off[i] = amount * remaining[i] / total
+			spread += off[i]
+		}
+		for i := range lines {
+			if spread == amount {
+				break
+			}
+			if off[i] < remaining[i] {
+				off[i]++
+				spread++
+			}
+		}
+
+	case entity.PromotionBuyXGetY:
+		units := 0
+		var order []int
+		for i := range lines {
+			if remaining[i] > 0 {
+				units += lines[i].Quantity
+				order = append(order, i)
+			}
+		}
+
+		// the cheapest books are the free ones
+		sort.SliceStable(order, func(a, b int) bool {
+			return remaining[order[a]]/lines[order[a]].Quantity < remaining[order[b]]/lines[order[b]].Quantity
+		})
+
+		free := units / (promotion.BuyQuantity + promotion.GetQuantity) * promotion.GetQuantity
+		for _, i := range order {
+			if free == 0 {
+				break
+			}
+
+			quantity := lines[i].Quantity
+			if quantity > free {
+				quantity = free
+			}
+
+			off[i] = remaining[i] / lines[i].Quantity * quantity
+			free -= quantity
+		}
+	}
+
+	return off
+}
+
+func inPromotionScope(promotion entity.Promotion, book entity.Book) bool {
+	var ids []int64
+	switch promotion.Scope {
+	case entity.ScopeAll:
+		return true
+	case entity.ScopeBook:
+		ids = []int64{book.ID}
+	case entity.ScopeCategory:
+		ids = []int64{book.CategoryID}
+		for _, category := range book.Categories {
+			ids = append(ids, category.CategoryID)
+		}
+	case entity.ScopePublisher:
+		ids = []int64{book.PublisherID}
+	}
+
+	for _, scopeID := range promotion.ScopeIDs {
+		for _, id := range ids {
+			if scopeID == id {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// describePromotion explains in a few words what a promotion gives
+func describePromotion(promotion entity.Promotion) string {
+	scope := map[string]string{
+		entity.ScopeAll:       "all books",
+		entity.ScopeBook:      "selected books",
+		entity.ScopeCategory:  "books in selected categories",
+		entity.ScopePublisher: "books from selected publishers",
+	}[promotion.Scope]
+
+	var description string
+	switch promotion.Type {
+	case entity.PromotionPercentage:
+		description = fmt.Sprintf("%d%% off %s", promotion.Value, scope)
+	case entity.PromotionFixed:
+		description = fmt.Sprintf("%d off %s", promotion.Value, scope)
+	case entity.PromotionBuyXGetY:
+		description = fmt.Sprintf("buy %d get %d free on %s", promotion.BuyQuantity, promotion.GetQuantity, scope)
+	}
+
+	if promotion.MinSpend > 0 {
+		description += fmt.Sprintf(" when spending at least %d", promotion.MinSpend)
+	}
+
+	return description
+}
+
+// normalizeCouponCodes upper-cases the codes and drops blanks and duplicates
+func normalizeCouponCodes(codes []string) []string {
+	seen := make(map[string]bool)
+	var normalized []string
+	for _, code := range codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" || seen[code] {
+			continue
+		}
+
+		seen[code] = true
+		normalized = append(normalized, code)
+	}
+
+	return normalized
+}
+
+func sum(values []int) int {
+	total := 0
+	for _, value := range values {
+		total += value
+	}
+
+	return total
+}
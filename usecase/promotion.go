@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+type PromotionUsecase interface {
+	GetPromotions(ctx context.Context) ([]entity.Promotion, error)
+	GetPromotion(ctx context.Context, id int64) (entity.Promotion, error)
+	CreatePromotion(ctx context.Context, promotion *entity.Promotion) error
+	UpdatePromotion(ctx context.Context, id int64, promotion *entity.Promotion) error
+	DeletePromotion(ctx context.Context, id int64) error
+	QuoteCart(ctx context.Context, cart *entity.Cart) (entity.Quote, error)
+}
+
+type PromotionRepository struct {
+	PromotionRepo repository.PromotionRepository
+	BookRepo      repository.BookRepository
+	SKURepo       repository.SKURepository
+	CategoryRepo  repository.CategoryRepository
+	TaxRepo       repository.TaxRepository
+	ShippingRepo  repository.ShippingRepository
+	BundleRepo    repository.BundleRepository
+	TaxPolicy     TaxPolicy
+}
+
+func NewPromotionUsecase(repo *PromotionRepository) PromotionUsecase {
+	return &PromotionRepository{
+		PromotionRepo: repo.PromotionRepo,
+		BookRepo:      repo.BookRepo,
+		SKURepo:       repo.SKURepo,
+		CategoryRepo:  repo.CategoryRepo,
+		TaxRepo:       repo.TaxRepo,
+		ShippingRepo:  repo.ShippingRepo,
+		BundleRepo:    repo.BundleRepo,
+		TaxPolicy:     repo.TaxPolicy,
+	}
+}
+
+func (r *PromotionRepository) GetPromotions(ctx context.Context) ([]entity.Promotion, error) {
+	res, err := r.PromotionRepo.GetPromotions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *PromotionRepository) GetPromotion(ctx context.Context, id int64) (entity.Promotion, error) {
+	res, err := r.PromotionRepo.GetPromotion(ctx, id)
+	if err != nil {
+		return entity.Promotion{}, err
+	}
+
+	return res, nil
+}
+
+func (r *PromotionRepository) CreatePromotion(ctx context.Context, promotion *entity.Promotion) error {
+	err := validatePromotion(promotion)
+	if err != nil {
+		return err
+	}
+
+	err = r.PromotionRepo.CreatePromotion(ctx, promotion)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PromotionRepository) UpdatePromotion(ctx context.Context, id int64, promotion *entity.Promotion) error {
+	err := validatePromotion(promotion)
+	if err != nil {
+		return err
+	}
+
+	err = r.PromotionRepo.UpdatePromotion(ctx, id, promotion)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PromotionRepository) DeletePromotion(ctx context.Context, id int64) error {
+	err := r.PromotionRepo.DeletePromotion(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// QuoteCart prices a cart at the current book, edition and bundle prices and explains the
+// promotions applied to it
+func (r *PromotionRepository) QuoteCart(ctx context.Context, cart *entity.Cart) (entity.Quote, error) {
+	cart.CustomerEmail = strings.TrimSpace(cart.CustomerEmail)
+
+	var err error
+	cart.ShippingCountry, cart.ShippingRegion, err = normalizeJurisdiction(cart.ShippingCountry, cart.ShippingRegion)
+	if err != nil {
+		return entity.Quote{}, err
+	}
+
+	lines, bundles, err := expandBundles(ctx, r.BundleRepo, cart.Lines)
+	if err != nil {
+		return entity.Quote{}, err
+	}
+
+	lines, err = mergeOrderLines(lines)
+	if err != nil {
+		return entity.Quote{}, err
+	}
+	cart.Lines = lines
+
+	books := make(map[int64]entity.Book)
+	for _, line := range lines {
+		if _, ok := books[line.BookID]; ok {
+			continue
+		}
+
+		book, err := r.BookRepo.GetBook(ctx, line.BookID)
+		if err != nil {
+			return entity.Quote{}, err
+		}
+
+		if book.ID == 0 {
+			return entity.Quote{}, fmt.Errorf("book ID %d was not found", line.BookID)
+		}
+
+		books[book.ID] = book
+	}
+
+	err = addBookCategories(ctx, r.CategoryRepo, books)
+	if err != nil {
+		return entity.Quote{}, err
+	}
+
+	skus, err := lineSKUs(ctx, r.SKURepo, lines)
+	if err != nil {
+		return entity.Quote{}, err
+	}
+
+	return priceCart(ctx, r.PromotionRepo, r.TaxRepo, r.ShippingRepo, r.TaxPolicy, *cart, books, skus, bundles)
+}
+
+func validatePromotion(promotion *entity.Promotion) error {
+	promotion.Name = strings.TrimSpace(promotion.Name)
+	promotion.CouponCode = strings.ToUpper(strings.TrimSpace(promotion.CouponCode))
+
+	if promotion.Name == "" {
+		return errors.New("promotion needs a name")
+	}
+
+	switch promotion.Type {
+	case entity.PromotionPercentage:
+		if promotion.Value < 1 || promotion.Value > 100 {
+			return errors.New("percentage must be between 1 and 100")
+		}
+	case entity.PromotionFixed:
+		if promotion.Value <= 0 {
+			return errors.New("fixed discount must be greater than zero")
+		}
+	case entity.PromotionBuyXGetY:
+		if promotion.BuyQuantity < 1 || promotion.GetQuantity < 1 {
+			return errors.New("buy_quantity and get_quantity must be at least 1")
+		}
+		promotion.Value = 0
+	default:
+		return fmt.Errorf("invalid promotion type %q", promotion.Type)
+	}
+
+	if promotion.Type != entity.PromotionBuyXGetY {
+		promotion.BuyQuantity = 0
+		promotion.GetQuantity = 0
+	}
+
+	switch promotion.Scope {
+	case "", entity.ScopeAll:
+		promotion.Scope = entity.ScopeAll
+		promotion.ScopeIDs = nil
+	case entity.ScopeBook, entity.ScopeCategory, entity.ScopePublisher:
+		if len(promotion.ScopeIDs) == 0 {
+			return fmt.Errorf("promotion scoped to %s needs scope_ids", promotion.Scope)
+		}
+	default:
+		return fmt.Errorf("invalid promotion scope %q", promotion.Scope)
+	}
+
+	if strings.ContainsAny(promotion.CouponCode, " \t") {
+		return errors.New("coupon code cannot contain spaces")
+	}
+
+	if promotion.MinSpend < 0 || promotion.UsageLimit < 0 || promotion.PerCustomerLimit < 0 {
+		return errors.New("min_spend and usage limits cannot be negative")
+	}
+
+	if promotion.StartsAt != nil && promotion.EndsAt != nil && !promotion.EndsAt.After(*promotion.StartsAt) {
+		return errors.New("ends_at must be after starts_at")
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreatePromotion(t *testing.T) {
+	testCases := []struct {
+		name      string
+		promotion entity.Promotion
+		isError   bool
+	}{
+		{name: "percentage on a category", promotion: entity.Promotion{Name: "Fiction Week", Type: entity.PromotionPercentage, Value: 20, Scope: entity.ScopeCategory, ScopeIDs: []int64{3}}, isError: false},
+		{name: "coupon", promotion: entity.Promotion{Name: "Welcome", Type: entity.PromotionFixed, Value: 10000, CouponCode: " welcome ", PerCustomerLimit: 1}, isError: false},
+		{name: "buy two get one", promotion: entity.Promotion{Name: "3 for 2", Type: entity.PromotionBuyXGetY, BuyQuantity: 2, GetQuantity: 1}, isError: false},
+		{name: "missing name", promotion: entity.Promotion{Type: entity.PromotionPercentage, Value: 20}, isError: true},
+		{name: "percentage above 100", promotion: entity.Promotion{Name: "Too Good", Type: entity.PromotionPercentage, Value: 120}, isError: true},
+		{name: "unknown type", promotion: entity.Promotion{Name: "Mystery", Type: "mystery", Value: 1}, isError: true},
+		{name: "scope without ids", promotion: entity.Promotion{Name: "Publisher Days", Type: entity.PromotionPercentage, Value: 10, Scope: entity.ScopePublisher}, isError: true},
+		{name: "coupon with spaces", promotion: entity.Promotion{Name: "Welcome", Type: entity.PromotionFixed, Value: 10000, CouponCode: "WEL COME"}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			promotionRepo := new(mocks.PromotionRepository)
+			promotionRepo.On("CreatePromotion", mock.Anything, mock.Anything).Return(nil)
+
+			promotionUsecase := usecase.NewPromotionUsecase(&usecase.PromotionRepository{PromotionRepo: promotionRepo})
+			err := promotionUsecase.CreatePromotion(context.Background(), &test.promotion)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				promotionRepo.AssertNotCalled(t, "CreatePromotion", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NotEmpty(t, test.promotion.Scope)
+			if test.promotion.CouponCode != "" {
+				assert.Equal(t, "WELCOME", test.promotion.CouponCode)
+			}
+		})
+	}
+}
+
+func TestQuoteCart(t *testing.T) {
+	yesterday := time.Now().Add(-24 * time.Hour)
+	tomorrow := time.Now().Add(24 * time.Hour)
+
+	// book 1 is fiction (category 3) from publisher 1, book 2 is a textbook (category 5) from publisher 2,
+	// book 3 is a textbook that is also fiction
+	books := map[int64]entity.Book{
+		1: {ID: 1, CategoryID: 3, PublisherID: 1, Price: 50000},
+		2: {ID: 2, CategoryID: 5, PublisherID: 2, Price: 30000},
+		3: {ID: 3, CategoryID: 5, PublisherID: 2, Price: 40000},
+	}
+	categories := map[int64][]entity.BookCategory{
+		3: {{CategoryID: 5, Primary: true}, {CategoryID: 3}},
+	}
+
+	fictionSale := entity.Promotion{ID: 1, Name: "Fiction Week", Type: entity.PromotionPercentage, Value: 20, Scope: entity.ScopeCategory, ScopeIDs: []int64{3}, Stackable: true, Priority: 10}
+	welcome := entity.Promotion{ID: 2, Name: "Welcome", Type: entity.PromotionFixed, Value: 10000, Scope: entity.ScopeAll, MinSpend: 100000, CouponCode: "WELCOME", PerCustomerLimit: 1, Stackable: true}
+	threeForTwo := entity.Promotion{ID: 3, Name: "3 for 2", Type: entity.PromotionBuyXGetY, BuyQuantity: 2, GetQuantity: 1, Scope: entity.ScopeAll}
+	halfPrice := entity.Promotion{ID: 4, Name: "Half Price", Type: entity.PromotionPercentage, Value: 50, Scope: entity.ScopePublisher, ScopeIDs: []int64{2}, CouponCode: "HALF"}
+	expired := entity.Promotion{ID: 5, Name: "Summer", Type: entity.PromotionPercentage, Value: 30, Scope: entity.ScopeAll, CouponCode: "SUMMER", StartsAt: &yesterday, EndsAt: &yesterday}
+	upcoming := entity.Promotion{ID: 6, Name: "Winter", Type: entity.PromotionPercentage, Value: 30, Scope: entity.ScopeAll, StartsAt: &tomorrow}
+
+	testCases := []struct {
+		name           string
+		cart           entity.Cart
+		promotions     []entity.Promotion
+		redemptions    map[int64]entity.Redemptions
+		wantDiscounts  []int
+		wantPromotions []int64
+		wantRejected   map[string]string
+	}{
+		{
+			name:           "percentage scoped to a category",
+			cart:           entity.Cart{Lines: []entity.OrderLine{{BookID: 1, Quantity: 1}, {BookID: 2, Quantity: 1}}},
+			promotions:     []entity.Promotion{fictionSale, upcoming},
+			wantDiscounts:  []int{10000, 0},
+			wantPromotions: []int64{1},
+		},
+		{
+			name:           "category scope covers secondary categories",
+			cart:           entity.Cart{Lines: []entity.OrderLine{{BookID: 2, Quantity: 1}, {BookID: 3, Quantity: 1}}},
+			promotions:     []entity.Promotion{fictionSale},
+			wantDiscounts:  []int{0, 8000},
+			wantPromotions: []int64{1},
+		},
+		{
+			name:           "stackable promotions apply one after the other",
+			cart:           entity.Cart{CustomerEmail: "jane@example.com", CouponCodes: []string{"welcome"}, Lines: []entity.OrderLine{{BookID: 1, Quantity: 2}, {BookID: 2, Quantity: 1}}},
+			promotions:     []entity.Promotion{fictionSale, welcome},
+			wantDiscounts:  []int{20000 + 7273, 2727},
+			wantPromotions: []int64{1, 2},
+		},
+		{
+			name:          "minimum spend not met",
+			cart:          entity.Cart{CouponCodes: []string{"WELCOME"}, Lines: []entity.OrderLine{{BookID: 2, Quantity: 1}}},
+			promotions:    []entity.Promotion{welcome},
+			wantDiscounts: []int{0},
+			wantRejected:  map[string]string{"WELCOME": "spend at least 100000 on qualifying books"},
+		},
+		{
+			name:          "per customer limit reached",
+			cart:          entity.Cart{CustomerEmail: "jane@example.com", CouponCodes: []string{"WELCOME"}, Lines: []entity.OrderLine{{BookID: 1, Quantity: 3}}},
+			promotions:    []entity.Promotion{welcome},
+			redemptions:   map[int64]entity.Redemptions{2: {Total: 40, Customer: 1}},
+			wantDiscounts: []int{0},
+			wantRejected:  map[string]string{"WELCOME": "customer already used this promotion as often as allowed"},
+		},
+		{
+			name:           "cheapest book is free",
+			cart:           entity.Cart{Lines: []entity.OrderLine{{BookID: 1, Quantity: 2}, {BookID: 2, Quantity: 1}}},
+			promotions:     []entity.Promotion{threeForTwo},
+			wantDiscounts:  []int{0, 30000},
+			wantPromotions: []int64{3},
+		},
+		{
+			name:           "best exclusive promotion beats the stackable ones",
+			cart:           entity.Cart{Lines: []entity.OrderLine{{BookID: 1, Quantity: 1}, {BookID: 2, Quantity: 2}}},
+			promotions:     []entity.Promotion{fictionSale, threeForTwo},
+			wantDiscounts:  []int{0, 30000},
+			wantPromotions: []int64{3},
+		},
+		{
+			name:           "coupon that does not combine",
+			cart:           entity.Cart{CouponCodes: []string{"HALF"}, Lines: []entity.OrderLine{{BookID: 1, Quantity: 3}, {BookID: 2, Quantity: 1}}},
+			promotions:     []entity.Promotion{fictionSale, halfPrice},
+			wantDiscounts:  []int{30000, 0},
+			wantPromotions: []int64{1},
+			wantRejected:   map[string]string{"HALF": "cannot be combined with the promotions applied to the cart"},
+		},
+		{
+			name:          "expired and unknown coupons",
+			cart:          entity.Cart{CouponCodes: []string{"SUMMER", "NOPE"}, Lines: []entity.OrderLine{{BookID: 2, Quantity: 1}}},
+			promotions:    []entity.Promotion{expired},
+			wantDiscounts: []int{0},
+			wantRejected:  map[string]string{"SUMMER": "promotion has ended", "NOPE": "unknown coupon code"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			bookRepo := new(mocks.BookRepository)
+			for id, book := range books {
+				bookRepo.On("GetBook", mock.Anything, id).Return(book, nil)
+			}
+
+			categoryRepo := new(mocks.CategoryRepository)
+			categoryRepo.On("GetBookCategories", mock.Anything, mock.Anything).Return(categories, nil)
+
+			promotionRepo := new(mocks.PromotionRepository)
+			promotionRepo.On("GetApplicablePromotions", mock.Anything, mock.Anything).Return(test.promotions, nil)
+			promotionRepo.On("GetRedemptions", mock.Anything, mock.Anything, mock.Anything).Return(test.redemptions, nil)
+
+			promotionUsecase := usecase.NewPromotionUsecase(&usecase.PromotionRepository{PromotionRepo: promotionRepo, BookRepo: bookRepo, CategoryRepo: categoryRepo})
+			quote, err := promotionUsecase.QuoteCart(context.Background(), &test.cart)
+
+			assert.NoError(t, err)
+
+			discount := 0
+			for i, line := range quote.Lines {
+				assert.Equal(t, test.wantDiscounts[i], line.Discount)
+				assert.Equal(t, line.Subtotal-line.Discount, line.Total)
+				discount += line.Discount
+			}
+			assert.Equal(t, discount, quote.Discount)
+			assert.Equal(t, quote.Subtotal-quote.Discount, quote.Total)
+
+			var applied []int64
+			for _, promotion := range quote.Promotions {
+				applied = append(applied, promotion.PromotionID)
+				assert.NotEmpty(t, promotion.Description)
+			}
+			assert.Equal(t, test.wantPromotions, applied)
+
+			rejected := make(map[string]string)
+			for _, coupon := range quote.RejectedCoupons {
+				rejected[coupon.Code] = coupon.Reason
+			}
+			if test.wantRejected == nil {
+				test.wantRejected = map[string]string{}
+			}
+			assert.Equal(t, test.wantRejected, rejected)
+		})
+	}
+}
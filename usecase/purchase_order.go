@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+type PurchaseOrderUsecase interface {
+	GetPurchaseOrders(ctx context.Context) ([]entity.PurchaseOrder, error)
+	GetPurchaseOrder(ctx context.Context, id int64) (entity.PurchaseOrder, error)
+	CreatePurchaseOrder(ctx context.Context, order *entity.PurchaseOrder) error
+	UpdatePurchaseOrder(ctx context.Context, id int64, order *entity.PurchaseOrder) error
+	PlacePurchaseOrder(ctx context.Context, id int64) error
+	CancelPurchaseOrder(ctx context.Context, id int64) error
+	ReceivePurchaseOrder(ctx context.Context, receipt *entity.PurchaseOrderReceipt) error
+}
+
+type PurchaseOrderRepository struct {
+	PurchaseOrderRepo repository.PurchaseOrderRepository
+	SupplierRepo      repository.SupplierRepository
+	WarehouseRepo     repository.WarehouseRepository
+	BookRepo          repository.BookRepository
+}
+
+func NewPurchaseOrderUsecase(repo *PurchaseOrderRepository) PurchaseOrderUsecase {
+	return &PurchaseOrderRepository{
+		PurchaseOrderRepo: repo.PurchaseOrderRepo,
+		SupplierRepo:      repo.SupplierRepo,
+		WarehouseRepo:     repo.WarehouseRepo,
+		BookRepo:          repo.BookRepo,
+	}
+}
+
+func (r *PurchaseOrderRepository) GetPurchaseOrders(ctx context.Context) ([]entity.PurchaseOrder, error) {
+	res, err := r.PurchaseOrderRepo.GetPurchaseOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *PurchaseOrderRepository) GetPurchaseOrder(ctx context.Context, id int64) (entity.PurchaseOrder, error) {
+	res, err := r.PurchaseOrderRepo.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		return entity.PurchaseOrder{}, err
+	}
+
+	return res, nil
+}
+
+// CreatePurchaseOrder stores a draft, or an order that is placed right away when its status is ordered
+func (r *PurchaseOrderRepository) CreatePurchaseOrder(ctx context.Context, order *entity.PurchaseOrder) error {
+	switch order.Status {
+	case "":
+		order.Status = entity.PurchaseOrderDraft
+	case entity.PurchaseOrderDraft, entity.PurchaseOrderOrdered:
+	default:
+		return fmt.Errorf("purchase order cannot be created as %s", order.Status)
+	}
+
+	err := r.validatePurchaseOrder(ctx, order)
+	if err != nil {
+		return err
+	}
+
+	err = r.PurchaseOrderRepo.CreatePurchaseOrder(ctx, order)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdatePurchaseOrder replaces the supplier, warehouse, dates and lines of a draft
+func (r *PurchaseOrderRepository) UpdatePurchaseOrder(ctx context.Context, id int64, order *entity.PurchaseOrder) error {
+	err := r.validatePurchaseOrder(ctx, order)
+	if err != nil {
+		return err
+	}
+
+	err = r.PurchaseOrderRepo.UpdatePurchaseOrder(ctx, id, order)
+	if err != nil {
+		if err == repository.ErrPurchaseOrderStatus {
+			return fmt.Errorf("purchase order ID %d can only be changed while it is a draft", id)
+		}
+		return err
+	}
+
+	order.Status = entity.PurchaseOrderDraft
+	return nil
+}
+
+// PlacePurchaseOrder sends a draft to the supplier
+func (r *PurchaseOrderRepository) PlacePurchaseOrder(ctx context.Context, id int64) error {
+	err := r.PurchaseOrderRepo.SetPurchaseOrderStatus(ctx, id, []string{entity.PurchaseOrderDraft}, entity.PurchaseOrderOrdered)
+	if err != nil {
+		if err == repository.ErrPurchaseOrderStatus {
+			return fmt.Errorf("purchase order ID %d is not a draft", id)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// CancelPurchaseOrder closes a purchase order. Partially received orders can be cancelled to
+// stop waiting for the rest, the stock already received is kept.
+func (r *PurchaseOrderRepository) CancelPurchaseOrder(ctx context.Context, id int64) error {
+	from := []string{entity.PurchaseOrderDraft, entity.PurchaseOrderOrdered, entity.PurchaseOrderPartiallyReceived}
+	err := r.PurchaseOrderRepo.SetPurchaseOrderStatus(ctx, id, from, entity.PurchaseOrderCancelled)
+	if err != nil {
+		if err == repository.ErrPurchaseOrderStatus {
+			return fmt.Errorf("purchase order ID %d is already received or cancelled", id)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ReceivePurchaseOrder records a delivery of the ordered books, which may be partial
+func (r *PurchaseOrderRepository) ReceivePurchaseOrder(ctx context.Context, receipt *entity.PurchaseOrderReceipt) error {
+	receipt.Actor = strings.TrimSpace(receipt.Actor)
+	if receipt.Actor == "" {
+		return errors.New("receipt needs an actor")
+	}
+
+	lines, err := mergeReceiptLines(receipt.Lines)
+	if err != nil {
+		return err
+	}
+
+	order, err := r.PurchaseOrderRepo.GetPurchaseOrder(ctx, receipt.PurchaseOrderID)
+	if err != nil {
+		return err
+	}
+
+	if order.ID == 0 {
+		return fmt.Errorf("purchase order ID %d was not found", receipt.PurchaseOrderID)
+	}
+
+	if order.Status != entity.PurchaseOrderOrdered && order.Status != entity.PurchaseOrderPartiallyReceived {
+		return fmt.Errorf("purchase order ID %d is %s and cannot be received", order.ID, order.Status)
+	}
+
+	outstanding := make(map[int64]int)
+	for _, line := range order.Lines {
+		outstanding[line.BookID] = line.Quantity - line.ReceivedQuantity
+	}
+
+	for _, line := range lines {
+		remaining, ok := outstanding[line.BookID]
+		if !ok {
+			return fmt.Errorf("book ID %d is not on purchase order ID %d", line.BookID, order.ID)
+		}
+
+		if line.Quantity > remaining {
+			return fmt.Errorf("only %d of book ID %d are still expected", remaining, line.BookID)
+		}
+	}
+
+	receipt.Lines = lines
+	err = r.PurchaseOrderRepo.ReceivePurchaseOrder(ctx, receipt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validatePurchaseOrder checks the supplier, warehouse and books exist and merges the lines of the same book
+func (r *PurchaseOrderRepository) validatePurchaseOrder(ctx context.Context, order *entity.PurchaseOrder) error {
+	order.Notes = strings.TrimSpace(order.Notes)
+
+	supplier, err := r.SupplierRepo.GetSupplier(ctx, order.SupplierID)
+	if err != nil {
+		return err
+	}
+
+	if supplier.ID == 0 {
+		return fmt.Errorf("supplier ID %d was not found", order.SupplierID)
+	}
+
+	warehouse, err := r.WarehouseRepo.GetWarehouse(ctx, order.WarehouseID)
+	if err != nil {
+		return err
+	}
+
+	if warehouse.ID == 0 {
+		return fmt.Errorf("warehouse ID %d was not found", order.WarehouseID)
+	}
+
+	if len(order.Lines) == 0 {
+		return errors.New("purchase order needs at least one line")
+	}
+
+	merged := make(map[int64]int)
+	var lines []entity.PurchaseOrderLine
+	for _, line := range order.Lines {
+		if line.Quantity <= 0 {
+			return fmt.Errorf("quantity of book ID %d must be greater than zero", line.BookID)
+		}
+
+		if line.UnitCost < 0 {
+			return fmt.Errorf("unit cost of book ID %d cannot be negative", line.BookID)
+		}
+
+		if i, ok := merged[line.BookID]; ok {
+			if lines[i].UnitCost != line.UnitCost {
+				return fmt.Errorf("book ID %d is ordered twice with different unit costs", line.BookID)
+			}
+			lines[i].Quantity += line.Quantity
+			continue
+		}
+
+		book, err := r.BookRepo.GetBook(ctx, line.BookID)
+		if err != nil {
+			return err
+		}
+
+		if book.ID == 0 {
+			return fmt.Errorf("book ID %d was not found", line.BookID)
+		}
+
+		merged[line.BookID] = len(lines)
+		lines = append(lines, entity.PurchaseOrderLine{BookID: line.BookID, Quantity: line.Quantity, UnitCost: line.UnitCost})
+	}
+
+	order.Lines = lines
+	return nil
+}
+
+// mergeReceiptLines validates the received lines and merges those of the same book, sorted by
+// book so concurrent receipts lock the stock rows in the same order
+func mergeReceiptLines(lines []entity.ReceiptLine) ([]entity.ReceiptLine, error) {
+	if len(lines) == 0 {
+		return nil, errors.New("receipt needs at least one line")
+	}
+
+	quantities := make(map[int64]int)
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("received quantity of book ID %d must be greater than zero", line.BookID)
+		}
+
+		quantities[line.BookID] += line.Quantity
+	}
+
+	merged := make([]entity.ReceiptLine, 0, len(quantities))
+	for bookID, quantity := range quantities {
+		merged = append(merged, entity.ReceiptLine{BookID: bookID, Quantity: quantity})
+	}
+
+	sort.Slice(merged, func(i, j int) bool { return merged[i].BookID < merged[j].BookID })
+	return merged, nil
+}
@@ -0,0 +1,201 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/repository"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreatePurchaseOrder(t *testing.T) {
+	testCases := []struct {
+		name       string
+		order      entity.PurchaseOrder
+		wantStatus string
+		wantLines  []entity.PurchaseOrderLine
+		isError    bool
+	}{
+		{
+			name:       "draft with merged lines",
+			order:      entity.PurchaseOrder{SupplierID: 1, WarehouseID: 1, Lines: []entity.PurchaseOrderLine{{BookID: 1, Quantity: 4, UnitCost: 5000}, {BookID: 2, Quantity: 1, UnitCost: 7000}, {BookID: 1, Quantity: 6, UnitCost: 5000}}},
+			wantStatus: entity.PurchaseOrderDraft,
+			wantLines:  []entity.PurchaseOrderLine{{BookID: 1, Quantity: 10, UnitCost: 5000}, {BookID: 2, Quantity: 1, UnitCost: 7000}},
+			isError:    false,
+		},
+		{
+			name:       "placed right away",
+			order:      entity.PurchaseOrder{SupplierID: 1, WarehouseID: 1, Status: entity.PurchaseOrderOrdered, Lines: []entity.PurchaseOrderLine{{BookID: 1, Quantity: 4}}},
+			wantStatus: entity.PurchaseOrderOrdered,
+			wantLines:  []entity.PurchaseOrderLine{{BookID: 1, Quantity: 4}},
+			isError:    false,
+		},
+		{
+			name:    "created as received",
+			order:   entity.PurchaseOrder{SupplierID: 1, WarehouseID: 1, Status: entity.PurchaseOrderReceived, Lines: []entity.PurchaseOrderLine{{BookID: 1, Quantity: 4}}},
+			isError: true,
+		},
+		{
+			name:    "supplier not found",
+			order:   entity.PurchaseOrder{SupplierID: 9, WarehouseID: 1, Lines: []entity.PurchaseOrderLine{{BookID: 1, Quantity: 4}}},
+			isError: true,
+		},
+		{
+			name:    "warehouse not found",
+			order:   entity.PurchaseOrder{SupplierID: 1, WarehouseID: 9, Lines: []entity.PurchaseOrderLine{{BookID: 1, Quantity: 4}}},
+			isError: true,
+		},
+		{
+			name:    "without lines",
+			order:   entity.PurchaseOrder{SupplierID: 1, WarehouseID: 1},
+			isError: true,
+		},
+		{
+			name:    "zero quantity",
+			order:   entity.PurchaseOrder{SupplierID: 1, WarehouseID: 1, Lines: []entity.PurchaseOrderLine{{BookID: 1, Quantity: 0}}},
+			isError: true,
+		},
+		{
+			name:    "book not found",
+			order:   entity.PurchaseOrder{SupplierID: 1, WarehouseID: 1, Lines: []entity.PurchaseOrderLine{{BookID: 9, Quantity: 4}}},
+			isError: true,
+		},
+		{
+			name:    "same book with different costs",
+			order:   entity.PurchaseOrder{SupplierID: 1, WarehouseID: 1, Lines: []entity.PurchaseOrderLine{{BookID: 1, Quantity: 4, UnitCost: 5000}, {BookID: 1, Quantity: 1, UnitCost: 4000}}},
+			isError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			purchaseOrderRepo := new(mocks.PurchaseOrderRepository)
+			purchaseOrderRepo.On("CreatePurchaseOrder", mock.Anything, mock.Anything).Return(nil)
+
+			supplierRepo := new(mocks.SupplierRepository)
+			supplierRepo.On("GetSupplier", mock.Anything, int64(1)).Return(entity.Supplier{ID: 1}, nil)
+			supplierRepo.On("GetSupplier", mock.Anything, int64(9)).Return(entity.Supplier{}, nil)
+
+			warehouseRepo := new(mocks.WarehouseRepository)
+			warehouseRepo.On("GetWarehouse", mock.Anything, int64(1)).Return(entity.Warehouse{ID: 1}, nil)
+			warehouseRepo.On("GetWarehouse", mock.Anything, int64(9)).Return(entity.Warehouse{}, nil)
+
+			bookRepo := new(mocks.BookRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(1)).Return(entity.Book{ID: 1}, nil)
+			bookRepo.On("GetBook", mock.Anything, int64(2)).Return(entity.Book{ID: 2}, nil)
+			bookRepo.On("GetBook", mock.Anything, int64(9)).Return(entity.Book{}, nil)
+
+			purchaseOrderUsecase := usecase.NewPurchaseOrderUsecase(&usecase.PurchaseOrderRepository{PurchaseOrderRepo: purchaseOrderRepo, SupplierRepo: supplierRepo, WarehouseRepo: warehouseRepo, BookRepo: bookRepo})
+			err := purchaseOrderUsecase.CreatePurchaseOrder(context.Background(), &test.order)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				purchaseOrderRepo.AssertNotCalled(t, "CreatePurchaseOrder", mock.Anything, mock.Anything)
+			} else {
+				assert.Equal(t, test.wantStatus, test.order.Status)
+				assert.Equal(t, test.wantLines, test.order.Lines)
+			}
+		})
+	}
+}
+
+func TestPlacePurchaseOrder(t *testing.T) {
+	testCases := []struct {
+		name    string
+		repoErr error
+		isError bool
+	}{
+		{name: "success", repoErr: nil, isError: false},
+		{name: "not a draft", repoErr: repository.ErrPurchaseOrderStatus, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			purchaseOrderRepo := new(mocks.PurchaseOrderRepository)
+			purchaseOrderRepo.On("SetPurchaseOrderStatus", mock.Anything, int64(5), []string{entity.PurchaseOrderDraft}, entity.PurchaseOrderOrdered).Return(test.repoErr)
+
+			purchaseOrderUsecase := usecase.NewPurchaseOrderUsecase(&usecase.PurchaseOrderRepository{PurchaseOrderRepo: purchaseOrderRepo})
+			err := purchaseOrderUsecase.PlacePurchaseOrder(context.Background(), 5)
+
+			assert.Equal(t, test.isError, err != nil)
+		})
+	}
+}
+
+func TestReceivePurchaseOrder(t *testing.T) {
+	ordered := entity.PurchaseOrder{ID: 5, Status: entity.PurchaseOrderPartiallyReceived, Lines: []entity.PurchaseOrderLine{{BookID: 1, Quantity: 10, ReceivedQuantity: 4}, {BookID: 2, Quantity: 3}}}
+
+	testCases := []struct {
+		name      string
+		order     entity.PurchaseOrder
+		receipt   entity.PurchaseOrderReceipt
+		wantLines []entity.ReceiptLine
+		isError   bool
+	}{
+		{
+			name:      "success with merged lines",
+			order:     ordered,
+			receipt:   entity.PurchaseOrderReceipt{PurchaseOrderID: 5, Actor: "admin", Lines: []entity.ReceiptLine{{BookID: 2, Quantity: 3}, {BookID: 1, Quantity: 2}, {BookID: 1, Quantity: 4}}},
+			wantLines: []entity.ReceiptLine{{BookID: 1, Quantity: 6}, {BookID: 2, Quantity: 3}},
+			isError:   false,
+		},
+		{
+			name:    "missing actor",
+			order:   ordered,
+			receipt: entity.PurchaseOrderReceipt{PurchaseOrderID: 5, Lines: []entity.ReceiptLine{{BookID: 1, Quantity: 1}}},
+			isError: true,
+		},
+		{
+			name:    "zero quantity",
+			order:   ordered,
+			receipt: entity.PurchaseOrderReceipt{PurchaseOrderID: 5, Actor: "admin", Lines: []entity.ReceiptLine{{BookID: 1, Quantity: 0}}},
+			isError: true,
+		},
+		{
+			name:    "purchase order not found",
+			order:   entity.PurchaseOrder{},
+			receipt: entity.PurchaseOrderReceipt{PurchaseOrderID: 5, Actor: "admin", Lines: []entity.ReceiptLine{{BookID: 1, Quantity: 1}}},
+			isError: true,
+		},
+		{
+			name:    "still a draft",
+			order:   entity.PurchaseOrder{ID: 5, Status: entity.PurchaseOrderDraft, Lines: ordered.Lines},
+			receipt: entity.PurchaseOrderReceipt{PurchaseOrderID: 5, Actor: "admin", Lines: []entity.ReceiptLine{{BookID: 1, Quantity: 1}}},
+			isError: true,
+		},
+		{
+			name:    "book not ordered",
+			order:   ordered,
+			receipt: entity.PurchaseOrderReceipt{PurchaseOrderID: 5, Actor: "admin", Lines: []entity.ReceiptLine{{BookID: 3, Quantity: 1}}},
+			isError: true,
+		},
+		{
+			name:    "more than outstanding",
+			order:   ordered,
+			receipt: entity.PurchaseOrderReceipt{PurchaseOrderID: 5, Actor: "admin", Lines: []entity.ReceiptLine{{BookID: 1, Quantity: 7}}},
+			isError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			purchaseOrderRepo := new(mocks.PurchaseOrderRepository)
+			purchaseOrderRepo.On("GetPurchaseOrder", mock.Anything, int64(5)).Return(test.order, nil)
+			purchaseOrderRepo.On("ReceivePurchaseOrder", mock.Anything, mock.Anything).Return(nil)
+
+			purchaseOrderUsecase := usecase.NewPurchaseOrderUsecase(&usecase.PurchaseOrderRepository{PurchaseOrderRepo: purchaseOrderRepo})
+			err := purchaseOrderUsecase.ReceivePurchaseOrder(context.Background(), &test.receipt)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				purchaseOrderRepo.AssertNotCalled(t, "ReceivePurchaseOrder", mock.Anything, mock.Anything)
+			} else {
+				assert.Equal(t, test.wantLines, test.receipt.Lines)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+type RecommendationUsecase interface {
+	GetRecommendations(ctx context.Context, bookID int64) ([]entity.Recommendation, error)
+	RefreshRecommendations(ctx context.Context) (int64, error)
+}
+
+// RecommendationRepository keeps at most Limit recommendations per book
+type RecommendationRepository struct {
+	RecommendationRepo repository.RecommendationRepository
+	BookRepo           repository.BookRepository
+	Limit              int
+}
+
+func NewRecommendationUsecase(repo *RecommendationRepository) RecommendationUsecase {
+	return &RecommendationRepository{
+		RecommendationRepo: repo.RecommendationRepo,
+		BookRepo:           repo.BookRepo,
+		Limit:              repo.Limit,
+	}
+}
+
+// GetRecommendations looks up the books precomputed for a book by the last refresh
+func (r *RecommendationRepository) GetRecommendations(ctx context.Context, bookID int64) ([]entity.Recommendation, error) {
+	book, err := r.BookRepo.GetBook(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if book.ID == 0 {
+		return nil, fmt.Errorf("book ID %d was not found", bookID)
+	}
+
+	res, err := r.RecommendationRepo.GetRecommendations(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RefreshRecommendations recomputes the recommendations of every book from the orders placed
+// so far and returns how many were stored
+func (r *RecommendationRepository) RefreshRecommendations(ctx context.Context) (int64, error) {
+	return r.RecommendationRepo.RefreshRecommendations(ctx, r.Limit, time.Now())
+}
@@ -0,0 +1,54 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetRecommendations(t *testing.T) {
+	testCases := []struct {
+		name    string
+		book    entity.Book
+		isError bool
+	}{
+		{name: "success", book: entity.Book{ID: 5}},
+		{name: "book not found", book: entity.Book{}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			bookRepo := new(mocks.BookRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(5)).Return(test.book, nil)
+
+			recommendationRepo := new(mocks.RecommendationRepository)
+			recommendationRepo.On("GetRecommendations", mock.Anything, int64(5)).Return([]entity.Recommendation{{BookID: 6, Reason: entity.RecommendationSimilar, Score: 3}}, nil)
+
+			recommendationUsecase := usecase.NewRecommendationUsecase(&usecase.RecommendationRepository{RecommendationRepo: recommendationRepo, BookRepo: bookRepo, Limit: 10})
+			recommendations, err := recommendationUsecase.GetRecommendations(context.Background(), 5)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Len(t, recommendations, 1)
+			} else {
+				recommendationRepo.AssertNotCalled(t, "GetRecommendations", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRefreshRecommendations(t *testing.T) {
+	recommendationRepo := new(mocks.RecommendationRepository)
+	recommendationRepo.On("RefreshRecommendations", mock.Anything, 10, mock.Anything).Return(int64(42), nil)
+
+	recommendationUsecase := usecase.NewRecommendationUsecase(&usecase.RecommendationRepository{RecommendationRepo: recommendationRepo, Limit: 10})
+	stored, err := recommendationUsecase.RefreshRecommendations(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), stored)
+}
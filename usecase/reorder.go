@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/notifier"
+	"winartodev/book-store-be/repository"
+)
+
+type ReorderUsecase interface {
+	GetReorderPoint(ctx context.Context, bookID int64) (entity.ReorderPoint, error)
+	SetReorderPoint(ctx context.Context, point *entity.ReorderPoint) error
+	DeleteReorderPoint(ctx context.Context, bookID int64) error
+	GetLowStock(ctx context.Context) ([]entity.LowStock, error)
+	ScanLowStock(ctx context.Context) (int, error)
+}
+
+type ReorderRepository struct {
+	ReorderRepo repository.ReorderRepository
+	BookRepo    repository.BookRepository
+	Notifier    notifier.Notifier
+}
+
+func NewReorderUsecase(repo *ReorderRepository) ReorderUsecase {
+	return &ReorderRepository{
+		ReorderRepo: repo.ReorderRepo,
+		BookRepo:    repo.BookRepo,
+		Notifier:    repo.Notifier,
+	}
+}
+
+func (r *ReorderRepository) GetReorderPoint(ctx context.Context, bookID int64) (entity.ReorderPoint, error) {
+	res, err := r.ReorderRepo.GetReorderPoint(ctx, bookID)
+	if err != nil {
+		return entity.ReorderPoint{}, err
+	}
+
+	return res, nil
+}
+
+func (r *ReorderRepository) SetReorderPoint(ctx context.Context, point *entity.ReorderPoint) error {
+	if point.Threshold < 0 {
+		return errors.New("reorder threshold cannot be negative")
+	}
+
+	if point.ReorderQuantity < 0 {
+		return errors.New("reorder quantity cannot be negative")
+	}
+
+	book, err := r.BookRepo.GetBook(ctx, point.BookID)
+	if err != nil {
+		return err
+	}
+
+	if book.ID == 0 {
+		return fmt.Errorf("book ID %d was not found", point.BookID)
+	}
+
+	err = r.ReorderRepo.SetReorderPoint(ctx, point)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReorderRepository) DeleteReorderPoint(ctx context.Context, bookID int64) error {
+	err := r.ReorderRepo.DeleteReorderPoint(ctx, bookID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReorderRepository) GetLowStock(ctx context.Context) ([]entity.LowStock, error) {
+	res, err := r.ReorderRepo.GetLowStock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ScanLowStock sends one alert for every book that fell to its reorder point since the last
+// scan and returns how many books were reported. Books are only marked as alerted once the
+// notifiers succeeded, a failed delivery is retried on the next scan.
+func (r *ReorderRepository) ScanLowStock(ctx context.Context) (int, error) {
+	_, err := r.ReorderRepo.ResetRestockedAlerts(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	books, err := r.ReorderRepo.GetLowStock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var pending []entity.LowStock
+	var ids []int64
+	for _, book := range books {
+		if book.AlertedAt == nil {
+			pending = append(pending, book)
+			ids = append(ids, book.BookID)
+		}
+	}
+
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	err = r.Notifier.Notify(ctx, pending)
+	if err != nil {
+		return 0, err
+	}
+
+	err = r.ReorderRepo.MarkAlerted(ctx, ids, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	return len(pending), nil
+}
@@ -0,0 +1,121 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetReorderPoint(t *testing.T) {
+	testCases := []struct {
+		name    string
+		point   entity.ReorderPoint
+		book    entity.Book
+		isError bool
+	}{
+		{
+			name:    "success",
+			point:   entity.ReorderPoint{BookID: 1, Threshold: 5, ReorderQuantity: 20},
+			book:    entity.Book{ID: 1},
+			isError: false,
+		},
+		{
+			name:    "negative threshold",
+			point:   entity.ReorderPoint{BookID: 1, Threshold: -1},
+			book:    entity.Book{ID: 1},
+			isError: true,
+		},
+		{
+			name:    "negative reorder quantity",
+			point:   entity.ReorderPoint{BookID: 1, Threshold: 5, ReorderQuantity: -20},
+			book:    entity.Book{ID: 1},
+			isError: true,
+		},
+		{
+			name:    "book not found",
+			point:   entity.ReorderPoint{BookID: 1, Threshold: 5},
+			book:    entity.Book{},
+			isError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			reorderRepo := new(mocks.ReorderRepository)
+			bookRepo := new(mocks.BookRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(1)).Return(test.book, nil)
+			reorderRepo.On("SetReorderPoint", mock.Anything, mock.Anything).Return(nil)
+
+			reorderUsecase := usecase.NewReorderUsecase(&usecase.ReorderRepository{ReorderRepo: reorderRepo, BookRepo: bookRepo})
+			err := reorderUsecase.SetReorderPoint(context.Background(), &test.point)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				reorderRepo.AssertNotCalled(t, "SetReorderPoint", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestScanLowStock(t *testing.T) {
+	alertedAt := time.Now().Add(-time.Hour)
+
+	testCases := []struct {
+		name        string
+		lowStock    []entity.LowStock
+		notifyErr   error
+		wantAlerted []int64
+		isError     bool
+	}{
+		{
+			name: "alerts books that were not alerted yet",
+			lowStock: []entity.LowStock{
+				{BookID: 1, Title: "Dune", Available: 0, Threshold: 3},
+				{BookID: 2, Title: "Emma", Available: 2, Threshold: 5, AlertedAt: &alertedAt},
+				{BookID: 3, Title: "Ulysses", Available: 4, Threshold: 5},
+			},
+			wantAlerted: []int64{1, 3},
+		},
+		{
+			name:     "everything was alerted already",
+			lowStock: []entity.LowStock{{BookID: 2, Title: "Emma", Available: 2, Threshold: 5, AlertedAt: &alertedAt}},
+		},
+		{
+			name:      "failed delivery is retried on the next scan",
+			lowStock:  []entity.LowStock{{BookID: 1, Title: "Dune", Available: 0, Threshold: 3}},
+			notifyErr: errors.New("connection refused"),
+			isError:   true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			reorderRepo := new(mocks.ReorderRepository)
+			notifier := new(mocks.Notifier)
+			reorderRepo.On("ResetRestockedAlerts", mock.Anything).Return(int64(0), nil)
+			reorderRepo.On("GetLowStock", mock.Anything).Return(test.lowStock, nil)
+			reorderRepo.On("MarkAlerted", mock.Anything, mock.Anything, mock.AnythingOfType("time.Time")).Return(nil)
+			notifier.On("Notify", mock.Anything, mock.Anything).Return(test.notifyErr)
+
+			reorderUsecase := usecase.NewReorderUsecase(&usecase.ReorderRepository{ReorderRepo: reorderRepo, Notifier: notifier})
+			alerted, err := reorderUsecase.ScanLowStock(context.Background())
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, len(test.wantAlerted), alerted)
+			if test.wantAlerted != nil {
+				reorderRepo.AssertCalled(t, "MarkAlerted", mock.Anything, test.wantAlerted, mock.Anything)
+				notifier.AssertCalled(t, "Notify", mock.Anything, mock.MatchedBy(func(books []entity.LowStock) bool { return len(books) == len(test.wantAlerted) }))
+			} else {
+				reorderRepo.AssertNotCalled(t, "MarkAlerted", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
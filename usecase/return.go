@@ -0,0 +1,351 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/payment"
+	"winartodev/book-store-be/repository"
+)
+
+type ReturnUsecase interface {
+	GetReturns(ctx context.Context) ([]entity.Return, error)
+	GetReturn(ctx context.Context, id int64) (entity.Return, error)
+	GetOrderReturns(ctx context.Context, orderID int64) ([]entity.Return, error)
+	CreateReturn(ctx context.Context, orderID int64, returned *entity.Return) error
+	ApproveReturn(ctx context.Context, id int64, staffNote string) (entity.Return, error)
+	RejectReturn(ctx context.Context, id int64, staffNote string) (entity.Return, error)
+	ReceiveReturn(ctx context.Context, id int64, lines []entity.ReturnLine, actor string) (entity.Return, error)
+	RefundReturn(ctx context.Context, id int64, amount int) (entity.Return, error)
+}
+
+type ReturnRepository struct {
+	ReturnRepo  repository.ReturnRepository
+	OrderRepo   repository.OrderRepository
+	PaymentRepo repository.PaymentRepository
+	Gateway     payment.PaymentGateway
+}
+
+func NewReturnUsecase(repo *ReturnRepository) ReturnUsecase {
+	return &ReturnRepository{
+		ReturnRepo:  repo.ReturnRepo,
+		OrderRepo:   repo.OrderRepo,
+		PaymentRepo: repo.PaymentRepo,
+		Gateway:     repo.Gateway,
+	}
+}
+
+func (r *ReturnRepository) GetReturns(ctx context.Context) ([]entity.Return, error) {
+	res, err := r.ReturnRepo.GetReturns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *ReturnRepository) GetReturn(ctx context.Context, id int64) (entity.Return, error) {
+	res, err := r.ReturnRepo.GetReturn(ctx, id)
+	if err != nil {
+		return entity.Return{}, err
+	}
+
+	return res, nil
+}
+
+func (r *ReturnRepository) GetOrderReturns(ctx context.Context, orderID int64) ([]entity.Return, error) {
+	res, err := r.ReturnRepo.GetOrderReturns(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// CreateReturn requests to send back books of a confirmed order. Each line names the order
+// line, how many of its books and why, an order line can be returned over several returns
+// until all its books are.
+func (r *ReturnRepository) CreateReturn(ctx context.Context, orderID int64, ret *entity.Return) error {
+	order, err := r.OrderRepo.GetOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.ID == 0 {
+		return fmt.Errorf("order ID %d was not found", orderID)
+	}
+
+	switch order.Status {
+	case entity.OrderConfirmed, entity.OrderPartiallyShipped, entity.OrderShipped, entity.OrderDelivered, entity.OrderPartiallyReturned:
+	default:
+		return fmt.Errorf("order ID %d is %s, only confirmed orders can be returned", orderID, order.Status)
+	}
+
+	if len(ret.Lines) == 0 {
+		return errors.New("return needs at least one line")
+	}
+
+	orderLines := make(map[int64]entity.OrderLine)
+	for _, line := range order.Lines {
+		orderLines[line.ID] = line
+	}
+
+	seen := make(map[int64]bool)
+	for i := range ret.Lines {
+		line := &ret.Lines[i]
+
+		orderLine, ok := orderLines[line.OrderLineID]
+		if !ok {
+			return fmt.Errorf("order line ID %d is not on order ID %d", line.OrderLineID, orderID)
+		}
+
+		if seen[line.OrderLineID] {
+			return fmt.Errorf("order line ID %d is returned more than once", line.OrderLineID)
+		}
+		seen[line.OrderLineID] = true
+
+		if line.Quantity <= 0 || line.Quantity > orderLine.Quantity {
+			return fmt.Errorf("quantity of order line ID %d must be between 1 and %d", line.OrderLineID, orderLine.Quantity)
+		}
+
+		if !isReturnReason(line.Reason) {
+			return fmt.Errorf("invalid return reason %q", line.Reason)
+		}
+
+		line.BookID = orderLine.BookID
+		line.Disposition, line.WarehouseID = "", 0
+	}
+
+	ret.OrderID = orderID
+	ret.Status = entity.ReturnRequested
+	ret.CustomerNote = strings.TrimSpace(ret.CustomerNote)
+	ret.StaffNote, ret.RefundAmount = "", 0
+
+	err = r.ReturnRepo.CreateReturn(ctx, ret)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReturnRepository) ApproveReturn(ctx context.Context, id int64, staffNote string) (entity.Return, error) {
+	return r.review(ctx, id, entity.ReturnApproved, staffNote)
+}
+
+func (r *ReturnRepository) RejectReturn(ctx context.Context, id int64, staffNote string) (entity.Return, error) {
+	return r.review(ctx, id, entity.ReturnRejected, staffNote)
+}
+
+// ReceiveReturn records the books of an approved return arriving back, or of one that was
+// refunded before its books arrived. Each line says whether its books are restocked or written
+// off and in which warehouse, which defaults to the one the books were shipped from. Lines that
+// are left out are restocked.
+func (r *ReturnRepository) ReceiveReturn(ctx context.Context, id int64, lines []entity.ReturnLine, actor string) (entity.Return, error) {
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		return entity.Return{}, errors.New("receiving a return needs an actor")
+	}
+
+	ret, err := r.ReturnRepo.GetReturn(ctx, id)
+	if err != nil {
+		return entity.Return{}, err
+	}
+
+	if ret.ID == 0 {
+		return entity.Return{}, fmt.Errorf("return ID %d was not found", id)
+	}
+
+	if ret.Status != entity.ReturnApproved && ret.Status != entity.ReturnRefunded {
+		return entity.Return{}, fmt.Errorf("return ID %d is %s, only approved or refunded returns can be received", id, ret.Status)
+	}
+
+	for _, line := range ret.Lines {
+		if line.Disposition != "" {
+			return entity.Return{}, fmt.Errorf("return ID %d was received already", id)
+		}
+	}
+
+	order, err := r.OrderRepo.GetOrder(ctx, ret.OrderID)
+	if err != nil {
+		return entity.Return{}, err
+	}
+
+	shippedFrom := make(map[int64]int64)
+	for _, line := range order.Lines {
+		if len(line.Allocations) > 0 {
+			shippedFrom[line.ID] = line.Allocations[0].WarehouseID
+		}
+	}
+
+	received := make(map[int64]entity.ReturnLine)
+	for _, line := range lines {
+		received[line.ID] = line
+	}
+
+	for i := range ret.Lines {
+		line := &ret.Lines[i]
+		in, ok := received[line.ID]
+		delete(received, line.ID)
+
+		line.Disposition, line.WarehouseID = entity.DispositionRestock, shippedFrom[line.OrderLineID]
+		if ok && in.Disposition != "" {
+			line.Disposition = in.Disposition
+		}
+		if ok && in.WarehouseID != 0 {
+			line.WarehouseID = in.WarehouseID
+		}
+
+		if line.Disposition != entity.DispositionRestock && line.Disposition != entity.DispositionWriteOff {
+			return entity.Return{}, fmt.Errorf("invalid disposition %q", line.Disposition)
+		}
+
+		if line.WarehouseID == 0 {
+			return entity.Return{}, fmt.Errorf("return line ID %d needs a warehouse_id", line.ID)
+		}
+	}
+
+	for lineID := range received {
+		return entity.Return{}, fmt.Errorf("return line ID %d is not on return ID %d", lineID, id)
+	}
+
+	err = r.ReturnRepo.ReceiveReturn(ctx, &ret, actor)
+	if err != nil {
+		return entity.Return{}, err
+	}
+
+	return ret, nil
+}
+
+// RefundReturn pays back the returned books through the payment of the order. An amount of 0
+// refunds what the books were paid, their share of the line discounts and taxes included, less
+// can be refunded for books that come back damaged. Books can be refunded before they are
+// received. Retrying a refund that failed halfway does not pay it back twice, the gateway
+// refunds a return only once.
+func (r *ReturnRepository) RefundReturn(ctx context.Context, id int64, amount int) (entity.Return, error) {
+	ret, err := r.ReturnRepo.GetReturn(ctx, id)
+	if err != nil {
+		return entity.Return{}, err
+	}
+
+	if ret.ID == 0 {
+		return entity.Return{}, fmt.Errorf("return ID %d was not found", id)
+	}
+
+	if ret.Status != entity.ReturnApproved && ret.Status != entity.ReturnReceived {
+		return entity.Return{}, fmt.Errorf("return ID %d is %s, only approved or received returns can be refunded", id, ret.Status)
+	}
+
+	order, err := r.OrderRepo.GetOrder(ctx, ret.OrderID)
+	if err != nil {
+		return entity.Return{}, err
+	}
+
+	worth := returnWorth(order, ret)
+	if amount == 0 {
+		amount = worth
+	}
+
+	if amount <= 0 || amount > worth {
+		return entity.Return{}, fmt.Errorf("refund must be between 1 and the %d the books were paid", worth)
+	}
+
+	payments, err := r.PaymentRepo.GetOrderPayments(ctx, ret.OrderID)
+	if err != nil {
+		return entity.Return{}, err
+	}
+
+	var paid entity.Payment
+	for _, p := range payments {
+		if p.Status == entity.PaymentCaptured && p.CapturedAmount-p.RefundedAmount >= amount {
+			paid = p
+			break
+		}
+	}
+
+	if paid.ID == 0 {
+		return entity.Return{}, fmt.Errorf("order ID %d has no captured payment to refund %d from", ret.OrderID, amount)
+	}
+
+	err = r.Gateway.Refund(ctx, paid.Reference, amount, fmt.Sprintf("return-%d", ret.ID))
+	if err != nil {
+		return entity.Return{}, err
+	}
+
+	ret.RefundAmount = amount
+	err = r.ReturnRepo.RefundReturn(ctx, &ret, paid.ID)
+	if err != nil {
+		return entity.Return{}, err
+	}
+
+	return ret, nil
+}
+
+func (r *ReturnRepository) review(ctx context.Context, id int64, status string, staffNote string) (entity.Return, error) {
+	ret, err := r.returnIn(ctx, id, entity.ReturnRequested)
+	if err != nil {
+		return entity.Return{}, err
+	}
+
+	ret.Status, ret.StaffNote = status, strings.TrimSpace(staffNote)
+	err = r.ReturnRepo.SetReturnStatus(ctx, id, entity.ReturnRequested, ret.Status, ret.StaffNote)
+	if err != nil {
+		return entity.Return{}, err
+	}
+
+	return ret, nil
+}
+
+func (r *ReturnRepository) returnIn(ctx context.Context, id int64, status string) (entity.Return, error) {
+	ret, err := r.ReturnRepo.GetReturn(ctx, id)
+	if err != nil {
+		return entity.Return{}, err
+	}
+
+	if ret.ID == 0 {
+		return entity.Return{}, fmt.Errorf("return ID %d was not found", id)
+	}
+
+	if ret.Status != status {
+		return entity.Return{}, fmt.Errorf("return ID %d is %s, not %s", id, ret.Status, status)
+	}
+
+	return ret, nil
+}
+
+// returnWorth is what the customer paid for the books of a return, each book its share of the
+// order line after discount, plus tax unless the prices include it
+func returnWorth(order entity.Order, ret entity.Return) int {
+	orderLines := make(map[int64]entity.OrderLine)
+	for _, line := range order.Lines {
+		orderLines[line.ID] = line
+	}
+
+	worth := 0
+	for _, line := range ret.Lines {
+		orderLine, ok := orderLines[line.OrderLineID]
+		if !ok || orderLine.Quantity == 0 {
+			continue
+		}
+
+		paid := orderLine.UnitPrice*orderLine.Quantity - orderLine.Discount
+		if !order.PricesIncludeTax {
+			paid += orderLine.Tax
+		}
+
+		worth += roundDiv(paid*line.Quantity, orderLine.Quantity)
+	}
+
+	return worth
+}
+
+func isReturnReason(reason string) bool {
+	switch reason {
+	case entity.ReturnDamaged, entity.ReturnWrongItem, entity.ReturnNotAsDescribed, entity.ReturnChangedMind, entity.ReturnOther:
+		return true
+	}
+
+	return false
+}
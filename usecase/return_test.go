@@ -0,0 +1,182 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/payment"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// returnableOrder line 3 was paid 2*50000 - 10000 + 9900 tax = 99900, shipped from warehouse 2
+var returnableOrder = entity.Order{
+	ID:     1,
+	Status: entity.OrderConfirmed,
+	Lines:  []entity.OrderLine{{ID: 3, BookID: 5, Quantity: 2, UnitPrice: 50000, Discount: 10000, Tax: 9900, Allocations: []entity.Allocation{{WarehouseID: 2, Quantity: 2}}}},
+}
+
+func TestCreateReturn(t *testing.T) {
+	testCases := []struct {
+		name    string
+		order   entity.Order
+		lines   []entity.ReturnLine
+		isError bool
+	}{
+		{name: "success", order: returnableOrder, lines: []entity.ReturnLine{{OrderLineID: 3, Quantity: 1, Reason: entity.ReturnDamaged}}},
+		{name: "order not confirmed", order: entity.Order{ID: 1, Status: entity.OrderPending, Lines: returnableOrder.Lines}, lines: []entity.ReturnLine{{OrderLineID: 3, Quantity: 1, Reason: entity.ReturnDamaged}}, isError: true},
+		{name: "line of another order", order: returnableOrder, lines: []entity.ReturnLine{{OrderLineID: 4, Quantity: 1, Reason: entity.ReturnDamaged}}, isError: true},
+		{name: "more than ordered", order: returnableOrder, lines: []entity.ReturnLine{{OrderLineID: 3, Quantity: 3, Reason: entity.ReturnDamaged}}, isError: true},
+		{name: "invalid reason", order: returnableOrder, lines: []entity.ReturnLine{{OrderLineID: 3, Quantity: 1, Reason: "bored"}}, isError: true},
+		{name: "no lines", order: returnableOrder, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			orderRepo := new(mocks.OrderRepository)
+			orderRepo.On("GetOrder", mock.Anything, int64(1)).Return(test.order, nil)
+
+			returnRepo := new(mocks.ReturnRepository)
+			returnRepo.On("CreateReturn", mock.Anything, mock.Anything).Return(nil)
+
+			returnUsecase := usecase.NewReturnUsecase(&usecase.ReturnRepository{ReturnRepo: returnRepo, OrderRepo: orderRepo})
+			ret := entity.Return{Status: entity.ReturnRefunded, RefundAmount: 100, Lines: test.lines}
+			err := returnUsecase.CreateReturn(context.Background(), 1, &ret)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, entity.ReturnRequested, ret.Status)
+				assert.Equal(t, 0, ret.RefundAmount)
+				assert.Equal(t, int64(5), ret.Lines[0].BookID)
+				returnRepo.AssertCalled(t, "CreateReturn", mock.Anything, &ret)
+			} else {
+				returnRepo.AssertNotCalled(t, "CreateReturn", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestReceiveReturn(t *testing.T) {
+	testCases := []struct {
+		name            string
+		status          string
+		received        string
+		lines           []entity.ReturnLine
+		wantDisposition string
+		wantWarehouse   int64
+		isError         bool
+	}{
+		{name: "restocked where it was shipped from", status: entity.ReturnApproved, wantDisposition: entity.DispositionRestock, wantWarehouse: 2},
+		{name: "written off", status: entity.ReturnApproved, lines: []entity.ReturnLine{{ID: 9, Disposition: entity.DispositionWriteOff, WarehouseID: 4}}, wantDisposition: entity.DispositionWriteOff, wantWarehouse: 4},
+		{name: "refunded before it arrived", status: entity.ReturnRefunded, wantDisposition: entity.DispositionRestock, wantWarehouse: 2},
+		{name: "refunded and received already", status: entity.ReturnRefunded, received: entity.DispositionRestock, isError: true},
+		{name: "not approved", status: entity.ReturnRequested, isError: true},
+		{name: "invalid disposition", status: entity.ReturnApproved, lines: []entity.ReturnLine{{ID: 9, Disposition: "burn"}}, isError: true},
+		{name: "line of another return", status: entity.ReturnApproved, lines: []entity.ReturnLine{{ID: 10, Disposition: entity.DispositionRestock}}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			orderRepo := new(mocks.OrderRepository)
+			orderRepo.On("GetOrder", mock.Anything, int64(1)).Return(returnableOrder, nil)
+
+			returnRepo := new(mocks.ReturnRepository)
+			returnRepo.On("GetReturn", mock.Anything, int64(7)).Return(entity.Return{ID: 7, OrderID: 1, Status: test.status, Lines: []entity.ReturnLine{{ID: 9, ReturnID: 7, OrderLineID: 3, BookID: 5, Quantity: 1, Disposition: test.received}}}, nil)
+			returnRepo.On("ReceiveReturn", mock.Anything, mock.Anything, "admin").Return(nil)
+
+			returnUsecase := usecase.NewReturnUsecase(&usecase.ReturnRepository{ReturnRepo: returnRepo, OrderRepo: orderRepo})
+			ret, err := returnUsecase.ReceiveReturn(context.Background(), 7, test.lines, "admin")
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, test.wantDisposition, ret.Lines[0].Disposition)
+				assert.Equal(t, test.wantWarehouse, ret.Lines[0].WarehouseID)
+			} else {
+				returnRepo.AssertNotCalled(t, "ReceiveReturn", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRefundReturn(t *testing.T) {
+	ctx := context.Background()
+	gateway := payment.NewFake("whsec")
+	auth, _ := gateway.Authorize(ctx, payment.AuthorizeRequest{IdempotencyKey: "key-1", Amount: 99900, Currency: "IDR", PaymentMethod: "tok_visa"})
+	assert.NoError(t, gateway.Capture(ctx, auth.Reference, 99900))
+
+	captured := entity.Payment{ID: 1, OrderID: 1, Reference: auth.Reference, Status: entity.PaymentCaptured, Amount: 99900, CapturedAmount: 99900}
+
+	testCases := []struct {
+		name       string
+		returnID   int64
+		status     string
+		amount     int
+		payments   []entity.Payment
+		wantAmount int
+		isError    bool
+	}{
+		{name: "what the book was paid", returnID: 7, status: entity.ReturnReceived, payments: []entity.Payment{captured}, wantAmount: 49950},
+		{name: "less for a damaged book", returnID: 8, status: entity.ReturnApproved, amount: 20000, payments: []entity.Payment{captured}, wantAmount: 20000},
+		{name: "more than the book was paid", returnID: 9, status: entity.ReturnReceived, amount: 50000, payments: []entity.Payment{captured}, isError: true},
+		{name: "not captured", returnID: 10, status: entity.ReturnReceived, payments: []entity.Payment{{ID: 1, Status: entity.PaymentAuthorized}}, isError: true},
+		{name: "not approved", returnID: 11, status: entity.ReturnRequested, payments: []entity.Payment{captured}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			orderRepo := new(mocks.OrderRepository)
+			orderRepo.On("GetOrder", mock.Anything, int64(1)).Return(returnableOrder, nil)
+
+			paymentRepo := new(mocks.PaymentRepository)
+			paymentRepo.On("GetOrderPayments", mock.Anything, int64(1)).Return(test.payments, nil)
+
+			returnRepo := new(mocks.ReturnRepository)
+			returnRepo.On("GetReturn", mock.Anything, test.returnID).Return(entity.Return{ID: test.returnID, OrderID: 1, Status: test.status, Lines: []entity.ReturnLine{{ID: 9, OrderLineID: 3, BookID: 5, Quantity: 1}}}, nil)
+			returnRepo.On("RefundReturn", mock.Anything, mock.Anything, int64(1)).Return(nil)
+
+			returnUsecase := usecase.NewReturnUsecase(&usecase.ReturnRepository{ReturnRepo: returnRepo, OrderRepo: orderRepo, PaymentRepo: paymentRepo, Gateway: gateway})
+			ret, err := returnUsecase.RefundReturn(ctx, test.returnID, test.amount)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, test.wantAmount, ret.RefundAmount)
+				returnRepo.AssertCalled(t, "RefundReturn", mock.Anything, mock.Anything, int64(1))
+			} else {
+				returnRepo.AssertNotCalled(t, "RefundReturn", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRefundThenReceiveReturn(t *testing.T) {
+	ctx := context.Background()
+	gateway := payment.NewFake("whsec")
+	auth, _ := gateway.Authorize(ctx, payment.AuthorizeRequest{IdempotencyKey: "key-1", Amount: 99900, Currency: "IDR", PaymentMethod: "tok_visa"})
+	assert.NoError(t, gateway.Capture(ctx, auth.Reference, 99900))
+
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("GetOrder", mock.Anything, int64(1)).Return(returnableOrder, nil)
+
+	paymentRepo := new(mocks.PaymentRepository)
+	paymentRepo.On("GetOrderPayments", mock.Anything, int64(1)).Return([]entity.Payment{{ID: 1, OrderID: 1, Reference: auth.Reference, Status: entity.PaymentCaptured, Amount: 99900, CapturedAmount: 99900}}, nil)
+
+	stored := entity.Return{ID: 7, OrderID: 1, Status: entity.ReturnApproved, Lines: []entity.ReturnLine{{ID: 9, ReturnID: 7, OrderLineID: 3, BookID: 5, Quantity: 1}}}
+	returnRepo := new(mocks.ReturnRepository)
+	returnRepo.On("GetReturn", mock.Anything, int64(7)).Return(func(context.Context, int64) entity.Return { return stored }, nil)
+	returnRepo.On("RefundReturn", mock.Anything, mock.Anything, int64(1)).Return(nil).Run(func(args mock.Arguments) {
+		stored.Status = entity.ReturnRefunded
+	})
+	returnRepo.On("ReceiveReturn", mock.Anything, mock.Anything, "admin").Return(nil)
+
+	returnUsecase := usecase.NewReturnUsecase(&usecase.ReturnRepository{ReturnRepo: returnRepo, OrderRepo: orderRepo, PaymentRepo: paymentRepo, Gateway: gateway})
+	_, err := returnUsecase.RefundReturn(ctx, 7, 0)
+	assert.NoError(t, err)
+
+	ret, err := returnUsecase.ReceiveReturn(ctx, 7, nil, "admin")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.DispositionRestock, ret.Lines[0].Disposition)
+	returnRepo.AssertCalled(t, "ReceiveReturn", mock.Anything, mock.Anything, "admin")
+}
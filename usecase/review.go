@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+// longest title and text of a review, in characters
+const (
+	maxReviewTitle = 200
+	maxReviewBody  = 5000
+)
+
+type ReviewUsecase interface {
+	GetReviews(ctx context.Context, filter entity.ReviewFilter) ([]entity.Review, error)
+	GetReview(ctx context.Context, id int64) (entity.Review, error)
+	CreateReview(ctx context.Context, bookID int64, review *entity.Review) error
+	ApproveReview(ctx context.Context, id int64, actor string) (entity.Review, error)
+	RejectReview(ctx context.Context, id int64, actor string) (entity.Review, error)
+}
+
+type ReviewRepository struct {
+	ReviewRepo repository.ReviewRepository
+	BookRepo   repository.BookRepository
+}
+
+func NewReviewUsecase(repo *ReviewRepository) ReviewUsecase {
+	return &ReviewRepository{
+		ReviewRepo: repo.ReviewRepo,
+		BookRepo:   repo.BookRepo,
+	}
+}
+
+func (r *ReviewRepository) GetReviews(ctx context.Context, filter entity.ReviewFilter) ([]entity.Review, error) {
+	if filter.Status != "" && !isReviewStatus(filter.Status) {
+		return nil, fmt.Errorf("unknown review status %q", filter.Status)
+	}
+
+	res, err := r.ReviewRepo.GetReviews(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *ReviewRepository) GetReview(ctx context.Context, id int64) (entity.Review, error) {
+	res, err := r.ReviewRepo.GetReview(ctx, id)
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	return res, nil
+}
+
+// CreateReview records a customer's review of a book, pending until it is moderated. It is a
+// verified purchase when the customer bought the book and did not return it.
+func (r *ReviewRepository) CreateReview(ctx context.Context, bookID int64, review *entity.Review) error {
+	review.CustomerEmail = strings.ToLower(strings.TrimSpace(review.CustomerEmail))
+	if review.CustomerEmail == "" {
+		return errors.New("review needs a customer email")
+	}
+
+	if review.Rating < 1 || review.Rating > 5 {
+		return errors.New("rating must be from 1 to 5 stars")
+	}
+
+	review.Title = strings.TrimSpace(review.Title)
+	if utf8.RuneCountInString(review.Title) > maxReviewTitle {
+		return fmt.Errorf("review title cannot be longer than %d characters", maxReviewTitle)
+	}
+
+	review.Body = strings.TrimSpace(review.Body)
+	if utf8.RuneCountInString(review.Body) > maxReviewBody {
+		return fmt.Errorf("review cannot be longer than %d characters", maxReviewBody)
+	}
+
+	book, err := r.BookRepo.GetBook(ctx, bookID)
+	if err != nil {
+		return err
+	}
+
+	if book.ID == 0 {
+		return fmt.Errorf("book ID %d was not found", bookID)
+	}
+
+	review.VerifiedPurchase, err = r.ReviewRepo.HasPurchased(ctx, review.CustomerEmail, bookID)
+	if err != nil {
+		return err
+	}
+
+	review.BookID = bookID
+	review.Status = entity.ReviewPending
+	review.ModeratedBy, review.ModeratedAt = "", nil
+
+	err = r.ReviewRepo.CreateReview(ctx, review)
+	if err == repository.ErrDuplicateReview {
+		return fmt.Errorf("%s already reviewed book ID %d", review.CustomerEmail, bookID)
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ApproveReview publishes a pending review, or one that was rejected
+func (r *ReviewRepository) ApproveReview(ctx context.Context, id int64, actor string) (entity.Review, error) {
+	return r.moderate(ctx, id, entity.ReviewApproved, actor)
+}
+
+// RejectReview hides a pending review, or takes back one that was approved
+func (r *ReviewRepository) RejectReview(ctx context.Context, id int64, actor string) (entity.Review, error) {
+	return r.moderate(ctx, id, entity.ReviewRejected, actor)
+}
+
+func (r *ReviewRepository) moderate(ctx context.Context, id int64, status string, actor string) (entity.Review, error) {
+	review, err := r.ReviewRepo.GetReview(ctx, id)
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	if review.ID == 0 {
+		return entity.Review{}, fmt.Errorf("review ID %d was not found", id)
+	}
+
+	if review.Status == status {
+		return entity.Review{}, fmt.Errorf("review ID %d is already %s", id, status)
+	}
+
+	from := review.Status
+	now := time.Now()
+	review.Status, review.ModeratedBy, review.ModeratedAt = status, actor, &now
+
+	err = r.ReviewRepo.ModerateReview(ctx, &review, from)
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	return review, nil
+}
+
+func isReviewStatus(status string) bool {
+	switch status {
+	case entity.ReviewPending, entity.ReviewApproved, entity.ReviewRejected:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,94 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/repository"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateReview(t *testing.T) {
+	testCases := []struct {
+		name         string
+		review       entity.Review
+		book         entity.Book
+		purchased    bool
+		createErr    error
+		wantVerified bool
+		isError      bool
+	}{
+		{name: "verified purchase", review: entity.Review{CustomerEmail: " Buyer@Mail.com ", Rating: 5, Body: "Loved it"}, book: entity.Book{ID: 5}, purchased: true, wantVerified: true},
+		{name: "not purchased", review: entity.Review{CustomerEmail: "buyer@mail.com", Rating: 3}, book: entity.Book{ID: 5}},
+		{name: "no customer", review: entity.Review{Rating: 3}, book: entity.Book{ID: 5}, isError: true},
+		{name: "rating out of range", review: entity.Review{CustomerEmail: "buyer@mail.com", Rating: 6}, book: entity.Book{ID: 5}, isError: true},
+		{name: "book not found", review: entity.Review{CustomerEmail: "buyer@mail.com", Rating: 3}, book: entity.Book{}, isError: true},
+		{name: "already reviewed", review: entity.Review{CustomerEmail: "buyer@mail.com", Rating: 3}, book: entity.Book{ID: 5}, createErr: repository.ErrDuplicateReview, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			bookRepo := new(mocks.BookRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(5)).Return(test.book, nil)
+
+			reviewRepo := new(mocks.ReviewRepository)
+			reviewRepo.On("HasPurchased", mock.Anything, "buyer@mail.com", int64(5)).Return(test.purchased, nil)
+			reviewRepo.On("CreateReview", mock.Anything, mock.Anything).Return(test.createErr)
+
+			reviewUsecase := usecase.NewReviewUsecase(&usecase.ReviewRepository{ReviewRepo: reviewRepo, BookRepo: bookRepo})
+			review := test.review
+			review.Status, review.VerifiedPurchase = entity.ReviewApproved, true
+			err := reviewUsecase.CreateReview(context.Background(), 5, &review)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, "buyer@mail.com", review.CustomerEmail)
+				assert.Equal(t, entity.ReviewPending, review.Status)
+				assert.Equal(t, test.wantVerified, review.VerifiedPurchase)
+				reviewRepo.AssertCalled(t, "CreateReview", mock.Anything, &review)
+			}
+		})
+	}
+}
+
+func TestModerateReview(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   string
+		approve  bool
+		wantFrom string
+		isError  bool
+	}{
+		{name: "approve pending", status: entity.ReviewPending, approve: true, wantFrom: entity.ReviewPending},
+		{name: "reject approved", status: entity.ReviewApproved, approve: false, wantFrom: entity.ReviewApproved},
+		{name: "approve approved", status: entity.ReviewApproved, approve: true, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			reviewRepo := new(mocks.ReviewRepository)
+			reviewRepo.On("GetReview", mock.Anything, int64(3)).Return(entity.Review{ID: 3, BookID: 5, Rating: 4, Status: test.status}, nil)
+			reviewRepo.On("ModerateReview", mock.Anything, mock.Anything, test.wantFrom).Return(nil)
+
+			reviewUsecase := usecase.NewReviewUsecase(&usecase.ReviewRepository{ReviewRepo: reviewRepo})
+			moderate := reviewUsecase.RejectReview
+			if test.approve {
+				moderate = reviewUsecase.ApproveReview
+			}
+			review, err := moderate(context.Background(), 3, "moderator")
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, "moderator", review.ModeratedBy)
+				assert.NotNil(t, review.ModeratedAt)
+				reviewRepo.AssertCalled(t, "ModerateReview", mock.Anything, &review, test.wantFrom)
+			} else {
+				reviewRepo.AssertNotCalled(t, "ModerateReview", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+type SeriesUsecase interface {
+	GetSeriesList(ctx context.Context) ([]entity.Series, error)
+	GetSeries(ctx context.Context, id int64) (entity.Series, error)
+	CreateSeries(ctx context.Context, series *entity.Series) error
+	UpdateSeries(ctx context.Context, id int64, series *entity.Series) error
+	DeleteSeries(ctx context.Context, id int64) error
+}
+
+type SeriesRepository struct {
+	SeriesRepo repository.SeriesRepository
+}
+
+func NewSeriesUsecase(repo *SeriesRepository) SeriesUsecase {
+	return &SeriesRepository{
+		SeriesRepo: repo.SeriesRepo,
+	}
+}
+
+func (r *SeriesRepository) GetSeriesList(ctx context.Context) ([]entity.Series, error) {
+	res, err := r.SeriesRepo.GetSeriesList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *SeriesRepository) GetSeries(ctx context.Context, id int64) (entity.Series, error) {
+	res, err := r.SeriesRepo.GetSeries(ctx, id)
+	if err != nil {
+		return entity.Series{}, err
+	}
+
+	return res, nil
+}
+
+func (r *SeriesRepository) CreateSeries(ctx context.Context, series *entity.Series) error {
+	err := validateSeries(series)
+	if err != nil {
+		return err
+	}
+
+	err = r.SeriesRepo.CreateSeries(ctx, series)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *SeriesRepository) UpdateSeries(ctx context.Context, id int64, series *entity.Series) error {
+	err := validateSeries(series)
+	if err != nil {
+		return err
+	}
+
+	err = r.SeriesRepo.UpdateSeries(ctx, id, series)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSeries removes a series, its books stay in the catalog
+func (r *SeriesRepository) DeleteSeries(ctx context.Context, id int64) error {
+	err := r.SeriesRepo.DeleteSeries(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateSeries(series *entity.Series) error {
+	series.Name = strings.TrimSpace(series.Name)
+	series.Description = strings.TrimSpace(series.Description)
+
+	if series.Name == "" {
+		return errors.New("series needs a name")
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateSeries(t *testing.T) {
+	testCases := []struct {
+		name    string
+		series  entity.Series
+		expName string
+		isError bool
+	}{
+		{
+			name:    "success",
+			series:  entity.Series{Name: " Discworld ", Description: "Novels set on the Disc"},
+			expName: "Discworld",
+			isError: false,
+		},
+		{
+			name:    "missing name",
+			series:  entity.Series{Name: "  "},
+			isError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			seriesRepo := new(mocks.SeriesRepository)
+			seriesRepo.On("CreateSeries", mock.Anything, mock.Anything).Return(nil)
+
+			seriesUsecase := usecase.NewSeriesUsecase(&usecase.SeriesRepository{SeriesRepo: seriesRepo})
+			err := seriesUsecase.CreateSeries(context.Background(), &test.series)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				seriesRepo.AssertNotCalled(t, "CreateSeries", mock.Anything, mock.Anything)
+			} else {
+				assert.Equal(t, test.expName, test.series.Name)
+			}
+		})
+	}
+}
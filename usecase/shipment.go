@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+type ShipmentUsecase interface {
+	GetShipment(ctx context.Context, id int64) (entity.Shipment, error)
+	GetOrderShipments(ctx context.Context, orderID int64) ([]entity.Shipment, error)
+	CreateShipment(ctx context.Context, orderID int64, shipment *entity.Shipment) error
+	UpdateShipmentStatus(ctx context.Context, id int64, status string) (entity.Shipment, error)
+}
+
+type ShipmentRepository struct {
+	ShipmentRepo repository.ShipmentRepository
+	OrderRepo    repository.OrderRepository
+	ShippingRepo repository.ShippingRepository
+}
+
+func NewShipmentUsecase(repo *ShipmentRepository) ShipmentUsecase {
+	return &ShipmentRepository{
+		ShipmentRepo: repo.ShipmentRepo,
+		OrderRepo:    repo.OrderRepo,
+		ShippingRepo: repo.ShippingRepo,
+	}
+}
+
+func (r *ShipmentRepository) GetShipment(ctx context.Context, id int64) (entity.Shipment, error) {
+	res, err := r.ShipmentRepo.GetShipment(ctx, id)
+	if err != nil {
+		return entity.Shipment{}, err
+	}
+
+	return res, nil
+}
+
+func (r *ShipmentRepository) GetOrderShipments(ctx context.Context, orderID int64) ([]entity.Shipment, error) {
+	res, err := r.ShipmentRepo.GetOrderShipments(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// CreateShipment records books of a confirmed order handed to a carrier with their tracking
+// number. The carrier defaults to the one of the order's shipping method and a shipment with no
+// lines ships all the books that are left.
+func (r *ShipmentRepository) CreateShipment(ctx context.Context, orderID int64, shipment *entity.Shipment) error {
+	shipment.TrackingNumber = strings.TrimSpace(shipment.TrackingNumber)
+	if shipment.TrackingNumber == "" {
+		return errors.New("shipment needs a tracking number")
+	}
+
+	order, err := r.OrderRepo.GetOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.ID == 0 {
+		return fmt.Errorf("order ID %d was not found", orderID)
+	}
+
+	if order.Status != entity.OrderConfirmed && order.Status != entity.OrderPartiallyShipped {
+		return fmt.Errorf("order ID %d is %s, only confirmed orders can be shipped", orderID, order.Status)
+	}
+
+	shipment.Carrier = strings.TrimSpace(shipment.Carrier)
+	if shipment.Carrier == "" && order.ShippingMethodID != 0 {
+		method, err := r.ShippingRepo.GetShippingMethod(ctx, order.ShippingMethodID)
+		if err != nil {
+			return err
+		}
+		shipment.Carrier = method.Carrier
+	}
+
+	if shipment.Carrier == "" {
+		return errors.New("shipment needs a carrier")
+	}
+
+	left, err := r.leftToShip(ctx, order)
+	if err != nil {
+		return err
+	}
+
+	lines, err := shipmentLines(order, shipment.Lines, left)
+	if err != nil {
+		return err
+	}
+
+	shipment.OrderID = orderID
+	shipment.Status = entity.ShipmentShipped
+	shipment.Lines = lines
+	shipment.DeliveredAt = nil
+
+	err = r.ShipmentRepo.CreateShipment(ctx, shipment)
+	if err != nil {
+		if errors.Is(err, repository.ErrOverShipment) {
+			return fmt.Errorf("another shipment was created meanwhile, please try again: %w", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// UpdateShipmentStatus records the carrier's tracking of a shipment, which only moves forward
+// from shipped through in transit to delivered
+func (r *ShipmentRepository) UpdateShipmentStatus(ctx context.Context, id int64, status string) (entity.Shipment, error) {
+	shipment, err := r.ShipmentRepo.GetShipment(ctx, id)
+	if err != nil {
+		return entity.Shipment{}, err
+	}
+
+	if shipment.ID == 0 {
+		return entity.Shipment{}, fmt.Errorf("shipment ID %d was not found", id)
+	}
+
+	from := shipment.Status
+	switch {
+	case from == entity.ShipmentShipped && status == entity.ShipmentInTransit:
+	case (from == entity.ShipmentShipped || from == entity.ShipmentInTransit) && status == entity.ShipmentDelivered:
+		now := time.Now()
+		shipment.DeliveredAt = &now
+	default:
+		return entity.Shipment{}, fmt.Errorf("shipment ID %d cannot go from %s to %q", id, from, status)
+	}
+
+	shipment.Status = status
+	err = r.ShipmentRepo.UpdateShipmentStatus(ctx, &shipment, from)
+	if err != nil {
+		return entity.Shipment{}, err
+	}
+
+	return shipment, nil
+}
+
+// leftToShip counts the books of every order line that are not shipped yet
+func (r *ShipmentRepository) leftToShip(ctx context.Context, order entity.Order) (map[int64]int, error) {
+	shipments, err := r.ShipmentRepo.GetOrderShipments(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	left := make(map[int64]int)
+	for _, line := range order.Lines {
+		left[line.ID] = line.Quantity
+	}
+
+	for _, shipment := range shipments {
+		for _, line := range shipment.Lines {
+			left[line.OrderLineID] -= line.Quantity
+		}
+	}
+
+	return left, nil
+}
+
+// shipmentLines validates the lines of a shipment against what is left of the order lines, or
+// ships all of it when there are none
+func shipmentLines(order entity.Order, lines []entity.ShipmentLine, left map[int64]int) ([]entity.ShipmentLine, error) {
+	if len(lines) == 0 {
+		for _, line := range order.Lines {
+			if left[line.ID] > 0 {
+				lines = append(lines, entity.ShipmentLine{OrderLineID: line.ID, Quantity: left[line.ID]})
+			}
+		}
+
+		if len(lines) == 0 {
+			return nil, fmt.Errorf("all the books of order ID %d are shipped", order.ID)
+		}
+	}
+
+	books := make(map[int64]int64)
+	for _, line := range order.Lines {
+		books[line.ID] = line.BookID
+	}
+
+	seen := make(map[int64]bool)
+	for i := range lines {
+		line := &lines[i]
+
+		bookID, ok := books[line.OrderLineID]
+		if !ok {
+			return nil, fmt.Errorf("order line ID %d is not on order ID %d", line.OrderLineID, order.ID)
+		}
+
+		if seen[line.OrderLineID] {
+			return nil, fmt.Errorf("order line ID %d is shipped more than once", line.OrderLineID)
+		}
+		seen[line.OrderLineID] = true
+
+		if line.Quantity <= 0 || line.Quantity > left[line.OrderLineID] {
+			return nil, fmt.Errorf("quantity of order line ID %d must be between 1 and the %d left to ship", line.OrderLineID, left[line.OrderLineID])
+		}
+
+		line.BookID = bookID
+	}
+
+	return lines, nil
+}
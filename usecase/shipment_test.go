@@ -0,0 +1,108 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// shippableOrder has 2 copies of book 5 on line 3 and 1 of book 6 on line 4, one copy of line 3
+// is shipped already
+var shippableOrder = entity.Order{
+	ID:               1,
+	Status:           entity.OrderPartiallyShipped,
+	ShippingMethodID: 2,
+	Lines:            []entity.OrderLine{{ID: 3, BookID: 5, Quantity: 2}, {ID: 4, BookID: 6, Quantity: 1}},
+}
+
+func TestCreateShipment(t *testing.T) {
+	testCases := []struct {
+		name        string
+		order       entity.Order
+		shipment    entity.Shipment
+		wantCarrier string
+		wantLines   []entity.ShipmentLine
+		isError     bool
+	}{
+		{
+			name:        "all that is left with the carrier of the shipping method",
+			order:       shippableOrder,
+			shipment:    entity.Shipment{TrackingNumber: "JNE123"},
+			wantCarrier: "JNE",
+			wantLines:   []entity.ShipmentLine{{OrderLineID: 3, BookID: 5, Quantity: 1}, {OrderLineID: 4, BookID: 6, Quantity: 1}},
+		},
+		{
+			name:        "some of the books",
+			order:       shippableOrder,
+			shipment:    entity.Shipment{Carrier: "SiCepat", TrackingNumber: "SC9", Lines: []entity.ShipmentLine{{OrderLineID: 4, Quantity: 1}}},
+			wantCarrier: "SiCepat",
+			wantLines:   []entity.ShipmentLine{{OrderLineID: 4, BookID: 6, Quantity: 1}},
+		},
+		{name: "more than is left", order: shippableOrder, shipment: entity.Shipment{TrackingNumber: "JNE123", Lines: []entity.ShipmentLine{{OrderLineID: 3, Quantity: 2}}}, isError: true},
+		{name: "no tracking number", order: shippableOrder, shipment: entity.Shipment{}, isError: true},
+		{name: "order not confirmed", order: entity.Order{ID: 1, Status: entity.OrderPending, Lines: shippableOrder.Lines}, shipment: entity.Shipment{TrackingNumber: "JNE123"}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			orderRepo := new(mocks.OrderRepository)
+			orderRepo.On("GetOrder", mock.Anything, int64(1)).Return(test.order, nil)
+
+			shippingRepo := new(mocks.ShippingRepository)
+			shippingRepo.On("GetShippingMethod", mock.Anything, int64(2)).Return(entity.ShippingMethod{ID: 2, Carrier: "JNE"}, nil)
+
+			shipmentRepo := new(mocks.ShipmentRepository)
+			shipmentRepo.On("GetOrderShipments", mock.Anything, int64(1)).Return([]entity.Shipment{{ID: 1, Lines: []entity.ShipmentLine{{OrderLineID: 3, BookID: 5, Quantity: 1}}}}, nil)
+			shipmentRepo.On("CreateShipment", mock.Anything, mock.Anything).Return(nil)
+
+			shipmentUsecase := usecase.NewShipmentUsecase(&usecase.ShipmentRepository{ShipmentRepo: shipmentRepo, OrderRepo: orderRepo, ShippingRepo: shippingRepo})
+			err := shipmentUsecase.CreateShipment(context.Background(), 1, &test.shipment)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, test.wantCarrier, test.shipment.Carrier)
+				assert.Equal(t, test.wantLines, test.shipment.Lines)
+				assert.Equal(t, entity.ShipmentShipped, test.shipment.Status)
+			} else {
+				shipmentRepo.AssertNotCalled(t, "CreateShipment", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestUpdateShipmentStatus(t *testing.T) {
+	testCases := []struct {
+		name          string
+		from          string
+		status        string
+		wantDelivered bool
+		isError       bool
+	}{
+		{name: "in transit", from: entity.ShipmentShipped, status: entity.ShipmentInTransit},
+		{name: "delivered", from: entity.ShipmentInTransit, status: entity.ShipmentDelivered, wantDelivered: true},
+		{name: "backwards", from: entity.ShipmentDelivered, status: entity.ShipmentInTransit, isError: true},
+		{name: "unknown status", from: entity.ShipmentShipped, status: "lost", isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			shipmentRepo := new(mocks.ShipmentRepository)
+			shipmentRepo.On("GetShipment", mock.Anything, int64(7)).Return(entity.Shipment{ID: 7, OrderID: 1, Status: test.from}, nil)
+			shipmentRepo.On("UpdateShipmentStatus", mock.Anything, mock.Anything, test.from).Return(nil)
+
+			shipmentUsecase := usecase.NewShipmentUsecase(&usecase.ShipmentRepository{ShipmentRepo: shipmentRepo})
+			shipment, err := shipmentUsecase.UpdateShipmentStatus(context.Background(), 7, test.status)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, test.status, shipment.Status)
+				assert.Equal(t, test.wantDelivered, shipment.DeliveredAt != nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+type ShippingUsecase interface {
+	GetShippingMethods(ctx context.Context) ([]entity.ShippingMethod, error)
+	GetShippingMethod(ctx context.Context, id int64) (entity.ShippingMethod, error)
+	CreateShippingMethod(ctx context.Context, method *entity.ShippingMethod) error
+	UpdateShippingMethod(ctx context.Context, id int64, method *entity.ShippingMethod) error
+	DeleteShippingMethod(ctx context.Context, id int64) error
+	GetShippingRate(ctx context.Context, id int64) (entity.ShippingRate, error)
+	CreateShippingRate(ctx context.Context, rate *entity.ShippingRate) error
+	UpdateShippingRate(ctx context.Context, id int64, rate *entity.ShippingRate) error
+	DeleteShippingRate(ctx context.Context, id int64) error
+}
+
+type ShippingRepository struct {
+	ShippingRepo repository.ShippingRepository
+}
+
+func NewShippingUsecase(repo *ShippingRepository) ShippingUsecase {
+	return &ShippingRepository{
+		ShippingRepo: repo.ShippingRepo,
+	}
+}
+
+func (r *ShippingRepository) GetShippingMethods(ctx context.Context) ([]entity.ShippingMethod, error) {
+	res, err := r.ShippingRepo.GetShippingMethods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *ShippingRepository) GetShippingMethod(ctx context.Context, id int64) (entity.ShippingMethod, error) {
+	res, err := r.ShippingRepo.GetShippingMethod(ctx, id)
+	if err != nil {
+		return entity.ShippingMethod{}, err
+	}
+
+	return res, nil
+}
+
+func (r *ShippingRepository) CreateShippingMethod(ctx context.Context, method *entity.ShippingMethod) error {
+	err := validateShippingMethod(method)
+	if err != nil {
+		return err
+	}
+
+	err = r.ShippingRepo.CreateShippingMethod(ctx, method)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ShippingRepository) UpdateShippingMethod(ctx context.Context, id int64, method *entity.ShippingMethod) error {
+	err := validateShippingMethod(method)
+	if err != nil {
+		return err
+	}
+
+	err = r.ShippingRepo.UpdateShippingMethod(ctx, id, method)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ShippingRepository) DeleteShippingMethod(ctx context.Context, id int64) error {
+	err := r.ShippingRepo.DeleteShippingMethod(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ShippingRepository) GetShippingRate(ctx context.Context, id int64) (entity.ShippingRate, error) {
+	res, err := r.ShippingRepo.GetShippingRate(ctx, id)
+	if err != nil {
+		return entity.ShippingRate{}, err
+	}
+
+	return res, nil
+}
+
+func (r *ShippingRepository) CreateShippingRate(ctx context.Context, rate *entity.ShippingRate) error {
+	err := r.validateShippingRate(ctx, rate)
+	if err != nil {
+		return err
+	}
+
+	err = r.ShippingRepo.CreateShippingRate(ctx, rate)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ShippingRepository) UpdateShippingRate(ctx context.Context, id int64, rate *entity.ShippingRate) error {
+	err := r.validateShippingRate(ctx, rate)
+	if err != nil {
+		return err
+	}
+
+	err = r.ShippingRepo.UpdateShippingRate(ctx, id, rate)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ShippingRepository) DeleteShippingRate(ctx context.Context, id int64) error {
+	err := r.ShippingRepo.DeleteShippingRate(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateShippingMethod(method *entity.ShippingMethod) error {
+	method.Code = slugify(method.Code)
+	if method.Code == "" {
+		return errors.New("shipping method needs a code")
+	}
+
+	method.Name = strings.TrimSpace(method.Name)
+	if method.Name == "" {
+		return errors.New("shipping method needs a name")
+	}
+
+	method.Carrier = strings.TrimSpace(method.Carrier)
+	if method.Carrier == "" {
+		return errors.New("shipping method needs a carrier")
+	}
+
+	return nil
+}
+
+func (r *ShippingRepository) validateShippingRate(ctx context.Context, rate *entity.ShippingRate) error {
+	var err error
+	rate.Country, rate.Region, err = normalizeJurisdiction(rate.Country, rate.Region)
+	if err != nil {
+		return err
+	}
+
+	if rate.MaxWeight < 0 {
+		return errors.New("max_weight cannot be negative")
+	}
+
+	if rate.Price < 0 {
+		return errors.New("price cannot be negative")
+	}
+
+	method, err := r.ShippingRepo.GetShippingMethod(ctx, rate.ShippingMethodID)
+	if err != nil {
+		return err
+	}
+
+	if method.ID == 0 {
+		return fmt.Errorf("shipping method ID %d was not found", rate.ShippingMethodID)
+	}
+
+	return nil
+}
+
+// shippingQuote weighs the books of the cart and adds what its shipping method charges for the
+// weight to the shipping address. An edition weighs what it says when it has a weight, digital
+// editions weigh nothing. Carts with no shipping method are not charged for shipping.
+func shippingQuote(ctx context.Context, shippingRepo repository.ShippingRepository, quote *entity.Quote, books map[int64]entity.Book, skus map[int64]entity.SKU, cart entity.Cart) error {
+	for _, line := range quote.Lines {
+		weight := books[line.BookID].WeightGrams
+		if sku, ok := skus[line.SKUID]; ok {
+			if sku.Digital() {
+				continue
+			}
+
+			if sku.WeightGrams > 0 {
+				weight = sku.WeightGrams
+			}
+		}
+
+		quote.WeightGrams += weight * line.Quantity
+	}
+
+	if cart.ShippingMethodID == 0 {
+		return nil
+	}
+
+	method, err := shippingRepo.GetShippingMethod(ctx, cart.ShippingMethodID)
+	if err != nil {
+		return err
+	}
+
+	if method.ID == 0 || !method.Active {
+		return fmt.Errorf("shipping method ID %d is not available", cart.ShippingMethodID)
+	}
+
+	rates, err := shippingRepo.GetZoneRates(ctx, method.ID, cart.ShippingCountry, cart.ShippingRegion)
+	if err != nil {
+		return err
+	}
+
+	rate, ok := shippingRate(rates, quote.WeightGrams, cart.ShippingRegion)
+	if !ok {
+		destination := strings.Trim(cart.ShippingCountry+" "+cart.ShippingRegion, " ")
+		if destination == "" {
+			destination = "no shipping country"
+		}
+		return fmt.Errorf("%s does not ship %d grams to %s", method.Name, quote.WeightGrams, destination)
+	}
+
+	quote.Shipping = rate.Price
+	quote.Total += quote.Shipping
+	return nil
+}
+
+// shippingRate picks the rate of the most specific zone, a region before its country before
+// anywhere, whose weight bracket covers the parcel, the narrowest bracket when several do
+func shippingRate(rates []entity.ShippingRate, weight int, region string) (entity.ShippingRate, bool) {
+	best, bestScore := entity.ShippingRate{}, -1
+	for _, rate := range rates {
+		if rate.MaxWeight != 0 && weight > rate.MaxWeight {
+			continue
+		}
+
+		score := 0
+		if rate.Country != "" {
+			score++
+		}
+		if rate.Region != "" {
+			if rate.Region != region {
+				continue
+			}
+			score++
+		}
+
+		narrower := best.MaxWeight == 0 || (rate.MaxWeight != 0 && rate.MaxWeight < best.MaxWeight)
+		if score > bestScore || (score == bestScore && narrower) {
+			best, bestScore = rate, score
+		}
+	}
+
+	return best, bestScore >= 0
+}
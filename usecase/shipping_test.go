@@ -0,0 +1,129 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateShippingRate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		rate        entity.ShippingRate
+		method      entity.ShippingMethod
+		wantCountry string
+		isError     bool
+	}{
+		{name: "success", rate: entity.ShippingRate{ShippingMethodID: 1, Country: "id", Region: "jk", MaxWeight: 1000, Price: 9000}, method: entity.ShippingMethod{ID: 1}, wantCountry: "ID"},
+		{name: "anywhere", rate: entity.ShippingRate{ShippingMethodID: 1, Price: 90000}, method: entity.ShippingMethod{ID: 1}},
+		{name: "region without a country", rate: entity.ShippingRate{ShippingMethodID: 1, Region: "JK", Price: 9000}, method: entity.ShippingMethod{ID: 1}, isError: true},
+		{name: "negative weight", rate: entity.ShippingRate{ShippingMethodID: 1, Country: "ID", MaxWeight: -1, Price: 9000}, method: entity.ShippingMethod{ID: 1}, isError: true},
+		{name: "method not found", rate: entity.ShippingRate{ShippingMethodID: 1, Country: "ID", Price: 9000}, method: entity.ShippingMethod{}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			shippingRepo := new(mocks.ShippingRepository)
+			shippingRepo.On("GetShippingMethod", mock.Anything, int64(1)).Return(test.method, nil)
+			shippingRepo.On("CreateShippingRate", mock.Anything, mock.Anything).Return(nil)
+
+			shippingUsecase := usecase.NewShippingUsecase(&usecase.ShippingRepository{ShippingRepo: shippingRepo})
+			err := shippingUsecase.CreateShippingRate(context.Background(), &test.rate)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, test.wantCountry, test.rate.Country)
+			}
+		})
+	}
+}
+
+func TestQuoteCartShipping(t *testing.T) {
+	// book 1 weighs 400 grams, its hardcover 900 grams, book 2 weighs 1200 grams
+	books := map[int64]entity.Book{
+		1: {ID: 1, Price: 50000, WeightGrams: 400},
+		2: {ID: 2, Price: 30000, WeightGrams: 1200},
+	}
+	skus := map[int64]entity.SKU{
+		10: {ID: 10, BookID: 1, Format: entity.FormatHardcover, Price: 90000, WeightGrams: 900},
+		11: {ID: 11, BookID: 1, Format: entity.FormatEbook, Price: 20000},
+		12: {ID: 12, BookID: 1, Format: entity.FormatPaperback, Price: 45000},
+	}
+
+	standard := entity.ShippingMethod{ID: 1, Name: "Standard", Carrier: "JNE", Active: true}
+	rates := []entity.ShippingRate{
+		{ID: 1, ShippingMethodID: 1, Country: "ID", MaxWeight: 1000, Price: 10000},
+		{ID: 2, ShippingMethodID: 1, Country: "ID", MaxWeight: 5000, Price: 20000},
+		{ID: 3, ShippingMethodID: 1, Country: "ID", Region: "JK", MaxWeight: 1000, Price: 8000},
+		{ID: 4, ShippingMethodID: 1, MaxWeight: 2000, Price: 90000},
+	}
+
+	testCases := []struct {
+		name         string
+		cart         entity.Cart
+		method       entity.ShippingMethod
+		wantWeight   int
+		wantShipping int
+		isError      bool
+	}{
+		{name: "no shipping method", cart: entity.Cart{ShippingCountry: "ID", Lines: []entity.OrderLine{{BookID: 1, Quantity: 2}}}, wantWeight: 800},
+		{name: "lightest bracket of the country", cart: entity.Cart{ShippingCountry: "ID", ShippingMethodID: 1, Lines: []entity.OrderLine{{BookID: 1, Quantity: 2}}}, method: standard, wantWeight: 800, wantShipping: 10000},
+		{name: "heavier bracket", cart: entity.Cart{ShippingCountry: "ID", ShippingMethodID: 1, Lines: []entity.OrderLine{{BookID: 1, Quantity: 1}, {BookID: 2, Quantity: 1}}}, method: standard, wantWeight: 1600, wantShipping: 20000},
+		{name: "region before country", cart: entity.Cart{ShippingCountry: "ID", ShippingRegion: "JK", ShippingMethodID: 1, Lines: []entity.OrderLine{{BookID: 1, Quantity: 1}}}, method: standard, wantWeight: 400, wantShipping: 8000},
+		{name: "region falls back to its country when too heavy", cart: entity.Cart{ShippingCountry: "ID", ShippingRegion: "JK", ShippingMethodID: 1, Lines: []entity.OrderLine{{BookID: 2, Quantity: 1}}}, method: standard, wantWeight: 1200, wantShipping: 20000},
+		{name: "anywhere else", cart: entity.Cart{ShippingCountry: "SG", ShippingMethodID: 1, Lines: []entity.OrderLine{{BookID: 2, Quantity: 1}}}, method: standard, wantWeight: 1200, wantShipping: 90000},
+		{name: "edition weighs its own weight", cart: entity.Cart{ShippingCountry: "ID", ShippingMethodID: 1, Lines: []entity.OrderLine{{BookID: 1, SKUID: 10, Quantity: 2}}}, method: standard, wantWeight: 1800, wantShipping: 20000},
+		{name: "digital edition weighs nothing", cart: entity.Cart{ShippingCountry: "ID", ShippingMethodID: 1, Lines: []entity.OrderLine{{BookID: 1, SKUID: 11, Quantity: 3}, {BookID: 1, Quantity: 1}}}, method: standard, wantWeight: 400, wantShipping: 10000},
+		{name: "edition without a weight weighs the book", cart: entity.Cart{ShippingCountry: "ID", ShippingMethodID: 1, Lines: []entity.OrderLine{{BookID: 1, SKUID: 12, Quantity: 1}}}, method: standard, wantWeight: 400, wantShipping: 10000},
+		{name: "too heavy to ship", cart: entity.Cart{ShippingCountry: "SG", ShippingMethodID: 1, Lines: []entity.OrderLine{{BookID: 2, Quantity: 2}}}, method: standard, isError: true},
+		{name: "inactive method", cart: entity.Cart{ShippingCountry: "ID", ShippingMethodID: 1, Lines: []entity.OrderLine{{BookID: 1, Quantity: 1}}}, method: entity.ShippingMethod{ID: 1, Name: "Standard"}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			bookRepo := new(mocks.BookRepository)
+			for id, book := range books {
+				bookRepo.On("GetBook", mock.Anything, id).Return(book, nil)
+			}
+
+			skuRepo := new(mocks.SKURepository)
+			for id, sku := range skus {
+				skuRepo.On("GetSKU", mock.Anything, id).Return(sku, nil)
+			}
+
+			promotionRepo := new(mocks.PromotionRepository)
+			promotionRepo.On("GetApplicablePromotions", mock.Anything, mock.Anything).Return(nil, nil)
+			promotionRepo.On("GetRedemptions", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+			taxRepo := new(mocks.TaxRepository)
+			taxRepo.On("GetJurisdictionRules", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+			shippingRepo := new(mocks.ShippingRepository)
+			shippingRepo.On("GetShippingMethod", mock.Anything, int64(1)).Return(test.method, nil)
+			shippingRepo.On("GetZoneRates", mock.Anything, int64(1), mock.Anything, mock.Anything).Return(func(_ context.Context, _ int64, country string, region string) []entity.ShippingRate {
+				var zone []entity.ShippingRate
+				for _, rate := range rates {
+					if rate.Country == "" || (rate.Country == country && (rate.Region == "" || rate.Region == region)) {
+						zone = append(zone, rate)
+					}
+				}
+				return zone
+			}, nil)
+
+			promotionUsecase := usecase.NewPromotionUsecase(&usecase.PromotionRepository{PromotionRepo: promotionRepo, BookRepo: bookRepo, SKURepo: skuRepo, CategoryRepo: noCategories(), TaxRepo: taxRepo, ShippingRepo: shippingRepo})
+			quote, err := promotionUsecase.QuoteCart(context.Background(), &test.cart)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, test.wantWeight, quote.WeightGrams)
+				assert.Equal(t, test.wantShipping, quote.Shipping)
+				assert.Equal(t, quote.Subtotal+test.wantShipping, quote.Total)
+			}
+		})
+	}
+}
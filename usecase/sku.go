@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+type SKUUsecase interface {
+	GetBookSKUs(ctx context.Context, bookID int64) ([]entity.SKU, error)
+	GetSKU(ctx context.Context, bookID int64, id int64) (entity.SKU, error)
+	CreateSKU(ctx context.Context, sku *entity.SKU) error
+	UpdateSKU(ctx context.Context, bookID int64, id int64, sku *entity.SKU) error
+	DeleteSKU(ctx context.Context, bookID int64, id int64) error
+}
+
+type SKURepository struct {
+	SKURepo  repository.SKURepository
+	BookRepo repository.BookRepository
+	TaxRepo  repository.TaxRepository
+}
+
+func NewSKUUsecase(repo *SKURepository) SKUUsecase {
+	return &SKURepository{
+		SKURepo:  repo.SKURepo,
+		BookRepo: repo.BookRepo,
+		TaxRepo:  repo.TaxRepo,
+	}
+}
+
+func (r *SKURepository) GetBookSKUs(ctx context.Context, bookID int64) ([]entity.SKU, error) {
+	res, err := r.SKURepo.GetSKUs(ctx, []int64{bookID})
+	if err != nil {
+		return nil, err
+	}
+
+	return res[bookID], nil
+}
+
+// GetSKU returns the SKU only when it belongs to the given book
+func (r *SKURepository) GetSKU(ctx context.Context, bookID int64, id int64) (entity.SKU, error) {
+	res, err := r.SKURepo.GetSKU(ctx, id)
+	if err != nil {
+		return entity.SKU{}, err
+	}
+
+	if res.BookID != bookID {
+		return entity.SKU{}, nil
+	}
+
+	return res, nil
+}
+
+func (r *SKURepository) CreateSKU(ctx context.Context, sku *entity.SKU) error {
+	err := validateSKU(sku)
+	if err != nil {
+		return err
+	}
+
+	book, err := r.BookRepo.GetBook(ctx, sku.BookID)
+	if err != nil {
+		return err
+	}
+
+	if book.ID == 0 {
+		return fmt.Errorf("book ID %d was not found", sku.BookID)
+	}
+
+	err = checkTaxCategory(ctx, r.TaxRepo, sku.TaxCategoryID)
+	if err != nil {
+		return err
+	}
+
+	// the opening stock is recorded as a receipt so the stock ledger adds up from the start
+	var receipt *entity.StockMovement
+	if sku.Stock > 0 {
+		receipt = &entity.StockMovement{Type: entity.MovementReceipt, Quantity: sku.Stock, Reason: "initial stock", Actor: systemActor}
+	}
+
+	err = r.SKURepo.CreateSKU(ctx, sku, receipt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateSKU changes the details of an edition, its stock only changes through stock movements
+func (r *SKURepository) UpdateSKU(ctx context.Context, bookID int64, id int64, sku *entity.SKU) error {
+	err := validateSKU(sku)
+	if err != nil {
+		return err
+	}
+
+	existing, err := r.GetSKU(ctx, bookID, id)
+	if err != nil {
+		return err
+	}
+
+	if existing.ID == 0 {
+		return fmt.Errorf("SKU ID %d was not found", id)
+	}
+
+	err = checkTaxCategory(ctx, r.TaxRepo, sku.TaxCategoryID)
+	if err != nil {
+		return err
+	}
+
+	sku.BookID = bookID
+	sku.Stock, sku.Reserved = existing.Stock, existing.Reserved
+	err = r.SKURepo.UpdateSKU(ctx, id, sku)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *SKURepository) DeleteSKU(ctx context.Context, bookID int64, id int64) error {
+	existing, err := r.GetSKU(ctx, bookID, id)
+	if err != nil {
+		return err
+	}
+
+	if existing.ID == 0 {
+		return fmt.Errorf("SKU ID %d was not found", id)
+	}
+
+	err = r.SKURepo.DeleteSKU(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// lineSKUs loads the editions ordered by the lines keyed by id, each has to be an edition of the
+// book of its line
+func lineSKUs(ctx context.Context, skuRepo repository.SKURepository, lines []entity.OrderLine) (map[int64]entity.SKU, error) {
+	skus := make(map[int64]entity.SKU)
+	for _, line := range lines {
+		if line.SKUID == 0 {
+			continue
+		}
+
+		if _, ok := skus[line.SKUID]; ok {
+			continue
+		}
+
+		sku, err := skuRepo.GetSKU(ctx, line.SKUID)
+		if err != nil {
+			return nil, err
+		}
+
+		if sku.ID == 0 || sku.BookID != line.BookID {
+			return nil, fmt.Errorf("SKU ID %d of book ID %d was not found", line.SKUID, line.BookID)
+		}
+
+		skus[sku.ID] = sku
+	}
+
+	return skus, nil
+}
+
+// checkSKUStock makes sure the shipped editions have the copies the lines order available, less
+// what the waiting pre-orders claimed
+func checkSKUStock(lines []entity.OrderLine, skus map[int64]entity.SKU, claimed map[int64]int) error {
+	_, quantities := shippedQuantities(lines, skus)
+	for id, quantity := range quantities {
+		sku := skus[id]
+		if available := sku.Stock - sku.Reserved - claimed[id]; available < quantity {
+			return fmt.Errorf("not enough stock of SKU ID %d, %d more copies are needed", id, quantity-available)
+		}
+	}
+
+	return nil
+}
+
+// validateSKU checks the format, ISBN and measures of an edition
+func validateSKU(sku *entity.SKU) error {
+	switch sku.Format {
+	case entity.FormatHardcover, entity.FormatPaperback:
+	case entity.FormatEbook, entity.FormatAudiobook:
+		// digital editions are never shipped and hold no stock
+		sku.Stock, sku.WeightGrams, sku.WidthMM, sku.HeightMM, sku.DepthMM = 0, 0, 0, 0, 0
+	default:
+		return fmt.Errorf("invalid format %q", sku.Format)
+	}
+
+	if sku.Price < 0 || sku.Stock < 0 || sku.WeightGrams < 0 || sku.WidthMM < 0 || sku.HeightMM < 0 || sku.DepthMM < 0 {
+		return errors.New("price, stock, weight and dimensions cannot be negative")
+	}
+
+	if sku.ISBN == "" {
+		return nil
+	}
+
+	sku.ISBN = normalizeISBN(sku.ISBN)
+	if !validISBN(sku.ISBN) {
+		return fmt.Errorf("invalid ISBN %q", sku.ISBN)
+	}
+
+	return nil
+}
+
+// normalizeISBN strips hyphens and spaces from an ISBN
+func normalizeISBN(isbn string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(isbn))
+}
+
+// validISBN verifies the check digit of a normalized ISBN-10 or ISBN-13
+func validISBN(isbn string) bool {
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i, c := range isbn {
+			var digit int
+			switch {
+			case c >= '0' && c <= '9':
+				digit = int(c - '0')
+			case c == 'X' && i == 9:
+				digit = 10
+			default:
+				return false
+			}
+			sum += digit * (10 - i)
+		}
+		return sum%11 == 0
+	case 13:
+		sum := 0
+		for i, c := range isbn {
+			if c < '0' || c > '9' {
+				return false
+			}
+			weight := 1
+			if i%2 == 1 {
+				weight = 3
+			}
+			sum += int(c-'0') * weight
+		}
+		return sum%10 == 0
+	}
+
+	return false
+}
@@ -0,0 +1,272 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockSKUProvider struct {
+	skuRepo  *mocks.SKURepository
+	bookRepo *mocks.BookRepository
+	taxRepo  *mocks.TaxRepository
+}
+
+func skuProvider() mockSKUProvider {
+	return mockSKUProvider{
+		skuRepo:  new(mocks.SKURepository),
+		bookRepo: new(mocks.BookRepository),
+		taxRepo:  new(mocks.TaxRepository),
+	}
+}
+
+func newSKUUsecase(prov mockSKUProvider) usecase.SKUUsecase {
+	return usecase.NewSKUUsecase(&usecase.SKURepository{SKURepo: prov.skuRepo, BookRepo: prov.bookRepo, TaxRepo: prov.taxRepo})
+}
+
+func TestGetBookSKUs(t *testing.T) {
+	testCases := []struct {
+		name    string
+		skus    map[int64][]entity.SKU
+		expLen  int
+		isError bool
+		wantErr error
+	}{
+		{
+			name:    "success",
+			skus:    map[int64][]entity.SKU{1: {{ID: 1, BookID: 1, Format: entity.FormatHardcover}, {ID: 2, BookID: 1, Format: entity.FormatEbook}}},
+			expLen:  2,
+			isError: false,
+			wantErr: nil,
+		},
+		{
+			name:    "failed",
+			skus:    nil,
+			expLen:  0,
+			isError: true,
+			wantErr: errors.New("Dummy Error"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := skuProvider()
+			prov.skuRepo.On("GetSKUs", mock.Anything, []int64{1}).Return(test.skus, test.wantErr)
+
+			res, err := newSKUUsecase(prov).GetBookSKUs(context.Background(), 1)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Len(t, res, test.expLen)
+		})
+	}
+}
+
+func TestGetSKU(t *testing.T) {
+	testCases := []struct {
+		name   string
+		bookID int64
+		sku    entity.SKU
+		expID  int64
+	}{
+		{
+			name:   "success",
+			bookID: 1,
+			sku:    entity.SKU{ID: 3, BookID: 1},
+			expID:  3,
+		},
+		{
+			name:   "SKU belongs to another book",
+			bookID: 2,
+			sku:    entity.SKU{ID: 3, BookID: 1},
+			expID:  0,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := skuProvider()
+			prov.skuRepo.On("GetSKU", mock.Anything, int64(3)).Return(test.sku, nil)
+
+			res, err := newSKUUsecase(prov).GetSKU(context.Background(), test.bookID, 3)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expID, res.ID)
+		})
+	}
+}
+
+func TestCreateSKU(t *testing.T) {
+	testCases := []struct {
+		name    string
+		sku     entity.SKU
+		book    entity.Book
+		expISBN string
+		isError bool
+	}{
+		{
+			name:    "success with hyphenated ISBN-13",
+			sku:     entity.SKU{BookID: 1, Format: entity.FormatHardcover, ISBN: "978-0-306-40615-7", Price: 250000, Stock: 5, WeightGrams: 600},
+			book:    entity.Book{ID: 1},
+			expISBN: "9780306406157",
+			isError: false,
+		},
+		{
+			name:    "success with ISBN-10 check digit X",
+			sku:     entity.SKU{BookID: 1, Format: entity.FormatPaperback, ISBN: "0-8044-2957-X"},
+			book:    entity.Book{ID: 1},
+			expISBN: "080442957X",
+			isError: false,
+		},
+		{
+			name:    "success without ISBN",
+			sku:     entity.SKU{BookID: 1, Format: entity.FormatAudiobook},
+			book:    entity.Book{ID: 1},
+			expISBN: "",
+			isError: false,
+		},
+		{
+			name:    "failed with invalid check digit",
+			sku:     entity.SKU{BookID: 1, Format: entity.FormatHardcover, ISBN: "978-0-306-40615-8"},
+			book:    entity.Book{ID: 1},
+			isError: true,
+		},
+		{
+			name:    "failed with unknown format",
+			sku:     entity.SKU{BookID: 1, Format: "scroll"},
+			book:    entity.Book{ID: 1},
+			isError: true,
+		},
+		{
+			name:    "failed with negative price",
+			sku:     entity.SKU{BookID: 1, Format: entity.FormatPaperback, Price: -1},
+			book:    entity.Book{ID: 1},
+			isError: true,
+		},
+		{
+			name:    "failed with book not found",
+			sku:     entity.SKU{BookID: 1, Format: entity.FormatPaperback},
+			book:    entity.Book{},
+			isError: true,
+		},
+		{
+			name:    "success with a tax category of its own",
+			sku:     entity.SKU{BookID: 1, Format: entity.FormatEbook, TaxCategoryID: 2},
+			book:    entity.Book{ID: 1, TaxCategoryID: 1},
+			isError: false,
+		},
+		{
+			name:    "failed with unknown tax category",
+			sku:     entity.SKU{BookID: 1, Format: entity.FormatEbook, TaxCategoryID: 9},
+			book:    entity.Book{ID: 1},
+			isError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := skuProvider()
+			prov.bookRepo.On("GetBook", mock.Anything, int64(1)).Return(test.book, nil)
+			prov.taxRepo.On("GetTaxCategory", mock.Anything, int64(2)).Return(entity.TaxCategory{ID: 2, Code: "ebooks"}, nil)
+			prov.taxRepo.On("GetTaxCategory", mock.Anything, int64(9)).Return(entity.TaxCategory{}, nil)
+			prov.skuRepo.On("CreateSKU", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			err := newSKUUsecase(prov).CreateSKU(context.Background(), &test.sku)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, test.expISBN, test.sku.ISBN)
+			}
+			if !test.isError && test.sku.Stock > 0 {
+				prov.skuRepo.AssertCalled(t, "CreateSKU", mock.Anything, mock.Anything, &entity.StockMovement{Type: entity.MovementReceipt, Quantity: test.sku.Stock, Reason: "initial stock", Actor: "system"})
+			}
+		})
+	}
+}
+
+func TestCreateDigitalSKUDropsMeasures(t *testing.T) {
+	prov := skuProvider()
+	prov.bookRepo.On("GetBook", mock.Anything, int64(1)).Return(entity.Book{ID: 1}, nil)
+	prov.skuRepo.On("CreateSKU", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	sku := entity.SKU{BookID: 1, Format: entity.FormatEbook, WeightGrams: 300, WidthMM: 130}
+	err := newSKUUsecase(prov).CreateSKU(context.Background(), &sku)
+
+	assert.NoError(t, err)
+	assert.Zero(t, sku.WeightGrams)
+	assert.Zero(t, sku.WidthMM)
+}
+
+func TestUpdateSKU(t *testing.T) {
+	testCases := []struct {
+		name     string
+		existing entity.SKU
+		isError  bool
+	}{
+		{
+			name:     "success",
+			existing: entity.SKU{ID: 3, BookID: 1},
+			isError:  false,
+		},
+		{
+			name:     "failed with SKU of another book",
+			existing: entity.SKU{ID: 3, BookID: 2},
+			isError:  true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := skuProvider()
+			prov.skuRepo.On("GetSKU", mock.Anything, int64(3)).Return(test.existing, nil)
+			prov.skuRepo.On("UpdateSKU", mock.Anything, int64(3), mock.Anything).Return(nil)
+
+			err := newSKUUsecase(prov).UpdateSKU(context.Background(), 1, 3, &entity.SKU{Format: entity.FormatPaperback})
+
+			assert.Equal(t, test.isError, err != nil)
+		})
+	}
+}
+
+func TestDeleteSKU(t *testing.T) {
+	testCases := []struct {
+		name      string
+		existing  entity.SKU
+		deleteErr error
+		isError   bool
+	}{
+		{
+			name:     "success",
+			existing: entity.SKU{ID: 3, BookID: 1},
+			isError:  false,
+		},
+		{
+			name:     "failed with SKU not found",
+			existing: entity.SKU{},
+			isError:  true,
+		},
+		{
+			name:      "failed to delete",
+			existing:  entity.SKU{ID: 3, BookID: 1},
+			deleteErr: errors.New("Dummy Error"),
+			isError:   true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			prov := skuProvider()
+			prov.skuRepo.On("GetSKU", mock.Anything, int64(3)).Return(test.existing, nil)
+			prov.skuRepo.On("DeleteSKU", mock.Anything, int64(3)).Return(test.deleteErr)
+
+			err := newSKUUsecase(prov).DeleteSKU(context.Background(), 1, 3)
+
+			assert.Equal(t, test.isError, err != nil)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+// systemActor records movements that are not made by a person, such as the initial stock of a new book
+const systemActor = "system"
+
+type StockUsecase interface {
+	GetStockMovements(ctx context.Context, bookID int64) ([]entity.StockMovement, error)
+	RecordMovement(ctx context.Context, movement *entity.StockMovement) error
+	TransferStock(ctx context.Context, transfer *entity.StockTransfer) error
+}
+
+type StockRepository struct {
+	StockRepo     repository.StockRepository
+	BookRepo      repository.BookRepository
+	SKURepo       repository.SKURepository
+	WarehouseRepo repository.WarehouseRepository
+}
+
+func NewStockUsecase(repo *StockRepository) StockUsecase {
+	return &StockRepository{
+		StockRepo:     repo.StockRepo,
+		BookRepo:      repo.BookRepo,
+		SKURepo:       repo.SKURepo,
+		WarehouseRepo: repo.WarehouseRepo,
+	}
+}
+
+func (r *StockRepository) GetStockMovements(ctx context.Context, bookID int64) ([]entity.StockMovement, error) {
+	res, err := r.StockRepo.GetStockMovements(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RecordMovement validates the movement, turns its quantity into the signed stock change and
+// appends it to the ledger. Movements without a warehouse go to the default warehouse, those of
+// an edition also change its stock.
+func (r *StockRepository) RecordMovement(ctx context.Context, movement *entity.StockMovement) error {
+	err := prepareStockMovement(movement)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.getBook(ctx, movement.BookID)
+	if err != nil {
+		return err
+	}
+
+	if movement.SKUID != 0 {
+		err = r.checkStockedSKU(ctx, movement.BookID, movement.SKUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if movement.WarehouseID != 0 {
+		err = r.checkWarehouse(ctx, movement.WarehouseID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = r.StockRepo.RecordMovement(ctx, movement)
+	if err != nil {
+		if err == repository.ErrInsufficientStock && movement.SKUID != 0 {
+			return fmt.Errorf("book ID %d does not have %d of SKU ID %d available in the warehouse", movement.BookID, -movement.Quantity, movement.SKUID)
+		}
+		if err == repository.ErrInsufficientStock {
+			return fmt.Errorf("book ID %d does not have %d available in the warehouse", movement.BookID, -movement.Quantity)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// TransferStock moves available stock of a book, or of one of its editions, from one warehouse
+// to another
+func (r *StockRepository) TransferStock(ctx context.Context, transfer *entity.StockTransfer) error {
+	transfer.Reason = strings.TrimSpace(transfer.Reason)
+	transfer.Actor = strings.TrimSpace(transfer.Actor)
+
+	if transfer.Actor == "" {
+		return errors.New("stock transfer needs an actor")
+	}
+
+	if transfer.Quantity <= 0 {
+		return errors.New("transfer quantity must be greater than zero")
+	}
+
+	if transfer.FromWarehouseID == transfer.ToWarehouseID {
+		return errors.New("stock can only be transferred between two different warehouses")
+	}
+
+	_, err := r.getBook(ctx, transfer.BookID)
+	if err != nil {
+		return err
+	}
+
+	if transfer.SKUID != 0 {
+		err = r.checkStockedSKU(ctx, transfer.BookID, transfer.SKUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, warehouseID := range []int64{transfer.FromWarehouseID, transfer.ToWarehouseID} {
+		err = r.checkWarehouse(ctx, warehouseID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = r.StockRepo.TransferStock(ctx, transfer)
+	if err != nil {
+		if err == repository.ErrInsufficientStock && transfer.SKUID != 0 {
+			return fmt.Errorf("warehouse ID %d does not have %d of SKU ID %d of book ID %d available", transfer.FromWarehouseID, transfer.Quantity, transfer.SKUID, transfer.BookID)
+		}
+		if err == repository.ErrInsufficientStock {
+			return fmt.Errorf("warehouse ID %d does not have %d of book ID %d available", transfer.FromWarehouseID, transfer.Quantity, transfer.BookID)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// checkStockedSKU makes sure the edition is one of the book's that holds stock
+func (r *StockRepository) checkStockedSKU(ctx context.Context, bookID, skuID int64) error {
+	sku, err := r.SKURepo.GetSKU(ctx, skuID)
+	if err != nil {
+		return err
+	}
+
+	if sku.ID == 0 || sku.BookID != bookID {
+		return fmt.Errorf("SKU ID %d of book ID %d was not found", skuID, bookID)
+	}
+
+	if sku.Digital() {
+		return fmt.Errorf("SKU ID %d is a digital edition and holds no stock", skuID)
+	}
+
+	return nil
+}
+
+func (r *StockRepository) getBook(ctx context.Context, bookID int64) (entity.Book, error) {
+	book, err := r.BookRepo.GetBook(ctx, bookID)
+	if err != nil {
+		return entity.Book{}, err
+	}
+
+	if book.ID == 0 {
+		return entity.Book{}, fmt.Errorf("book ID %d was not found", bookID)
+	}
+
+	return book, nil
+}
+
+func (r *StockRepository) checkWarehouse(ctx context.Context, warehouseID int64) error {
+	warehouse, err := r.WarehouseRepo.GetWarehouse(ctx, warehouseID)
+	if err != nil {
+		return err
+	}
+
+	if warehouse.ID == 0 {
+		return fmt.Errorf("warehouse ID %d was not found", warehouseID)
+	}
+
+	return nil
+}
+
+// prepareStockMovement checks the movement and signs its quantity. Receipts and returns add
+// stock, sales and damages remove it and adjustments carry their own sign.
+func prepareStockMovement(movement *entity.StockMovement) error {
+	movement.Reason = strings.TrimSpace(movement.Reason)
+	movement.Actor = strings.TrimSpace(movement.Actor)
+
+	if movement.Actor == "" {
+		return errors.New("stock movement needs an actor")
+	}
+
+	switch movement.Type {
+	case entity.MovementReceipt, entity.MovementReturn:
+		if movement.Quantity <= 0 {
+			return fmt.Errorf("%s quantity must be greater than zero", movement.Type)
+		}
+	case entity.MovementSale, entity.MovementDamage:
+		if movement.Quantity <= 0 {
+			return fmt.Errorf("%s quantity must be greater than zero", movement.Type)
+		}
+		movement.Quantity = -movement.Quantity
+	case entity.MovementAdjustment:
+		if movement.Quantity == 0 {
+			return errors.New("adjustment quantity cannot be zero")
+		}
+	default:
+		return fmt.Errorf("invalid stock movement type %q", movement.Type)
+	}
+
+	if movement.Reason == "" && (movement.Type == entity.MovementAdjustment || movement.Type == entity.MovementDamage) {
+		return fmt.Errorf("%s needs a reason", movement.Type)
+	}
+
+	return nil
+}
@@ -0,0 +1,273 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/repository"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetStockMovements(t *testing.T) {
+	testCases := []struct {
+		name      string
+		movements []entity.StockMovement
+		wantErr   error
+		isError   bool
+	}{
+		{
+			name:      "success",
+			movements: []entity.StockMovement{{ID: 1, BookID: 1, Type: entity.MovementReceipt, Quantity: 5, StockAfter: 5}},
+			isError:   false,
+		},
+		{
+			name:    "failed",
+			wantErr: errors.New("Dummy Error"),
+			isError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			stockRepo := new(mocks.StockRepository)
+			stockRepo.On("GetStockMovements", mock.Anything, int64(1)).Return(test.movements, test.wantErr)
+
+			stockUsecase := usecase.NewStockUsecase(&usecase.StockRepository{StockRepo: stockRepo})
+			res, err := stockUsecase.GetStockMovements(context.Background(), 1)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.movements, res)
+		})
+	}
+}
+
+func TestRecordMovement(t *testing.T) {
+	testCases := []struct {
+		name         string
+		movement     entity.StockMovement
+		book         entity.Book
+		recordErr    error
+		wantQuantity int
+		isError      bool
+	}{
+		{
+			name:         "receipt adds stock",
+			movement:     entity.StockMovement{BookID: 1, Type: entity.MovementReceipt, Quantity: 10, Actor: "warehouse"},
+			book:         entity.Book{ID: 1},
+			wantQuantity: 10,
+		},
+		{
+			name:         "sale removes stock",
+			movement:     entity.StockMovement{BookID: 1, Type: entity.MovementSale, Quantity: 2, Actor: "warehouse"},
+			book:         entity.Book{ID: 1, Stock: 5},
+			wantQuantity: -2,
+		},
+		{
+			name:         "damage removes stock",
+			movement:     entity.StockMovement{BookID: 1, Type: entity.MovementDamage, Quantity: 1, Reason: "water damage", Actor: "warehouse"},
+			book:         entity.Book{ID: 1, Stock: 5},
+			wantQuantity: -1,
+		},
+		{
+			name:         "negative adjustment",
+			movement:     entity.StockMovement{BookID: 1, Type: entity.MovementAdjustment, Quantity: -3, Reason: "stock count", Actor: "warehouse"},
+			book:         entity.Book{ID: 1, Stock: 5},
+			wantQuantity: -3,
+		},
+		{
+			name:     "negative sale quantity",
+			movement: entity.StockMovement{BookID: 1, Type: entity.MovementSale, Quantity: -2, Actor: "warehouse"},
+			book:     entity.Book{ID: 1},
+			isError:  true,
+		},
+		{
+			name:     "adjustment without reason",
+			movement: entity.StockMovement{BookID: 1, Type: entity.MovementAdjustment, Quantity: 3, Actor: "warehouse"},
+			book:     entity.Book{ID: 1},
+			isError:  true,
+		},
+		{
+			name:     "missing actor",
+			movement: entity.StockMovement{BookID: 1, Type: entity.MovementReceipt, Quantity: 3},
+			book:     entity.Book{ID: 1},
+			isError:  true,
+		},
+		{
+			name:     "invalid type",
+			movement: entity.StockMovement{BookID: 1, Type: "theft", Quantity: 3, Actor: "warehouse"},
+			book:     entity.Book{ID: 1},
+			isError:  true,
+		},
+		{
+			name:     "book not found",
+			movement: entity.StockMovement{BookID: 1, Type: entity.MovementReceipt, Quantity: 3, Actor: "warehouse"},
+			book:     entity.Book{},
+			isError:  true,
+		},
+		{
+			name:         "receipt into a warehouse",
+			movement:     entity.StockMovement{BookID: 1, WarehouseID: 2, Type: entity.MovementReceipt, Quantity: 4, Actor: "warehouse"},
+			book:         entity.Book{ID: 1},
+			wantQuantity: 4,
+		},
+		{
+			name:     "warehouse not found",
+			movement: entity.StockMovement{BookID: 1, WarehouseID: 9, Type: entity.MovementReceipt, Quantity: 4, Actor: "warehouse"},
+			book:     entity.Book{ID: 1},
+			isError:  true,
+		},
+		{
+			name:         "insufficient stock",
+			movement:     entity.StockMovement{BookID: 1, Type: entity.MovementSale, Quantity: 3, Actor: "warehouse"},
+			book:         entity.Book{ID: 1, Stock: 2},
+			recordErr:    repository.ErrInsufficientStock,
+			wantQuantity: -3,
+			isError:      true,
+		},
+		{
+			name:         "receipt of an edition",
+			movement:     entity.StockMovement{BookID: 1, SKUID: 10, Type: entity.MovementReceipt, Quantity: 4, Actor: "warehouse"},
+			book:         entity.Book{ID: 1},
+			wantQuantity: 4,
+		},
+		{
+			name:     "edition of another book",
+			movement: entity.StockMovement{BookID: 1, SKUID: 12, Type: entity.MovementReceipt, Quantity: 4, Actor: "warehouse"},
+			book:     entity.Book{ID: 1},
+			isError:  true,
+		},
+		{
+			name:     "digital edition",
+			movement: entity.StockMovement{BookID: 1, SKUID: 11, Type: entity.MovementReceipt, Quantity: 4, Actor: "warehouse"},
+			book:     entity.Book{ID: 1},
+			isError:  true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			stockRepo := new(mocks.StockRepository)
+			bookRepo := new(mocks.BookRepository)
+			skuRepo := new(mocks.SKURepository)
+			warehouseRepo := new(mocks.WarehouseRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(1)).Return(test.book, nil)
+			skuRepo.On("GetSKU", mock.Anything, int64(10)).Return(entity.SKU{ID: 10, BookID: 1, Format: entity.FormatPaperback}, nil)
+			skuRepo.On("GetSKU", mock.Anything, int64(11)).Return(entity.SKU{ID: 11, BookID: 1, Format: entity.FormatEbook}, nil)
+			skuRepo.On("GetSKU", mock.Anything, int64(12)).Return(entity.SKU{ID: 12, BookID: 2, Format: entity.FormatPaperback}, nil)
+			warehouseRepo.On("GetWarehouse", mock.Anything, int64(2)).Return(entity.Warehouse{ID: 2}, nil)
+			warehouseRepo.On("GetWarehouse", mock.Anything, int64(9)).Return(entity.Warehouse{}, nil)
+			stockRepo.On("RecordMovement", mock.Anything, mock.Anything).Return(test.recordErr)
+
+			stockUsecase := usecase.NewStockUsecase(&usecase.StockRepository{StockRepo: stockRepo, BookRepo: bookRepo, SKURepo: skuRepo, WarehouseRepo: warehouseRepo})
+			err := stockUsecase.RecordMovement(context.Background(), &test.movement)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.wantQuantity != 0 {
+				assert.Equal(t, test.wantQuantity, test.movement.Quantity)
+				stockRepo.AssertCalled(t, "RecordMovement", mock.Anything, &test.movement)
+			} else {
+				stockRepo.AssertNotCalled(t, "RecordMovement", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestTransferStock(t *testing.T) {
+	testCases := []struct {
+		name        string
+		transfer    entity.StockTransfer
+		book        entity.Book
+		transferErr error
+		called      bool
+		isError     bool
+	}{
+		{
+			name:     "success",
+			transfer: entity.StockTransfer{BookID: 1, FromWarehouseID: 1, ToWarehouseID: 2, Quantity: 3, Actor: "warehouse"},
+			book:     entity.Book{ID: 1},
+			called:   true,
+		},
+		{
+			name:     "edition",
+			transfer: entity.StockTransfer{BookID: 1, SKUID: 5, FromWarehouseID: 1, ToWarehouseID: 2, Quantity: 3, Actor: "warehouse"},
+			book:     entity.Book{ID: 1},
+			called:   true,
+		},
+		{
+			name:     "digital edition",
+			transfer: entity.StockTransfer{BookID: 1, SKUID: 6, FromWarehouseID: 1, ToWarehouseID: 2, Quantity: 3, Actor: "warehouse"},
+			book:     entity.Book{ID: 1},
+			isError:  true,
+		},
+		{
+			name:     "same warehouse",
+			transfer: entity.StockTransfer{BookID: 1, FromWarehouseID: 1, ToWarehouseID: 1, Quantity: 3, Actor: "warehouse"},
+			book:     entity.Book{ID: 1},
+			isError:  true,
+		},
+		{
+			name:     "zero quantity",
+			transfer: entity.StockTransfer{BookID: 1, FromWarehouseID: 1, ToWarehouseID: 2, Actor: "warehouse"},
+			book:     entity.Book{ID: 1},
+			isError:  true,
+		},
+		{
+			name:     "missing actor",
+			transfer: entity.StockTransfer{BookID: 1, FromWarehouseID: 1, ToWarehouseID: 2, Quantity: 3},
+			book:     entity.Book{ID: 1},
+			isError:  true,
+		},
+		{
+			name:     "book not found",
+			transfer: entity.StockTransfer{BookID: 1, FromWarehouseID: 1, ToWarehouseID: 2, Quantity: 3, Actor: "warehouse"},
+			book:     entity.Book{},
+			isError:  true,
+		},
+		{
+			name:     "warehouse not found",
+			transfer: entity.StockTransfer{BookID: 1, FromWarehouseID: 1, ToWarehouseID: 9, Quantity: 3, Actor: "warehouse"},
+			book:     entity.Book{ID: 1},
+			isError:  true,
+		},
+		{
+			name:        "insufficient stock",
+			transfer:    entity.StockTransfer{BookID: 1, FromWarehouseID: 1, ToWarehouseID: 2, Quantity: 3, Actor: "warehouse"},
+			book:        entity.Book{ID: 1},
+			transferErr: repository.ErrInsufficientStock,
+			called:      true,
+			isError:     true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			stockRepo := new(mocks.StockRepository)
+			bookRepo := new(mocks.BookRepository)
+			warehouseRepo := new(mocks.WarehouseRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(1)).Return(test.book, nil)
+			warehouseRepo.On("GetWarehouse", mock.Anything, int64(1)).Return(entity.Warehouse{ID: 1}, nil)
+			warehouseRepo.On("GetWarehouse", mock.Anything, int64(2)).Return(entity.Warehouse{ID: 2}, nil)
+			warehouseRepo.On("GetWarehouse", mock.Anything, int64(9)).Return(entity.Warehouse{}, nil)
+			stockRepo.On("TransferStock", mock.Anything, mock.Anything).Return(test.transferErr)
+			skuRepo := new(mocks.SKURepository)
+			skuRepo.On("GetSKU", mock.Anything, int64(5)).Return(entity.SKU{ID: 5, BookID: 1, Format: entity.FormatHardcover}, nil)
+			skuRepo.On("GetSKU", mock.Anything, int64(6)).Return(entity.SKU{ID: 6, BookID: 1, Format: entity.FormatEbook}, nil)
+
+			stockUsecase := usecase.NewStockUsecase(&usecase.StockRepository{StockRepo: stockRepo, BookRepo: bookRepo, SKURepo: skuRepo, WarehouseRepo: warehouseRepo})
+			err := stockUsecase.TransferStock(context.Background(), &test.transfer)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.called {
+				stockRepo.AssertCalled(t, "TransferStock", mock.Anything, &test.transfer)
+			} else {
+				stockRepo.AssertNotCalled(t, "TransferStock", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+type SupplierUsecase interface {
+	GetSuppliers(ctx context.Context) ([]entity.Supplier, error)
+	GetSupplier(ctx context.Context, id int64) (entity.Supplier, error)
+	CreateSupplier(ctx context.Context, supplier *entity.Supplier) error
+	UpdateSupplier(ctx context.Context, id int64, supplier *entity.Supplier) error
+	DeleteSupplier(ctx context.Context, id int64) error
+}
+
+type SupplierRepository struct {
+	SupplierRepo  repository.SupplierRepository
+	PublisherRepo repository.PublisherRepository
+}
+
+func NewSupplierUsecase(repo *SupplierRepository) SupplierUsecase {
+	return &SupplierRepository{
+		SupplierRepo:  repo.SupplierRepo,
+		PublisherRepo: repo.PublisherRepo,
+	}
+}
+
+func (r *SupplierRepository) GetSuppliers(ctx context.Context) ([]entity.Supplier, error) {
+	res, err := r.SupplierRepo.GetSuppliers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *SupplierRepository) GetSupplier(ctx context.Context, id int64) (entity.Supplier, error) {
+	res, err := r.SupplierRepo.GetSupplier(ctx, id)
+	if err != nil {
+		return entity.Supplier{}, err
+	}
+
+	return res, nil
+}
+
+func (r *SupplierRepository) CreateSupplier(ctx context.Context, supplier *entity.Supplier) error {
+	err := r.validateSupplier(ctx, supplier)
+	if err != nil {
+		return err
+	}
+
+	err = r.SupplierRepo.CreateSupplier(ctx, supplier)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *SupplierRepository) UpdateSupplier(ctx context.Context, id int64, supplier *entity.Supplier) error {
+	err := r.validateSupplier(ctx, supplier)
+	if err != nil {
+		return err
+	}
+
+	err = r.SupplierRepo.UpdateSupplier(ctx, id, supplier)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *SupplierRepository) DeleteSupplier(ctx context.Context, id int64) error {
+	err := r.SupplierRepo.DeleteSupplier(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateSupplier requires a name and checks the linked publisher exists
+func (r *SupplierRepository) validateSupplier(ctx context.Context, supplier *entity.Supplier) error {
+	supplier.Name = strings.TrimSpace(supplier.Name)
+	supplier.Email = strings.TrimSpace(supplier.Email)
+
+	if supplier.Name == "" {
+		return errors.New("supplier needs a name")
+	}
+
+	if supplier.PublisherID == nil {
+		return nil
+	}
+
+	publisher, err := r.PublisherRepo.GetPublisher(ctx, *supplier.PublisherID)
+	if err != nil {
+		return err
+	}
+
+	if publisher.ID == 0 {
+		return fmt.Errorf("publisher ID %d was not found", *supplier.PublisherID)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetSuppliers(t *testing.T) {
+	testCases := []struct {
+		name      string
+		suppliers []entity.Supplier
+		isError   bool
+		wantErr   error
+	}{
+		{
+			name:      "success",
+			suppliers: []entity.Supplier{{ID: 1, Name: "Gramedia Distribution"}},
+			isError:   false,
+			wantErr:   nil,
+		},
+		{
+			name:      "failed",
+			suppliers: []entity.Supplier{},
+			isError:   true,
+			wantErr:   errors.New("Dummy Error"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			supplierRepo := new(mocks.SupplierRepository)
+			supplierRepo.On("GetSuppliers", mock.Anything).Return(test.suppliers, test.wantErr)
+
+			supplierUsecase := usecase.NewSupplierUsecase(&usecase.SupplierRepository{SupplierRepo: supplierRepo})
+			res, err := supplierUsecase.GetSuppliers(context.Background())
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, test.suppliers, res)
+			}
+		})
+	}
+}
+
+func TestCreateSupplier(t *testing.T) {
+	publisherID := int64(1)
+	missingPublisherID := int64(9)
+
+	testCases := []struct {
+		name     string
+		supplier entity.Supplier
+		wantName string
+		isError  bool
+	}{
+		{
+			name:     "success",
+			supplier: entity.Supplier{Name: " Gramedia Distribution ", PublisherID: &publisherID},
+			wantName: "Gramedia Distribution",
+			isError:  false,
+		},
+		{
+			name:     "without publisher",
+			supplier: entity.Supplier{Name: "Local Wholesaler"},
+			wantName: "Local Wholesaler",
+			isError:  false,
+		},
+		{
+			name:     "missing name",
+			supplier: entity.Supplier{Name: " "},
+			isError:  true,
+		},
+		{
+			name:     "publisher not found",
+			supplier: entity.Supplier{Name: "Gramedia Distribution", PublisherID: &missingPublisherID},
+			isError:  true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			supplierRepo := new(mocks.SupplierRepository)
+			supplierRepo.On("CreateSupplier", mock.Anything, mock.Anything).Return(nil)
+
+			publisherRepo := new(mocks.PublisherRepository)
+			publisherRepo.On("GetPublisher", mock.Anything, publisherID).Return(entity.Publisher{ID: publisherID}, nil)
+			publisherRepo.On("GetPublisher", mock.Anything, missingPublisherID).Return(entity.Publisher{}, nil)
+
+			supplierUsecase := usecase.NewSupplierUsecase(&usecase.SupplierRepository{SupplierRepo: supplierRepo, PublisherRepo: publisherRepo})
+			err := supplierUsecase.CreateSupplier(context.Background(), &test.supplier)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				supplierRepo.AssertNotCalled(t, "CreateSupplier", mock.Anything, mock.Anything)
+			} else {
+				assert.Equal(t, test.wantName, test.supplier.Name)
+			}
+		})
+	}
+}
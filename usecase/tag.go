@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+const tagSuggestionLimit = 10
+
+type TagUsecase interface {
+	GetTags(ctx context.Context, query string) ([]entity.Tag, error)
+}
+
+type TagRepository struct {
+	TagRepo repository.TagRepository
+}
+
+func NewTagUsecase(repo *TagRepository) TagUsecase {
+	return &TagRepository{
+		TagRepo: repo.TagRepo,
+	}
+}
+
+// GetTags suggests existing tags starting with query for autocomplete
+func (r *TagRepository) GetTags(ctx context.Context, query string) ([]entity.Tag, error) {
+	res, err := r.TagRepo.GetTags(ctx, slugify(query), tagSuggestionLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// slugify lowercases s and joins its letters and digits with dashes
+func slugify(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(words, "-")
+}
+
+// normalizeTags trims tag names and drops empty and duplicate tags
+func normalizeTags(names []string) []entity.Tag {
+	var tags []entity.Tag
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		name = strings.Join(strings.Fields(name), " ")
+		slug := slugify(name)
+		if slug == "" || seen[slug] {
+			continue
+		}
+
+		seen[slug] = true
+		tags = append(tags, entity.Tag{Name: name, Slug: slug})
+	}
+
+	return tags
+}
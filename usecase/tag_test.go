@@ -0,0 +1,54 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetTags(t *testing.T) {
+	testCases := []struct {
+		name    string
+		query   string
+		slug    string
+		tags    []entity.Tag
+		isError bool
+		wantErr error
+	}{
+		{
+			name:    "success",
+			query:   " Travel Gu",
+			slug:    "travel-gu",
+			tags:    []entity.Tag{{ID: 1, Name: "Travel Guide", Slug: "travel-guide"}},
+			isError: false,
+			wantErr: nil,
+		},
+		{
+			name:    "failed",
+			query:   "travel",
+			slug:    "travel",
+			tags:    nil,
+			isError: true,
+			wantErr: errors.New("Dummy Error"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			tagRepo := new(mocks.TagRepository)
+			tagRepo.On("GetTags", mock.Anything, test.slug, mock.AnythingOfType("int")).Return(test.tags, test.wantErr)
+
+			tagUsecase := usecase.NewTagUsecase(&usecase.TagRepository{TagRepo: tagRepo})
+			res, err := tagUsecase.GetTags(context.Background(), test.query)
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.tags, res)
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+// maxTaxRate is 100% in basis points
+const maxTaxRate = 10000
+
+type TaxUsecase interface {
+	GetTaxCategories(ctx context.Context) ([]entity.TaxCategory, error)
+	GetTaxCategory(ctx context.Context, id int64) (entity.TaxCategory, error)
+	CreateTaxCategory(ctx context.Context, category *entity.TaxCategory) error
+	UpdateTaxCategory(ctx context.Context, id int64, category *entity.TaxCategory) error
+	DeleteTaxCategory(ctx context.Context, id int64) error
+	GetTaxRules(ctx context.Context) ([]entity.TaxRule, error)
+	GetTaxRule(ctx context.Context, id int64) (entity.TaxRule, error)
+	CreateTaxRule(ctx context.Context, rule *entity.TaxRule) error
+	UpdateTaxRule(ctx context.Context, id int64, rule *entity.TaxRule) error
+	DeleteTaxRule(ctx context.Context, id int64) error
+}
+
+type TaxRepository struct {
+	TaxRepo repository.TaxRepository
+}
+
+func NewTaxUsecase(repo *TaxRepository) TaxUsecase {
+	return &TaxRepository{
+		TaxRepo: repo.TaxRepo,
+	}
+}
+
+// TaxPolicy tells how carts and orders are taxed
+type TaxPolicy struct {
+	// PricesIncludeTax is true when book prices are set and shown with the tax included, the
+	// tax is then taken out of them rather than added on top
+	PricesIncludeTax bool
+	// DefaultCountry is the jurisdiction of carts with no shipping country, none are taxed
+	// when it is empty
+	DefaultCountry string
+}
+
+func (r *TaxRepository) GetTaxCategories(ctx context.Context) ([]entity.TaxCategory, error) {
+	res, err := r.TaxRepo.GetTaxCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *TaxRepository) GetTaxCategory(ctx context.Context, id int64) (entity.TaxCategory, error) {
+	res, err := r.TaxRepo.GetTaxCategory(ctx, id)
+	if err != nil {
+		return entity.TaxCategory{}, err
+	}
+
+	return res, nil
+}
+
+func (r *TaxRepository) CreateTaxCategory(ctx context.Context, category *entity.TaxCategory) error {
+	err := validateTaxCategory(category)
+	if err != nil {
+		return err
+	}
+
+	err = r.TaxRepo.CreateTaxCategory(ctx, category)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TaxRepository) UpdateTaxCategory(ctx context.Context, id int64, category *entity.TaxCategory) error {
+	err := validateTaxCategory(category)
+	if err != nil {
+		return err
+	}
+
+	err = r.TaxRepo.UpdateTaxCategory(ctx, id, category)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TaxRepository) DeleteTaxCategory(ctx context.Context, id int64) error {
+	err := r.TaxRepo.DeleteTaxCategory(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TaxRepository) GetTaxRules(ctx context.Context) ([]entity.TaxRule, error) {
+	res, err := r.TaxRepo.GetTaxRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *TaxRepository) GetTaxRule(ctx context.Context, id int64) (entity.TaxRule, error) {
+	res, err := r.TaxRepo.GetTaxRule(ctx, id)
+	if err != nil {
+		return entity.TaxRule{}, err
+	}
+
+	return res, nil
+}
+
+func (r *TaxRepository) CreateTaxRule(ctx context.Context, rule *entity.TaxRule) error {
+	err := r.validateTaxRule(ctx, rule)
+	if err != nil {
+		return err
+	}
+
+	err = r.TaxRepo.CreateTaxRule(ctx, rule)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TaxRepository) UpdateTaxRule(ctx context.Context, id int64, rule *entity.TaxRule) error {
+	err := r.validateTaxRule(ctx, rule)
+	if err != nil {
+		return err
+	}
+
+	err = r.TaxRepo.UpdateTaxRule(ctx, id, rule)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TaxRepository) DeleteTaxRule(ctx context.Context, id int64) error {
+	err := r.TaxRepo.DeleteTaxRule(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateTaxCategory(category *entity.TaxCategory) error {
+	category.Code = slugify(category.Code)
+	if category.Code == "" {
+		return errors.New("tax category needs a code")
+	}
+
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return errors.New("tax category needs a name")
+	}
+
+	return nil
+}
+
+func (r *TaxRepository) validateTaxRule(ctx context.Context, rule *entity.TaxRule) error {
+	var err error
+	rule.Country, rule.Region, err = normalizeJurisdiction(rule.Country, rule.Region)
+	if err != nil {
+		return err
+	}
+
+	if rule.Country == "" {
+		return errors.New("tax rule needs a country")
+	}
+
+	rule.Name = strings.TrimSpace(rule.Name)
+	if rule.Name == "" {
+		return errors.New("tax rule needs a name, like VAT")
+	}
+
+	if rule.Rate < 0 || rule.Rate > maxTaxRate {
+		return fmt.Errorf("rate must be between 0 and %d basis points", maxTaxRate)
+	}
+
+	if rule.TaxCategoryID != 0 {
+		category, err := r.TaxRepo.GetTaxCategory(ctx, rule.TaxCategoryID)
+		if err != nil {
+			return err
+		}
+
+		if category.ID == 0 {
+			return fmt.Errorf("tax category ID %d was not found", rule.TaxCategoryID)
+		}
+	}
+
+	return nil
+}
+
+// normalizeJurisdiction upper-cases an ISO 3166 country code and the region code within it
+func normalizeJurisdiction(country string, region string) (string, string, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	region = strings.ToUpper(strings.TrimSpace(region))
+
+	if country != "" && (len(country) != 2 || strings.Trim(country, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "") {
+		return "", "", fmt.Errorf("country %q is not a two letter ISO 3166 code", country)
+	}
+
+	if country == "" && region != "" {
+		return "", "", errors.New("a region needs a country")
+	}
+
+	return country, region, nil
+}
+
+// checkTaxCategory makes sure the tax category of a book or an edition exists, they may have none
+func checkTaxCategory(ctx context.Context, taxRepo repository.TaxRepository, id int64) error {
+	if id == 0 {
+		return nil
+	}
+
+	category, err := taxRepo.GetTaxCategory(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if category.ID == 0 {
+		return fmt.Errorf("tax category ID %d was not found", id)
+	}
+
+	return nil
+}
+
+// taxQuote charges the taxes of the jurisdiction the cart ships to on what is left of its lines
+// after the discounts. Every line is taxed by the most specific rule covering its edition, or
+// its book when the edition has no tax category: a rule of that tax category before one of no
+// category, and a rule of the region before one of the whole country. Lines no rule covers are
+// not taxed.
+func taxQuote(ctx context.Context, taxRepo repository.TaxRepository, policy TaxPolicy, quote *entity.Quote, books map[int64]entity.Book, skus map[int64]entity.SKU, country string, region string) error {
+	quote.PricesIncludeTax = policy.PricesIncludeTax
+	if country == "" {
+		country, region = policy.DefaultCountry, ""
+	}
+
+	if country == "" {
+		return nil
+	}
+
+	rules, err := taxRepo.GetJurisdictionRules(ctx, country, region)
+	if err != nil {
+		return err
+	}
+
+	index := make(map[entity.TaxLine]int)
+	for i := range quote.Lines {
+		line := &quote.Lines[i]
+
+		categoryID := books[line.BookID].TaxCategoryID
+		if sku, ok := skus[line.SKUID]; ok && sku.TaxCategoryID != 0 {
+			categoryID = sku.TaxCategoryID
+		}
+
+		rule, ok := taxRule(rules, categoryID, region)
+		if !ok {
+			continue
+		}
+
+		taxable := line.Subtotal - line.Discount
+		if policy.PricesIncludeTax {
+			line.Tax = roundDiv(taxable*rule.Rate, maxTaxRate+rule.Rate)
+			taxable -= line.Tax
+		} else {
+			line.Tax = roundDiv(taxable*rule.Rate, maxTaxRate)
+			line.Total += line.Tax
+		}
+
+		key := entity.TaxLine{Name: rule.Name, Rate: rule.Rate}
+		j, ok := index[key]
+		if !ok {
+			j = len(quote.TaxLines)
+			index[key] = j
+			quote.TaxLines = append(quote.TaxLines, key)
+		}
+
+		quote.TaxLines[j].Taxable += taxable
+		quote.TaxLines[j].Amount += line.Tax
+		quote.Tax += line.Tax
+	}
+
+	if !policy.PricesIncludeTax {
+		quote.Total += quote.Tax
+	}
+
+	return nil
+}
+
+// taxRule picks the most specific of the jurisdiction's rules covering books of the category
+func taxRule(rules []entity.TaxRule, categoryID int64, region string) (entity.TaxRule, bool) {
+	best, bestScore := entity.TaxRule{}, -1
+	for _, rule := range rules {
+		score := 0
+		switch rule.TaxCategoryID {
+		case 0:
+		case categoryID:
+			score += 2
+		default:
+			continue
+		}
+
+		if rule.Region != "" {
+			if rule.Region != region {
+				continue
+			}
+			score++
+		}
+
+		if score > bestScore {
+			best, bestScore = rule, score
+		}
+	}
+
+	return best, bestScore >= 0
+}
+
+// roundDiv divides non-negative amounts rounding half up
+func roundDiv(a int, b int) int {
+	return (2*a + b) / (2 * b)
+}
@@ -0,0 +1,182 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTaxRule(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rule    entity.TaxRule
+		isError bool
+	}{
+		{name: "country wide", rule: entity.TaxRule{Country: "id", Name: "PPN", Rate: 1100}, isError: false},
+		{name: "zero-rated category in a region", rule: entity.TaxRule{Country: "US", Region: "ny", TaxCategoryID: 1, Name: "Sales Tax", Rate: 0}, isError: false},
+		{name: "missing country", rule: entity.TaxRule{Name: "VAT", Rate: 2000}, isError: true},
+		{name: "invalid country", rule: entity.TaxRule{Country: "IDN", Name: "PPN", Rate: 1100}, isError: true},
+		{name: "missing name", rule: entity.TaxRule{Country: "GB", Rate: 2000}, isError: true},
+		{name: "rate above 100%", rule: entity.TaxRule{Country: "GB", Name: "VAT", Rate: 20000}, isError: true},
+		{name: "unknown tax category", rule: entity.TaxRule{Country: "GB", TaxCategoryID: 9, Name: "VAT", Rate: 2000}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			taxRepo := new(mocks.TaxRepository)
+			taxRepo.On("GetTaxCategory", mock.Anything, int64(1)).Return(entity.TaxCategory{ID: 1, Code: "printed-books"}, nil)
+			taxRepo.On("GetTaxCategory", mock.Anything, int64(9)).Return(entity.TaxCategory{}, nil)
+			taxRepo.On("CreateTaxRule", mock.Anything, mock.Anything).Return(nil)
+
+			taxUsecase := usecase.NewTaxUsecase(&usecase.TaxRepository{TaxRepo: taxRepo})
+			err := taxUsecase.CreateTaxRule(context.Background(), &test.rule)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, strings.ToUpper(test.rule.Country), test.rule.Country)
+				assert.Equal(t, strings.ToUpper(test.rule.Region), test.rule.Region)
+			}
+		})
+	}
+}
+
+func TestQuoteCartTaxes(t *testing.T) {
+	// book 1 is printed (tax category 1), book 2 is an ebook with no tax category
+	books := map[int64]entity.Book{
+		1: {ID: 1, Price: 111000, TaxCategoryID: 1},
+		2: {ID: 2, Price: 50000},
+	}
+
+	rules := []entity.TaxRule{
+		{ID: 1, Country: "GB", Name: "VAT", Rate: 2000},
+		{ID: 2, Country: "GB", TaxCategoryID: 1, Name: "VAT", Rate: 0},
+		{ID: 3, Country: "ID", Name: "PPN", Rate: 1100},
+		{ID: 4, Country: "US", Name: "Sales Tax", Rate: 400},
+		{ID: 5, Country: "US", Region: "NY", Name: "Sales Tax", Rate: 800},
+	}
+
+	testCases := []struct {
+		name         string
+		country      string
+		region       string
+		policy       usecase.TaxPolicy
+		wantTaxes    []int
+		wantTaxLines []entity.TaxLine
+		wantTotal    int
+	}{
+		{
+			name:         "zero-rated category",
+			country:      "gb",
+			wantTaxes:    []int{0, 10000},
+			wantTaxLines: []entity.TaxLine{{Name: "VAT", Rate: 0, Taxable: 111000, Amount: 0}, {Name: "VAT", Rate: 2000, Taxable: 50000, Amount: 10000}},
+			wantTotal:    171000,
+		},
+		{
+			name:         "prices include tax",
+			country:      "ID",
+			policy:       usecase.TaxPolicy{PricesIncludeTax: true},
+			wantTaxes:    []int{11000, 4955},
+			wantTaxLines: []entity.TaxLine{{Name: "PPN", Rate: 1100, Taxable: 145045, Amount: 15955}},
+			wantTotal:    161000,
+		},
+		{
+			name:         "region rule",
+			country:      "US",
+			region:       "ny",
+			wantTaxes:    []int{8880, 4000},
+			wantTaxLines: []entity.TaxLine{{Name: "Sales Tax", Rate: 800, Taxable: 161000, Amount: 12880}},
+			wantTotal:    173880,
+		},
+		{
+			name:         "default country",
+			policy:       usecase.TaxPolicy{PricesIncludeTax: true, DefaultCountry: "ID"},
+			wantTaxes:    []int{11000, 4955},
+			wantTaxLines: []entity.TaxLine{{Name: "PPN", Rate: 1100, Taxable: 145045, Amount: 15955}},
+			wantTotal:    161000,
+		},
+		{
+			name:      "no jurisdiction",
+			wantTaxes: []int{0, 0},
+			wantTotal: 161000,
+		},
+		{
+			name:      "country without rules",
+			country:   "SG",
+			wantTaxes: []int{0, 0},
+			wantTotal: 161000,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			bookRepo := new(mocks.BookRepository)
+			for id, book := range books {
+				bookRepo.On("GetBook", mock.Anything, id).Return(book, nil)
+			}
+
+			promotionRepo := new(mocks.PromotionRepository)
+			promotionRepo.On("GetApplicablePromotions", mock.Anything, mock.Anything).Return(nil, nil)
+			promotionRepo.On("GetRedemptions", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+			taxRepo := new(mocks.TaxRepository)
+			taxRepo.On("GetJurisdictionRules", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, country string, region string) []entity.TaxRule {
+				var matching []entity.TaxRule
+				for _, rule := range rules {
+					if rule.Country == country && (rule.Region == "" || rule.Region == region) {
+						matching = append(matching, rule)
+					}
+				}
+				return matching
+			}, nil)
+
+			promotionUsecase := usecase.NewPromotionUsecase(&usecase.PromotionRepository{PromotionRepo: promotionRepo, BookRepo: bookRepo, CategoryRepo: noCategories(), TaxRepo: taxRepo, TaxPolicy: test.policy})
+			cart := entity.Cart{ShippingCountry: test.country, ShippingRegion: test.region, Lines: []entity.OrderLine{{BookID: 1, Quantity: 1}, {BookID: 2, Quantity: 1}}}
+			quote, err := promotionUsecase.QuoteCart(context.Background(), &cart)
+
+			assert.NoError(t, err)
+			for i, line := range quote.Lines {
+				assert.Equal(t, test.wantTaxes[i], line.Tax)
+			}
+			assert.Equal(t, test.wantTaxLines, quote.TaxLines)
+			assert.Equal(t, test.wantTotal, quote.Total)
+			assert.Equal(t, test.policy.PricesIncludeTax, quote.PricesIncludeTax)
+		})
+	}
+}
+
+func TestQuoteCartSKUTaxCategory(t *testing.T) {
+	// the printed book is zero-rated (tax category 1), its ebook edition is standard-rated
+	// (tax category 2) and its paperback edition is taxed like the book
+	bookRepo := new(mocks.BookRepository)
+	bookRepo.On("GetBook", mock.Anything, int64(1)).Return(entity.Book{ID: 1, Price: 100000, TaxCategoryID: 1}, nil)
+
+	skuRepo := new(mocks.SKURepository)
+	skuRepo.On("GetSKU", mock.Anything, int64(7)).Return(entity.SKU{ID: 7, BookID: 1, Format: entity.FormatEbook, Price: 50000, TaxCategoryID: 2}, nil)
+	skuRepo.On("GetSKU", mock.Anything, int64(8)).Return(entity.SKU{ID: 8, BookID: 1, Format: entity.FormatPaperback, Price: 80000}, nil)
+
+	promotionRepo := new(mocks.PromotionRepository)
+	promotionRepo.On("GetApplicablePromotions", mock.Anything, mock.Anything).Return(nil, nil)
+	promotionRepo.On("GetRedemptions", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+	taxRepo := new(mocks.TaxRepository)
+	taxRepo.On("GetJurisdictionRules", mock.Anything, "GB", "").Return([]entity.TaxRule{
+		{ID: 1, Country: "GB", Name: "VAT", Rate: 2000},
+		{ID: 2, Country: "GB", TaxCategoryID: 1, Name: "VAT", Rate: 0},
+		{ID: 3, Country: "GB", TaxCategoryID: 2, Name: "VAT", Rate: 2000},
+	}, nil)
+
+	promotionUsecase := usecase.NewPromotionUsecase(&usecase.PromotionRepository{PromotionRepo: promotionRepo, BookRepo: bookRepo, SKURepo: skuRepo, CategoryRepo: noCategories(), TaxRepo: taxRepo})
+	cart := entity.Cart{ShippingCountry: "GB", Lines: []entity.OrderLine{{BookID: 1, Quantity: 1}, {BookID: 1, SKUID: 7, Quantity: 1}, {BookID: 1, SKUID: 8, Quantity: 1}}}
+	quote, err := promotionUsecase.QuoteCart(context.Background(), &cart)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, quote.Lines[0].Tax)
+	assert.Equal(t, 10000, quote.Lines[1].Tax)
+	assert.Equal(t, 0, quote.Lines[2].Tax)
+}
@@ -0,0 +1,323 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+type TranslationUsecase interface {
+	GetBookTranslations(ctx context.Context, bookID int64) ([]entity.BookTranslation, error)
+	SetBookTranslation(ctx context.Context, translation *entity.BookTranslation) error
+	DeleteBookTranslation(ctx context.Context, bookID int64, locale string) error
+	GetCategoryTranslations(ctx context.Context, categoryID int64) ([]entity.CategoryTranslation, error)
+	SetCategoryTranslation(ctx context.Context, translation *entity.CategoryTranslation) error
+	DeleteCategoryTranslation(ctx context.Context, categoryID int64, locale string) error
+	GetPublisherTranslations(ctx context.Context, publisherID int64) ([]entity.PublisherTranslation, error)
+	SetPublisherTranslation(ctx context.Context, translation *entity.PublisherTranslation) error
+	DeletePublisherTranslation(ctx context.Context, publisherID int64, locale string) error
+	LocalizeBooks(ctx context.Context, books []entity.Book, locales []string) error
+	LocalizeCategories(ctx context.Context, categories []entity.Category, locales []string) error
+	LocalizePublishers(ctx context.Context, publishers []entity.Publisher, locales []string) error
+}
+
+type TranslationRepository struct {
+	TranslationRepo repository.TranslationRepository
+	BookRepo        repository.BookRepository
+	CategoryRepo    repository.CategoryRepository
+	PublisherRepo   repository.PublisherRepository
+	Fallbacks       entity.LocaleFallbacks
+}
+
+func NewTranslationUsecase(repo *TranslationRepository) TranslationUsecase {
+	return &TranslationRepository{
+		TranslationRepo: repo.TranslationRepo,
+		BookRepo:        repo.BookRepo,
+		CategoryRepo:    repo.CategoryRepo,
+		PublisherRepo:   repo.PublisherRepo,
+		Fallbacks:       repo.Fallbacks,
+	}
+}
+
+func (r *TranslationRepository) GetBookTranslations(ctx context.Context, bookID int64) ([]entity.BookTranslation, error) {
+	res, err := r.TranslationRepo.GetBookTranslations(ctx, []int64{bookID}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return res[bookID], nil
+}
+
+func (r *TranslationRepository) SetBookTranslation(ctx context.Context, translation *entity.BookTranslation) error {
+	locale, err := r.translationLocale(translation.Locale)
+	if err != nil {
+		return err
+	}
+	translation.Locale = locale
+
+	translation.Title = strings.TrimSpace(translation.Title)
+	translation.Description = strings.TrimSpace(translation.Description)
+	if translation.Title == "" && translation.Description == "" {
+		return errors.New("translation needs a title or a description")
+	}
+
+	book, err := r.BookRepo.GetBook(ctx, translation.BookID)
+	if err != nil {
+		return err
+	}
+
+	if book.ID == 0 {
+		return fmt.Errorf("book ID %d was not found", translation.BookID)
+	}
+
+	return r.TranslationRepo.SetBookTranslation(ctx, translation)
+}
+
+func (r *TranslationRepository) DeleteBookTranslation(ctx context.Context, bookID int64, locale string) error {
+	locale, err := r.translationLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	return r.TranslationRepo.DeleteBookTranslation(ctx, bookID, locale)
+}
+
+func (r *TranslationRepository) GetCategoryTranslations(ctx context.Context, categoryID int64) ([]entity.CategoryTranslation, error) {
+	res, err := r.TranslationRepo.GetCategoryTranslations(ctx, []int64{categoryID}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return res[categoryID], nil
+}
+
+func (r *TranslationRepository) SetCategoryTranslation(ctx context.Context, translation *entity.CategoryTranslation) error {
+	locale, err := r.translationLocale(translation.Locale)
+	if err != nil {
+		return err
+	}
+	translation.Locale = locale
+
+	translation.Name = strings.TrimSpace(translation.Name)
+	if translation.Name == "" {
+		return errors.New("translation needs a name")
+	}
+
+	category, err := r.CategoryRepo.GetCategory(ctx, translation.CategoryID)
+	if err != nil {
+		return err
+	}
+
+	if category.ID == 0 {
+		return fmt.Errorf("category ID %d was not found", translation.CategoryID)
+	}
+
+	return r.TranslationRepo.SetCategoryTranslation(ctx, translation)
+}
+
+func (r *TranslationRepository) DeleteCategoryTranslation(ctx context.Context, categoryID int64, locale string) error {
+	locale, err := r.translationLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	return r.TranslationRepo.DeleteCategoryTranslation(ctx, categoryID, locale)
+}
+
+func (r *TranslationRepository) GetPublisherTranslations(ctx context.Context, publisherID int64) ([]entity.PublisherTranslation, error) {
+	res, err := r.TranslationRepo.GetPublisherTranslations(ctx, []int64{publisherID}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return res[publisherID], nil
+}
+
+func (r *TranslationRepository) SetPublisherTranslation(ctx context.Context, translation *entity.PublisherTranslation) error {
+	locale, err := r.translationLocale(translation.Locale)
+	if err != nil {
+		return err
+	}
+	translation.Locale = locale
+
+	translation.Name = strings.TrimSpace(translation.Name)
+	if translation.Name == "" {
+		return errors.New("translation needs a name")
+	}
+
+	publisher, err := r.PublisherRepo.GetPublisher(ctx, translation.PublisherID)
+	if err != nil {
+		return err
+	}
+
+	if publisher.ID == 0 {
+		return fmt.Errorf("publisher ID %d was not found", translation.PublisherID)
+	}
+
+	return r.TranslationRepo.SetPublisherTranslation(ctx, translation)
+}
+
+func (r *TranslationRepository) DeletePublisherTranslation(ctx context.Context, publisherID int64, locale string) error {
+	locale, err := r.translationLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	return r.TranslationRepo.DeletePublisherTranslation(ctx, publisherID, locale)
+}
+
+// LocalizeBooks puts the titles, descriptions and category names of books in the locales
+// asked for, best first. Text without a translation in any of them stays as it is.
+func (r *TranslationRepository) LocalizeBooks(ctx context.Context, books []entity.Book, locales []string) error {
+	chain := r.Fallbacks.Chain(locales)
+	if len(chain) == 0 || len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(books))
+	var categoryIDs []int64
+	for i, book := range books {
+		ids[i] = book.ID
+		for _, category := range book.Categories {
+			categoryIDs = append(categoryIDs, category.CategoryID)
+		}
+	}
+
+	translations, err := r.TranslationRepo.GetBookTranslations(ctx, ids, chain)
+	if err != nil {
+		return err
+	}
+
+	categoryNames, err := r.categoryNames(ctx, categoryIDs, chain)
+	if err != nil {
+		return err
+	}
+
+	for i := range books {
+		titles := make(map[string]string)
+		descriptions := make(map[string]string)
+		for _, translation := range translations[books[i].ID] {
+			titles[translation.Locale] = translation.Title
+			descriptions[translation.Locale] = translation.Description
+		}
+
+		books[i].Title = translated(chain, titles, books[i].Title)
+		books[i].Description = translated(chain, descriptions, books[i].Description)
+		for j := range books[i].Categories {
+			category := &books[i].Categories[j]
+			category.Name = translated(chain, categoryNames[category.CategoryID], category.Name)
+		}
+	}
+
+	return nil
+}
+
+// LocalizeCategories puts the names of categories and of their children in the locales asked
+// for, best first
+func (r *TranslationRepository) LocalizeCategories(ctx context.Context, categories []entity.Category, locales []string) error {
+	chain := r.Fallbacks.Chain(locales)
+	if len(chain) == 0 || len(categories) == 0 {
+		return nil
+	}
+
+	names, err := r.categoryNames(ctx, categoryTreeIDs(categories), chain)
+	if err != nil {
+		return err
+	}
+
+	localizeCategoryTree(categories, chain, names)
+	return nil
+}
+
+// LocalizePublishers puts the names of publishers in the locales asked for, best first
+func (r *TranslationRepository) LocalizePublishers(ctx context.Context, publishers []entity.Publisher, locales []string) error {
+	chain := r.Fallbacks.Chain(locales)
+	if len(chain) == 0 || len(publishers) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(publishers))
+	for i, publisher := range publishers {
+		ids[i] = publisher.ID
+	}
+
+	translations, err := r.TranslationRepo.GetPublisherTranslations(ctx, ids, chain)
+	if err != nil {
+		return err
+	}
+
+	for i := range publishers {
+		names := make(map[string]string)
+		for _, translation := range translations[publishers[i].ID] {
+			names[translation.Locale] = translation.Name
+		}
+
+		publishers[i].Name = translated(chain, names, publishers[i].Name)
+	}
+
+	return nil
+}
+
+// translationLocale returns the locale a translation is stored under, the catalog's own
+// locale has no translations
+func (r *TranslationRepository) translationLocale(tag string) (string, error) {
+	locale := entity.NormalizeLocale(tag)
+	if locale == "" {
+		return "", fmt.Errorf("%q is not a locale", tag)
+	}
+
+	if locale == r.Fallbacks.Default {
+		return "", fmt.Errorf("the catalog is written in %s, it needs no translation", locale)
+	}
+
+	return locale, nil
+}
+
+// categoryNames returns the translated names of categories keyed by category and locale
+func (r *TranslationRepository) categoryNames(ctx context.Context, ids []int64, chain []string) (map[int64]map[string]string, error) {
+	translations, err := r.TranslationRepo.GetCategoryTranslations(ctx, ids, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]map[string]string)
+	for id, list := range translations {
+		names[id] = make(map[string]string)
+		for _, translation := range list {
+			names[id][translation.Locale] = translation.Name
+		}
+	}
+
+	return names, nil
+}
+
+func categoryTreeIDs(categories []entity.Category) []int64 {
+	var ids []int64
+	for _, category := range categories {
+		ids = append(ids, category.ID)
+		ids = append(ids, categoryTreeIDs(category.Children)...)
+	}
+
+	return ids
+}
+
+func localizeCategoryTree(categories []entity.Category, chain []string, names map[int64]map[string]string) {
+	for i := range categories {
+		categories[i].Name = translated(chain, names[categories[i].ID], categories[i].Name)
+		localizeCategoryTree(categories[i].Children, chain, names)
+	}
+}
+
+// translated returns the text of the first locale of chain that has one, or else text
+func translated(chain []string, texts map[string]string, text string) string {
+	for _, locale := range chain {
+		if texts[locale] != "" {
+			return texts[locale]
+		}
+	}
+
+	return text
+}
@@ -0,0 +1,153 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLocalizeBooksInLocale(t *testing.T) {
+	testCases := []struct {
+		name      string
+		locales   []string
+		fallbacks map[string]string
+		chain     []string
+		wantTitle string
+		wantDesc  string
+		wantCat   string
+	}{
+		{
+			name:      "catalog locale",
+			locales:   []string{"en", "fr"},
+			chain:     nil,
+			wantTitle: "The Colour of Magic",
+			wantDesc:  "A Discworld novel",
+			wantCat:   "Fantasy",
+		},
+		{
+			name:      "catalog locale is preferred to later locales",
+			locales:   []string{"en-GB", "fr"},
+			chain:     []string{"en-GB"},
+			wantTitle: "The Colour of Magic",
+			wantDesc:  "A Discworld novel",
+			wantCat:   "Fantasy",
+		},
+		{
+			name:      "regional locale falls back to its language",
+			locales:   []string{"fr_ca", "en"},
+			chain:     []string{"fr-CA", "fr"},
+			wantTitle: "La Couleur de la magie",
+			wantDesc:  "Un roman du Disque-monde",
+			wantCat:   "Fantaisie",
+		},
+		{
+			name:      "configured fallback",
+			locales:   []string{"oc"},
+			fallbacks: map[string]string{"oc": "fr"},
+			chain:     []string{"oc", "fr"},
+			wantTitle: "La Couleur de la magie",
+			wantDesc:  "A Discworld novel",
+			wantCat:   "Fantaisie",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			translationRepo := new(mocks.TranslationRepository)
+			translationRepo.On("GetBookTranslations", mock.Anything, []int64{1}, test.chain).Return(map[int64][]entity.BookTranslation{1: {
+				{BookID: 1, Locale: "fr", Title: "La Couleur de la magie"},
+				{BookID: 1, Locale: "fr-CA", Description: "Un roman du Disque-monde"},
+			}}, nil)
+			translationRepo.On("GetCategoryTranslations", mock.Anything, []int64{4}, test.chain).Return(map[int64][]entity.CategoryTranslation{4: {
+				{CategoryID: 4, Locale: "fr", Name: "Fantaisie"},
+			}}, nil)
+
+			translationUsecase := usecase.NewTranslationUsecase(&usecase.TranslationRepository{
+				TranslationRepo: translationRepo,
+				Fallbacks:       entity.LocaleFallbacks{Default: "en", Next: test.fallbacks},
+			})
+
+			books := []entity.Book{{ID: 1, Title: "The Colour of Magic", Description: "A Discworld novel", Categories: []entity.BookCategory{{CategoryID: 4, Name: "Fantasy"}}}}
+			err := translationUsecase.LocalizeBooks(context.Background(), books, test.locales)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantTitle, books[0].Title)
+			assert.Equal(t, test.wantDesc, books[0].Description)
+			assert.Equal(t, test.wantCat, books[0].Categories[0].Name)
+			if test.chain == nil {
+				translationRepo.AssertNotCalled(t, "GetBookTranslations", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestSetBookTranslation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		translation entity.BookTranslation
+		book        entity.Book
+		wantLocale  string
+		isError     bool
+	}{
+		{
+			name:        "success",
+			translation: entity.BookTranslation{BookID: 1, Locale: "pt_br", Title: " A Cor da Magia "},
+			book:        entity.Book{ID: 1},
+			wantLocale:  "pt-BR",
+			isError:     false,
+		},
+		{
+			name:        "not a locale",
+			translation: entity.BookTranslation{BookID: 1, Locale: "portuguese!", Title: "A Cor da Magia"},
+			book:        entity.Book{ID: 1},
+			isError:     true,
+		},
+		{
+			name:        "catalog locale",
+			translation: entity.BookTranslation{BookID: 1, Locale: "EN", Title: "The Colour of Magic"},
+			book:        entity.Book{ID: 1},
+			isError:     true,
+		},
+		{
+			name:        "nothing translated",
+			translation: entity.BookTranslation{BookID: 1, Locale: "pt-BR", Title: " "},
+			book:        entity.Book{ID: 1},
+			isError:     true,
+		},
+		{
+			name:        "book not found",
+			translation: entity.BookTranslation{BookID: 1, Locale: "pt-BR", Title: "A Cor da Magia"},
+			book:        entity.Book{},
+			isError:     true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			translationRepo := new(mocks.TranslationRepository)
+			translationRepo.On("SetBookTranslation", mock.Anything, mock.Anything).Return(nil)
+			bookRepo := new(mocks.BookRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(1)).Return(test.book, nil)
+
+			translationUsecase := usecase.NewTranslationUsecase(&usecase.TranslationRepository{
+				TranslationRepo: translationRepo,
+				BookRepo:        bookRepo,
+				Fallbacks:       entity.LocaleFallbacks{Default: "en"},
+			})
+			err := translationUsecase.SetBookTranslation(context.Background(), &test.translation)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				translationRepo.AssertNotCalled(t, "SetBookTranslation", mock.Anything, mock.Anything)
+			} else {
+				assert.Equal(t, test.wantLocale, test.translation.Locale)
+				assert.Equal(t, "A Cor da Magia", test.translation.Title)
+			}
+		})
+	}
+}
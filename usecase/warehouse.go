@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/repository"
+)
+
+type WarehouseUsecase interface {
+	GetWarehouses(ctx context.Context) ([]entity.Warehouse, error)
+	GetWarehouse(ctx context.Context, id int64) (entity.Warehouse, error)
+	CreateWarehouse(ctx context.Context, warehouse *entity.Warehouse) error
+	UpdateWarehouse(ctx context.Context, id int64, warehouse *entity.Warehouse) error
+	DeleteWarehouse(ctx context.Context, id int64) error
+}
+
+type WarehouseRepository struct {
+	WarehouseRepo repository.WarehouseRepository
+}
+
+func NewWarehouseUsecase(repo *WarehouseRepository) WarehouseUsecase {
+	return &WarehouseRepository{
+		WarehouseRepo: repo.WarehouseRepo,
+	}
+}
+
+func (r *WarehouseRepository) GetWarehouses(ctx context.Context) ([]entity.Warehouse, error) {
+	res, err := r.WarehouseRepo.GetWarehouses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *WarehouseRepository) GetWarehouse(ctx context.Context, id int64) (entity.Warehouse, error) {
+	res, err := r.WarehouseRepo.GetWarehouse(ctx, id)
+	if err != nil {
+		return entity.Warehouse{}, err
+	}
+
+	return res, nil
+}
+
+func (r *WarehouseRepository) CreateWarehouse(ctx context.Context, warehouse *entity.Warehouse) error {
+	err := validateWarehouse(warehouse)
+	if err != nil {
+		return err
+	}
+
+	err = r.WarehouseRepo.CreateWarehouse(ctx, warehouse)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *WarehouseRepository) UpdateWarehouse(ctx context.Context, id int64, warehouse *entity.Warehouse) error {
+	err := validateWarehouse(warehouse)
+	if err != nil {
+		return err
+	}
+
+	err = r.WarehouseRepo.UpdateWarehouse(ctx, id, warehouse)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteWarehouse only removes warehouses that keep no stock, the stock has to be transferred first
+func (r *WarehouseRepository) DeleteWarehouse(ctx context.Context, id int64) error {
+	total, err := r.WarehouseRepo.GetWarehouseStockTotal(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if total > 0 {
+		return fmt.Errorf("warehouse ID %d still keeps %d books", id, total)
+	}
+
+	err = r.WarehouseRepo.DeleteWarehouse(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateWarehouse(warehouse *entity.Warehouse) error {
+	warehouse.Code = strings.ToUpper(strings.TrimSpace(warehouse.Code))
+	warehouse.Name = strings.TrimSpace(warehouse.Name)
+
+	if warehouse.Code == "" || warehouse.Name == "" {
+		return errors.New("warehouse needs a code and a name")
+	}
+
+	if warehouse.Latitude < -90 || warehouse.Latitude > 90 || warehouse.Longitude < -180 || warehouse.Longitude > 180 {
+		return errors.New("warehouse coordinates are out of range")
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetWarehouses(t *testing.T) {
+	testCases := []struct {
+		name       string
+		warehouses []entity.Warehouse
+		isError    bool
+		wantErr    error
+	}{
+		{
+			name:       "success",
+			warehouses: []entity.Warehouse{{ID: 1, Code: "MAIN", Name: "Main Warehouse"}},
+			isError:    false,
+			wantErr:    nil,
+		},
+		{
+			name:       "failed",
+			warehouses: []entity.Warehouse{},
+			isError:    true,
+			wantErr:    errors.New("Dummy Error"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			warehouseRepo := new(mocks.WarehouseRepository)
+			warehouseRepo.On("GetWarehouses", mock.Anything).Return(test.warehouses, test.wantErr)
+
+			warehouseUsecase := usecase.NewWarehouseUsecase(&usecase.WarehouseRepository{WarehouseRepo: warehouseRepo})
+			res, err := warehouseUsecase.GetWarehouses(context.Background())
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, test.warehouses, res)
+			}
+		})
+	}
+}
+
+func TestCreateWarehouse(t *testing.T) {
+	testCases := []struct {
+		name      string
+		warehouse entity.Warehouse
+		wantCode  string
+		isError   bool
+	}{
+		{
+			name:      "success",
+			warehouse: entity.Warehouse{Code: " sby ", Name: "Surabaya", Latitude: -7.25, Longitude: 112.75},
+			wantCode:  "SBY",
+			isError:   false,
+		},
+		{
+			name:      "missing name",
+			warehouse: entity.Warehouse{Code: "SBY"},
+			isError:   true,
+		},
+		{
+			name:      "latitude out of range",
+			warehouse: entity.Warehouse{Code: "SBY", Name: "Surabaya", Latitude: 112.75, Longitude: -7.25},
+			isError:   true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			warehouseRepo := new(mocks.WarehouseRepository)
+			warehouseRepo.On("CreateWarehouse", mock.Anything, mock.Anything).Return(nil)
+
+			warehouseUsecase := usecase.NewWarehouseUsecase(&usecase.WarehouseRepository{WarehouseRepo: warehouseRepo})
+			err := warehouseUsecase.CreateWarehouse(context.Background(), &test.warehouse)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				warehouseRepo.AssertNotCalled(t, "CreateWarehouse", mock.Anything, mock.Anything)
+			} else {
+				assert.Equal(t, test.wantCode, test.warehouse.Code)
+			}
+		})
+	}
+}
+
+func TestDeleteWarehouse(t *testing.T) {
+	testCases := []struct {
+		name    string
+		total   int
+		isError bool
+	}{
+		{name: "empty warehouse", total: 0, isError: false},
+		{name: "warehouse still keeps stock", total: 4, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			warehouseRepo := new(mocks.WarehouseRepository)
+			warehouseRepo.On("GetWarehouseStockTotal", mock.Anything, int64(2)).Return(test.total, nil)
+			warehouseRepo.On("DeleteWarehouse", mock.Anything, int64(2)).Return(nil)
+
+			warehouseUsecase := usecase.NewWarehouseUsecase(&usecase.WarehouseRepository{WarehouseRepo: warehouseRepo})
+			err := warehouseUsecase.DeleteWarehouse(context.Background(), 2)
+
+			assert.Equal(t, test.isError, err != nil)
+			if test.isError {
+				warehouseRepo.AssertNotCalled(t, "DeleteWarehouse", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/notifier"
+	"winartodev/book-store-be/repository"
+)
+
+type WishlistUsecase interface {
+	GetWishlist(ctx context.Context, customerEmail string) ([]entity.WishlistItem, error)
+	AddToWishlist(ctx context.Context, customerEmail string, bookID int64) (entity.WishlistItem, error)
+	RemoveFromWishlist(ctx context.Context, customerEmail string, bookID int64) error
+	SubscribeBackInStock(ctx context.Context, bookID int64, customerEmail string) (entity.StockSubscription, error)
+	UnsubscribeBackInStock(ctx context.Context, bookID int64, customerEmail string) error
+	NotifyBackInStock(ctx context.Context) (int, error)
+}
+
+type WishlistRepository struct {
+	WishlistRepo repository.WishlistRepository
+	BookRepo     repository.BookRepository
+	Notifier     notifier.Notifier
+}
+
+func NewWishlistUsecase(repo *WishlistRepository) WishlistUsecase {
+	return &WishlistRepository{
+		WishlistRepo: repo.WishlistRepo,
+		BookRepo:     repo.BookRepo,
+		Notifier:     repo.Notifier,
+	}
+}
+
+func (r *WishlistRepository) GetWishlist(ctx context.Context, customerEmail string) ([]entity.WishlistItem, error) {
+	customerEmail, err := normalizeEmail(customerEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.WishlistRepo.GetWishlist(ctx, customerEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *WishlistRepository) AddToWishlist(ctx context.Context, customerEmail string, bookID int64) (entity.WishlistItem, error) {
+	customerEmail, err := normalizeEmail(customerEmail)
+	if err != nil {
+		return entity.WishlistItem{}, err
+	}
+
+	book, err := r.getBook(ctx, bookID)
+	if err != nil {
+		return entity.WishlistItem{}, err
+	}
+
+	item := entity.WishlistItem{CustomerEmail: customerEmail, BookID: bookID, Title: book.Title, Available: book.Stock - book.Reserved}
+	err = r.WishlistRepo.AddWishlistItem(ctx, &item)
+	if err == repository.ErrDuplicateWishlistItem {
+		return entity.WishlistItem{}, fmt.Errorf("book ID %d is already on the wishlist of %s", bookID, customerEmail)
+	}
+	if err != nil {
+		return entity.WishlistItem{}, err
+	}
+
+	return item, nil
+}
+
+func (r *WishlistRepository) RemoveFromWishlist(ctx context.Context, customerEmail string, bookID int64) error {
+	customerEmail, err := normalizeEmail(customerEmail)
+	if err != nil {
+		return err
+	}
+
+	return r.WishlistRepo.RemoveWishlistItem(ctx, customerEmail, bookID)
+}
+
+// SubscribeBackInStock asks for a notice once an out-of-stock book can be ordered again
+func (r *WishlistRepository) SubscribeBackInStock(ctx context.Context, bookID int64, customerEmail string) (entity.StockSubscription, error) {
+	customerEmail, err := normalizeEmail(customerEmail)
+	if err != nil {
+		return entity.StockSubscription{}, err
+	}
+
+	book, err := r.getBook(ctx, bookID)
+	if err != nil {
+		return entity.StockSubscription{}, err
+	}
+
+	if book.Stock-book.Reserved > 0 {
+		return entity.StockSubscription{}, fmt.Errorf("book ID %d is in stock", bookID)
+	}
+
+	subscription := entity.StockSubscription{BookID: bookID, CustomerEmail: customerEmail}
+	err = r.WishlistRepo.CreateStockSubscription(ctx, &subscription)
+	if err == repository.ErrDuplicateStockSubscription {
+		return entity.StockSubscription{}, fmt.Errorf("%s is already waiting for book ID %d", customerEmail, bookID)
+	}
+	if err != nil {
+		return entity.StockSubscription{}, err
+	}
+
+	return subscription, nil
+}
+
+func (r *WishlistRepository) UnsubscribeBackInStock(ctx context.Context, bookID int64, customerEmail string) error {
+	customerEmail, err := normalizeEmail(customerEmail)
+	if err != nil {
+		return err
+	}
+
+	return r.WishlistRepo.DeleteStockSubscription(ctx, bookID, customerEmail)
+}
+
+// NotifyBackInStock tells every customer waiting for a book that came back in stock, one
+// notice per customer listing all their books, and returns how many customers were told.
+// Subscriptions are only marked as notified once the notifiers succeeded, a failed delivery
+// is retried on the next run while the other customers are still told.
+func (r *WishlistRepository) NotifyBackInStock(ctx context.Context) (int, error) {
+	books, err := r.WishlistRepo.GetBackInStock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var notified int
+	var failures []string
+	for start := 0; start < len(books); {
+		end := start + 1
+		for end < len(books) && books[end].CustomerEmail == books[start].CustomerEmail {
+			end++
+		}
+
+		customerBooks := books[start:end]
+		start = end
+
+		err := r.notify(ctx, customerBooks)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+
+		notified++
+	}
+
+	if len(failures) > 0 {
+		return notified, fmt.Errorf("%d back-in-stock notices failed: %s", len(failures), strings.Join(failures, "; "))
+	}
+
+	return notified, nil
+}
+
+func (r *WishlistRepository) notify(ctx context.Context, books []entity.BackInStock) error {
+	customerEmail := books[0].CustomerEmail
+	err := r.Notifier.NotifyBackInStock(ctx, customerEmail, books)
+	if err != nil {
+		return fmt.Errorf("%s: %w", customerEmail, err)
+	}
+
+	ids := make([]int64, 0, len(books))
+	for _, book := range books {
+		ids = append(ids, book.SubscriptionID)
+	}
+
+	return r.WishlistRepo.MarkNotified(ctx, ids, time.Now())
+}
+
+func (r *WishlistRepository) getBook(ctx context.Context, bookID int64) (entity.Book, error) {
+	book, err := r.BookRepo.GetBook(ctx, bookID)
+	if err != nil {
+		return entity.Book{}, err
+	}
+
+	if book.ID == 0 {
+		return entity.Book{}, fmt.Errorf("book ID %d was not found", bookID)
+	}
+
+	return book, nil
+}
+
+// normalizeEmail lowercases the email a customer is known by, which cannot be empty
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return "", errors.New("customer email cannot be empty")
+	}
+
+	return email, nil
+}
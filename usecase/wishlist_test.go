@@ -0,0 +1,90 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"winartodev/book-store-be/entity"
+	"winartodev/book-store-be/mocks"
+	"winartodev/book-store-be/repository"
+	"winartodev/book-store-be/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSubscribeBackInStock(t *testing.T) {
+	testCases := []struct {
+		name      string
+		email     string
+		book      entity.Book
+		createErr error
+		isError   bool
+	}{
+		{name: "out of stock", email: " Reader@Mail.com ", book: entity.Book{ID: 5}},
+		{name: "all stock reserved", email: "reader@mail.com", book: entity.Book{ID: 5, Stock: 2, Reserved: 2}},
+		{name: "in stock", email: "reader@mail.com", book: entity.Book{ID: 5, Stock: 3}, isError: true},
+		{name: "no customer", email: " ", book: entity.Book{ID: 5}, isError: true},
+		{name: "book not found", email: "reader@mail.com", book: entity.Book{}, isError: true},
+		{name: "already waiting", email: "reader@mail.com", book: entity.Book{ID: 5}, createErr: repository.ErrDuplicateStockSubscription, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			bookRepo := new(mocks.BookRepository)
+			bookRepo.On("GetBook", mock.Anything, int64(5)).Return(test.book, nil)
+
+			wishlistRepo := new(mocks.WishlistRepository)
+			wishlistRepo.On("CreateStockSubscription", mock.Anything, mock.Anything).Return(test.createErr)
+
+			wishlistUsecase := usecase.NewWishlistUsecase(&usecase.WishlistRepository{WishlistRepo: wishlistRepo, BookRepo: bookRepo})
+			subscription, err := wishlistUsecase.SubscribeBackInStock(context.Background(), 5, test.email)
+
+			assert.Equal(t, test.isError, err != nil)
+			if !test.isError {
+				assert.Equal(t, entity.StockSubscription{BookID: 5, CustomerEmail: "reader@mail.com"}, subscription)
+			}
+		})
+	}
+}
+
+func TestNotifyBackInStock(t *testing.T) {
+	books := []entity.BackInStock{
+		{SubscriptionID: 7, CustomerEmail: "a@mail.com", BookID: 5, Title: "Dune", Available: 4},
+		{SubscriptionID: 8, CustomerEmail: "a@mail.com", BookID: 6, Title: "Emma", Available: 1},
+		{SubscriptionID: 9, CustomerEmail: "b@mail.com", BookID: 5, Title: "Dune", Available: 4},
+	}
+
+	testCases := []struct {
+		name         string
+		notifyErr    error
+		wantNotified int
+		wantMarked   [][]int64
+		isError      bool
+	}{
+		{name: "every customer told", wantNotified: 2, wantMarked: [][]int64{{7, 8}, {9}}},
+		{name: "one delivery failed", notifyErr: errors.New("connection refused"), wantNotified: 1, wantMarked: [][]int64{{9}}, isError: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			wishlistRepo := new(mocks.WishlistRepository)
+			wishlistRepo.On("GetBackInStock", mock.Anything).Return(books, nil)
+			wishlistRepo.On("MarkNotified", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			notifier := new(mocks.Notifier)
+			notifier.On("NotifyBackInStock", mock.Anything, "a@mail.com", books[:2]).Return(test.notifyErr)
+			notifier.On("NotifyBackInStock", mock.Anything, "b@mail.com", books[2:]).Return(nil)
+
+			wishlistUsecase := usecase.NewWishlistUsecase(&usecase.WishlistRepository{WishlistRepo: wishlistRepo, Notifier: notifier})
+			notified, err := wishlistUsecase.NotifyBackInStock(context.Background())
+
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.wantNotified, notified)
+			wishlistRepo.AssertNumberOfCalls(t, "MarkNotified", len(test.wantMarked))
+			for _, ids := range test.wantMarked {
+				wishlistRepo.AssertCalled(t, "MarkNotified", mock.Anything, ids, mock.Anything)
+			}
+		})
+	}
+}
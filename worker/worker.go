@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"context"
+	"time"
+	"winartodev/book-store-be/logger"
+)
+
+// Job is a unit of background work
+type Job func(ctx context.Context) error
+
+// Run calls job every interval until ctx is done. Failures are logged and the job is tried
+// again on the next tick.
+func Run(ctx context.Context, name string, interval time.Duration, job Job) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// a tick and the cancellation can arrive together, do not start work after being stopped
+			if ctx.Err() != nil {
+				return
+			}
+
+			err := job(ctx)
+			if err != nil {
+				logger.Error(err, logger.Fields{"job": name})
+			}
+		}
+	}
+}
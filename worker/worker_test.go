@@ -0,0 +1,40 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+	"winartodev/book-store-be/logger"
+	"winartodev/book-store-be/worker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	logger.Init()
+
+	var calls int32
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		worker.Run(ctx, "dummy", time.Millisecond, func(ctx context.Context) error {
+			if atomic.AddInt32(&calls, 1) == 3 {
+				cancel()
+			}
+			return errors.New("Dummy Error")
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop after the context was cancelled")
+	}
+
+	// failures do not stop the worker, it stops once the context is done
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}